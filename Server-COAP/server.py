@@ -3,9 +3,21 @@ import aiocoap.resource as resource
 import aiocoap
 import aiohttp
 import json
+import os
 
 API_BASE_URL = "https://api.loiccapdeville.fr/api/sensormanager"
 
+# Clé d'API d'un utilisateur de rôle "device", envoyée à resgate dans l'en-tête Authorization
+API_KEY = os.environ.get("SENSORMANAGER_API_KEY", "")
+# Site auquel la passerelle rattache les capteurs de la maison
+SITE_ID = os.environ.get("SENSORMANAGER_SITE_ID", "default")
+
+# Capteurs d'ambiance (DHT22, LDR) : types du registre du backend, dont la mesure est envoyée dans "value"
+AMBIENT_SENSOR_TYPES = ("temperature", "humidity", "illuminance")
+
+# Télémétrie des appareils (signal, uptime, mémoire, cause du redémarrage, firmware)
+TELEMETRY_FIELDS = ("rssi", "uptimeSeconds", "freeHeap", "resetReason", "ipAddress", "firmwareVersion")
+
 http_session = None
 
 class SensorResource(resource.Resource):
@@ -23,6 +35,13 @@ class SensorResource(resource.Resource):
             return aiocoap.Message(code=aiocoap.BAD_REQUEST, payload=b"Invalid JSON")
         
         api_data = self._prepare_data(device_id, value)
+        if api_data is not None:
+            # Identifiant, horodatage de la mesure et signature HMAC calculés par l'appareil, transmis tels quels au backend
+            for field in ("messageId", "recordedAt", "sequence", "timestamp", "nonce", "signature"):
+                if field in data:
+                    api_data[field] = data[field]
+            # Adresse de l'appareil, à laquelle le backend envoie ses commandes CoAP
+            api_data["sourceAddress"] = request.remote.hostinfo
         asyncio.create_task(self._send_to_api(api_data))
         
         return aiocoap.Message(code=aiocoap.CHANGED, payload=b"OK")
@@ -35,6 +54,8 @@ class SensorResource(resource.Resource):
                 return {"deviceId": device_id, "decibels": float(value)}
             elif self.sensor_type == "motion":
                 return {"deviceId": device_id, "motionDetected": str(value).lower() in ["1", "true", "yes"]}
+            elif self.sensor_type in AMBIENT_SENSOR_TYPES:
+                return {"deviceId": device_id, "value": float(value)}
         except (ValueError, TypeError):
             return None
     
@@ -44,15 +65,72 @@ class SensorResource(resource.Resource):
         if data is None:
             return False
         
-        url = f"{API_BASE_URL}/sensor/{self.sensor_type}/record"
+        url = f"{API_BASE_URL}/sites/{SITE_ID}/sensor/{self.sensor_type}/record"
         
         try:
-            async with http_session.post(url, json=data, headers={"Content-Type": "application/json"}, timeout=aiohttp.ClientTimeout(total=3)) as response:
+            async with http_session.post(url, json=data, headers={"Content-Type": "application/json", "Authorization": f"Bearer {API_KEY}"}, timeout=aiohttp.ClientTimeout(total=3)) as response:
+                return response.status == 200
+        except:
+            return False
+
+
+class TelemetryResource(resource.Resource):
+
+    async def render_put(self, request):
+        try:
+            data = json.loads(request.payload.decode('utf-8'))
+        except json.JSONDecodeError:
+            return aiocoap.Message(code=aiocoap.BAD_REQUEST, payload=b"Invalid JSON")
+
+        # Les champs sont transmis tels quels : ils font partie du message signé par l'appareil
+        api_data = {"deviceId": data.get('deviceId', 'UNKNOWN')}
+        for field in TELEMETRY_FIELDS + ("recordedAt", "timestamp", "nonce", "signature"):
+            if field in data:
+                api_data[field] = data[field]
+        api_data["sourceAddress"] = request.remote.hostinfo
+        asyncio.create_task(self._send_to_api(api_data))
+
+        return aiocoap.Message(code=aiocoap.CHANGED, payload=b"OK")
+
+    async def _send_to_api(self, data):
+        url = f"{API_BASE_URL}/sites/{SITE_ID}/device/telemetry/record"
+
+        try:
+            async with http_session.post(url, json=data, headers={"Content-Type": "application/json", "Authorization": f"Bearer {API_KEY}"}, timeout=aiohttp.ClientTimeout(total=3)) as response:
                 return response.status == 200
         except:
             return False
 
 
+class ShadowResource(resource.Resource):
+    """Configuration appliquée par l'appareil : le delta restant à appliquer lui est retourné dans la réponse CoAP."""
+
+    async def render_put(self, request):
+        try:
+            data = json.loads(request.payload.decode('utf-8'))
+        except json.JSONDecodeError:
+            return aiocoap.Message(code=aiocoap.BAD_REQUEST, payload=b"Invalid JSON")
+
+        api_data = {"deviceId": data.get('deviceId', 'UNKNOWN'), "reported": data.get('reported', {})}
+        for field in ("timestamp", "nonce", "signature"):
+            if field in data:
+                api_data[field] = data[field]
+        api_data["sourceAddress"] = request.remote.hostinfo
+
+        url = f"{API_BASE_URL}/sites/{SITE_ID}/shadows/report"
+
+        try:
+            async with http_session.post(url, json=api_data, headers={"Content-Type": "application/json", "Authorization": f"Bearer {API_KEY}"}, timeout=aiohttp.ClientTimeout(total=3)) as response:
+                if response.status != 200:
+                    return aiocoap.Message(code=aiocoap.BAD_GATEWAY, payload=b"Rejected")
+                result = await response.json()
+        except:
+            return aiocoap.Message(code=aiocoap.SERVICE_UNAVAILABLE, payload=b"Unavailable")
+
+        payload = json.dumps({"version": result.get("version", 0), "delta": result.get("delta", {})}, separators=(",", ":"))
+        return aiocoap.Message(code=aiocoap.CONTENT, payload=payload.encode('utf-8'))
+
+
 async def main():
     global http_session
     
@@ -61,6 +139,10 @@ async def main():
     root.add_resource(['distance'], SensorResource('distance'))
     root.add_resource(['microphone'], SensorResource('microphone'))
     root.add_resource(['motion'], SensorResource('motion'))
+    for sensor_type in AMBIENT_SENSOR_TYPES:
+        root.add_resource([sensor_type], SensorResource(sensor_type))
+    root.add_resource(['telemetry'], TelemetryResource())
+    root.add_resource(['shadow'], ShadowResource())
     
     await aiocoap.Context.create_server_context(root, bind=('192.168.40.241', 4832))
     
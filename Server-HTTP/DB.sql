@@ -1,90 +1,452 @@
-CREATE TABLE microphone_data (
-    id BIGSERIAL PRIMARY KEY,
-    device_id VARCHAR(50) NOT NULL,
-    decibels DECIMAL(10, 2) NOT NULL,
-    recorded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-);
-
-CREATE TABLE distance_data (
-    id BIGSERIAL PRIMARY KEY,
-    device_id VARCHAR(50) NOT NULL,
-    distance_cm DECIMAL(10, 2) NOT NULL,
-    recorded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-);
-
-CREATE TABLE motion_data (
-    id BIGSERIAL PRIMARY KEY,
-    device_id VARCHAR(50) NOT NULL,
-    motion_detected BOOLEAN NOT NULL,
-    recorded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+-- Sites (maisons) : appareils, jetons push et alertes sont isolés par site
+CREATE TABLE sites (
+    id VARCHAR(50) PRIMARY KEY, -- ex: 'maison', utilisé dans les noms de ressources RES
+    name VARCHAR(100) NOT NULL,
+    armed BOOLEAN NOT NULL DEFAULT TRUE, -- Alarme désarmée : les alertes de mouvement ne sont pas notifiées
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
-CREATE INDEX idx_microphone_time ON microphone_data(recorded_at DESC);
-CREATE INDEX idx_distance_time ON distance_data(recorded_at DESC);
-CREATE INDEX idx_motion_time ON motion_data(recorded_at DESC);
+INSERT INTO sites (id, name) VALUES ('default', 'Maison');
 
 CREATE TABLE push_tokens (
     id BIGSERIAL PRIMARY KEY,
-    token VARCHAR(500) NOT NULL UNIQUE,
+    token VARCHAR(500) NOT NULL,
+    site_id VARCHAR(50) NOT NULL DEFAULT 'default', -- un même téléphone peut suivre plusieurs sites
     platform VARCHAR(20) NOT NULL,
     device_info JSONB,
+    locale VARCHAR(5), -- langue des notifications ('fr', 'en'), NULL pour celle de l'utilisateur ou du serveur
     is_active BOOLEAN DEFAULT TRUE,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (token, site_id)
 );
 
 CREATE INDEX idx_push_tokens_active ON push_tokens(is_active) WHERE is_active = TRUE;
 CREATE INDEX idx_push_tokens_platform ON push_tokens(platform);
 
--- Alertes pour les données de microphone (décibels)
-CREATE TABLE microphone_alerts (
+-- Registre des appareils et de leur état de santé
+CREATE TABLE devices (
+    device_id VARCHAR(50) PRIMARY KEY,
+    site_id VARCHAR(50) NOT NULL DEFAULT 'default' REFERENCES sites(id),
+    sensor_type VARCHAR(32) NOT NULL,
+    status VARCHAR(20) DEFAULT 'active' CHECK (status IN ('active', 'disabled', 'pending', 'rejected')), -- 'pending' : identifiant inconnu en attente d'approbation
+    secret VARCHAR(64), -- secret HMAC, NULL = appareil non provisionné
+    secret_rotated_at TIMESTAMP,
+    location VARCHAR(100), -- ex: 'couloir', sert à regrouper les alertes en incidents
+    health VARCHAR(20) DEFAULT 'ok' CHECK (health IN ('ok', 'stuck', 'flat', 'drift')),
+    health_reason TEXT,
+    health_updated_at TIMESTAMP,
+    -- dernières valeurs de télémétrie connues
+    rssi INT, -- dBm
+    uptime_seconds BIGINT,
+    free_heap BIGINT, -- octets
+    reset_reason VARCHAR(64),
+    ip_address VARCHAR(45),
+    firmware_version VARCHAR(32),
+    telemetry_at TIMESTAMP,
+    address VARCHAR(64), -- dernière adresse 'ip:port' connue, destinataire des commandes CoAP
+    address_updated_at TIMESTAMP,
+    last_seen_at TIMESTAMP, -- dernière mesure reçue, mise à jour au plus une fois par minute
+    offline_since TIMESTAMP, -- NULL tant que l'appareil envoie des mesures ou de la télémétrie
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Mesures des appareils en attente d'approbation, déplacées dans l'historique à l'approbation
+CREATE TABLE quarantined_readings (
+    id BIGSERIAL PRIMARY KEY,
+    site_id VARCHAR(50) NOT NULL REFERENCES sites(id) ON DELETE CASCADE,
+    device_id VARCHAR(50) NOT NULL REFERENCES devices(device_id) ON DELETE CASCADE,
+    sensor_type VARCHAR(32) NOT NULL,
+    value DECIMAL(12, 3) NOT NULL, -- 1 ou 0 pour les types booléens
+    recorded_at TIMESTAMP NOT NULL,
+    received_at TIMESTAMP NOT NULL,
+    sequence BIGINT
+);
+
+CREATE INDEX idx_quarantined_readings_device ON quarantined_readings(device_id, received_at);
+
+-- Défauts capteur (valeur bloquée, variance nulle, dérive), distincts des alertes de sécurité
+CREATE TABLE sensor_fault_alerts (
     id BIGSERIAL PRIMARY KEY,
     device_id VARCHAR(50) NOT NULL,
-    data_id BIGINT REFERENCES microphone_data(id) ON DELETE SET NULL,
-    decibels DECIMAL(10, 2) NOT NULL,
-    threshold_exceeded DECIMAL(10, 2) NOT NULL,
+    sensor_type VARCHAR(20) NOT NULL,
+    fault_type VARCHAR(20) NOT NULL CHECK (fault_type IN ('stuck', 'flat', 'drift')),
+    value DECIMAL(10, 2) NOT NULL,
+    details TEXT,
     alert_status VARCHAR(20) DEFAULT 'active' CHECK (alert_status IN ('active', 'acknowledged', 'resolved')),
     acknowledged_at TIMESTAMP,
     resolved_at TIMESTAMP,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
--- Alertes pour les données de distance
-CREATE TABLE distance_alerts (
+CREATE INDEX idx_sensor_fault_alerts_device ON sensor_fault_alerts(device_id);
+CREATE INDEX idx_sensor_fault_alerts_status ON sensor_fault_alerts(alert_status);
+CREATE INDEX idx_sensor_fault_alerts_time ON sensor_fault_alerts(created_at DESC);
+
+-- Historique de la télémétrie des appareils (signal, uptime, mémoire, redémarrages)
+CREATE TABLE device_telemetry (
+    id BIGSERIAL PRIMARY KEY,
+    device_id VARCHAR(50) NOT NULL REFERENCES devices(device_id) ON DELETE CASCADE,
+    rssi INT,
+    uptime_seconds BIGINT,
+    free_heap BIGINT,
+    reset_reason VARCHAR(64),
+    ip_address VARCHAR(45),
+    firmware_version VARCHAR(32),
+    rebooted BOOLEAN NOT NULL DEFAULT FALSE, -- l'uptime a diminué depuis le rapport précédent
+    recorded_at TIMESTAMP NOT NULL,
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_device_telemetry_device ON device_telemetry(device_id, recorded_at DESC);
+CREATE INDEX idx_device_telemetry_reboots ON device_telemetry(device_id, recorded_at) WHERE rebooted;
+
+-- Alertes de santé des appareils (boucle de redémarrage, signal Wi-Fi faible)
+CREATE TABLE telemetry_alerts (
     id BIGSERIAL PRIMARY KEY,
     device_id VARCHAR(50) NOT NULL,
-    data_id BIGINT REFERENCES distance_data(id) ON DELETE SET NULL,
-    distance_cm DECIMAL(10, 2) NOT NULL,
-    threshold_type VARCHAR(20) NOT NULL CHECK (threshold_type IN ('too_close', 'too_far')),
-    threshold_value DECIMAL(10, 2) NOT NULL,
+    alert_kind VARCHAR(20) NOT NULL CHECK (alert_kind IN ('reboot_loop', 'weak_signal')),
+    value DECIMAL(10, 2) NOT NULL,
+    threshold DECIMAL(10, 2) NOT NULL,
+    details TEXT,
     alert_status VARCHAR(20) DEFAULT 'active' CHECK (alert_status IN ('active', 'acknowledged', 'resolved')),
     acknowledged_at TIMESTAMP,
     resolved_at TIMESTAMP,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
--- Alertes pour les données de mouvement
-CREATE TABLE motion_alerts (
+CREATE INDEX idx_telemetry_alerts_device ON telemetry_alerts(device_id, alert_kind);
+CREATE INDEX idx_telemetry_alerts_status ON telemetry_alerts(alert_status);
+CREATE INDEX idx_telemetry_alerts_time ON telemetry_alerts(created_at DESC);
+
+-- Commandes envoyées aux appareils sur la ressource CoAP 'alert' du firmware
+CREATE TABLE device_commands (
+    id BIGSERIAL PRIMARY KEY,
+    device_id VARCHAR(50) NOT NULL REFERENCES devices(device_id) ON DELETE CASCADE,
+    command VARCHAR(20) NOT NULL CHECK (command IN ('beep', 'led_on', 'led_off', 'reboot', 'set_interval', 'config')),
+    interval_seconds INT, -- set_interval uniquement
+    address VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'acknowledged', 'rejected', 'timeout', 'failed')),
+    attempts INT, -- transmissions CoAP jusqu'à l'ACK
+    response_code VARCHAR(8), -- ex: '2.04'
+    error TEXT,
+    requested_by VARCHAR(100), -- ex: 'user:3', 'alert:distance:42'
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX idx_device_commands_device ON device_commands(device_id, created_at DESC);
+
+-- Shadow des appareils : configuration désirée (RES) et configuration appliquée (rapportée par l'appareil)
+CREATE TABLE device_shadows (
+    device_id VARCHAR(50) PRIMARY KEY REFERENCES devices(device_id) ON DELETE CASCADE,
+    desired JSONB NOT NULL DEFAULT '{}',
+    reported JSONB NOT NULL DEFAULT '{}',
+    version BIGINT NOT NULL DEFAULT 0, -- incrémentée à chaque changement de l'une des deux configurations
+    desired_updated_at TIMESTAMP,
+    reported_updated_at TIMESTAMP
+);
+
+-- Registre des firmwares OTA : binaire stocké en base ou sur disque (chemin)
+CREATE TABLE firmwares (
+    id BIGSERIAL PRIMARY KEY,
+    version VARCHAR(32) NOT NULL UNIQUE, -- envoyée par l'appareil dans l'en-tête x-ESP8266-version
+    sha256 CHAR(64) NOT NULL,
+    md5 CHAR(32) NOT NULL, -- vérifié par ESPhttpUpdate via l'en-tête x-MD5
+    size BIGINT NOT NULL,
+    binary_data BYTEA, -- NULL quand le binaire est stocké sur disque
+    path TEXT,
+    notes TEXT,
+    uploaded_by VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((binary_data IS NULL) <> (path IS NULL))
+);
+
+-- Déploiements d'un firmware sur des appareils ou un emplacement, par étapes (pourcentages cumulés)
+CREATE TABLE firmware_rollouts (
+    id BIGSERIAL PRIMARY KEY,
+    site_id VARCHAR(50) NOT NULL REFERENCES sites(id) ON DELETE CASCADE,
+    firmware_id BIGINT NOT NULL REFERENCES firmwares(id),
+    location VARCHAR(100), -- groupe ciblé, NULL quand les appareils sont listés
+    stages INTEGER[] NOT NULL, -- ex: {10,50,100}
+    current_stage INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'paused', 'halted', 'completed', 'cancelled')),
+    halt_reason TEXT,
+    created_by VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE firmware_deployments (
+    rollout_id BIGINT NOT NULL REFERENCES firmware_rollouts(id) ON DELETE CASCADE,
+    device_id VARCHAR(50) NOT NULL REFERENCES devices(device_id) ON DELETE CASCADE,
+    stage INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled' CHECK (status IN ('scheduled', 'pending', 'installing', 'succeeded', 'failed', 'unresponsive')),
+    previous_version VARCHAR(32), -- version rapportée par l'appareil au téléchargement
+    device_mac VARCHAR(17), -- en-tête x-ESP8266-STA-MAC du téléchargement
+    offered_at TIMESTAMP, -- ouverture de l'étape de l'appareil
+    downloaded_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    error TEXT,
+    PRIMARY KEY (rollout_id, device_id)
+);
+
+CREATE INDEX idx_firmware_rollouts_site ON firmware_rollouts(site_id, created_at DESC);
+CREATE INDEX idx_firmware_deployments_device ON firmware_deployments(device_id, status);
+
+-- Mesures et alertes des types de capteurs du registre
+CREATE TABLE sensor_readings (
     id BIGSERIAL PRIMARY KEY,
+    sensor_type VARCHAR(32) NOT NULL, -- nom du type dans le registre, ex: 'temperature'
     device_id VARCHAR(50) NOT NULL,
-    data_id BIGINT REFERENCES motion_data(id) ON DELETE SET NULL,
-    motion_detected BOOLEAN NOT NULL,
-    alert_reason VARCHAR(100), -- ex: 'unexpected_motion', 'continuous_motion'
+    value DECIMAL(12, 3) NOT NULL, -- 1 ou 0 pour les types booléens
+    filtered_value DECIMAL(12, 3), -- valeur filtrée des types filtrés, NULL quand la mesure brute est rejetée par le filtre
+    recorded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP, -- heure de la mesure selon l'appareil, sinon heure de réception
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    sequence BIGINT -- numéro de séquence de l'appareil, NULL s'il n'en envoie pas
+);
+
+CREATE INDEX idx_sensor_readings_device ON sensor_readings(sensor_type, device_id, recorded_at DESC);
+
+CREATE TABLE sensor_alerts (
+    id BIGSERIAL PRIMARY KEY,
+    sensor_type VARCHAR(32) NOT NULL,
+    device_id VARCHAR(50) NOT NULL,
+    data_id BIGINT REFERENCES sensor_readings(id) ON DELETE SET NULL,
+    rule VARCHAR(64) NOT NULL, -- règle d'alerte du type, ex: 'frost', ou événement de mouvement
+    value DECIMAL(12, 3) NOT NULL,
+    threshold DECIMAL(12, 3), -- NULL pour les alertes de mouvement
+    reference DECIMAL(12, 3), -- valeur précédente d'une règle de variation
     alert_status VARCHAR(20) DEFAULT 'active' CHECK (alert_status IN ('active', 'acknowledged', 'resolved')),
     acknowledged_at TIMESTAMP,
     resolved_at TIMESTAMP,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
--- Index pour optimiser les requêtes
-CREATE INDEX idx_microphone_alerts_device ON microphone_alerts(device_id);
-CREATE INDEX idx_microphone_alerts_status ON microphone_alerts(alert_status);
-CREATE INDEX idx_microphone_alerts_time ON microphone_alerts(created_at DESC);
+CREATE INDEX idx_sensor_alerts_device ON sensor_alerts(sensor_type, device_id);
+CREATE INDEX idx_sensor_alerts_status ON sensor_alerts(alert_status);
+CREATE INDEX idx_sensor_alerts_time ON sensor_alerts(created_at DESC);
+
+-- Seuils d'alerte propres à un site, remplaçant les seuils par défaut des règles
+CREATE TABLE alert_thresholds (
+    site_id VARCHAR(50) NOT NULL REFERENCES sites(id) ON DELETE CASCADE,
+    sensor_type VARCHAR(32) NOT NULL,
+    rule VARCHAR(32) NOT NULL, -- ex: 'high_noise', 'large_variation', 'frost'
+    threshold DECIMAL(12, 3) NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (site_id, sensor_type, rule)
+);
+
+-- Politiques d'escalade des alertes non acquittées
+CREATE TABLE escalation_policies (
+    id BIGSERIAL PRIMARY KEY,
+    site_id VARCHAR(50) NOT NULL REFERENCES sites(id) ON DELETE CASCADE, -- ne s'applique qu'aux alertes du site
+    name VARCHAR(100) NOT NULL,
+    alert_type VARCHAR(32), -- NULL = toutes, sinon 'sensor_fault' ou un type du registre des capteurs
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_escalation_policies_site ON escalation_policies(site_id);
+
+CREATE TABLE escalation_steps (
+    id BIGSERIAL PRIMARY KEY,
+    policy_id BIGINT NOT NULL REFERENCES escalation_policies(id) ON DELETE CASCADE,
+    step_order INT NOT NULL,
+    delay_minutes INT NOT NULL CHECK (delay_minutes >= 0), -- depuis la création de l'alerte
+    target VARCHAR(20) NOT NULL CHECK (target IN ('all', 'tokens')),
+    push_tokens TEXT[],
+    repeat_every_minutes INT DEFAULT 0 CHECK (repeat_every_minutes >= 0),
+    repeat_limit INT DEFAULT 0 CHECK (repeat_limit >= 0),
+    UNIQUE (policy_id, step_order)
+);
+
+-- Journal des étapes d'escalade exécutées
+CREATE TABLE alert_escalations (
+    id BIGSERIAL PRIMARY KEY,
+    site_id VARCHAR(50) NOT NULL REFERENCES sites(id) ON DELETE CASCADE,
+    alert_type VARCHAR(32) NOT NULL,
+    alert_id BIGINT NOT NULL,
+    policy_id BIGINT REFERENCES escalation_policies(id) ON DELETE SET NULL,
+    step_id BIGINT REFERENCES escalation_steps(id) ON DELETE SET NULL,
+    step_order INT NOT NULL,
+    repetition INT NOT NULL DEFAULT 0,
+    recipients INT NOT NULL DEFAULT 0,
+    success BOOLEAN NOT NULL,
+    error_message TEXT,
+    executed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_alert_escalations_alert ON alert_escalations(alert_type, alert_id);
+
+-- Incidents : alertes d'un même appareil ou d'un même emplacement sur une fenêtre glissante
+CREATE TABLE incidents (
+    id BIGSERIAL PRIMARY KEY,
+    site_id VARCHAR(50) NOT NULL REFERENCES sites(id),
+    group_key VARCHAR(150) NOT NULL, -- 'location:<emplacement>' ou 'device:<device_id>'
+    status VARCHAR(20) DEFAULT 'open' CHECK (status IN ('open', 'acknowledged', 'closed')),
+    alert_count INT NOT NULL DEFAULT 0,
+    opened_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_alert_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    acknowledged_at TIMESTAMP,
+    closed_at TIMESTAMP
+);
+
+CREATE TABLE incident_alerts (
+    incident_id BIGINT NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    alert_type VARCHAR(32) NOT NULL,
+    alert_id BIGINT NOT NULL,
+    device_id VARCHAR(50) NOT NULL,
+    message TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (alert_type, alert_id)
+);
+
+CREATE INDEX idx_incidents_group ON incidents(site_id, group_key, last_alert_at DESC) WHERE status <> 'closed';
+CREATE INDEX idx_incidents_status ON incidents(site_id, status);
+CREATE INDEX idx_incident_alerts_incident ON incident_alerts(incident_id);
+
+-- Utilisateurs et clés d'API (authentification via resgate)
+CREATE TABLE users (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('device', 'viewer', 'member', 'admin')),
+    locale VARCHAR(5), -- langue par défaut des téléphones enregistrés par l'utilisateur
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(20) NOT NULL, -- début de la clé, pour la reconnaître
+    key_hash CHAR(64) NOT NULL UNIQUE, -- SHA-256 de la clé, jamais stockée en clair
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_api_keys_user ON api_keys(user_id);
+
+-- Anti-rejeu des mesures signées : un nonce n'est accepté qu'une fois par appareil
+CREATE TABLE device_nonces (
+    device_id VARCHAR(50) NOT NULL REFERENCES devices(device_id) ON DELETE CASCADE,
+    nonce VARCHAR(64) NOT NULL,
+    received_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (device_id, nonce)
+);
+
+CREATE INDEX idx_device_nonces_received ON device_nonces(device_id, received_at);
+
+-- Mesures identifiées par l'appareil (messageId ou séquence) : une retransmission reçoit la réponse d'origine
+CREATE TABLE reading_receipts (
+    site_id VARCHAR(50) NOT NULL REFERENCES sites(id) ON DELETE CASCADE,
+    device_id VARCHAR(50) NOT NULL,
+    sensor_type VARCHAR(32) NOT NULL, -- chaque capteur d'un appareil numérote ses mesures
+    message_id VARCHAR(64) NOT NULL,
+    response JSONB, -- NULL tant que la mesure est en cours d'enregistrement
+    received_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (site_id, device_id, sensor_type, message_id)
+);
+
+CREATE INDEX idx_reading_receipts_received ON reading_receipts(site_id, device_id, received_at);
+
+-- Membres des sites : un utilisateur ne voit que les sites dont il est membre (sauf admin)
+CREATE TABLE site_members (
+    site_id VARCHAR(50) NOT NULL REFERENCES sites(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (site_id, user_id)
+);
+
+CREATE INDEX idx_site_members_user ON site_members(user_id);
+CREATE INDEX idx_devices_site ON devices(site_id);
+
+-- Outbox des événements publiés sur NATS : écrits avec la modification qu'ils décrivent, publiés au moins une fois
+CREATE TABLE event_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    version INTEGER NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    site_id VARCHAR(50) NOT NULL,
+    data JSONB NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    published_at TIMESTAMP -- NULL tant que l'événement n'a pas été publié
+);
+
+CREATE INDEX idx_event_outbox_pending ON event_outbox(id) WHERE published_at IS NULL;
+CREATE INDEX idx_event_outbox_published ON event_outbox(published_at);
+
+-- Webhooks : URL appelées pour les événements choisis, avec une signature HMAC-SHA256 du corps
+CREATE TABLE webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    site_id VARCHAR(50) NOT NULL REFERENCES sites(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    url TEXT NOT NULL,
+    secret VARCHAR(64) NOT NULL, -- clé HMAC, communiquée une seule fois à la création
+    events TEXT[] NOT NULL, -- 'alert.created', 'alert.acknowledged', 'alert.resolved', 'device.offline', 'device.online'
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhooks_site ON webhooks(site_id);
 
-CREATE INDEX idx_distance_alerts_device ON distance_alerts(device_id);
-CREATE INDEX idx_distance_alerts_status ON distance_alerts(alert_status);
-CREATE INDEX idx_distance_alerts_time ON distance_alerts(created_at DESC);
+-- Livraisons des webhooks, écrites avec la modification qu'elles décrivent et réessayées jusqu'à la lettre morte
+CREATE TABLE webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL,
+    version INTEGER NOT NULL,
+    site_id VARCHAR(50) NOT NULL,
+    data JSONB NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_status_code INT, -- NULL quand la requête n'a pas abouti
+    last_error TEXT,
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, id DESC);
+
+-- Journal des actions sur les alertes (acquittement, résolution, mise en sourdine)
+CREATE TABLE alert_audit (
+    id BIGSERIAL PRIMARY KEY,
+    site_id VARCHAR(50) NOT NULL REFERENCES sites(id) ON DELETE CASCADE,
+    alert_type VARCHAR(32) NOT NULL,
+    alert_id BIGINT NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('acknowledge', 'resolve', 'snooze')),
+    actor VARCHAR(100) NOT NULL, -- ex: 'notification' pour un jeton d'action
+    snoozed_until TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
 
-CREATE INDEX idx_motion_alerts_device ON motion_alerts(device_id);
-CREATE INDEX idx_motion_alerts_status ON motion_alerts(alert_status);
-CREATE INDEX idx_motion_alerts_time ON motion_alerts(created_at DESC);
\ No newline at end of file
+CREATE INDEX idx_alert_audit_alert ON alert_audit(alert_type, alert_id, id DESC);
+
+-- Alertes mises en sourdine : l'escalade est suspendue jusqu'à snoozed_until
+CREATE TABLE alert_snoozes (
+    alert_type VARCHAR(32) NOT NULL,
+    alert_id BIGINT NOT NULL,
+    snoozed_until TIMESTAMP NOT NULL,
+    PRIMARY KEY (alert_type, alert_id)
+);
+
+-- Jetons d'action déjà utilisés : un jeton n'est accepté qu'une fois, jusqu'à son expiration
+CREATE TABLE alert_action_tokens (
+    nonce VARCHAR(32) PRIMARY KEY,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_alert_action_tokens_expires ON alert_action_tokens(expires_at);
+
+-- Modèles des notifications (text/template Go) par type d'événement et langue, remplaçant les modèles par défaut
+CREATE TABLE notification_templates (
+    kind VARCHAR(64) NOT NULL, -- ex: 'alert', 'alert.distance', 'sensor_fault', 'escalation'
+    locale VARCHAR(5) NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (kind, locale)
+);
@@ -0,0 +1,162 @@
+package sensormanager
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// AlertAction is an action taken on an alert, recorded in its audit trail.
+type AlertAction string
+
+const (
+	AlertActionAcknowledge AlertAction = "acknowledge"
+	AlertActionResolve     AlertAction = "resolve"
+	AlertActionSnooze      AlertAction = "snooze" // Suspend l'escalade de l'alerte
+)
+
+func (a AlertAction) Validate() error {
+	switch a {
+	case AlertActionAcknowledge, AlertActionResolve, AlertActionSnooze:
+		return nil
+	default:
+		return errors.New("invalid alert action")
+	}
+}
+
+// Status returns the status of an alert after the action, or an empty string when the action keeps its status.
+func (a AlertAction) Status() AlertStatus {
+	switch a {
+	case AlertActionAcknowledge:
+		return AlertStatusAcknowledged
+	case AlertActionResolve:
+		return AlertStatusResolved
+	default:
+		return ""
+	}
+}
+
+// MaxSnooze bounds the duration of a snooze.
+const MaxSnooze = 24 * time.Hour
+
+// AlertActionClaims are the claims of an action token, sent with the notification of an alert so that it can be
+// acknowledged, resolved or snoozed from the notification, without login.
+type AlertActionClaims struct {
+	SiteID    string    `json:"siteId"`
+	AlertType AlertType `json:"alertType"`
+	AlertID   int64     `json:"alertId"`
+	Nonce     string    `json:"nonce"` // Identifie le jeton pour qu'il ne serve qu'une fois
+	ExpiresAt int64     `json:"exp"`   // Secondes Unix
+}
+
+var (
+	ErrInvalidAlertActionToken = errors.New("invalid alert action token")
+	ErrAlertActionTokenExpired = errors.New("alert action token expired")
+	ErrAlertActionTokenUsed    = errors.New("alert action token already used")
+)
+
+// SignAlertActionToken returns the token of the claims: their base64url JSON, a "." and the base64url HMAC-SHA256 of
+// the JSON with the secret.
+func SignAlertActionToken(secret []byte, claims *AlertActionClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// ParseAlertActionToken returns the claims of a token signed with the secret. It returns ErrInvalidAlertActionToken
+// when the token is malformed or not signed with the secret, and ErrAlertActionTokenExpired after its expiry.
+func ParseAlertActionToken(secret []byte, token string, now time.Time) (*AlertActionClaims, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, ErrInvalidAlertActionToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrInvalidAlertActionToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, ErrInvalidAlertActionToken
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, ErrInvalidAlertActionToken
+	}
+
+	var claims AlertActionClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Nonce == "" || claims.AlertID == 0 {
+		return nil, ErrInvalidAlertActionToken
+	}
+
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrAlertActionTokenExpired
+	}
+
+	return &claims, nil
+}
+
+// AlertActionToken is an action token with its expiry, added to the data of the notification of an alert.
+type AlertActionToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+type ExecuteAlertActionParams struct {
+	Token  string
+	Action AlertAction
+	Snooze time.Duration // Durée de la mise en sourdine, pour l'action snooze
+	Actor  string        // Auteur enregistré dans le journal de l'alerte
+}
+
+func (p *ExecuteAlertActionParams) Sanitize() error {
+	if p.Token == "" {
+		return errors.New("token is required")
+	}
+
+	if err := p.Action.Validate(); err != nil {
+		return err
+	}
+
+	if p.Action == AlertActionSnooze && (p.Snooze <= 0 || p.Snooze > MaxSnooze) {
+		return errors.New("snooze duration must be positive and at most 24 hours")
+	}
+
+	return nil
+}
+
+// AlertAuditEntry is an action taken on an alert.
+type AlertAuditEntry struct {
+	ID           int64
+	SiteID       string
+	AlertType    AlertType
+	AlertID      int64
+	Action       AlertAction
+	Actor        string
+	SnoozedUntil *time.Time
+	CreatedAt    time.Time
+}
+
+type AlertActionManager interface {
+	// IssueAlertActionToken returns a short-lived token allowing a single action on the alert.
+	IssueAlertActionToken(siteID string, alertType AlertType, alertID int64) (*AlertActionToken, error)
+
+	// ExecuteAlertAction takes the action on the alert of the token, and records it in the audit trail of the alert.
+	// The token is only accepted once.
+	ExecuteAlertAction(params *ExecuteAlertActionParams) (*AlertAuditEntry, error)
+
+	GetAlertAudit(siteID string, alertType AlertType, alertID int64) ([]*AlertAuditEntry, error)
+}
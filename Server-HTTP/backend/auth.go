@@ -0,0 +1,108 @@
+package sensormanager
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type Role string
+
+const (
+	// RoleDevice can only record readings.
+	RoleDevice Role = "device"
+
+	// RoleViewer can read readings, alerts and incidents.
+	RoleViewer Role = "viewer"
+
+	// RoleMember is a household member: viewer permissions plus acknowledging and resolving alerts.
+	RoleMember Role = "member"
+
+	// RoleAdmin can do everything, including configuration and notifications.send.
+	RoleAdmin Role = "admin"
+)
+
+func (r Role) Validate() error {
+	switch r {
+	case RoleDevice, RoleViewer, RoleMember, RoleAdmin:
+		return nil
+	default:
+		return errors.New("invalid role")
+	}
+}
+
+type User struct {
+	ID        int64
+	Name      string
+	Role      Role
+	Locale    Locale   // Optionnel - langue des notifications des téléphones de l'utilisateur
+	Sites     []string // Sites dont l'utilisateur est membre
+	KeyID     int64    // Clé d'API avec laquelle l'utilisateur s'est authentifié, 0 pour la clé d'amorçage
+	CreatedAt time.Time
+}
+
+// APIKey describes a key without its secret, which is only returned once on creation.
+type APIKey struct {
+	ID        int64
+	UserID    int64
+	Name      string
+	Prefix    string // Premiers caractères de la clé, pour l'identifier
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+type CreateUserParams struct {
+	Name   string
+	Role   string
+	Locale string // Optionnel
+}
+
+type CreateAPIKeyParams struct {
+	UserID int64
+	Name   string
+}
+
+func (p *CreateUserParams) Sanitize() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if p.Locale != "" {
+		if err := Locale(p.Locale).Validate(); err != nil {
+			return err
+		}
+	}
+
+	return Role(p.Role).Validate()
+}
+
+func (p *CreateAPIKeyParams) Sanitize() error {
+	if p.UserID == 0 {
+		return errors.New("userId is required")
+	}
+
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+
+	return nil
+}
+
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+type AuthManager interface {
+	CreateUser(params *CreateUserParams) (*User, error)
+	GetUsers() ([]*User, error)
+
+	// CreateAPIKey returns the key description and its secret. Only a hash of the secret is stored.
+	CreateAPIKey(params *CreateAPIKeyParams) (*APIKey, string, error)
+	GetAPIKeys(userID int64) ([]*APIKey, error)
+	RevokeAPIKey(keyID int64) error
+
+	// Authenticate returns the owner of the key, or ErrInvalidAPIKey when the key is unknown or revoked.
+	Authenticate(key string) (*User, error)
+	// AuthenticateKeyID is Authenticate for a key already presented, so its connections get an up to date token.
+	AuthenticateKeyID(keyID int64) (*User, error)
+}
@@ -2,10 +2,24 @@ package main
 
 import (
 	"fmt"
+	"net/http"
 	_ "net/http/pprof"
+	"sensormanager"
+	"sensormanager/coap"
 	"sensormanager/environment"
+	"sensormanager/escalation"
+	"sensormanager/events"
+	"sensormanager/filter"
+	"sensormanager/homeassistant"
+	"sensormanager/ingestion"
+	"sensormanager/motion"
+	"sensormanager/mqtt"
+	"sensormanager/ota"
+	"sensormanager/quality"
 	"sensormanager/server"
 	"sensormanager/store"
+	"sensormanager/webhooks"
+	"time"
 
 	"github.com/jirenius/go-res"
 	"github.com/loungeup/go-loungeup/pkg/log"
@@ -33,6 +47,63 @@ func main() {
 
 	store := store.New(
 		store.WithDB(db),
+		store.WithDistanceFilter(filter.Config{
+			WindowSize:    variables.DistanceFilterWindowSize,
+			MinValue:      variables.DistanceFilterMinCm,
+			MaxValue:      variables.DistanceFilterMaxCm,
+			Confirmations: variables.DistanceFilterConfirmations,
+		}),
+		store.WithMicrophoneFilter(filter.Config{
+			WindowSize:    variables.MicrophoneFilterWindowSize,
+			MinValue:      variables.MicrophoneFilterMinDB,
+			MaxValue:      variables.MicrophoneFilterMaxDB,
+			Confirmations: variables.MicrophoneFilterConfirmations,
+		}),
+		store.WithMotionDetector(motion.Config{
+			DebounceCount:   variables.MotionDebounceCount,
+			DebounceWindow:  variables.MotionDebounceWindow,
+			ContinuousAfter: variables.MotionContinuousAfter,
+			QuietPeriod:     variables.MotionQuietPeriod,
+			ExpireAfter:     variables.MotionExpireAfter,
+		}),
+		store.WithDistanceQuality(quality.Config{
+			StuckCount:     variables.DistanceQualityStuckCount,
+			VarianceWindow: variables.DistanceQualityVarianceWindow,
+			MinVariance:    variables.DistanceQualityMinVariance,
+			DriftAlpha:     variables.DistanceQualityDriftAlpha,
+			DriftWarmup:    variables.DistanceQualityDriftWarmup,
+			MaxDrift:       variables.DistanceQualityMaxDrift,
+		}),
+		store.WithMicrophoneQuality(quality.Config{
+			StuckCount:     variables.MicrophoneQualityStuckCount,
+			VarianceWindow: variables.MicrophoneQualityVarianceWindow,
+			MinVariance:    variables.MicrophoneQualityMinVariance,
+			DriftAlpha:     variables.MicrophoneQualityDriftAlpha,
+			DriftWarmup:    variables.MicrophoneQualityDriftWarmup,
+			MaxDrift:       variables.MicrophoneQualityMaxDrift,
+		}),
+		store.WithIncidentWindow(variables.IncidentWindow),
+		store.WithBootstrapAdminKey(variables.AuthBootstrapAdminKey),
+		store.WithDeviceSignatures(variables.DeviceSignatureRequired, variables.DeviceSignatureMaxAge),
+		store.WithUnknownDevicePolicy(sensormanager.UnknownDevicePolicy(variables.UnknownDevicePolicy)),
+		store.WithReadingTime(variables.ReadingMaxClockSkew, variables.ReadingMaxAge, variables.ReadingAlertHorizon),
+		store.WithDedupWindow(variables.ReadingDedupWindow, variables.ReadingClaimLease),
+		store.WithTelemetryAlerts(
+			variables.TelemetryWeakSignalRSSI,
+			variables.TelemetryWeakSignalFor,
+			variables.TelemetryRebootLoopCount,
+			variables.TelemetryRebootLoopWindow,
+		),
+		store.WithCoAP(coap.Config{
+			AckTimeout:    variables.CoAPAckTimeout,
+			MaxRetransmit: variables.CoAPMaxRetransmit,
+		}),
+		store.WithFirmwareStorage(variables.FirmwareDir),
+		store.WithEventOutbox(variables.EventsEnabled),
+		store.WithWebhooks(variables.WebhooksEnabled),
+		store.WithOfflineDetection(variables.DeviceOfflineAfter),
+		store.WithAlertActionTokens(variables.AlertActionSecret, variables.AlertActionTokenTTL),
+		store.WithNotificationLocale(sensormanager.Locale(variables.NotificationLocale)),
 	)
 
 	// store.Sensors.SetThreshold(&sensormanager.ThresholdConfig{
@@ -42,11 +113,176 @@ func main() {
 	// 	CooldownSec: 0,
 	// })
 
-	server.New(
+	serverOptions := []server.Option{
 		server.WithService(service),
 		server.WithStore(store),
+		server.WithAlertBeep(variables.AlertBeepEnabled),
+	}
+
+	var dispatcher *webhooks.Dispatcher
+	if variables.WebhooksEnabled {
+		dispatcher = webhooks.New(
+			webhooks.WithStore(store),
+			webhooks.WithInterval(variables.WebhooksInterval),
+			webhooks.WithTimeout(variables.WebhooksTimeout),
+			webhooks.WithRetries(variables.WebhooksMaxAttempts, variables.WebhooksRetryDelay, variables.WebhooksMaxRetryDelay),
+			webhooks.WithRetention(variables.WebhooksRetention),
+		)
+
+		serverOptions = append(serverOptions, server.WithWebhooks(dispatcher))
+	}
+
+	if variables.HomeAssistantBrokerURL != "" {
+		publisher := homeassistant.New(
+			homeassistant.WithBroker(mqtt.Config{
+				BrokerURL: variables.HomeAssistantBrokerURL,
+				ClientID:  variables.HomeAssistantClientID,
+				Username:  variables.HomeAssistantUsername,
+				Password:  variables.HomeAssistantPassword,
+			}),
+			homeassistant.WithStore(store),
+			homeassistant.WithTopics(variables.HomeAssistantDiscoveryPrefix, variables.HomeAssistantTopicPrefix),
+			homeassistant.WithSyncInterval(variables.HomeAssistantSyncInterval),
+		)
+
+		if err := publisher.Start(); err != nil {
+			panic(fmt.Errorf("could not start Home Assistant publisher: %w", err))
+		}
+
+		fmt.Printf("Publishing to Home Assistant on %s\n", variables.HomeAssistantBrokerURL)
+
+		serverOptions = append(serverOptions, server.WithObserver(publisher))
+	}
+
+	var queue *ingestion.Queue
+	if variables.IngestionQueueEnabled {
+		queue = ingestion.New(
+			ingestion.WithConn(natsConn),
+			ingestion.WithConfig(ingestion.Config{
+				Stream:   variables.IngestionQueueStream,
+				Consumer: variables.IngestionQueueConsumer,
+				MaxAge:   variables.IngestionQueueMaxAge,
+				MaxBytes: variables.IngestionQueueMaxBytes,
+				MaxMsgs:  variables.IngestionQueueMaxMsgs,
+			}),
+			ingestion.WithHealthCheck(db.Ping),
+			ingestion.WithRetryDelay(variables.IngestionQueueRetryDelay),
+			ingestion.WithAckWait(variables.IngestionQueueAckWait),
+		)
+
+		if err := queue.Start(); err != nil {
+			panic(fmt.Errorf("could not start ingestion queue: %w", err))
+		}
+
+		serverOptions = append(serverOptions, server.WithQueue(queue))
+	}
+
+	resServer := server.New(serverOptions...)
+
+	// Fonctions lancées une fois le service prêt
+	var onServe []func()
+
+	if variables.MQTTBrokerURL != "" {
+		mappings, err := mqtt.LoadMappings(variables.MQTTMappingsFile)
+		if err != nil {
+			panic(fmt.Errorf("could not load MQTT mappings: %w", err))
+		}
+
+		bridge := mqtt.New(
+			mqtt.WithBroker(mqtt.Config{
+				BrokerURL: variables.MQTTBrokerURL,
+				ClientID:  variables.MQTTClientID,
+				Username:  variables.MQTTUsername,
+				Password:  variables.MQTTPassword,
+			}),
+			mqtt.WithRecorder(resServer),
+			mqtt.WithMappings(mappings),
+		)
+
+		// Les mesures MQTT publient des événements RES : le pont démarre une fois le service prêt.
+		onServe = append(onServe, func() {
+			if err := bridge.Start(); err != nil {
+				panic(fmt.Errorf("could not start MQTT bridge: %w", err))
+			}
+
+			fmt.Printf("Bridging %d MQTT topics from %s\n", len(mappings), variables.MQTTBrokerURL)
+		})
+	}
+
+	if queue != nil {
+		// Comme pour le pont MQTT, les mesures de la file publient des événements RES.
+		onServe = append(onServe, func() {
+			if err := queue.Consume(resServer); err != nil {
+				panic(fmt.Errorf("could not consume ingestion queue: %w", err))
+			}
+
+			fmt.Printf("Recording readings from stream %s\n", variables.IngestionQueueStream)
+		})
+	}
+
+	service.SetOnServe(func(*res.Service) {
+		for _, start := range onServe {
+			start()
+		}
+	})
+
+	if variables.EventsEnabled {
+		relay := events.New(
+			events.WithStore(store),
+			events.WithConn(natsConn),
+			events.WithInterval(variables.EventsInterval),
+			events.WithBatchSize(variables.EventsBatchSize),
+			events.WithRetention(variables.EventsRetention),
+			events.WithJetStream(variables.EventsJetStreamStream, variables.EventsJetStreamMaxAge),
+		)
+
+		if err := relay.Start(); err != nil {
+			panic(fmt.Errorf("could not start event relay: %w", err))
+		}
+
+		go relay.Run()
+	}
+
+	if dispatcher != nil {
+		go dispatcher.Run()
+	}
+
+	// 📴 Détection des appareils muets, indépendante des webhooks : les événements de connectivité sont publiés sur RES.
+	if variables.DeviceOfflineAfter > 0 {
+		go func() {
+			ticker := time.NewTicker(variables.DeviceOfflineInterval)
+			defer ticker.Stop()
+
+			for range ticker.C {
+				if marked, err := store.Devices.MarkOfflineDevices(); err != nil {
+					fmt.Printf("❌ Could not mark offline devices: %v\n", err)
+				} else if marked > 0 {
+					fmt.Printf("📴 %d devices marked offline\n", marked)
+				}
+			}
+		}()
+	}
+
+	go escalation.New(
+		escalation.WithStore(store),
+		escalation.WithInterval(variables.EscalationInterval),
+	).Run()
+
+	updater := ota.New(
+		ota.WithStore(store),
+		ota.WithRollouts(variables.FirmwareRolloutInterval, variables.FirmwareRolloutHealthTimeout),
 	)
 
+	go updater.Run()
+
+	go func() {
+		fmt.Printf("Serving firmware updates on %s\n", variables.FirmwareHTTPAddr)
+
+		if err := http.ListenAndServe(variables.FirmwareHTTPAddr, updater); err != nil {
+			panic(fmt.Errorf("could not serve firmware updates: %w", err))
+		}
+	}()
+
 	if variables.HealthEnabled {
 		go checker.HTTP(
 			func() error {
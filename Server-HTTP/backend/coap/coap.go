@@ -0,0 +1,315 @@
+// Package coap sends confirmable CoAP requests (RFC 7252) to the devices and waits for their acknowledgement.
+package coap
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+type messageType uint8
+
+const (
+	typeConfirmable     messageType = 0
+	typeAcknowledgement messageType = 2
+	typeReset           messageType = 3
+)
+
+// Code is the code of a CoAP message, in the class.detail format of RFC 7252, e.g. 2.04 for Changed.
+type Code uint8
+
+const (
+	CodeEmpty   Code = 0x00
+	CodePut     Code = 0x03
+	CodeChanged Code = 0x44
+	CodeContent Code = 0x45
+)
+
+func (c Code) String() string { return fmt.Sprintf("%d.%02d", c>>5, c&0x1f) }
+
+// Success reports whether the code is a 2.xx response code.
+func (c Code) Success() bool { return c>>5 == 2 }
+
+const (
+	optionURIPath       = 11
+	optionContentFormat = 12
+
+	contentFormatJSON = 50
+
+	payloadMarker = 0xff
+	maxTokenSize  = 8
+)
+
+var (
+	// ErrTimeout is returned when the device did not acknowledge the request after every retransmission.
+	ErrTimeout = errors.New("coap: request was not acknowledged")
+
+	// ErrReset is returned when the device rejected the request with a Reset message.
+	ErrReset = errors.New("coap: request was reset by the device")
+
+	errInvalidMessage = errors.New("coap: invalid message")
+)
+
+type Config struct {
+	// AckTimeout is the delay before the first retransmission. It doubles at each retransmission.
+	AckTimeout time.Duration
+
+	// MaxRetransmit is the number of retransmissions before the request times out. Zero values use the defaults of
+	// RFC 7252: 2 seconds and 4 retransmissions.
+	MaxRetransmit int
+}
+
+// Client sends the requests. It is safe for concurrent use.
+type Client struct {
+	config Config
+
+	mu        sync.Mutex
+	messageID uint16
+}
+
+func New(config Config) *Client {
+	// Valeurs par défaut de la RFC 7252
+	if config.AckTimeout <= 0 {
+		config.AckTimeout = 2 * time.Second
+	}
+	if config.MaxRetransmit <= 0 {
+		config.MaxRetransmit = 4
+	}
+
+	var seed [2]byte
+	rand.Read(seed[:])
+
+	return &Client{config: config, messageID: binary.BigEndian.Uint16(seed[:])}
+}
+
+// Response is the response piggybacked in the acknowledgement. Its code is CodeEmpty when the device acknowledged
+// the request without responding yet.
+type Response struct {
+	Code    Code
+	Payload []byte
+
+	// Attempts is the number of transmissions of the request, retransmissions included.
+	Attempts int
+}
+
+// Put sends a confirmable PUT request with a JSON payload to the path of the device at address ("ip:port"). It
+// returns once the request is acknowledged, reset, or timed out.
+func (c *Client) Put(ctx context.Context, address, path string, payload []byte) (*Response, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	token := make([]byte, 4)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	request := &message{
+		messageType: typeConfirmable,
+		code:        CodePut,
+		messageID:   c.nextMessageID(),
+		token:       token,
+		path:        path,
+		payload:     payload,
+	}
+
+	data, err := request.marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := c.config.AckTimeout
+	buffer := make([]byte, 1500)
+
+	for attempt := 1; attempt <= c.config.MaxRetransmit+1; attempt++ {
+		if _, err := conn.Write(data); err != nil {
+			return nil, err
+		}
+
+		deadline := time.Now().Add(timeout)
+		if contextDeadline, ok := ctx.Deadline(); ok && contextDeadline.Before(deadline) {
+			deadline = contextDeadline
+		}
+		conn.SetReadDeadline(deadline)
+
+		for {
+			n, err := conn.Read(buffer)
+			if isTimeout(err) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			response, err := unmarshal(buffer[:n])
+			if err != nil || response.messageID != request.messageID {
+				continue // Message d'un autre échange, ignoré
+			}
+
+			switch response.messageType {
+			case typeAcknowledgement:
+				return &Response{Code: response.code, Payload: response.payload, Attempts: attempt}, nil
+			case typeReset:
+				return nil, ErrReset
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		timeout *= 2
+	}
+
+	return nil, ErrTimeout
+}
+
+func (c *Client) nextMessageID() uint16 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.messageID++
+
+	return c.messageID
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// message is a CoAP message. Only the Uri-Path and Content-Format options are encoded, the other options are
+// skipped when decoding.
+type message struct {
+	messageType messageType
+	code        Code
+	messageID   uint16
+	token       []byte
+	path        string
+	payload     []byte
+}
+
+func (m *message) marshal() ([]byte, error) {
+	if len(m.token) > maxTokenSize {
+		return nil, errInvalidMessage
+	}
+
+	data := []byte{1<<6 | byte(m.messageType)<<4 | byte(len(m.token)), byte(m.code), 0, 0}
+	binary.BigEndian.PutUint16(data[2:], m.messageID)
+	data = append(data, m.token...)
+
+	previous := 0
+	if m.path != "" {
+		for _, segment := range strings.Split(strings.Trim(m.path, "/"), "/") {
+			data = appendOption(data, optionURIPath-previous, []byte(segment))
+			previous = optionURIPath
+		}
+	}
+
+	if len(m.payload) > 0 {
+		data = appendOption(data, optionContentFormat-previous, []byte{contentFormatJSON})
+		data = append(data, payloadMarker)
+		data = append(data, m.payload...)
+	}
+
+	return data, nil
+}
+
+func appendOption(data []byte, delta int, value []byte) []byte {
+	deltaNibble, deltaExtended := optionNibble(delta)
+	lengthNibble, lengthExtended := optionNibble(len(value))
+
+	data = append(data, deltaNibble<<4|lengthNibble)
+	data = append(data, deltaExtended...)
+	data = append(data, lengthExtended...)
+
+	return append(data, value...)
+}
+
+// optionNibble encodes an option delta or length, with its extended bytes.
+func optionNibble(value int) (byte, []byte) {
+	switch {
+	case value < 13:
+		return byte(value), nil
+	case value < 269:
+		return 13, []byte{byte(value - 13)}
+	default:
+		extended := make([]byte, 2)
+		binary.BigEndian.PutUint16(extended, uint16(value-269))
+		return 14, extended
+	}
+}
+
+func unmarshal(data []byte) (*message, error) {
+	if len(data) < 4 || data[0]>>6 != 1 {
+		return nil, errInvalidMessage
+	}
+
+	tokenLength := int(data[0] & 0x0f)
+	if tokenLength > maxTokenSize || len(data) < 4+tokenLength {
+		return nil, errInvalidMessage
+	}
+
+	result := &message{
+		messageType: messageType(data[0] >> 4 & 0x03),
+		code:        Code(data[1]),
+		messageID:   binary.BigEndian.Uint16(data[2:4]),
+		token:       data[4 : 4+tokenLength],
+	}
+
+	var segments []string
+	option := 0
+	rest := data[4+tokenLength:]
+
+	for len(rest) > 0 {
+		if rest[0] == payloadMarker {
+			result.payload = rest[1:]
+			break
+		}
+
+		header := rest[0]
+		rest = rest[1:]
+
+		delta, remaining, err := readNibble(header>>4, rest)
+		if err != nil {
+			return nil, err
+		}
+
+		length, remaining, err := readNibble(header&0x0f, remaining)
+		if err != nil || len(remaining) < length {
+			return nil, errInvalidMessage
+		}
+
+		option += delta
+		if option == optionURIPath {
+			segments = append(segments, string(remaining[:length]))
+		}
+
+		rest = remaining[length:]
+	}
+
+	result.path = strings.Join(segments, "/")
+
+	return result, nil
+}
+
+func readNibble(nibble byte, data []byte) (int, []byte, error) {
+	switch {
+	case nibble < 13:
+		return int(nibble), data, nil
+	case nibble == 13 && len(data) >= 1:
+		return int(data[0]) + 13, data[1:], nil
+	case nibble == 14 && len(data) >= 2:
+		return int(binary.BigEndian.Uint16(data)) + 269, data[2:], nil
+	default:
+		return 0, nil, errInvalidMessage
+	}
+}
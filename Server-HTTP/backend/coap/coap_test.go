@@ -0,0 +1,126 @@
+package coap
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// device simule un appareil : handle reçoit chaque requête et retourne la réponse à envoyer, ou nil pour l'ignorer.
+func device(t *testing.T, handle func(request *message, received int) *message) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buffer := make([]byte, 1500)
+		for received := 1; ; received++ {
+			n, address, err := conn.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+
+			request, err := unmarshal(buffer[:n])
+			if err != nil {
+				t.Errorf("invalid request: %v", err)
+				return
+			}
+
+			if response := handle(request, received); response != nil {
+				data, _ := response.marshal()
+				conn.WriteTo(data, address)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func acknowledge(request *message, code Code) *message {
+	return &message{messageType: typeAcknowledgement, code: code, messageID: request.messageID, token: request.token}
+}
+
+func TestPut(t *testing.T) {
+	address := device(t, func(request *message, _ int) *message {
+		if request.messageType != typeConfirmable || request.code != CodePut {
+			t.Errorf("got a %d request with code %s, want a confirmable PUT", request.messageType, request.code)
+		}
+		if request.path != "alert" {
+			t.Errorf("got path %q, want %q", request.path, "alert")
+		}
+		if string(request.payload) != `{"command":"beep"}` {
+			t.Errorf("got payload %q", request.payload)
+		}
+
+		return acknowledge(request, CodeChanged)
+	})
+
+	client := New(Config{AckTimeout: 50 * time.Millisecond, MaxRetransmit: 2})
+
+	response, err := client.Put(context.Background(), address, "alert", []byte(`{"command":"beep"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.Code != CodeChanged || response.Attempts != 1 {
+		t.Fatalf("got code %s after %d attempts, want 2.04 after 1 attempt", response.Code, response.Attempts)
+	}
+}
+
+func TestPutRetransmits(t *testing.T) {
+	// Les deux premières transmissions sont perdues.
+	address := device(t, func(request *message, received int) *message {
+		if received < 3 {
+			return nil
+		}
+
+		return acknowledge(request, CodeEmpty)
+	})
+
+	client := New(Config{AckTimeout: 20 * time.Millisecond, MaxRetransmit: 3})
+
+	response, err := client.Put(context.Background(), address, "alert", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.Attempts != 3 {
+		t.Fatalf("got %d attempts, want 3", response.Attempts)
+	}
+}
+
+func TestPutTimeout(t *testing.T) {
+	received := make(chan struct{}, 10)
+	address := device(t, func(*message, int) *message {
+		received <- struct{}{}
+		return nil
+	})
+
+	client := New(Config{AckTimeout: 10 * time.Millisecond, MaxRetransmit: 2})
+
+	if _, err := client.Put(context.Background(), address, "alert", nil); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("got error %v, want %v", err, ErrTimeout)
+	}
+
+	if len(received) != 3 {
+		t.Fatalf("got %d transmissions, want 3", len(received))
+	}
+}
+
+func TestPutReset(t *testing.T) {
+	address := device(t, func(request *message, _ int) *message {
+		return &message{messageType: typeReset, messageID: request.messageID}
+	})
+
+	client := New(Config{AckTimeout: 50 * time.Millisecond})
+
+	if _, err := client.Put(context.Background(), address, "alert", nil); !errors.Is(err, ErrReset) {
+		t.Fatalf("got error %v, want %v", err, ErrReset)
+	}
+}
@@ -0,0 +1,160 @@
+package sensormanager
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"time"
+)
+
+// ReadingSource is the network address a device reported from, forwarded by the CoAP gateway. The commands of the
+// device are sent to its last known address.
+type ReadingSource struct {
+	SourceAddress string `json:"sourceAddress,omitempty"` // ex: "192.168.40.12:4832"
+
+	// Gateway names the gateway of the backend that received the reading, e.g. "mqtt". Such readings are
+	// authenticated by the gateway and are not signed. It cannot be set by RES clients.
+	Gateway string `json:"-"`
+
+	// ReceivedAt is the time the reading was received, when it is recorded later, e.g. by the ingestion queue. The
+	// reading time and the signature are checked against it instead of the time of recording. It cannot be set by RES
+	// clients.
+	ReceivedAt time.Time `json:"-"`
+}
+
+func (s *ReadingSource) Sanitize() error {
+	if s.SourceAddress == "" {
+		return nil
+	}
+
+	host, port, err := net.SplitHostPort(s.SourceAddress)
+	if err != nil || net.ParseIP(host) == nil {
+		return errors.New("sourceAddress must be an IP address and a port")
+	}
+
+	if number, err := strconv.Atoi(port); err != nil || number <= 0 || number > 65535 {
+		return errors.New("sourceAddress must be an IP address and a port")
+	}
+
+	return nil
+}
+
+// CommandKind is a command understood by the "alert" CoAP resource of the firmware.
+type CommandKind string
+
+const (
+	CommandBeep        CommandKind = "beep"
+	CommandLEDOn       CommandKind = "led_on"
+	CommandLEDOff      CommandKind = "led_off"
+	CommandReboot      CommandKind = "reboot"
+	CommandSetInterval CommandKind = "set_interval" // Intervalle entre deux envois de mesures
+	CommandConfig      CommandKind = "config"       // Delta du shadow de l'appareil
+)
+
+func (k CommandKind) Validate() error {
+	switch k {
+	case CommandBeep, CommandLEDOn, CommandLEDOff, CommandReboot, CommandSetInterval, CommandConfig:
+		return nil
+	default:
+		return errors.New("invalid command")
+	}
+}
+
+type CommandStatus string
+
+const (
+	CommandStatusPending      CommandStatus = "pending"      // En cours d'envoi
+	CommandStatusAcknowledged CommandStatus = "acknowledged" // ACK CoAP reçu
+	CommandStatusRejected     CommandStatus = "rejected"     // Reset ou code de réponse d'erreur
+	CommandStatusTimeout      CommandStatus = "timeout"      // Aucun ACK après les retransmissions
+	CommandStatusFailed       CommandStatus = "failed"       // Erreur réseau
+)
+
+// CommandResource is the CoAP resource of the firmware receiving the commands.
+const CommandResource = "alert"
+
+const (
+	MinCommandInterval = 1    // Secondes
+	MaxCommandInterval = 3600 // Secondes
+)
+
+var ErrDeviceUnreachable = errors.New("device address is unknown")
+
+type SendCommandParams struct {
+	SiteID          string
+	DeviceID        string
+	Kind            CommandKind
+	IntervalSeconds *int           // Requis pour set_interval
+	Config          ShadowDocument // Requis pour config
+	RequestedBy     string         // ex: "user:3", "alert:distance:42"
+}
+
+func (p *SendCommandParams) Sanitize() error {
+	if p.SiteID == "" {
+		return errors.New("siteId is required")
+	}
+	if p.DeviceID == "" {
+		return errors.New("deviceId is required")
+	}
+	if err := p.Kind.Validate(); err != nil {
+		return err
+	}
+
+	if p.Kind != CommandConfig && p.Config != nil {
+		return errors.New("config is only accepted by the config command")
+	}
+	if p.Kind == CommandConfig {
+		return p.Config.validate("config")
+	}
+
+	if p.Kind != CommandSetInterval {
+		if p.IntervalSeconds != nil {
+			return errors.New("intervalSeconds is only accepted by the set_interval command")
+		}
+
+		return nil
+	}
+
+	if p.IntervalSeconds == nil {
+		return errors.New("intervalSeconds is required")
+	}
+	if *p.IntervalSeconds < MinCommandInterval || *p.IntervalSeconds > MaxCommandInterval {
+		return errors.New("intervalSeconds must be between 1 and 3600")
+	}
+
+	return nil
+}
+
+// Payload returns the JSON payload sent to the device, e.g. {"command":"set_interval","intervalSeconds":5}.
+func (p *SendCommandParams) Payload() []byte {
+	payload, _ := json.Marshal(struct {
+		Command         CommandKind    `json:"command"`
+		IntervalSeconds *int           `json:"intervalSeconds,omitempty"`
+		Config          ShadowDocument `json:"config,omitempty"`
+	}{p.Kind, p.IntervalSeconds, p.Config})
+
+	return payload
+}
+
+type DeviceCommand struct {
+	ID              int64
+	DeviceID        string
+	Kind            CommandKind
+	IntervalSeconds *int
+	Address         string
+	Status          CommandStatus
+	Attempts        int    // Transmissions CoAP, retransmissions comprises
+	ResponseCode    string // Optionnel - code de la réponse de l'appareil, ex: "2.04"
+	Error           string
+	RequestedBy     string
+	CreatedAt       time.Time
+	CompletedAt     *time.Time
+}
+
+type CommandManager interface {
+	// SendCommand records the command and sends it to the last known address of the device in the background. The
+	// returned command is pending: its delivery is tracked by GetCommands.
+	SendCommand(params *SendCommandParams) (*DeviceCommand, error)
+	GetCommands(siteID, deviceID string, limit int) ([]*DeviceCommand, error)
+}
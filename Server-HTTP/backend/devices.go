@@ -0,0 +1,206 @@
+package sensormanager
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type DeviceHealth string
+
+const (
+	DeviceHealthOK    DeviceHealth = "ok"
+	DeviceHealthStuck DeviceHealth = "stuck"
+	DeviceHealthFlat  DeviceHealth = "flat"
+	DeviceHealthDrift DeviceHealth = "drift"
+)
+
+type DeviceStatus string
+
+const (
+	DeviceStatusActive   DeviceStatus = "active"
+	DeviceStatusDisabled DeviceStatus = "disabled"
+	DeviceStatusPending  DeviceStatus = "pending"  // Appareil inconnu en attente d'approbation
+	DeviceStatusRejected DeviceStatus = "rejected" // Appareil inconnu refusé, ses mesures sont rejetées
+)
+
+// UnknownDevicePolicy is how the readings of a device that has not been registered yet are handled. The device is
+// added to the pending devices, unless the policy is UnknownDeviceReject.
+type UnknownDevicePolicy string
+
+const (
+	UnknownDeviceAccept     UnknownDevicePolicy = "accept"     // Mesures enregistrées et évaluées normalement
+	UnknownDeviceQuarantine UnknownDevicePolicy = "quarantine" // Mesures mises de côté jusqu'à l'approbation
+	UnknownDeviceReject     UnknownDevicePolicy = "reject"     // Mode strict : seuls les appareils enregistrés sont acceptés
+)
+
+func (p UnknownDevicePolicy) Validate() error {
+	switch p {
+	case UnknownDeviceAccept, UnknownDeviceQuarantine, UnknownDeviceReject:
+		return nil
+	default:
+		return errors.New("invalid unknown device policy")
+	}
+}
+
+type Device struct {
+	SiteID           string
+	DeviceID         string
+	SensorType       SensorType
+	Status           DeviceStatus
+	Provisioned      bool       // Un secret a été émis pour l'appareil
+	SecretRotatedAt  *time.Time // Optionnel - date d'émission du secret courant
+	Location         string     // Optionnel - regroupe les alertes en incidents
+	Health           DeviceHealth
+	HealthReason     string
+	HealthUpdatedAt  *time.Time
+	Telemetry        *DeviceTelemetry // Optionnel - dernières valeurs de télémétrie reçues
+	Address          string           // Optionnel - dernière adresse "ip:port" connue, destinataire des commandes
+	AddressUpdatedAt *time.Time
+	LastSeenAt       *time.Time // Optionnel - dernière mesure reçue, à la minute près
+	OfflineSince     *time.Time // Optionnel - l'appareil n'envoie plus rien depuis le délai configuré
+	CreatedAt        time.Time
+}
+
+// PendingDevice is a device that sent readings without being registered, waiting to be approved or rejected.
+type PendingDevice struct {
+	*Device
+	QuarantinedReadings int        // Mesures conservées jusqu'à l'approbation
+	LastReadingAt       *time.Time // Optionnel - dernière mesure mise en quarantaine
+}
+
+type ProvisionDeviceParams struct {
+	SiteID     string
+	DeviceID   string
+	SensorType string
+}
+
+func (p *ProvisionDeviceParams) Sanitize() error {
+	if p.SiteID == "" {
+		return errors.New("siteId is required")
+	}
+
+	p.DeviceID = strings.TrimSpace(p.DeviceID)
+	if p.DeviceID == "" {
+		return errors.New("deviceId is required")
+	}
+
+	if _, err := LookupSensorType(SensorType(p.SensorType)); err != nil {
+		return errors.New("invalid sensorType")
+	}
+
+	return nil
+}
+
+// SignedPayload authenticates a reading with the secret of its device. The signature is the hexadecimal
+// HMAC-SHA256 of the message returned by SignedMessage.
+type SignedPayload struct {
+	Timestamp int64  `json:"timestamp"` // Secondes Unix
+	Nonce     string `json:"nonce"`
+	Signature string `json:"signature"`
+}
+
+var (
+	ErrUnknownDevice    = errors.New("unknown device") // Also returned for a device of another site
+	ErrDeviceDisabled   = errors.New("device is disabled")
+	ErrDeviceRejected   = errors.New("device has been rejected")
+	ErrDeviceNotPending = errors.New("device is not pending approval")
+
+	// ErrUnregisteredDevice rejects the readings of a device that is not registered, in strict mode.
+	ErrUnregisteredDevice = errors.New("device is not registered")
+	ErrSignatureRequired  = errors.New("payload signature is required")
+	ErrInvalidSignature   = errors.New("invalid payload signature")
+	ErrExpiredPayload     = errors.New("payload timestamp is out of the accepted window")
+	ErrReplayedPayload    = errors.New("payload nonce has already been used")
+)
+
+// SignedMessage returns the message signed by the devices: the sensor type, device ID, value, timestamp, nonce, then
+// the reading time in Unix milliseconds, sequence and messageId, separated by "|" and empty when missing. Decimal
+// values are formatted with the shortest representation, without trailing zeros nor exponent, e.g. "12.5" for a
+// reading sent as 12.50, and booleans as "true" or "false". The timing and ID are nil for messages without them.
+func SignedMessage(
+	sensorType SensorType,
+	deviceID string,
+	value string,
+	timing *ReadingTime,
+	id *ReadingID,
+	timestamp int64,
+	nonce string,
+) string {
+	var recordedAt, sequence, messageID string
+	if timing != nil && timing.RecordedAt != nil {
+		recordedAt = strconv.FormatInt(timing.RecordedAt.UnixMilli(), 10)
+	}
+	if timing != nil && timing.Sequence != nil {
+		sequence = strconv.FormatInt(*timing.Sequence, 10)
+	}
+	if id != nil {
+		messageID = id.MessageID
+	}
+
+	return fmt.Sprintf(
+		"%s|%s|%s|%d|%s|%s|%s|%s",
+		sensorType, deviceID, value, timestamp, nonce, recordedAt, sequence, messageID,
+	)
+}
+
+// Verify checks the signature of the payload against the device secret.
+func (p *SignedPayload) Verify(
+	secret string,
+	sensorType SensorType,
+	deviceID string,
+	value string,
+	timing *ReadingTime,
+	id *ReadingID,
+) error {
+	if p.Nonce == "" || p.Timestamp == 0 {
+		return ErrInvalidSignature
+	}
+
+	signature, err := hex.DecodeString(p.Signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(SignedMessage(sensorType, deviceID, value, timing, id, p.Timestamp, p.Nonce)))
+
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+type DeviceManager interface {
+	GetDevices(siteID string) ([]*Device, error)
+	GetDevice(siteID, deviceID string) (*Device, error)
+	SetDeviceLocation(siteID, deviceID string, location string) error
+
+	// ProvisionDevice registers the device, or re-enables it, and returns its new secret. The secret is only
+	// returned here and by RotateDeviceSecret.
+	ProvisionDevice(params *ProvisionDeviceParams) (*Device, string, error)
+	RotateDeviceSecret(siteID, deviceID string) (string, error)
+
+	// RevokeDevice disables the device and drops its secret: its readings are rejected until it is provisioned again.
+	RevokeDevice(siteID, deviceID string) error
+
+	GetPendingDevices(siteID string) ([]*PendingDevice, error)
+
+	// ApproveDevice activates a pending or rejected device. Its quarantined readings are moved to the history, without
+	// evaluating alerts.
+	ApproveDevice(siteID, deviceID string) (*Device, error)
+
+	// RejectDevice drops the quarantined readings of a pending device. Its next readings are rejected until it is
+	// approved or provisioned.
+	RejectDevice(siteID, deviceID string) error
+
+	// MarkOfflineDevices marks offline the active devices that sent neither reading nor telemetry for the configured
+	// delay, and returns their number. A device is back online with its next reading.
+	MarkOfflineDevices() (int, error)
+}
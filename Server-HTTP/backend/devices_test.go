@@ -0,0 +1,125 @@
+package sensormanager
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sign(secret, message string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestSignedMessage(t *testing.T) {
+	recordedAt := time.UnixMilli(1772362795250)
+	sequence := int64(42)
+
+	tests := map[string]struct {
+		sensorType SensorType
+		value      string
+		timing     *ReadingTime
+		id         *ReadingID
+		want       string
+	}{
+		"decimal value": {SensorTypeDistance, "12.5", nil, nil, "distance|ESP_001|12.5|1772362800|n-1|||"},
+		"boolean value": {SensorTypeMotion, "true", nil, nil, "motion|ESP_001|true|1772362800|n-1|||"},
+		"empty value":   {TelemetrySignatureType, "", nil, nil, "telemetry|ESP_001||1772362800|n-1|||"},
+		"reading time and ID": {
+			SensorTypeDistance, "12.5", &ReadingTime{RecordedAt: &recordedAt, Sequence: &sequence}, &ReadingID{MessageID: "m-1"},
+			"distance|ESP_001|12.5|1772362800|n-1|1772362795250|42|m-1",
+		},
+		// La position dans la file d'ingestion est attribuée par le serveur : l'appareil ne la signe pas.
+		"queue position": {SensorTypeDistance, "12.5", &ReadingTime{}, &ReadingID{QueueID: "READINGS:7"}, "distance|ESP_001|12.5|1772362800|n-1|||"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := SignedMessage(test.sensorType, "ESP_001", test.value, test.timing, test.id, 1772362800, "n-1"); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSignedPayloadVerify(t *testing.T) {
+	const secret = "secret"
+	valid := sign(secret, "distance|ESP_001|12.5|1772362800|n-1|||")
+
+	tests := map[string]struct {
+		payload SignedPayload
+		secret  string
+		value   string
+		want    error
+	}{
+		"valid signature": {SignedPayload{Timestamp: 1772362800, Nonce: "n-1", Signature: valid}, secret, "12.5", nil},
+		"uppercase signature": {
+			SignedPayload{Timestamp: 1772362800, Nonce: "n-1", Signature: strings.ToUpper(valid)}, secret, "12.5", nil,
+		},
+		"other secret": {SignedPayload{Timestamp: 1772362800, Nonce: "n-1", Signature: valid}, "other", "12.5", ErrInvalidSignature},
+		// Le microcontrôleur doit signer la valeur canonique : "12.50" n'est pas "12.5".
+		"value with trailing zeros": {
+			SignedPayload{Timestamp: 1772362800, Nonce: "n-1", Signature: sign(secret, "distance|ESP_001|12.50|1772362800|n-1|||")},
+			secret, "12.5", ErrInvalidSignature,
+		},
+		"other timestamp": {SignedPayload{Timestamp: 1772362801, Nonce: "n-1", Signature: valid}, secret, "12.5", ErrInvalidSignature},
+		"other nonce":     {SignedPayload{Timestamp: 1772362800, Nonce: "n-2", Signature: valid}, secret, "12.5", ErrInvalidSignature},
+		"missing nonce":   {SignedPayload{Timestamp: 1772362800, Signature: valid}, secret, "12.5", ErrInvalidSignature},
+		"missing timestamp": {
+			SignedPayload{Nonce: "n-1", Signature: sign(secret, "distance|ESP_001|12.5|0|n-1|||")}, secret, "12.5", ErrInvalidSignature,
+		},
+		"signature is not hexadecimal": {
+			SignedPayload{Timestamp: 1772362800, Nonce: "n-1", Signature: "not-hex"}, secret, "12.5", ErrInvalidSignature,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := test.payload.Verify(test.secret, SensorTypeDistance, "ESP_001", test.value, nil, nil); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+// TestSignedPayloadVerifyReadingTime checks that the time, sequence and ID of a reading cannot be changed without
+// invalidating its signature.
+func TestSignedPayloadVerifyReadingTime(t *testing.T) {
+	const secret = "secret"
+
+	recordedAt := time.UnixMilli(1772362795000)
+	sequence := int64(42)
+	payload := &SignedPayload{
+		Timestamp: 1772362800,
+		Nonce:     "n-1",
+		Signature: sign(secret, "distance|ESP_001|12.5|1772362800|n-1|1772362795000|42|m-1"),
+	}
+
+	tampered := recordedAt.Add(-time.Hour)
+	otherSequence := int64(43)
+
+	tests := map[string]struct {
+		timing *ReadingTime
+		id     *ReadingID
+		want   error
+	}{
+		"signed time and ID":  {&ReadingTime{RecordedAt: &recordedAt, Sequence: &sequence}, &ReadingID{MessageID: "m-1"}, nil},
+		"tampered recordedAt": {&ReadingTime{RecordedAt: &tampered, Sequence: &sequence}, &ReadingID{MessageID: "m-1"}, ErrInvalidSignature},
+		"removed recordedAt":  {&ReadingTime{Sequence: &sequence}, &ReadingID{MessageID: "m-1"}, ErrInvalidSignature},
+		"tampered sequence":   {&ReadingTime{RecordedAt: &recordedAt, Sequence: &otherSequence}, &ReadingID{MessageID: "m-1"}, ErrInvalidSignature},
+		"tampered messageId":  {&ReadingTime{RecordedAt: &recordedAt, Sequence: &sequence}, &ReadingID{MessageID: "m-2"}, ErrInvalidSignature},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := payload.Verify(secret, SensorTypeDistance, "ESP_001", "12.5", test.timing, test.id); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -28,6 +28,150 @@ type Variables struct {
 
 	NATSURL string `env:"FM_NATS_URL,required" envDefault:"nats://nats_iot:4222"`
 
+	// The HC-SR04 reports 0 cm on timeout and cannot measure beyond 400 cm.
+	DistanceFilterWindowSize    int     `env:"FM_DISTANCE_FILTER_WINDOW_SIZE" envDefault:"5"`
+	DistanceFilterMinCm         float64 `env:"FM_DISTANCE_FILTER_MIN_CM" envDefault:"2"`
+	DistanceFilterMaxCm         float64 `env:"FM_DISTANCE_FILTER_MAX_CM" envDefault:"400"`
+	DistanceFilterConfirmations int     `env:"FM_DISTANCE_FILTER_CONFIRMATIONS" envDefault:"2"`
+
+	MicrophoneFilterWindowSize    int     `env:"FM_MICROPHONE_FILTER_WINDOW_SIZE" envDefault:"3"`
+	MicrophoneFilterMinDB         float64 `env:"FM_MICROPHONE_FILTER_MIN_DB" envDefault:"0"`
+	MicrophoneFilterMaxDB         float64 `env:"FM_MICROPHONE_FILTER_MAX_DB" envDefault:"115"`
+	MicrophoneFilterConfirmations int     `env:"FM_MICROPHONE_FILTER_CONFIRMATIONS" envDefault:"1"`
+
+	MotionDebounceCount   int           `env:"FM_MOTION_DEBOUNCE_COUNT" envDefault:"2"`
+	MotionDebounceWindow  time.Duration `env:"FM_MOTION_DEBOUNCE_WINDOW" envDefault:"3s"`
+	MotionContinuousAfter time.Duration `env:"FM_MOTION_CONTINUOUS_AFTER" envDefault:"2m"`
+	MotionQuietPeriod     time.Duration `env:"FM_MOTION_QUIET_PERIOD" envDefault:"30s"`
+	MotionExpireAfter     time.Duration `env:"FM_MOTION_EXPIRE_AFTER" envDefault:"5m"`
+
+	// Readings arrive roughly every second, so 3600 identical readings is about an hour.
+	DistanceQualityStuckCount     int     `env:"FM_DISTANCE_QUALITY_STUCK_COUNT" envDefault:"3600"`
+	DistanceQualityVarianceWindow int     `env:"FM_DISTANCE_QUALITY_VARIANCE_WINDOW" envDefault:"1800"`
+	DistanceQualityMinVariance    float64 `env:"FM_DISTANCE_QUALITY_MIN_VARIANCE" envDefault:"0.01"`
+	DistanceQualityDriftAlpha     float64 `env:"FM_DISTANCE_QUALITY_DRIFT_ALPHA" envDefault:"0.001"`
+	DistanceQualityDriftWarmup    int     `env:"FM_DISTANCE_QUALITY_DRIFT_WARMUP" envDefault:"3600"`
+	DistanceQualityMaxDrift       float64 `env:"FM_DISTANCE_QUALITY_MAX_DRIFT" envDefault:"20"`
+
+	MicrophoneQualityStuckCount     int     `env:"FM_MICROPHONE_QUALITY_STUCK_COUNT" envDefault:"3600"`
+	MicrophoneQualityVarianceWindow int     `env:"FM_MICROPHONE_QUALITY_VARIANCE_WINDOW" envDefault:"1800"`
+	MicrophoneQualityMinVariance    float64 `env:"FM_MICROPHONE_QUALITY_MIN_VARIANCE" envDefault:"0.01"`
+	MicrophoneQualityDriftAlpha     float64 `env:"FM_MICROPHONE_QUALITY_DRIFT_ALPHA" envDefault:"0.001"`
+	MicrophoneQualityDriftWarmup    int     `env:"FM_MICROPHONE_QUALITY_DRIFT_WARMUP" envDefault:"3600"`
+	MicrophoneQualityMaxDrift       float64 `env:"FM_MICROPHONE_QUALITY_MAX_DRIFT" envDefault:"15"`
+
+	EscalationInterval time.Duration `env:"FM_ESCALATION_INTERVAL" envDefault:"1m"`
+
+	// Alerts of the same location (or device) received within this window are grouped into one incident.
+	IncidentWindow time.Duration `env:"FM_INCIDENT_WINDOW" envDefault:"5m"`
+
+	// Admin API key accepted without being stored in the database. Leave empty once the first admin has been created.
+	AuthBootstrapAdminKey string `env:"FM_AUTH_BOOTSTRAP_ADMIN_KEY"`
+
+	// When disabled, devices that have not been provisioned yet can still send unsigned readings.
+	DeviceSignatureRequired bool          `env:"FM_DEVICE_SIGNATURE_REQUIRED" envDefault:"true"`
+	DeviceSignatureMaxAge   time.Duration `env:"FM_DEVICE_SIGNATURE_MAX_AGE" envDefault:"5m"`
+
+	// Readings of unknown devices are accepted, quarantined until the device is approved, or rejected (strict mode).
+	UnknownDevicePolicy string `env:"FM_UNKNOWN_DEVICE_POLICY" envDefault:"quarantine"`
+
+	// Bounds of the recordedAt sent by devices, and age beyond which a reading is stored without evaluating alerts.
+	ReadingMaxClockSkew time.Duration `env:"FM_READING_MAX_CLOCK_SKEW" envDefault:"1m"`
+	ReadingMaxAge       time.Duration `env:"FM_READING_MAX_AGE" envDefault:"24h"`
+	ReadingAlertHorizon time.Duration `env:"FM_READING_ALERT_HORIZON" envDefault:"2m"`
+
+	// Retransmissions of a reading carrying a messageId or a sequence number are ignored within this window. A reading
+	// still being recorded after the claim lease (e.g. after a crash) can be sent again.
+	ReadingDedupWindow time.Duration `env:"FM_READING_DEDUP_WINDOW" envDefault:"10m"`
+	ReadingClaimLease  time.Duration `env:"FM_READING_CLAIM_LEASE" envDefault:"30s"`
+
+	// Devices are alerted on when their signal stays below the RSSI for the duration, or when they reboot too often.
+	TelemetryWeakSignalRSSI   int           `env:"FM_TELEMETRY_WEAK_SIGNAL_RSSI" envDefault:"-80"`
+	TelemetryWeakSignalFor    time.Duration `env:"FM_TELEMETRY_WEAK_SIGNAL_FOR" envDefault:"5m"`
+	TelemetryRebootLoopCount  int           `env:"FM_TELEMETRY_REBOOT_LOOP_COUNT" envDefault:"3"`
+	TelemetryRebootLoopWindow time.Duration `env:"FM_TELEMETRY_REBOOT_LOOP_WINDOW" envDefault:"15m"`
+
+	// Commands are sent to the devices as confirmable CoAP requests, retransmitted until acknowledged.
+	CoAPAckTimeout    time.Duration `env:"FM_COAP_ACK_TIMEOUT" envDefault:"2s"`
+	CoAPMaxRetransmit int           `env:"FM_COAP_MAX_RETRANSMIT" envDefault:"4"`
+
+	// Firmware binaries are stored in the database unless a directory is given. The devices check for updates on the
+	// HTTP address, and a rollout halts when a device sends no telemetry within the health timeout of its download.
+	FirmwareDir                  string        `env:"FM_FIRMWARE_DIR"`
+	FirmwareHTTPAddr             string        `env:"FM_FIRMWARE_HTTP_ADDR" envDefault:":8084"`
+	FirmwareRolloutInterval      time.Duration `env:"FM_FIRMWARE_ROLLOUT_INTERVAL" envDefault:"30s"`
+	FirmwareRolloutHealthTimeout time.Duration `env:"FM_FIRMWARE_ROLLOUT_HEALTH_TIMEOUT" envDefault:"10m"`
+
+	// Sensors publishing over MQTT (Tasmota, Zigbee2MQTT...) are bridged when a broker URL is given. Their topics and
+	// payload fields are mapped to readings by the JSON array of the mappings file, see mqtt-mappings.example.json.
+	MQTTBrokerURL    string `env:"FM_MQTT_BROKER_URL"`
+	MQTTClientID     string `env:"FM_MQTT_CLIENT_ID" envDefault:"sensormanager"`
+	MQTTUsername     string `env:"FM_MQTT_USERNAME"`
+	MQTTPassword     string `env:"FM_MQTT_PASSWORD"`
+	MQTTMappingsFile string `env:"FM_MQTT_MAPPINGS_FILE" envDefault:"mqtt-mappings.json"`
+
+	// The sites, devices, readings and alerts are published to Home Assistant through MQTT discovery when a broker URL
+	// is given. Devices and alert states are synchronized at every interval.
+	HomeAssistantBrokerURL       string        `env:"FM_HOMEASSISTANT_BROKER_URL"`
+	HomeAssistantClientID        string        `env:"FM_HOMEASSISTANT_CLIENT_ID" envDefault:"sensormanager-homeassistant"`
+	HomeAssistantUsername        string        `env:"FM_HOMEASSISTANT_USERNAME"`
+	HomeAssistantPassword        string        `env:"FM_HOMEASSISTANT_PASSWORD"`
+	HomeAssistantDiscoveryPrefix string        `env:"FM_HOMEASSISTANT_DISCOVERY_PREFIX" envDefault:"homeassistant"`
+	HomeAssistantTopicPrefix     string        `env:"FM_HOMEASSISTANT_TOPIC_PREFIX" envDefault:"sensormanager"`
+	HomeAssistantSyncInterval    time.Duration `env:"FM_HOMEASSISTANT_SYNC_INTERVAL" envDefault:"30s"`
+
+	// When enabled, the readings, alerts and status changes are written to an outbox and published on the "events.>"
+	// NATS subjects. Published events are kept in the outbox for the retention. A JetStream stream capturing the events
+	// is created when its name is given.
+	EventsEnabled         bool          `env:"FM_EVENTS_ENABLED" envDefault:"false"`
+	EventsInterval        time.Duration `env:"FM_EVENTS_INTERVAL" envDefault:"1s"`
+	EventsBatchSize       int           `env:"FM_EVENTS_BATCH_SIZE" envDefault:"100"`
+	EventsRetention       time.Duration `env:"FM_EVENTS_RETENTION" envDefault:"168h"`
+	EventsJetStreamStream string        `env:"FM_EVENTS_JETSTREAM_STREAM"`
+	EventsJetStreamMaxAge time.Duration `env:"FM_EVENTS_JETSTREAM_MAX_AGE" envDefault:"168h"`
+
+	// When enabled, the record calls append the readings to a JetStream stream and return at once; a durable consumer
+	// records them, retrying while the database is unavailable. The stream refuses new readings once a limit is
+	// reached, -1 or 0 meaning no limit.
+	IngestionQueueEnabled    bool          `env:"FM_INGESTION_QUEUE_ENABLED" envDefault:"false"`
+	IngestionQueueStream     string        `env:"FM_INGESTION_QUEUE_STREAM" envDefault:"READINGS"`
+	IngestionQueueConsumer   string        `env:"FM_INGESTION_QUEUE_CONSUMER" envDefault:"sensormanager"`
+	IngestionQueueMaxAge     time.Duration `env:"FM_INGESTION_QUEUE_MAX_AGE" envDefault:"24h"`
+	IngestionQueueMaxBytes   int64         `env:"FM_INGESTION_QUEUE_MAX_BYTES" envDefault:"-1"`
+	IngestionQueueMaxMsgs    int64         `env:"FM_INGESTION_QUEUE_MAX_MSGS" envDefault:"-1"`
+	IngestionQueueRetryDelay time.Duration `env:"FM_INGESTION_QUEUE_RETRY_DELAY" envDefault:"5s"`
+	IngestionQueueAckWait    time.Duration `env:"FM_INGESTION_QUEUE_ACK_WAIT" envDefault:"30s"`
+
+	// When enabled, the alerts and connectivity changes are delivered to the webhooks of their site, signed with the
+	// secret of the webhook. A failed delivery is retried with an exponential backoff, from the retry delay up to the
+	// max retry delay, and becomes dead after the max attempts. Delivered and dead deliveries are kept for the retention.
+	WebhooksEnabled       bool          `env:"FM_WEBHOOKS_ENABLED" envDefault:"false"`
+	WebhooksInterval      time.Duration `env:"FM_WEBHOOKS_INTERVAL" envDefault:"5s"`
+	WebhooksTimeout       time.Duration `env:"FM_WEBHOOKS_TIMEOUT" envDefault:"10s"`
+	WebhooksMaxAttempts   int           `env:"FM_WEBHOOKS_MAX_ATTEMPTS" envDefault:"8"`
+	WebhooksRetryDelay    time.Duration `env:"FM_WEBHOOKS_RETRY_DELAY" envDefault:"30s"`
+	WebhooksMaxRetryDelay time.Duration `env:"FM_WEBHOOKS_MAX_RETRY_DELAY" envDefault:"1h"`
+	WebhooksRetention     time.Duration `env:"FM_WEBHOOKS_RETENTION" envDefault:"720h"`
+
+	// The active devices that sent nothing for this delay are marked offline, checked at every interval, which is
+	// published as a connectivity event and delivered to the webhooks as device.offline, then device.online with their
+	// next reading. 0 disables the detection.
+	DeviceOfflineAfter    time.Duration `env:"FM_DEVICE_OFFLINE_AFTER" envDefault:"15m"`
+	DeviceOfflineInterval time.Duration `env:"FM_DEVICE_OFFLINE_INTERVAL" envDefault:"1m"`
+
+	// The notifications of the alerts carry a token acknowledging, resolving or snoozing the alert once, without login,
+	// through alerts.actions.execute. Without secret, a random one is generated at startup and the tokens of the
+	// notifications sent before a restart are refused.
+	AlertActionSecret   string        `env:"FM_ALERT_ACTION_SECRET"`
+	AlertActionTokenTTL time.Duration `env:"FM_ALERT_ACTION_TOKEN_TTL" envDefault:"30m"`
+
+	// Locale ("fr" or "en") of the notifications sent to the push tokens registered without locale, by users without
+	// locale. The templates of the notifications are editable through notifications.templates.
+	NotificationLocale string `env:"FM_NOTIFICATION_LOCALE" envDefault:"fr"`
+
+	// When enabled, each alert makes the device that raised it beep.
+	AlertBeepEnabled bool `env:"FM_ALERT_BEEP_ENABLED" envDefault:"false"`
+
 	LoungeUpClientHTTPTimeout time.Duration `env:"GP_LOUNGEUP_CLIENT_HTTP_TIMEOUT" envDefault:"2s"`
 	LoungeUpClientNATSTimeout time.Duration `env:"GP_LOUNGEUP_CLIENT_NATS_TIMEOUT" envDefault:"10s"`
 
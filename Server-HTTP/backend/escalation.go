@@ -0,0 +1,159 @@
+package sensormanager
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type EscalationTarget string
+
+const (
+	// EscalationTargetAll re-notifies every active push token of the site of the alert.
+	EscalationTargetAll EscalationTarget = "all"
+
+	// EscalationTargetTokens notifies the push tokens listed on the step that are registered on the site of the alert.
+	EscalationTargetTokens EscalationTarget = "tokens"
+)
+
+type EscalationPolicy struct {
+	ID        int64
+	SiteID    string
+	Name      string
+	AlertType AlertType // Optionnel - vide = toutes les alertes
+	IsActive  bool
+	Steps     []*EscalationStep
+	CreatedAt time.Time
+}
+
+type EscalationStep struct {
+	ID                 int64
+	StepOrder          int
+	DelayMinutes       int // Délai depuis la création de l'alerte
+	Target             EscalationTarget
+	PushTokens         []string
+	RepeatEveryMinutes int // Optionnel - 0 = pas de répétition
+	RepeatLimit        int
+}
+
+type EscalationPolicyParams struct {
+	SiteID    string
+	Name      string
+	AlertType string
+	Steps     []*EscalationStepParams
+}
+
+type EscalationStepParams struct {
+	DelayMinutes       int
+	Target             string
+	PushTokens         []string
+	RepeatEveryMinutes int
+	RepeatLimit        int
+}
+
+// EscalationRecord is the audit entry of an executed escalation step.
+type EscalationRecord struct {
+	ID           int64
+	SiteID       string
+	AlertType    AlertType
+	AlertID      int64
+	PolicyID     int64
+	StepID       int64
+	StepOrder    int
+	Repetition   int
+	Recipients   int
+	Success      bool
+	ErrorMessage string
+	ExecutedAt   time.Time
+}
+
+func (p *EscalationPolicyParams) Sanitize() error {
+	if p.SiteID == "" {
+		return errors.New("siteId is required")
+	}
+
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if p.AlertType != "" {
+		if err := AlertType(p.AlertType).Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(p.Steps) == 0 {
+		return errors.New("at least one step is required")
+	}
+
+	for _, step := range p.Steps {
+		if step.DelayMinutes < 0 {
+			return errors.New("delayMinutes must be positive")
+		}
+
+		if step.RepeatEveryMinutes < 0 || step.RepeatLimit < 0 {
+			return errors.New("repeatEveryMinutes and repeatLimit must be positive")
+		}
+
+		switch EscalationTarget(step.Target) {
+		case EscalationTargetAll:
+		case EscalationTargetTokens:
+			if len(step.PushTokens) == 0 {
+				return errors.New("pushTokens are required when target is tokens")
+			}
+
+			for i, token := range step.PushTokens {
+				step.PushTokens[i] = strings.TrimSpace(token)
+				if step.PushTokens[i] == "" {
+					return errors.New("pushTokens cannot be empty")
+				}
+			}
+		default:
+			return errors.New("invalid target")
+		}
+	}
+
+	return nil
+}
+
+// NextRepetition returns the latest repetition of the step that is due at the given time, when it has not been
+// executed yet. Repetition 0 is the first execution of the step. The earlier repetitions missed while the scheduler was
+// stopped are skipped, as are those due before the policy was created: a new policy or a restart sends at most one
+// reminder per step instead of replaying past ones.
+func (s *EscalationStep) NextRepetition(alertCreatedAt, policyCreatedAt, now time.Time, executed map[int]bool) (int, bool) {
+	latest := -1
+	for repetition := 0; repetition <= s.RepeatLimit; repetition++ {
+		if repetition > 0 && s.RepeatEveryMinutes == 0 {
+			break
+		}
+
+		dueAt := alertCreatedAt.Add(time.Duration(s.DelayMinutes+repetition*s.RepeatEveryMinutes) * time.Minute)
+		if dueAt.After(now) {
+			break
+		}
+
+		if !dueAt.Before(policyCreatedAt) {
+			latest = repetition
+		}
+	}
+
+	if latest < 0 || executed[latest] {
+		return 0, false
+	}
+
+	return latest, true
+}
+
+type EscalationManager interface {
+	CreateEscalationPolicy(params *EscalationPolicyParams) (*EscalationPolicy, error)
+	GetEscalationPolicies(siteID string) ([]*EscalationPolicy, error)
+	DeleteEscalationPolicy(siteID string, policyID int64) error
+
+	// GetActiveEscalationPolicies returns the active policies of every site, each one only applying to the alerts of
+	// its own site.
+	GetActiveEscalationPolicies() ([]*EscalationPolicy, error)
+
+	GetEscalationRecords(siteID string, alertType AlertType, alertID int64) ([]*EscalationRecord, error)
+	RecordEscalation(record *EscalationRecord) error
+}
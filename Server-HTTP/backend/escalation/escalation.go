@@ -0,0 +1,209 @@
+// Package escalation periodically re-notifies alerts that have not been acknowledged, following the escalation
+// policies stored in the database.
+package escalation
+
+import (
+	"fmt"
+	"sensormanager"
+	"sensormanager/store"
+	"time"
+)
+
+type Scheduler struct {
+	store    *store.Store
+	interval time.Duration
+}
+
+type Option func(*Scheduler)
+
+func New(options ...Option) *Scheduler {
+	result := &Scheduler{interval: time.Minute}
+
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.store == nil {
+		panic("could not create escalation scheduler without Store configuration")
+	}
+
+	return result
+}
+
+func WithStore(store *store.Store) Option { return func(s *Scheduler) { s.store = store } }
+
+func WithInterval(interval time.Duration) Option {
+	return func(s *Scheduler) { s.interval = interval }
+}
+
+// Run scans the active alerts at every interval. It never returns.
+func (s *Scheduler) Run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		if err := s.scan(now); err != nil {
+			fmt.Printf("❌ Escalation: %v\n", err)
+		}
+	}
+}
+
+func (s *Scheduler) scan(now time.Time) error {
+	policies, err := s.store.Escalations.GetActiveEscalationPolicies()
+	if err != nil {
+		return fmt.Errorf("could not get escalation policies: %w", err)
+	}
+
+	if len(policies) == 0 {
+		return nil
+	}
+
+	// Les alertes acquittées ou résolues ne sont plus actives : l'escalade s'arrête d'elle-même.
+	alerts, err := s.store.Sensors.GetActiveAlerts()
+	if err != nil {
+		return fmt.Errorf("could not get active alerts: %w", err)
+	}
+
+	for _, alert := range alerts {
+		// 💤 Alerte mise en sourdine depuis sa notification : l'escalade reprend à la fin de la sourdine.
+		if alert.SnoozedUntil != nil && now.Before(*alert.SnoozedUntil) {
+			continue
+		}
+
+		if err := s.escalate(alert, policies, now); err != nil {
+			fmt.Printf("❌ Escalation of %s alert %d: %v\n", alert.Type, alert.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Scheduler) escalate(alert *sensormanager.ActiveAlert, policies []*sensormanager.EscalationPolicy, now time.Time) error {
+	records, err := s.store.Escalations.GetEscalationRecords(alert.SiteID, alert.Type, alert.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range policies {
+		// Une politique ne s'applique qu'aux alertes de son site : ses jetons ne reçoivent rien des autres sites.
+		if policy.SiteID != alert.SiteID {
+			continue
+		}
+
+		if policy.AlertType != "" && policy.AlertType != alert.Type {
+			continue
+		}
+
+		for _, step := range policy.Steps {
+			executed := make(map[int]bool)
+			for _, record := range records {
+				if record.StepID == step.ID {
+					executed[record.Repetition] = true
+				}
+			}
+
+			repetition, due := step.NextRepetition(alert.CreatedAt, policy.CreatedAt, now, executed)
+			if !due {
+				continue
+			}
+
+			if err := s.execute(alert, policy, step, repetition, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Scheduler) execute(
+	alert *sensormanager.ActiveAlert,
+	policy *sensormanager.EscalationPolicy,
+	step *sensormanager.EscalationStep,
+	repetition int,
+	now time.Time,
+) error {
+	elapsed := now.Sub(alert.CreatedAt).Round(time.Minute)
+
+	values := sensormanager.NewNotificationValues(alert.Type, sensormanager.SensorType(alert.Type), alert.DeviceID, alert.CreatedAt)
+	values.Elapsed = elapsed
+
+	notifParams := &sensormanager.NotificationParams{
+		Title: "⏰ Alert reminder",
+		Body:  fmt.Sprintf("%s alert from %s is still not acknowledged after %s", alert.Type, alert.DeviceID, elapsed),
+		Data: map[string]interface{}{
+			"type":       string(alert.Type),
+			"siteId":     alert.SiteID,
+			"alertId":    alert.ID,
+			"deviceId":   alert.DeviceID,
+			"escalation": step.StepOrder,
+			"repetition": repetition,
+		},
+		Kind:   sensormanager.NotificationKindEscalation,
+		Values: values,
+	}
+
+	if token, err := s.store.AlertActions.IssueAlertActionToken(alert.SiteID, alert.Type, alert.ID); err == nil {
+		notifParams.Data["actionToken"] = token.Token
+		notifParams.Data["actionTokenExpiresAt"] = token.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z")
+	} else {
+		fmt.Printf("❌ Could not issue action token of %s alert %d: %v\n", alert.Type, alert.ID, err)
+	}
+
+	record := &sensormanager.EscalationRecord{
+		SiteID:     alert.SiteID,
+		AlertType:  alert.Type,
+		AlertID:    alert.ID,
+		PolicyID:   policy.ID,
+		StepID:     step.ID,
+		StepOrder:  step.StepOrder,
+		Repetition: repetition,
+		ExecutedAt: now,
+	}
+
+	var err error
+	switch step.Target {
+	case sensormanager.EscalationTargetTokens:
+		var tokens []string
+		if tokens, err = s.siteTokens(alert.SiteID, step.PushTokens); err == nil {
+			record.Recipients = len(tokens)
+			err = s.store.Notifications.SendNotificationToTokens(tokens, notifParams)
+		}
+	default:
+		var tokens []*sensormanager.PushToken
+		if tokens, err = s.store.Notifications.GetActivePushTokens(alert.SiteID); err == nil {
+			record.Recipients = len(tokens)
+			err = s.store.Notifications.SendNotificationToSite(alert.SiteID, notifParams)
+		}
+	}
+
+	record.Success = err == nil
+	if err != nil {
+		record.ErrorMessage = err.Error()
+	}
+
+	return s.store.Escalations.RecordEscalation(record)
+}
+
+// siteTokens keeps the tokens of the step that are registered on the site: a token that left the site no longer
+// receives its alerts nor their action tokens.
+func (s *Scheduler) siteTokens(siteID string, stepTokens []string) ([]string, error) {
+	registered, err := s.store.Notifications.GetActivePushTokens(siteID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make(map[string]bool, len(registered))
+	for _, token := range registered {
+		active[token.Token] = true
+	}
+
+	result := []string{}
+	for _, token := range stepTokens {
+		if active[token] {
+			result = append(result, token)
+		}
+	}
+
+	return result, nil
+}
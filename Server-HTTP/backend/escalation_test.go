@@ -0,0 +1,56 @@
+package sensormanager
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextRepetition(t *testing.T) {
+	alertCreatedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	repeating := &EscalationStep{DelayMinutes: 5, RepeatEveryMinutes: 10, RepeatLimit: 3}
+
+	tests := map[string]struct {
+		step            *EscalationStep
+		policyCreatedAt time.Time
+		now             time.Duration // Depuis la création de l'alerte
+		executed        []int
+		want            int // -1 quand aucune répétition n'est due
+	}{
+		"not due before the delay": {repeating, alertCreatedAt, 4 * time.Minute, nil, -1},
+		"first execution":          {repeating, alertCreatedAt, 5 * time.Minute, nil, 0},
+		"already executed":         {repeating, alertCreatedAt, 10 * time.Minute, []int{0}, -1},
+		"next repetition":          {repeating, alertCreatedAt, 15 * time.Minute, []int{0}, 1},
+		"missed repetitions are skipped after a restart": {
+			repeating, alertCreatedAt, 36 * time.Minute, []int{0}, 3,
+		},
+		"nothing after the repeat limit": {repeating, alertCreatedAt, 2 * time.Hour, []int{3}, -1},
+		"repetitions due before the policy are skipped": {
+			repeating, alertCreatedAt.Add(20 * time.Minute), 24 * time.Minute, nil, -1,
+		},
+		"repetitions due after the policy are executed": {
+			repeating, alertCreatedAt.Add(20 * time.Minute), 26 * time.Minute, nil, 2,
+		},
+		"single step of a newer policy is not replayed": {
+			&EscalationStep{DelayMinutes: 5}, alertCreatedAt.Add(time.Hour), 2 * time.Hour, nil, -1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			executed := make(map[int]bool)
+			for _, repetition := range test.executed {
+				executed[repetition] = true
+			}
+
+			repetition, due := test.step.NextRepetition(alertCreatedAt, test.policyCreatedAt, alertCreatedAt.Add(test.now), executed)
+
+			got := -1
+			if due {
+				got = repetition
+			}
+			if got != test.want {
+				t.Errorf("got repetition %d, want %d", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package sensormanager
+
+import (
+	"strings"
+	"time"
+)
+
+// EventVersion is the version of the schema of the events. It is incremented when a field is removed or changes
+// meaning; adding a field keeps the version.
+const EventVersion = 1
+
+// EventSubjectPrefix is the first token of the NATS subjects of the events.
+const EventSubjectPrefix = "events"
+
+type EventType string
+
+const (
+	EventTypeReadingRecorded     EventType = "reading.recorded"
+	EventTypeAlertCreated        EventType = "alert.created"
+	EventTypeAlertStatusChanged  EventType = "alert.status_changed"
+	EventTypeDeviceStatusChanged EventType = "device.status_changed"
+	EventTypeDeviceConnectivity  EventType = "device.connectivity_changed"
+	EventTypeSiteArmedChanged    EventType = "site.armed_changed"
+)
+
+// Event is a domain event published to the other services through the outbox. It is published as JSON on its
+// subject, with its data under "data".
+type Event struct {
+	ID         int64       `json:"id"` // Identifiant croissant de l'outbox, pour dédupliquer les redélivrances
+	Version    int         `json:"version"`
+	Type       EventType   `json:"type"`
+	Subject    string      `json:"subject"`
+	SiteID     string      `json:"siteId"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	Data       interface{} `json:"data"`
+}
+
+// ReadingEventData is the data of a reading.recorded event, published on events.sensor.<sensorType>.<deviceId>.
+type ReadingEventData struct {
+	SensorType SensorType `json:"sensorType"`
+	DeviceID   string     `json:"deviceId"`
+	Value      float64    `json:"value"`
+	RecordedAt time.Time  `json:"recordedAt"`
+}
+
+// AlertEventData is the data of the alert.created event, published on events.alert.created, and of the
+// alert.status_changed events, published on events.alert.<status>. Value, threshold and message are only set on
+// creation.
+type AlertEventData struct {
+	AlertType AlertType   `json:"alertType"`
+	AlertID   int64       `json:"alertId"`
+	DeviceID  string      `json:"deviceId"`
+	Status    AlertStatus `json:"status"`
+	Value     *float64    `json:"value,omitempty"`
+	Threshold *float64    `json:"threshold,omitempty"`
+	Message   string      `json:"message,omitempty"`
+}
+
+// DeviceEventData is the data of a device.status_changed event, published on events.device.<status>.<deviceId>.
+type DeviceEventData struct {
+	DeviceID   string       `json:"deviceId"`
+	SensorType SensorType   `json:"sensorType,omitempty"`
+	Status     DeviceStatus `json:"status"`
+}
+
+// DeviceConnectivityEventData is the data of a device.connectivity_changed event, published on
+// events.device.offline.<deviceId> or events.device.online.<deviceId>.
+type DeviceConnectivityEventData struct {
+	DeviceID   string     `json:"deviceId"`
+	SensorType SensorType `json:"sensorType,omitempty"`
+	Online     bool       `json:"online"`
+	LastSeenAt time.Time  `json:"lastSeenAt"`
+}
+
+// SiteEventData is the data of a site.armed_changed event, published on events.site.armed or events.site.disarmed.
+type SiteEventData struct {
+	Armed bool `json:"armed"`
+}
+
+func newEvent(eventType EventType, siteID string, occurredAt time.Time, data interface{}, tokens ...string) *Event {
+	escaped := make([]string, len(tokens))
+	for i, token := range tokens {
+		escaped[i] = SubjectToken(token)
+	}
+
+	return &Event{
+		Version:    EventVersion,
+		Type:       eventType,
+		Subject:    EventSubjectPrefix + "." + strings.Join(escaped, "."),
+		SiteID:     siteID,
+		OccurredAt: occurredAt.UTC(),
+		Data:       data,
+	}
+}
+
+func NewReadingEvent(siteID string, sensorType SensorType, deviceID string, value float64, recordedAt time.Time) *Event {
+	return newEvent(EventTypeReadingRecorded, siteID, recordedAt, &ReadingEventData{
+		SensorType: sensorType,
+		DeviceID:   deviceID,
+		Value:      value,
+		RecordedAt: recordedAt.UTC(),
+	}, "sensor", string(sensorType), deviceID)
+}
+
+func NewAlertCreatedEvent(alertType AlertType, siteID string, response *AlertResponse) *Event {
+	value, threshold := response.Value, response.Threshold
+
+	return newEvent(EventTypeAlertCreated, siteID, response.RecordedAt, &AlertEventData{
+		AlertType: alertType,
+		AlertID:   response.AlertID,
+		DeviceID:  response.DeviceID,
+		Status:    AlertStatusActive,
+		Value:     &value,
+		Threshold: &threshold,
+		Message:   response.Message,
+	}, "alert", "created")
+}
+
+func NewSensorFaultEvent(siteID string, fault *SensorFaultAlert) *Event {
+	value := fault.Value
+
+	return newEvent(EventTypeAlertCreated, siteID, fault.CreatedAt, &AlertEventData{
+		AlertType: AlertTypeSensorFault,
+		AlertID:   fault.ID,
+		DeviceID:  fault.DeviceID,
+		Status:    AlertStatusActive,
+		Value:     &value,
+		Message:   fault.Details,
+	}, "alert", "created")
+}
+
+func NewAlertStatusEvent(alertType AlertType, siteID string, alertID int64, deviceID string, status AlertStatus) *Event {
+	return newEvent(EventTypeAlertStatusChanged, siteID, time.Now(), &AlertEventData{
+		AlertType: alertType,
+		AlertID:   alertID,
+		DeviceID:  deviceID,
+		Status:    status,
+	}, "alert", string(status))
+}
+
+func NewDeviceStatusEvent(siteID, deviceID string, sensorType SensorType, status DeviceStatus) *Event {
+	return newEvent(EventTypeDeviceStatusChanged, siteID, time.Now(), &DeviceEventData{
+		DeviceID:   deviceID,
+		SensorType: sensorType,
+		Status:     status,
+	}, "device", string(status), deviceID)
+}
+
+// NewDeviceConnectivityEvent returns the event of a device going offline, or back online, at the given time.
+func NewDeviceConnectivityEvent(siteID, deviceID string, sensorType SensorType, online bool, lastSeenAt time.Time) *Event {
+	state := "offline"
+	if online {
+		state = "online"
+	}
+
+	return newEvent(EventTypeDeviceConnectivity, siteID, time.Now(), &DeviceConnectivityEventData{
+		DeviceID:   deviceID,
+		SensorType: sensorType,
+		Online:     online,
+		LastSeenAt: lastSeenAt,
+	}, "device", state, deviceID)
+}
+
+func NewSiteArmedEvent(site *Site) *Event {
+	state := "disarmed"
+	if site.Armed {
+		state = "armed"
+	}
+
+	return newEvent(EventTypeSiteArmedChanged, site.ID, time.Now(), &SiteEventData{Armed: site.Armed}, "site", state)
+}
+
+// SubjectToken replaces the characters that cannot appear in a token of a NATS subject, e.g. a device ID containing
+// a dot.
+func SubjectToken(token string) string {
+	if token == "" {
+		return "_"
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '*', '>', ' ', '\t', '\r', '\n':
+			return '_'
+		default:
+			return r
+		}
+	}, token)
+}
+
+type EventManager interface {
+	// GetPendingEvents returns the oldest events that have not been published, in the order they were recorded.
+	GetPendingEvents(limit int) ([]*Event, error)
+	MarkEventsPublished(ids []int64) error
+
+	// DeletePublishedEvents drops the events published before the given time and returns how many were dropped.
+	DeletePublishedEvents(before time.Time) (int64, error)
+}
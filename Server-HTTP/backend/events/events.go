@@ -0,0 +1,249 @@
+// Package events publishes the domain events of the SensorManager on NATS, so that other services (automations,
+// loggers...) can react to the readings and alerts without polling.
+//
+// The events are written to the event_outbox table in the transaction of the change they describe, then published by
+// the Relay in the order they were recorded. An event is marked as published once NATS has received it: it is
+// delivered at least once, and consumers deduplicate the redeliveries by its id, also sent as the Nats-Msg-Id header.
+//
+// Every event is a JSON object with the same envelope:
+//
+//	{
+//	  "id": 42,                            // Identifiant croissant de l'outbox
+//	  "version": 1,                        // sensormanager.EventVersion
+//	  "type": "reading.recorded",
+//	  "subject": "events.sensor.distance.ESP_002",
+//	  "siteId": "home",
+//	  "occurredAt": "2024-01-01T12:00:00Z",
+//	  "data": {...}
+//	}
+//
+// The subjects and the data of the types are:
+//
+//	reading.recorded            events.sensor.<sensorType>.<deviceId>     {sensorType, deviceId, value, recordedAt}
+//	alert.created               events.alert.created                      {alertType, alertId, deviceId, status, value, threshold, message}
+//	alert.status_changed        events.alert.<status>                     {alertType, alertId, deviceId, status}
+//	device.status_changed       events.device.<status>.<deviceId>         {deviceId, sensorType, status}
+//	device.connectivity_changed events.device.<offline|online>.<deviceId> {deviceId, sensorType, online, lastSeenAt}
+//	site.armed_changed          events.site.armed, events.site.disarmed   {armed}
+//
+// The characters of a device ID that cannot appear in a subject token ('.', '*', '>' and whitespaces) are replaced by
+// '_' in the subject; the data keep the device ID as is. The version is incremented when a field is removed or changes
+// meaning, consumers must ignore the fields they do not know.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sensormanager"
+	"sensormanager/store"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const publishTimeout = 5 * time.Second
+
+// StreamSubjects are the subjects captured by the JetStream stream of the events.
+const StreamSubjects = sensormanager.EventSubjectPrefix + ".>"
+
+type Relay struct {
+	store *store.Store
+	conn  *nats.Conn
+
+	interval  time.Duration
+	batchSize int
+	retention time.Duration
+
+	// Flux JetStream créé au démarrage, vide pour publier sans JetStream
+	stream       string
+	streamMaxAge time.Duration
+	jetStream    jetstream.JetStream
+}
+
+type Option func(*Relay)
+
+func New(options ...Option) *Relay {
+	result := &Relay{
+		interval:  time.Second,
+		batchSize: 100,
+		retention: 7 * 24 * time.Hour,
+	}
+
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.store == nil {
+		panic("could not create event relay without Store configuration")
+	}
+
+	if result.conn == nil {
+		panic("could not create event relay without NATS connection")
+	}
+
+	return result
+}
+
+func WithStore(store *store.Store) Option { return func(r *Relay) { r.store = store } }
+
+func WithConn(conn *nats.Conn) Option { return func(r *Relay) { r.conn = conn } }
+
+func WithInterval(interval time.Duration) Option { return func(r *Relay) { r.interval = interval } }
+
+func WithBatchSize(size int) Option { return func(r *Relay) { r.batchSize = size } }
+
+// WithRetention sets how long the published events are kept in the outbox.
+func WithRetention(retention time.Duration) Option { return func(r *Relay) { r.retention = retention } }
+
+// WithJetStream publishes the events through JetStream, to the stream created or updated by Start. The stream keeps
+// the events for maxAge, zero keeping them forever.
+func WithJetStream(stream string, maxAge time.Duration) Option {
+	return func(r *Relay) {
+		r.stream = stream
+		r.streamMaxAge = maxAge
+	}
+}
+
+// Start creates or updates the JetStream stream of the events, when configured.
+func (r *Relay) Start() error {
+	if r.stream == "" {
+		return nil
+	}
+
+	jetStream, err := jetstream.New(r.conn)
+	if err != nil {
+		return fmt.Errorf("could not create JetStream context: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	if _, err := jetStream.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:        r.stream,
+		Description: "SensorManager domain events",
+		Subjects:    []string{StreamSubjects},
+		MaxAge:      r.streamMaxAge,
+		// Les redélivrances de l'outbox sont ignorées par le flux dans cette fenêtre.
+		Duplicates: 2 * time.Minute,
+	}); err != nil {
+		return fmt.Errorf("could not create stream %s: %w", r.stream, err)
+	}
+
+	r.jetStream = jetStream
+
+	return nil
+}
+
+// Run publishes the pending events at every interval and drops the expired ones. It never returns.
+func (r *Relay) Run() {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	lastPurge := time.Time{}
+
+	for now := range ticker.C {
+		if err := r.relay(); err != nil {
+			fmt.Printf("❌ Events: %v\n", err)
+		}
+
+		if now.Sub(lastPurge) < time.Hour {
+			continue
+		}
+		lastPurge = now
+
+		if deleted, err := r.store.Events.DeletePublishedEvents(now.Add(-r.retention)); err != nil {
+			fmt.Printf("❌ Events: could not delete published events: %v\n", err)
+		} else if deleted > 0 {
+			fmt.Printf("🧹 %d published events deleted\n", deleted)
+		}
+	}
+}
+
+// relay publishes the pending events, batch after batch, until the outbox is empty. It stops at the first event that
+// cannot be published, so that the events are published in order.
+func (r *Relay) relay() error {
+	for {
+		events, err := r.store.Events.GetPendingEvents(r.batchSize)
+		if err != nil {
+			return fmt.Errorf("could not get pending events: %w", err)
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		published, publishErr := r.publish(events)
+
+		if err := r.store.Events.MarkEventsPublished(published); err != nil {
+			return fmt.Errorf("could not mark events as published: %w", err)
+		}
+
+		if publishErr != nil {
+			return publishErr
+		}
+
+		if len(events) < r.batchSize {
+			return nil
+		}
+	}
+}
+
+// publish publishes the events and returns the IDs of those received by NATS.
+func (r *Relay) publish(events []*sensormanager.Event) ([]int64, error) {
+	published := make([]int64, 0, len(events))
+
+	for _, event := range events {
+		msg, err := message(event)
+		if err != nil {
+			// Un événement illisible bloquerait les suivants : il est abandonné.
+			fmt.Printf("❌ Events: could not encode event %d: %v\n", event.ID, err)
+			published = append(published, event.ID)
+			continue
+		}
+
+		if r.jetStream != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+			_, err = r.jetStream.PublishMsg(ctx, msg, jetstream.WithMsgID(msg.Header.Get(nats.MsgIdHdr)))
+			cancel()
+		} else {
+			err = r.conn.PublishMsg(msg)
+		}
+		if err != nil {
+			err = fmt.Errorf("could not publish event %d on %s: %w", event.ID, event.Subject, err)
+
+			// Sans JetStream, rien ne confirme la réception des événements précédents : ils seront republiés.
+			if r.jetStream == nil {
+				return nil, err
+			}
+
+			return published, err
+		}
+
+		published = append(published, event.ID)
+	}
+
+	// Sans JetStream, le flush confirme que le serveur NATS a reçu les événements.
+	if r.jetStream == nil {
+		if err := r.conn.FlushTimeout(publishTimeout); err != nil {
+			return nil, fmt.Errorf("could not flush events: %w", err)
+		}
+	}
+
+	return published, nil
+}
+
+func message(event *sensormanager.Event) (*nats.Msg, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := nats.NewMsg(event.Subject)
+	msg.Header.Set(nats.MsgIdHdr, strconv.FormatInt(event.ID, 10))
+	msg.Data = data
+
+	return msg, nil
+}
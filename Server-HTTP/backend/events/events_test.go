@@ -0,0 +1,198 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"sensormanager"
+	"sensormanager/store"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+// fakeOutbox keeps the events in memory, like the event_outbox table.
+type fakeOutbox struct {
+	mu        sync.Mutex
+	events    []*sensormanager.Event
+	published map[int64]bool
+}
+
+func newFakeOutbox(events ...*sensormanager.Event) *fakeOutbox {
+	for i, event := range events {
+		event.ID = int64(i + 1)
+	}
+
+	return &fakeOutbox{events: events, published: make(map[int64]bool)}
+}
+
+func (o *fakeOutbox) GetPendingEvents(limit int) ([]*sensormanager.Event, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var result []*sensormanager.Event
+	for _, event := range o.events {
+		if !o.published[event.ID] && len(result) < limit {
+			result = append(result, event)
+		}
+	}
+
+	return result, nil
+}
+
+func (o *fakeOutbox) MarkEventsPublished(ids []int64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, id := range ids {
+		o.published[id] = true
+	}
+
+	return nil
+}
+
+func (o *fakeOutbox) DeletePublishedEvents(time.Time) (int64, error) { return 0, nil }
+
+func newTestConn(t *testing.T) *nats.Conn {
+	natsServer, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go natsServer.Start()
+	t.Cleanup(natsServer.Shutdown)
+
+	if !natsServer.ReadyForConnections(5 * time.Second) {
+		t.Fatal("NATS server is not ready")
+	}
+
+	conn, err := nats.Connect(natsServer.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(conn.Close)
+
+	return conn
+}
+
+func testEvents() []*sensormanager.Event {
+	recordedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	return []*sensormanager.Event{
+		sensormanager.NewReadingEvent("home", sensormanager.SensorTypeDistance, "ESP_002", 12.5, recordedAt),
+		sensormanager.NewAlertCreatedEvent(sensormanager.AlertTypeDistance, "home", &sensormanager.AlertResponse{
+			Alert: true, AlertID: 7, DeviceID: "ESP_002", Value: 12.5, Threshold: 30, Message: "Object too close", RecordedAt: recordedAt,
+		}),
+		sensormanager.NewAlertStatusEvent(sensormanager.AlertTypeDistance, "home", 7, "ESP_002", sensormanager.AlertStatusAcknowledged),
+		sensormanager.NewDeviceStatusEvent("home", "ESP.003", sensormanager.SensorTypeMotion, sensormanager.DeviceStatusPending),
+	}
+}
+
+func TestRelay(t *testing.T) {
+	conn := newTestConn(t)
+
+	received := make(chan *nats.Msg, 16)
+	subscription, err := conn.ChanSubscribe(StreamSubjects, received)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { subscription.Unsubscribe() })
+
+	outbox := newFakeOutbox(testEvents()...)
+	relay := New(WithStore(&store.Store{Events: outbox}), WithConn(conn), WithBatchSize(3))
+
+	if err := relay.relay(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSubjects := []string{
+		"events.sensor.distance.ESP_002",
+		"events.alert.created",
+		"events.alert.acknowledged",
+		"events.device.pending.ESP_003",
+	}
+
+	for i, subject := range expectedSubjects {
+		select {
+		case msg := <-received:
+			if msg.Subject != subject {
+				t.Fatalf("expected event %d on %s, got %s", i+1, subject, msg.Subject)
+			}
+			if id := msg.Header.Get(nats.MsgIdHdr); id != strconv.Itoa(i+1) {
+				t.Errorf("expected message ID %d, got %s", i+1, id)
+			}
+
+			if i == 1 {
+				var event struct {
+					ID      int64                        `json:"id"`
+					Version int                          `json:"version"`
+					Type    sensormanager.EventType      `json:"type"`
+					SiteID  string                       `json:"siteId"`
+					Data    sensormanager.AlertEventData `json:"data"`
+				}
+				if err := json.Unmarshal(msg.Data, &event); err != nil {
+					t.Fatal(err)
+				}
+
+				if event.ID != 2 || event.Version != sensormanager.EventVersion || event.Type != sensormanager.EventTypeAlertCreated ||
+					event.SiteID != "home" || event.Data.AlertID != 7 || *event.Data.Threshold != 30 {
+					t.Errorf("unexpected alert event %s", msg.Data)
+				}
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("event %d was not published", i+1)
+		}
+	}
+
+	if pending, _ := outbox.GetPendingEvents(10); len(pending) != 0 {
+		t.Errorf("expected every event to be marked as published, %d are pending", len(pending))
+	}
+}
+
+func TestRelayJetStream(t *testing.T) {
+	conn := newTestConn(t)
+
+	outbox := newFakeOutbox(testEvents()...)
+	relay := New(WithStore(&store.Store{Events: outbox}), WithConn(conn), WithJetStream("EVENTS", time.Hour))
+
+	if err := relay.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := relay.relay(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Une redélivrance de l'outbox (ex: arrêt avant le marquage) est ignorée par le flux.
+	outbox.published = make(map[int64]bool)
+	if err := relay.relay(); err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := relay.jetStream.Stream(context.Background(), "EVENTS")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := stream.Info(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.State.Msgs != 4 {
+		t.Errorf("expected the stream to hold 4 events, got %d", info.State.Msgs)
+	}
+	if info.Config.MaxAge != time.Hour {
+		t.Errorf("expected the stream to keep the events for an hour, got %s", info.Config.MaxAge)
+	}
+}
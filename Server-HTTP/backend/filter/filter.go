@@ -0,0 +1,108 @@
+// Package filter pre-processes noisy sensor readings before they are evaluated for alerts.
+package filter
+
+import (
+	"sort"
+	"sync"
+)
+
+// Config describes how readings of a sensor type are filtered. The zero value lets every reading through unchanged.
+type Config struct {
+	// WindowSize is the number of accepted readings the median is computed over.
+	WindowSize int
+
+	// MinValue and MaxValue bound the physically possible readings. Both are ignored when MaxValue is not greater
+	// than MinValue.
+	MinValue float64
+	MaxValue float64
+
+	// Confirmations is the number of consecutive accepted readings required before the filtered value is trusted.
+	Confirmations int
+}
+
+// Result is the outcome of filtering a single reading.
+type Result struct {
+	Raw      float64
+	Filtered float64
+
+	// Rejected is set when the raw reading is outside of the configured range. Filtered is meaningless in this case.
+	Rejected bool
+
+	// Confirmed is set when enough consecutive readings have been accepted for the filtered value to feed alerts.
+	Confirmed bool
+}
+
+// Filter keeps a median window per device.
+type Filter struct {
+	config Config
+
+	mu      sync.Mutex
+	devices map[string]*deviceState
+}
+
+type deviceState struct {
+	window []float64
+	streak int
+}
+
+func New(config Config) *Filter {
+	if config.WindowSize < 1 {
+		config.WindowSize = 1
+	}
+
+	return &Filter{
+		config:  config,
+		devices: make(map[string]*deviceState),
+	}
+}
+
+// Apply filters a raw reading of the given device.
+func (f *Filter) Apply(deviceID string, value float64) Result {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	state, exists := f.devices[deviceID]
+	if !exists {
+		state = &deviceState{}
+		f.devices[deviceID] = state
+	}
+
+	if !f.inRange(value) {
+		state.streak = 0
+
+		return Result{Raw: value, Rejected: true}
+	}
+
+	state.window = append(state.window, value)
+	if len(state.window) > f.config.WindowSize {
+		state.window = state.window[len(state.window)-f.config.WindowSize:]
+	}
+	state.streak++
+
+	return Result{
+		Raw:       value,
+		Filtered:  median(state.window),
+		Confirmed: state.streak >= f.config.Confirmations,
+	}
+}
+
+func (f *Filter) inRange(value float64) bool {
+	if f.config.MaxValue <= f.config.MinValue {
+		return true
+	}
+
+	return value >= f.config.MinValue && value <= f.config.MaxValue
+}
+
+func median(values []float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+
+	return sorted[middle]
+}
@@ -0,0 +1,88 @@
+package filter
+
+import "testing"
+
+type reading struct {
+	value float64
+	want  Result
+}
+
+func TestFilter(t *testing.T) {
+	tests := map[string]struct {
+		config   Config
+		readings []reading
+	}{
+		"zero config lets readings through": {
+			Config{},
+			[]reading{
+				{12, Result{Raw: 12, Filtered: 12, Confirmed: true}},
+				{-3, Result{Raw: -3, Filtered: -3, Confirmed: true}},
+			},
+		},
+		"median of the window drops a spike": {
+			Config{WindowSize: 3},
+			[]reading{
+				{10, Result{Raw: 10, Filtered: 10, Confirmed: true}},
+				{300, Result{Raw: 300, Filtered: 155, Confirmed: true}},
+				{12, Result{Raw: 12, Filtered: 12, Confirmed: true}},
+				{11, Result{Raw: 11, Filtered: 12, Confirmed: true}},
+			},
+		},
+		"window only keeps the latest readings": {
+			Config{WindowSize: 2},
+			[]reading{
+				{1, Result{Raw: 1, Filtered: 1, Confirmed: true}},
+				{3, Result{Raw: 3, Filtered: 2, Confirmed: true}},
+				{7, Result{Raw: 7, Filtered: 5, Confirmed: true}},
+			},
+		},
+		"out of range readings are rejected": {
+			Config{MinValue: 2, MaxValue: 400},
+			[]reading{
+				{1, Result{Raw: 1, Rejected: true}},
+				{2, Result{Raw: 2, Filtered: 2, Confirmed: true}},
+				{400, Result{Raw: 400, Filtered: 400, Confirmed: true}},
+				{401, Result{Raw: 401, Rejected: true}},
+			},
+		},
+		"range is ignored when max is not above min": {
+			Config{MinValue: 10, MaxValue: 10},
+			[]reading{
+				{500, Result{Raw: 500, Filtered: 500, Confirmed: true}},
+			},
+		},
+		"confirmations need consecutive accepted readings": {
+			Config{MinValue: 0, MaxValue: 100, Confirmations: 2},
+			[]reading{
+				{50, Result{Raw: 50, Filtered: 50}},
+				{50, Result{Raw: 50, Filtered: 50, Confirmed: true}},
+				{150, Result{Raw: 150, Rejected: true}},
+				{50, Result{Raw: 50, Filtered: 50}},
+				{50, Result{Raw: 50, Filtered: 50, Confirmed: true}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			filter := New(test.config)
+
+			for i, r := range test.readings {
+				if got := filter.Apply("ESP_001", r.value); got != r.want {
+					t.Errorf("reading %d: got %+v, want %+v", i, got, r.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterKeepsDevicesApart(t *testing.T) {
+	filter := New(Config{WindowSize: 3, Confirmations: 2})
+
+	filter.Apply("ESP_001", 10)
+	filter.Apply("ESP_001", 10)
+
+	if got := filter.Apply("ESP_002", 90); got.Filtered != 90 || got.Confirmed {
+		t.Errorf("expected the first reading of another device to be unconfirmed and unfiltered, got %+v", got)
+	}
+}
@@ -0,0 +1,235 @@
+package sensormanager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Firmware is a binary of the registry, served to the devices by the OTA update endpoint.
+type Firmware struct {
+	ID         int64
+	Version    string // Envoyée par l'appareil dans l'en-tête x-ESP8266-version
+	SHA256     string
+	MD5        string // Vérifié par ESPhttpUpdate avant l'installation
+	Size       int64
+	Notes      string
+	UploadedBy string
+	CreatedAt  time.Time
+}
+
+// MaxFirmwareSize is the largest binary accepted by the registry: an ESP8266 with 4 MB of flash cannot install a
+// larger sketch over the air.
+const MaxFirmwareSize = 2 << 20
+
+var firmwareVersionPattern = regexp.MustCompile(`^[0-9A-Za-z][0-9A-Za-z._+-]{0,31}$`)
+
+type UploadFirmwareParams struct {
+	Version    string
+	SHA256     string // Empreinte annoncée, comparée à celle du binaire reçu
+	Binary     []byte
+	Notes      string
+	UploadedBy string
+}
+
+func (p *UploadFirmwareParams) Sanitize() error {
+	p.Version = strings.TrimSpace(p.Version)
+	if !firmwareVersionPattern.MatchString(p.Version) {
+		return errors.New("version must be 1 to 32 letters, digits, '.', '_', '+' or '-'")
+	}
+
+	if len(p.Binary) == 0 {
+		return errors.New("binary is required")
+	}
+	if len(p.Binary) > MaxFirmwareSize {
+		return errors.New("binary cannot be larger than 2 MB")
+	}
+
+	p.SHA256 = strings.ToLower(strings.TrimSpace(p.SHA256))
+	if p.SHA256 == "" {
+		return errors.New("sha256 is required")
+	}
+
+	checksum := sha256.Sum256(p.Binary)
+	if p.SHA256 != hex.EncodeToString(checksum[:]) {
+		return ErrFirmwareChecksumMismatch
+	}
+
+	p.Notes = strings.TrimSpace(p.Notes)
+
+	return nil
+}
+
+type RolloutStatus string
+
+const (
+	RolloutStatusActive    RolloutStatus = "active"
+	RolloutStatusPaused    RolloutStatus = "paused"    // Plus aucun binaire servi, reprise manuelle
+	RolloutStatusHalted    RolloutStatus = "halted"    // Arrêté automatiquement après un échec
+	RolloutStatusCompleted RolloutStatus = "completed" // Tous les appareils ont rapporté la nouvelle version
+	RolloutStatusCancelled RolloutStatus = "cancelled" // Abandonné, les appareils peuvent rejoindre un autre déploiement
+)
+
+type DeploymentStatus string
+
+const (
+	DeploymentStatusScheduled    DeploymentStatus = "scheduled"    // Étape pas encore ouverte
+	DeploymentStatusPending      DeploymentStatus = "pending"      // En attente de la prochaine vérification de l'appareil
+	DeploymentStatusInstalling   DeploymentStatus = "installing"   // Binaire téléchargé, en attente du redémarrage
+	DeploymentStatusSucceeded    DeploymentStatus = "succeeded"    // Nouvelle version annoncée par la télémétrie ou la vérification OTA
+	DeploymentStatusFailed       DeploymentStatus = "failed"       // Autre version rapportée, ou binaire trop grand
+	DeploymentStatusUnresponsive DeploymentStatus = "unresponsive" // Aucune nouvelle après le téléchargement
+)
+
+// FirmwareRollout deploys a firmware to devices, stage by stage. A stage opens once every device of the previous
+// stages runs the new version, and the rollout halts as soon as a device fails or stops reporting.
+type FirmwareRollout struct {
+	ID           int64
+	SiteID       string
+	Firmware     *Firmware
+	Location     string // Optionnel - emplacement ciblé, vide quand les appareils sont listés
+	Stages       []int  // Pourcentages cumulés d'appareils, ex: [10, 50, 100]
+	CurrentStage int
+	Status       RolloutStatus
+	HaltReason   string
+	CreatedBy    string
+	Deployments  map[DeploymentStatus]int // Nombre d'appareils par état
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type FirmwareDeployment struct {
+	RolloutID       int64
+	DeviceID        string
+	Stage           int
+	Status          DeploymentStatus
+	PreviousVersion string
+	OfferedAt       *time.Time
+	DownloadedAt    *time.Time
+	CompletedAt     *time.Time
+	Error           string
+}
+
+type CreateRolloutParams struct {
+	SiteID     string
+	FirmwareID int64
+
+	// Exactement une cible : des appareils, ou tous les appareils actifs d'un emplacement
+	DeviceIDs []string
+	Location  string
+
+	Stages    []int // Optionnel - [100] par défaut
+	CreatedBy string
+}
+
+func (p *CreateRolloutParams) Sanitize() error {
+	if p.SiteID == "" {
+		return errors.New("siteId is required")
+	}
+	if p.FirmwareID == 0 {
+		return errors.New("firmwareId is required")
+	}
+
+	p.Location = strings.TrimSpace(p.Location)
+	if (len(p.DeviceIDs) == 0) == (p.Location == "") {
+		return errors.New("either deviceIds or location is required")
+	}
+
+	// Ordre stable : la répartition des appareils entre les étapes ne dépend pas de l'ordre de la requête.
+	deviceIDs := make([]string, 0, len(p.DeviceIDs))
+	seen := make(map[string]bool, len(p.DeviceIDs))
+	for _, deviceID := range p.DeviceIDs {
+		deviceID = strings.TrimSpace(deviceID)
+		if deviceID == "" {
+			return errors.New("deviceIds cannot contain an empty ID")
+		}
+		if !seen[deviceID] {
+			seen[deviceID] = true
+			deviceIDs = append(deviceIDs, deviceID)
+		}
+	}
+	sort.Strings(deviceIDs)
+	p.DeviceIDs = deviceIDs
+
+	if len(p.Stages) == 0 {
+		p.Stages = []int{100}
+	}
+
+	previous := 0
+	for _, stage := range p.Stages {
+		if stage <= previous || stage > 100 {
+			return errors.New("stages must be increasing percentages between 1 and 100")
+		}
+		previous = stage
+	}
+	if previous != 100 {
+		return errors.New("the last stage must be 100")
+	}
+
+	return nil
+}
+
+// StageOf returns the stage of the device at the given index among count devices: stage i covers the first
+// Stages[i] percent of the devices, rounded up.
+func StageOf(stages []int, index, count int) int {
+	for stage, percent := range stages {
+		if index < (count*percent+99)/100 {
+			return stage
+		}
+	}
+
+	return len(stages) - 1
+}
+
+// FirmwareUpdateRequest is an update check of the ESP8266 httpUpdate client, authenticated with the device secret
+// as the HTTP basic auth password.
+type FirmwareUpdateRequest struct {
+	DeviceID       string
+	Secret         string
+	CurrentVersion string // En-tête x-ESP8266-version
+	MAC            string // En-tête x-ESP8266-STA-MAC
+	FreeSpace      *int64 // Optionnel - en-tête x-ESP8266-free-space
+}
+
+var (
+	ErrFirmwareChecksumMismatch = errors.New("sha256 does not match the binary")
+	ErrFirmwareVersionExists    = errors.New("firmware version already exists")
+	ErrUnknownFirmware          = errors.New("unknown firmware")
+	ErrUnknownRollout           = errors.New("unknown rollout")
+	ErrRolloutInProgress        = errors.New("a device already has a rollout in progress")
+	ErrNoRolloutDevices         = errors.New("rollout has no devices")
+	ErrInvalidDeviceSecret      = errors.New("invalid device secret")
+	ErrInvalidRolloutStatus     = errors.New("invalid rollout status change")
+
+	// ErrNoFirmwareUpdate is returned to a device running the version of its rollout, or that has none.
+	ErrNoFirmwareUpdate = errors.New("no firmware update")
+
+	// ErrFirmwareTooLarge is returned when the device has not enough free space for the binary. Its deployment fails.
+	ErrFirmwareTooLarge = errors.New("not enough free space for the firmware")
+)
+
+type FirmwareManager interface {
+	UploadFirmware(params *UploadFirmwareParams) (*Firmware, error)
+	GetFirmwares() ([]*Firmware, error)
+
+	CreateRollout(params *CreateRolloutParams) (*FirmwareRollout, error)
+	GetRollouts(siteID string) ([]*FirmwareRollout, error)
+	GetDeployments(siteID string, rolloutID int64) ([]*FirmwareDeployment, error)
+
+	// SetRolloutStatus pauses an active rollout, resumes a paused or halted one, or cancels it. Resuming retries the
+	// devices that failed.
+	SetRolloutStatus(siteID string, rolloutID int64, status RolloutStatus) (*FirmwareRollout, error)
+
+	// CheckUpdate returns the firmware to install when the device has a deployment in an open stage of an active
+	// rollout, and records that the device downloads it. It returns ErrNoFirmwareUpdate otherwise.
+	CheckUpdate(request *FirmwareUpdateRequest) (*Firmware, []byte, error)
+
+	// AdvanceRollouts checks the telemetry of the devices that downloaded a firmware, halts the rollouts where a device
+	// failed or has not reported within healthTimeout, and opens the next stages. It returns the rollouts whose status
+	// or stage changed.
+	AdvanceRollouts(now time.Time, healthTimeout time.Duration) ([]*FirmwareRollout, error)
+}
@@ -0,0 +1,466 @@
+// Package homeassistant publishes the devices, readings, active alerts and alarm state of the sites to Home Assistant
+// through MQTT discovery (https://www.home-assistant.io/integrations/mqtt/#mqtt-discovery).
+package homeassistant
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sensormanager"
+	"sensormanager/mqtt"
+	"sensormanager/store"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+const connectTimeout = 10 * time.Second
+
+var errConnectTimeout = errors.New("homeassistant: connection to the broker timed out")
+
+// deviceClasses are the Home Assistant device classes of the sensor types, which pick their icon and unit
+// conversions. The other types have no device class.
+var deviceClasses = map[sensormanager.SensorType]string{
+	sensormanager.SensorTypeDistance:    "distance",
+	sensormanager.SensorTypeMicrophone:  "sound_pressure",
+	sensormanager.SensorTypeMotion:      "motion",
+	sensormanager.SensorTypeTemperature: "temperature",
+	sensormanager.SensorTypeHumidity:    "humidity",
+	sensormanager.SensorTypeIlluminance: "illuminance",
+}
+
+// Les identifiants d'objet de la découverte n'acceptent que ces caractères.
+var objectIDPattern = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// Publisher mirrors the sites in Home Assistant. Every site has an "armed" switch, and every active device a sensor
+// for its readings and a "problem" binary sensor, on while the device has an active alert.
+//
+// The readings and the alarm changes are published as they happen. The devices and the alert states are
+// synchronized at every interval, acknowledged and resolved alerts turning their binary sensor off.
+type Publisher struct {
+	config          mqtt.Config
+	store           *store.Store
+	discoveryPrefix string
+	topicPrefix     string
+	interval        time.Duration
+
+	client  paho.Client
+	refresh chan struct{}
+	done    chan struct{}
+
+	mu      sync.Mutex
+	configs map[string]bool // Configurations publiées, retirées de Home Assistant quand l'appareil disparaît
+}
+
+type Option func(*Publisher)
+
+func New(options ...Option) *Publisher {
+	result := &Publisher{
+		config:          mqtt.Config{ClientID: "sensormanager-homeassistant"},
+		discoveryPrefix: "homeassistant",
+		topicPrefix:     "sensormanager",
+		interval:        30 * time.Second,
+		configs:         make(map[string]bool),
+	}
+
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.config.BrokerURL == "" {
+		panic("could not create Home Assistant publisher without broker URL")
+	}
+
+	if result.store == nil {
+		panic("could not create Home Assistant publisher without Store configuration")
+	}
+
+	return result
+}
+
+func WithBroker(config mqtt.Config) Option {
+	return func(p *Publisher) {
+		clientID := p.config.ClientID
+		p.config = config
+
+		if p.config.ClientID == "" {
+			p.config.ClientID = clientID
+		}
+	}
+}
+
+func WithStore(store *store.Store) Option { return func(p *Publisher) { p.store = store } }
+
+// WithTopics sets the discovery prefix of Home Assistant, "homeassistant" by default, and the prefix of the state
+// and command topics, "sensormanager" by default.
+func WithTopics(discoveryPrefix, topicPrefix string) Option {
+	return func(p *Publisher) {
+		p.discoveryPrefix = discoveryPrefix
+		p.topicPrefix = topicPrefix
+	}
+}
+
+func WithSyncInterval(interval time.Duration) Option {
+	return func(p *Publisher) { p.interval = interval }
+}
+
+// Start connects to the broker, publishes the discovery configurations and listens to the commands of Home
+// Assistant. Everything is published again when the connection is restored or Home Assistant restarts.
+func (p *Publisher) Start() error {
+	p.refresh = make(chan struct{}, 1)
+	p.done = make(chan struct{})
+
+	subscribed := make(chan error, 1)
+
+	options := paho.NewClientOptions().
+		AddBroker(p.config.BrokerURL).
+		SetClientID(p.config.ClientID).
+		SetUsername(p.config.Username).
+		SetPassword(p.config.Password).
+		SetAutoReconnect(true).
+		SetWill(p.availabilityTopic(), "offline", 1, true).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			fmt.Printf("❌ Home Assistant connection lost: %v\n", err)
+		}).
+		SetOnConnectHandler(func(client paho.Client) {
+			err := p.subscribe(client)
+			if err != nil {
+				fmt.Printf("❌ Home Assistant subscription: %v\n", err)
+			} else {
+				client.Publish(p.availabilityTopic(), 1, true, "online")
+				p.requestSync()
+			}
+
+			select {
+			case subscribed <- err:
+			default:
+			}
+		})
+
+	p.client = paho.NewClient(options)
+
+	token := p.client.Connect()
+	if !token.WaitTimeout(connectTimeout) {
+		return errConnectTimeout
+	}
+	if err := token.Error(); err != nil {
+		return fmt.Errorf("homeassistant: could not connect to %s: %w", p.config.BrokerURL, err)
+	}
+
+	if err := <-subscribed; err != nil {
+		p.client.Disconnect(250)
+		return err
+	}
+
+	go p.run()
+
+	return nil
+}
+
+// Stop marks the entities unavailable and disconnects from the broker.
+func (p *Publisher) Stop() {
+	close(p.done)
+
+	p.client.Publish(p.availabilityTopic(), 1, true, "offline").WaitTimeout(connectTimeout)
+	p.client.Disconnect(250)
+}
+
+func (p *Publisher) subscribe(client paho.Client) error {
+	handlers := map[string]paho.MessageHandler{
+		p.topicPrefix + "/+/armed/set": p.handleArmedCommand,
+		// Home Assistant annonce son redémarrage : les configurations non conservées sont à republier.
+		p.discoveryPrefix + "/status": func(_ paho.Client, m paho.Message) {
+			if string(m.Payload()) == "online" {
+				p.requestSync()
+			}
+		},
+	}
+
+	for topic, handler := range handlers {
+		token := client.Subscribe(topic, 1, handler)
+		if !token.WaitTimeout(connectTimeout) {
+			return errConnectTimeout
+		}
+		if err := token.Error(); err != nil {
+			return fmt.Errorf("could not subscribe to %s: %w", topic, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Publisher) run() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-p.refresh:
+		case <-p.done:
+			return
+		}
+
+		if err := p.sync(); err != nil {
+			fmt.Printf("❌ Home Assistant: %v\n", err)
+		}
+	}
+}
+
+func (p *Publisher) requestSync() {
+	select {
+	case p.refresh <- struct{}{}:
+	default:
+	}
+}
+
+// ReadingRecorded publishes the reading to the state topic of its sensor, and turns the alert binary sensor of the
+// device on when the reading raised an alert.
+func (p *Publisher) ReadingRecorded(sensorType sensormanager.SensorType, reading *sensormanager.AlertResponse) {
+	p.publish(p.stateTopic(reading.SiteID, reading.DeviceID, string(sensorType)), strconv.FormatFloat(reading.Value, 'f', -1, 64))
+
+	if reading.Alert {
+		p.publishAlert(reading.SiteID, reading.DeviceID, map[string]interface{}{
+			"type":    string(sensorType),
+			"alertId": reading.AlertID,
+			"message": reading.Message,
+			"since":   reading.RecordedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	// 🆕 Premier relevé d'un appareil : sa configuration est publiée sans attendre la prochaine synchronisation.
+	p.mu.Lock()
+	known := p.configs[p.configTopic(p.sensorComponent(sensorType), reading.SiteID, reading.DeviceID+"_"+string(sensorType))]
+	p.mu.Unlock()
+
+	if !known {
+		p.requestSync()
+	}
+}
+
+// SiteArmedChanged publishes the state of the armed switch of the site.
+func (p *Publisher) SiteArmedChanged(site *sensormanager.Site) {
+	p.publish(p.armedTopic(site.ID), onOff(site.Armed))
+}
+
+// handleArmedCommand arms or disarms a site from its switch in Home Assistant.
+func (p *Publisher) handleArmedCommand(_ paho.Client, m paho.Message) {
+	siteID := strings.TrimSuffix(strings.TrimPrefix(m.Topic(), p.topicPrefix+"/"), "/armed/set")
+
+	var armed bool
+	switch string(m.Payload()) {
+	case "ON":
+		armed = true
+	case "OFF":
+		armed = false
+	default:
+		fmt.Printf("❌ Home Assistant: invalid armed command %q for site %s\n", m.Payload(), siteID)
+		return
+	}
+
+	site, err := p.store.Sites.SetSiteArmed(siteID, armed)
+	if err != nil {
+		fmt.Printf("❌ Home Assistant: could not set the alarm of site %s: %v\n", siteID, err)
+		return
+	}
+
+	fmt.Printf("🏠 Alarm of site %s set to %s from Home Assistant\n", site.ID, onOff(site.Armed))
+
+	p.SiteArmedChanged(site)
+}
+
+// sync publishes the configurations of the sites and of their active devices with the states of their alarm and
+// alerts, and removes the configurations of the devices that are gone.
+func (p *Publisher) sync() error {
+	sites, err := p.store.Sites.GetSites()
+	if err != nil {
+		return err
+	}
+
+	activeAlerts, err := p.store.Sensors.GetActiveAlerts()
+	if err != nil {
+		return err
+	}
+
+	// Alerte active la plus récente de chaque appareil
+	alerts := make(map[string]*sensormanager.ActiveAlert)
+	for _, alert := range activeAlerts {
+		alerts[alert.SiteID+"/"+alert.DeviceID] = alert
+	}
+
+	configs := make(map[string]bool)
+
+	for _, site := range sites {
+		devices, err := p.store.Devices.GetDevices(site.ID)
+		if err != nil {
+			return err
+		}
+
+		p.publishConfig(configs, "switch", site.ID, "armed", map[string]interface{}{
+			"name":          "Armed",
+			"icon":          "mdi:shield-home",
+			"state_topic":   p.armedTopic(site.ID),
+			"command_topic": p.armedTopic(site.ID) + "/set",
+			"device":        p.siteDevice(site),
+		})
+		p.SiteArmedChanged(site)
+
+		for _, device := range devices {
+			if device.Status != "" && device.Status != sensormanager.DeviceStatusActive {
+				continue
+			}
+
+			p.publishDevice(configs, site, device)
+
+			if alert, active := alerts[site.ID+"/"+device.DeviceID]; active {
+				p.publishAlert(site.ID, device.DeviceID, map[string]interface{}{
+					"type":    string(alert.Type),
+					"alertId": alert.ID,
+					"since":   alert.CreatedAt.Format("2006-01-02T15:04:05Z"),
+				})
+			} else {
+				p.publish(p.alertTopic(site.ID, device.DeviceID), "OFF")
+			}
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// 🗑️ Une configuration vide retire l'entité de Home Assistant
+	for topic := range p.configs {
+		if !configs[topic] {
+			p.publish(topic, "")
+		}
+	}
+	p.configs = configs
+
+	return nil
+}
+
+func (p *Publisher) publishDevice(configs map[string]bool, site *sensormanager.Site, device *sensormanager.Device) {
+	definition, err := sensormanager.LookupSensorType(device.SensorType)
+	if err != nil {
+		return
+	}
+
+	haDevice := map[string]interface{}{
+		"identifiers": []string{p.topicPrefix + "_" + device.DeviceID},
+		"name":        device.DeviceID,
+		"model":       definition.Label,
+		"via_device":  p.topicPrefix + "_site_" + site.ID,
+	}
+	if device.Location != "" {
+		haDevice["suggested_area"] = device.Location
+	}
+	if device.Telemetry != nil && device.Telemetry.FirmwareVersion != "" {
+		haDevice["sw_version"] = device.Telemetry.FirmwareVersion
+	}
+
+	sensor := map[string]interface{}{
+		"name":        definition.Label,
+		"state_topic": p.stateTopic(site.ID, device.DeviceID, string(definition.Name)),
+		"device":      haDevice,
+	}
+	if deviceClass, exists := deviceClasses[definition.Name]; exists {
+		sensor["device_class"] = deviceClass
+	}
+	if definition.Kind == sensormanager.ValueKindBool {
+		sensor["payload_on"] = "1"
+		sensor["payload_off"] = "0"
+	} else {
+		sensor["state_class"] = "measurement"
+		if definition.Unit != "" {
+			sensor["unit_of_measurement"] = definition.Unit
+		}
+	}
+
+	p.publishConfig(configs, p.sensorComponent(definition.Name), site.ID, device.DeviceID+"_"+string(definition.Name), sensor)
+
+	p.publishConfig(configs, "binary_sensor", site.ID, device.DeviceID+"_alert", map[string]interface{}{
+		"name":                  "Alert",
+		"device_class":          "problem",
+		"state_topic":           p.alertTopic(site.ID, device.DeviceID),
+		"json_attributes_topic": p.alertTopic(site.ID, device.DeviceID) + "/attributes",
+		"device":                haDevice,
+	})
+}
+
+// publishConfig publishes the discovery configuration of an entity, its unique ID and availability included.
+func (p *Publisher) publishConfig(configs map[string]bool, component, siteID, objectID string, config map[string]interface{}) {
+	uniqueID := p.topicPrefix + "_" + siteID + "_" + objectIDPattern.ReplaceAllString(objectID, "_")
+
+	config["unique_id"] = uniqueID
+	config["object_id"] = uniqueID
+	config["availability_topic"] = p.availabilityTopic()
+
+	payload, err := json.Marshal(config)
+	if err != nil {
+		fmt.Printf("❌ Home Assistant: could not encode the configuration of %s: %v\n", uniqueID, err)
+		return
+	}
+
+	topic := p.configTopic(component, siteID, objectID)
+	configs[topic] = true
+
+	p.publish(topic, string(payload))
+}
+
+func (p *Publisher) publishAlert(siteID, deviceID string, attributes map[string]interface{}) {
+	payload, _ := json.Marshal(attributes)
+
+	p.publish(p.alertTopic(siteID, deviceID)+"/attributes", string(payload))
+	p.publish(p.alertTopic(siteID, deviceID), "ON")
+}
+
+// publish sends a retained message, so that Home Assistant gets the last states when it subscribes. The delivery is
+// not awaited: paho reports its failures to the connection handlers.
+func (p *Publisher) publish(topic, payload string) {
+	p.client.Publish(topic, 1, true, payload)
+}
+
+func (p *Publisher) siteDevice(site *sensormanager.Site) map[string]interface{} {
+	return map[string]interface{}{
+		"identifiers":  []string{p.topicPrefix + "_site_" + site.ID},
+		"name":         site.Name,
+		"manufacturer": "SensorManager",
+		"model":        "Site",
+	}
+}
+
+func (p *Publisher) sensorComponent(sensorType sensormanager.SensorType) string {
+	if definition, err := sensormanager.LookupSensorType(sensorType); err == nil && definition.Kind == sensormanager.ValueKindBool {
+		return "binary_sensor"
+	}
+
+	return "sensor"
+}
+
+func (p *Publisher) configTopic(component, siteID, objectID string) string {
+	return p.discoveryPrefix + "/" + component + "/" + p.topicPrefix + "_" + siteID + "/" +
+		objectIDPattern.ReplaceAllString(objectID, "_") + "/config"
+}
+
+func (p *Publisher) availabilityTopic() string { return p.topicPrefix + "/status" }
+
+func (p *Publisher) armedTopic(siteID string) string { return p.topicPrefix + "/" + siteID + "/armed" }
+
+func (p *Publisher) stateTopic(siteID, deviceID, sensorType string) string {
+	return p.topicPrefix + "/" + siteID + "/" + deviceID + "/" + sensorType
+}
+
+func (p *Publisher) alertTopic(siteID, deviceID string) string {
+	return p.topicPrefix + "/" + siteID + "/" + deviceID + "/alert"
+}
+
+func onOff(on bool) string {
+	if on {
+		return "ON"
+	}
+
+	return "OFF"
+}
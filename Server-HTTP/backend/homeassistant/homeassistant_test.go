@@ -0,0 +1,221 @@
+package homeassistant
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sensormanager"
+	"sensormanager/mqtt"
+	"sensormanager/store"
+	"sync"
+	"testing"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/mochi-mqtt/server/v2/packets"
+)
+
+type fakeSites struct {
+	sensormanager.SiteManager
+
+	mu   sync.Mutex
+	site *sensormanager.Site
+}
+
+func (f *fakeSites) GetSites() ([]*sensormanager.Site, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	site := *f.site
+	return []*sensormanager.Site{&site}, nil
+}
+
+func (f *fakeSites) SetSiteArmed(siteID string, armed bool) (*sensormanager.Site, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if siteID != f.site.ID {
+		return nil, sensormanager.ErrUnknownDevice
+	}
+
+	f.site.Armed = armed
+	site := *f.site
+	return &site, nil
+}
+
+type fakeDevices struct{ sensormanager.DeviceManager }
+
+func (fakeDevices) GetDevices(siteID string) ([]*sensormanager.Device, error) {
+	return []*sensormanager.Device{
+		{SiteID: siteID, DeviceID: "ESP_002", SensorType: sensormanager.SensorTypeDistance, Status: sensormanager.DeviceStatusActive, Location: "Entrée"},
+		{SiteID: siteID, DeviceID: "ESP_0002", SensorType: sensormanager.SensorTypeDistance, Status: sensormanager.DeviceStatusPending},
+	}, nil
+}
+
+type fakeSensors struct{ sensormanager.SensorManager }
+
+func (fakeSensors) GetActiveAlerts() ([]*sensormanager.ActiveAlert, error) {
+	return []*sensormanager.ActiveAlert{
+		{Type: sensormanager.AlertTypeDistance, ID: 7, SiteID: "home", DeviceID: "ESP_002", CreatedAt: time.Now()},
+	}, nil
+}
+
+// retained keeps the last message of every topic published on the broker.
+type retained struct {
+	mu       sync.Mutex
+	messages map[string]string
+}
+
+func (r *retained) wait(t *testing.T, topic string, accept func(payload string) bool) string {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		r.mu.Lock()
+		payload, exists := r.messages[topic]
+		r.mu.Unlock()
+
+		if exists && accept(payload) {
+			return payload
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("nothing expected was published on %s", topic)
+	return ""
+}
+
+func anything(string) bool { return true }
+
+func equals(expected string) func(string) bool {
+	return func(payload string) bool { return payload == expected }
+}
+
+func newTestPublisher(t *testing.T) (*Publisher, *mochi.Server, *retained, *fakeSites) {
+	broker := mochi.New(&mochi.Options{
+		InlineClient: true,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	if err := broker.AddHook(new(auth.AllowHook), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	listener := listeners.NewTCP(listeners.Config{ID: "test", Address: "127.0.0.1:0"})
+	if err := broker.AddListener(listener); err != nil {
+		t.Fatal(err)
+	}
+	if err := broker.Serve(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { broker.Close() })
+
+	messages := &retained{messages: make(map[string]string)}
+	if err := broker.Subscribe("#", 1, func(_ *mochi.Client, _ packets.Subscription, pk packets.Packet) {
+		messages.mu.Lock()
+		messages.messages[pk.TopicName] = string(pk.Payload)
+		messages.mu.Unlock()
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	sites := &fakeSites{site: &sensormanager.Site{ID: "home", Name: "Maison", Armed: true}}
+
+	publisher := New(
+		WithBroker(mqtt.Config{BrokerURL: "tcp://" + listener.Address()}),
+		WithStore(&store.Store{Sites: sites, Devices: fakeDevices{}, Sensors: fakeSensors{}}),
+		WithSyncInterval(time.Hour),
+	)
+	if err := publisher.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(publisher.Stop)
+
+	return publisher, broker, messages, sites
+}
+
+func TestDiscovery(t *testing.T) {
+	_, _, messages, _ := newTestPublisher(t)
+
+	var sensor map[string]interface{}
+	payload := messages.wait(t, "homeassistant/sensor/sensormanager_home/ESP_002_distance/config", anything)
+	if err := json.Unmarshal([]byte(payload), &sensor); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"state_topic":         "sensormanager/home/ESP_002/distance",
+		"unit_of_measurement": "cm",
+		"device_class":        "distance",
+		"unique_id":           "sensormanager_home_ESP_002_distance",
+		"availability_topic":  "sensormanager/status",
+	}
+	for key, value := range expected {
+		if sensor[key] != value {
+			t.Errorf("expected %s to be %v, got %v", key, value, sensor[key])
+		}
+	}
+	if device := sensor["device"].(map[string]interface{}); device["suggested_area"] != "Entrée" {
+		t.Errorf("expected the location of the device to be its area, got %v", device["suggested_area"])
+	}
+
+	messages.wait(t, "homeassistant/binary_sensor/sensormanager_home/ESP_002_alert/config", anything)
+	messages.wait(t, "homeassistant/switch/sensormanager_home/armed/config", anything)
+	messages.wait(t, "sensormanager/home/ESP_002/alert", equals("ON"))
+	messages.wait(t, "sensormanager/home/armed", equals("ON"))
+	messages.wait(t, "sensormanager/status", equals("online"))
+
+	// Les appareils en attente d'approbation n'apparaissent pas dans Home Assistant.
+	messages.mu.Lock()
+	_, published := messages.messages["homeassistant/sensor/sensormanager_home/ESP_0002_distance/config"]
+	messages.mu.Unlock()
+	if published {
+		t.Error("the pending device was published")
+	}
+}
+
+func TestReadingRecorded(t *testing.T) {
+	publisher, _, messages, _ := newTestPublisher(t)
+
+	publisher.ReadingRecorded(sensormanager.SensorTypeMotion, &sensormanager.AlertResponse{
+		SiteID:     "home",
+		DeviceID:   "ESP_003",
+		Value:      1,
+		Alert:      true,
+		AlertID:    12,
+		Message:    "Motion detected",
+		RecordedAt: time.Now(),
+	})
+
+	messages.wait(t, "sensormanager/home/ESP_003/motion", equals("1"))
+	messages.wait(t, "sensormanager/home/ESP_003/alert", equals("ON"))
+
+	var attributes map[string]interface{}
+	payload := messages.wait(t, "sensormanager/home/ESP_003/alert/attributes", anything)
+	if err := json.Unmarshal([]byte(payload), &attributes); err != nil {
+		t.Fatal(err)
+	}
+	if attributes["alertId"] != float64(12) || attributes["type"] != "motion" {
+		t.Errorf("unexpected alert attributes %v", attributes)
+	}
+}
+
+func TestArmedCommand(t *testing.T) {
+	_, broker, messages, sites := newTestPublisher(t)
+
+	messages.wait(t, "sensormanager/home/armed", equals("ON"))
+
+	if err := broker.Publish("sensormanager/home/armed/set", []byte("OFF"), false, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	messages.wait(t, "sensormanager/home/armed", equals("OFF"))
+
+	sites.mu.Lock()
+	defer sites.mu.Unlock()
+	if sites.site.Armed {
+		t.Error("the site is still armed")
+	}
+}
@@ -0,0 +1,77 @@
+package sensormanager
+
+import (
+	"errors"
+	"time"
+)
+
+type IncidentStatus string
+
+const (
+	IncidentStatusOpen         IncidentStatus = "open"
+	IncidentStatusAcknowledged IncidentStatus = "acknowledged"
+	IncidentStatusClosed       IncidentStatus = "closed"
+)
+
+func (s IncidentStatus) Validate() error {
+	switch s {
+	case IncidentStatusOpen, IncidentStatusAcknowledged, IncidentStatusClosed:
+		return nil
+	default:
+		return errors.New("invalid incident status")
+	}
+}
+
+// Incident groups the alerts raised by the same device or location within a rolling window.
+type Incident struct {
+	ID             int64
+	SiteID         string
+	GroupKey       string
+	Status         IncidentStatus
+	AlertCount     int
+	Alerts         []*IncidentAlert
+	OpenedAt       time.Time
+	LastAlertAt    time.Time
+	AcknowledgedAt *time.Time
+	ClosedAt       *time.Time
+}
+
+type IncidentAlert struct {
+	AlertType AlertType
+	AlertID   int64
+	DeviceID  string
+	Message   string
+	CreatedAt time.Time
+}
+
+type AttachAlertParams struct {
+	SiteID    string
+	AlertType AlertType
+	AlertID   int64
+	DeviceID  string
+	Message   string
+}
+
+type GetIncidentsParams struct {
+	SiteID string
+	Status IncidentStatus // Optionnel - vide = tous
+	Limit  int
+}
+
+type UpdateIncidentStatusParams struct {
+	SiteID     string
+	IncidentID int64
+	Status     IncidentStatus
+}
+
+type IncidentManager interface {
+	// AttachAlert adds the alert to the current incident of its device or location, opening a new incident when
+	// there is none. The returned boolean is set when the incident has been opened by this alert.
+	AttachAlert(params *AttachAlertParams) (*Incident, bool, error)
+
+	GetIncidents(params *GetIncidentsParams) ([]*Incident, error)
+	GetIncident(siteID string, incidentID int64) (*Incident, error)
+
+	// UpdateIncidentStatus updates the incident and cascades the status to its member alerts.
+	UpdateIncidentStatus(params *UpdateIncidentStatusParams) error
+}
@@ -0,0 +1,30 @@
+package sensormanager
+
+import (
+	"errors"
+	"time"
+)
+
+// IngestionSubjectPrefix is the first token of the NATS subjects of the readings appended to the ingestion queue.
+const IngestionSubjectPrefix = "ingest"
+
+// ErrIngestionUnavailable is returned when a reading cannot be appended to the ingestion queue, e.g. when the stream
+// is full.
+var ErrIngestionUnavailable = errors.New("ingestion queue is unavailable")
+
+// IngestionStats describes the backlog of the ingestion queue, the readings accepted by the record calls but not yet
+// recorded.
+type IngestionStats struct {
+	Stream   string
+	Consumer string
+	Messages uint64 // Mesures conservées par le flux
+	Bytes    uint64
+
+	Pending     uint64 // Mesures pas encore délivrées au consommateur
+	AckPending  int    // Mesures délivrées en cours d'enregistrement
+	Redelivered int
+
+	// Lag is the number of readings not yet recorded, and OldestPendingAt the time the oldest of them was received.
+	Lag             uint64
+	OldestPendingAt *time.Time
+}
@@ -0,0 +1,308 @@
+// Package ingestion decouples the record calls from the database: the readings are appended to a JetStream stream and
+// the call returns as soon as NATS has stored them. A durable consumer then records them one by one, in the order they
+// were received, through the same path as the record calls, alerts and notifications included.
+//
+// A reading is acknowledged once recorded. When the database is unavailable, it is delivered again after the retry
+// delay, blocking the following readings to keep their order, so that the readings survive an outage of the database
+// for as long as the stream keeps them. A reading rejected while the database is available (invalid signature,
+// unknown device...) is logged and dropped.
+//
+// The readings are appended on ingest.<siteId>.<sensorType>.<deviceId> as the JSON of sensormanager.ReadingParams,
+// with the time they were received under "receivedAt".
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sensormanager"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const requestTimeout = 5 * time.Second
+
+// StreamSubjects are the subjects captured by the stream of the ingestion queue.
+const StreamSubjects = sensormanager.IngestionSubjectPrefix + ".>"
+
+// Recorder records the readings through the path of the record calls, alerts and notifications included.
+type Recorder interface {
+	Ingest(params *sensormanager.ReadingParams) (*sensormanager.AlertResponse, error)
+}
+
+// Config sets the limits of the stream. When a limit is reached, the new readings are refused rather than the oldest
+// ones, which may not be recorded yet, being dropped.
+type Config struct {
+	Stream   string        // ex: "READINGS"
+	Consumer string        // Consommateur durable
+	MaxAge   time.Duration // 0 : sans limite
+	MaxBytes int64         // -1 : sans limite
+	MaxMsgs  int64         // -1 : sans limite
+}
+
+type Queue struct {
+	conn        *nats.Conn
+	config      Config
+	healthCheck func() error
+	retryDelay  time.Duration
+	ackWait     time.Duration
+
+	jetStream jetstream.JetStream
+	stream    jetstream.Stream
+	consumer  jetstream.Consumer
+	consuming jetstream.ConsumeContext
+}
+
+// message is a reading of the queue. The gateway and the time of reception cannot be set by RES clients and are not
+// encoded with the reading.
+type message struct {
+	sensormanager.ReadingParams
+	Gateway    string    `json:"gateway,omitempty"`
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+type Option func(*Queue)
+
+func New(options ...Option) *Queue {
+	result := &Queue{
+		config: Config{
+			Stream:   "READINGS",
+			Consumer: "sensormanager",
+			MaxAge:   24 * time.Hour,
+			MaxBytes: -1,
+			MaxMsgs:  -1,
+		},
+		retryDelay: 5 * time.Second,
+		ackWait:    30 * time.Second,
+	}
+
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.conn == nil {
+		panic("could not create ingestion queue without NATS connection")
+	}
+
+	if result.config.Stream == "" || result.config.Consumer == "" {
+		panic("could not create ingestion queue without stream and consumer names")
+	}
+
+	return result
+}
+
+func WithConn(conn *nats.Conn) Option { return func(q *Queue) { q.conn = conn } }
+
+func WithConfig(config Config) Option { return func(q *Queue) { q.config = config } }
+
+// WithHealthCheck sets the check of the database, e.g. db.Ping. A reading that cannot be recorded while the check
+// fails is delivered again instead of being dropped.
+func WithHealthCheck(check func() error) Option { return func(q *Queue) { q.healthCheck = check } }
+
+// WithRetryDelay sets the delay before a reading that could not be recorded is delivered again.
+func WithRetryDelay(delay time.Duration) Option { return func(q *Queue) { q.retryDelay = delay } }
+
+// WithAckWait sets how long a reading may take to be recorded before it is delivered again.
+func WithAckWait(wait time.Duration) Option { return func(q *Queue) { q.ackWait = wait } }
+
+// Start creates or updates the stream and its durable consumer.
+func (q *Queue) Start() error {
+	jetStream, err := jetstream.New(q.conn)
+	if err != nil {
+		return fmt.Errorf("could not create JetStream context: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	stream, err := jetStream.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:        q.config.Stream,
+		Description: "SensorManager readings waiting to be recorded",
+		Subjects:    []string{StreamSubjects},
+		// Une mesure enregistrée est retirée du flux.
+		Retention: jetstream.WorkQueuePolicy,
+		Discard:   jetstream.DiscardNew,
+		MaxAge:    q.config.MaxAge,
+		MaxBytes:  q.config.MaxBytes,
+		MaxMsgs:   q.config.MaxMsgs,
+		Storage:   jetstream.FileStorage,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create stream %s: %w", q.config.Stream, err)
+	}
+
+	consumer, err := stream.CreateOrUpdateConsumer(ctx, jetstream.ConsumerConfig{
+		Durable:   q.config.Consumer,
+		AckPolicy: jetstream.AckExplicitPolicy,
+		AckWait:   q.ackWait,
+		// Une seule mesure en cours : les mesures sont enregistrées dans l'ordre de réception.
+		MaxAckPending: 1,
+		MaxDeliver:    -1,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create consumer %s: %w", q.config.Consumer, err)
+	}
+
+	q.jetStream = jetStream
+	q.stream = stream
+	q.consumer = consumer
+
+	return nil
+}
+
+// Append appends the reading to the stream. It returns once NATS has stored the reading.
+func (q *Queue) Append(reading *sensormanager.ReadingParams) error {
+	data, err := json.Marshal(&message{
+		ReadingParams: *reading,
+		Gateway:       reading.Gateway,
+		ReceivedAt:    time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	if _, err := q.jetStream.Publish(ctx, subject(reading), data); err != nil {
+		return fmt.Errorf("%w: %v", sensormanager.ErrIngestionUnavailable, err)
+	}
+
+	return nil
+}
+
+// Consume records the readings of the stream with the recorder until Stop is called.
+func (q *Queue) Consume(recorder Recorder) error {
+	consuming, err := q.consumer.Consume(func(msg jetstream.Msg) { q.handle(recorder, msg) })
+	if err != nil {
+		return fmt.Errorf("could not consume stream %s: %w", q.config.Stream, err)
+	}
+
+	q.consuming = consuming
+
+	return nil
+}
+
+// Stop stops consuming the readings. The reading being recorded is delivered again if it is not acknowledged.
+func (q *Queue) Stop() {
+	if q.consuming != nil {
+		q.consuming.Stop()
+	}
+}
+
+func (q *Queue) handle(recorder Recorder, msg jetstream.Msg) {
+	reading, err := decode(msg)
+	if err != nil {
+		// Une mesure illisible bloquerait les suivantes : elle est abandonnée.
+		fmt.Printf("❌ Ingestion: could not decode reading on %s: %v\n", msg.Subject(), err)
+		terminate(msg)
+		return
+	}
+
+	if _, err := recorder.Ingest(reading); err != nil {
+		if q.retryable(err) {
+			fmt.Printf("❌ Ingestion: reading of device %s will be retried in %s: %v\n", reading.DeviceID, q.retryDelay, err)
+
+			if err := msg.NakWithDelay(q.retryDelay); err != nil {
+				fmt.Printf("❌ Ingestion: could not delay reading of device %s: %v\n", reading.DeviceID, err)
+			}
+			return
+		}
+
+		fmt.Printf("❌ Ingestion: reading of device %s rejected: %v\n", reading.DeviceID, err)
+		terminate(msg)
+		return
+	}
+
+	if err := msg.Ack(); err != nil {
+		// La mesure sera délivrée à nouveau et reconnue comme une retransmission.
+		fmt.Printf("❌ Ingestion: could not acknowledge reading of device %s: %v\n", reading.DeviceID, err)
+	}
+}
+
+// retryable tells whether a reading that could not be recorded must be delivered again: the database is unavailable,
+// or a previous delivery of the reading is still being recorded.
+func (q *Queue) retryable(err error) bool {
+	if errors.Is(err, sensormanager.ErrReadingInProgress) {
+		return true
+	}
+
+	return q.healthCheck != nil && q.healthCheck() != nil
+}
+
+func terminate(msg jetstream.Msg) {
+	if err := msg.Term(); err != nil {
+		fmt.Printf("❌ Ingestion: could not drop reading on %s: %v\n", msg.Subject(), err)
+	}
+}
+
+// decode decodes a reading of the queue. A reading the device did not identify is identified by its position in
+// the stream, so that a redelivery of a recorded reading is recognized as a retransmission.
+func decode(msg jetstream.Msg) (*sensormanager.ReadingParams, error) {
+	var decoded message
+	if err := json.Unmarshal(msg.Data(), &decoded); err != nil {
+		return nil, err
+	}
+
+	reading := &decoded.ReadingParams
+	reading.Gateway = decoded.Gateway
+	reading.ReceivedAt = decoded.ReceivedAt
+
+	if reading.DedupKey(&reading.ReadingTime) == "" {
+		metadata, err := msg.Metadata()
+		if err != nil {
+			return nil, err
+		}
+
+		reading.QueueID = fmt.Sprintf("%s:%d", metadata.Stream, metadata.Sequence.Stream)
+	}
+
+	return reading, nil
+}
+
+// Stats returns the backlog of the queue.
+func (q *Queue) Stats() (*sensormanager.IngestionStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	streamInfo, err := q.stream.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get stream %s: %w", q.config.Stream, err)
+	}
+
+	consumerInfo, err := q.consumer.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get consumer %s: %w", q.config.Consumer, err)
+	}
+
+	result := &sensormanager.IngestionStats{
+		Stream:      q.config.Stream,
+		Consumer:    q.config.Consumer,
+		Messages:    streamInfo.State.Msgs,
+		Bytes:       streamInfo.State.Bytes,
+		Pending:     consumerInfo.NumPending,
+		AckPending:  consumerInfo.NumAckPending,
+		Redelivered: consumerInfo.NumRedelivered,
+		Lag:         consumerInfo.NumPending + uint64(consumerInfo.NumAckPending),
+	}
+
+	if result.Lag == 0 {
+		return result, nil
+	}
+
+	// La plus ancienne mesure en attente suit la dernière mesure reconnue, si elle n'a pas expiré depuis.
+	sequence := max(consumerInfo.AckFloor.Stream+1, streamInfo.State.FirstSeq)
+	if oldest, err := q.stream.GetMsg(ctx, sequence); err == nil {
+		result.OldestPendingAt = &oldest.Time
+	}
+
+	return result, nil
+}
+
+func subject(reading *sensormanager.ReadingParams) string {
+	return sensormanager.IngestionSubjectPrefix + "." + sensormanager.SubjectToken(reading.SiteID) + "." +
+		sensormanager.SubjectToken(string(reading.SensorType)) + "." + sensormanager.SubjectToken(reading.DeviceID)
+}
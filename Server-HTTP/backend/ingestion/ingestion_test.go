@@ -0,0 +1,252 @@
+package ingestion
+
+import (
+	"errors"
+	"sensormanager"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+// fakeRecorder records the readings in memory. The errors are returned to the first calls, in order.
+type fakeRecorder struct {
+	mu       sync.Mutex
+	errs     []error
+	calls    []*sensormanager.ReadingParams
+	recorded chan *sensormanager.ReadingParams
+}
+
+func newFakeRecorder(errs ...error) *fakeRecorder {
+	return &fakeRecorder{errs: errs, recorded: make(chan *sensormanager.ReadingParams, 16)}
+}
+
+func (r *fakeRecorder) Ingest(params *sensormanager.ReadingParams) (*sensormanager.AlertResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, params)
+
+	if len(r.errs) > 0 {
+		err := r.errs[0]
+		r.errs = r.errs[1:]
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r.recorded <- params
+
+	return &sensormanager.AlertResponse{DeviceID: params.DeviceID, Value: params.Value}, nil
+}
+
+func (r *fakeRecorder) next(t *testing.T) *sensormanager.ReadingParams {
+	t.Helper()
+
+	select {
+	case reading := <-r.recorded:
+		return reading
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reading was recorded")
+		return nil
+	}
+}
+
+func newTestConn(t *testing.T) *nats.Conn {
+	natsServer, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go natsServer.Start()
+	t.Cleanup(natsServer.Shutdown)
+
+	if !natsServer.ReadyForConnections(5 * time.Second) {
+		t.Fatal("NATS server is not ready")
+	}
+
+	conn, err := nats.Connect(natsServer.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(conn.Close)
+
+	return conn
+}
+
+func newTestQueue(t *testing.T, options ...Option) *Queue {
+	queue := New(append([]Option{WithConn(newTestConn(t)), WithRetryDelay(50 * time.Millisecond)}, options...)...)
+
+	if err := queue.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(queue.Stop)
+
+	return queue
+}
+
+func testReading(deviceID string, value float64) *sensormanager.ReadingParams {
+	return &sensormanager.ReadingParams{
+		SiteID:     "home",
+		SensorType: sensormanager.SensorTypeDistance,
+		DeviceID:   deviceID,
+		Value:      value,
+	}
+}
+
+func TestQueue(t *testing.T) {
+	queue := newTestQueue(t)
+
+	sequence := int64(3)
+	recordedAt := time.Now().Add(-time.Minute).UTC()
+	identified := testReading("ESP.002", 12.5)
+	identified.ReadingTime = sensormanager.ReadingTime{RecordedAt: &recordedAt, Sequence: &sequence}
+
+	gateway := testReading("ESP_003", 40)
+	gateway.Gateway = "mqtt"
+
+	before := time.Now()
+	for _, reading := range []*sensormanager.ReadingParams{testReading("ESP_001", 10), identified, gateway} {
+		if err := queue.Append(reading); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats, err := queue.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Lag != 3 || stats.OldestPendingAt == nil || stats.OldestPendingAt.Before(before.Add(-time.Second)) {
+		t.Errorf("expected a lag of 3 readings since the first append, got %+v", stats)
+	}
+
+	recorder := newFakeRecorder()
+	if err := queue.Consume(recorder); err != nil {
+		t.Fatal(err)
+	}
+
+	first := recorder.next(t)
+	if first.DeviceID != "ESP_001" || first.QueueID != "READINGS:1" || first.ReceivedAt.Before(before.Add(-time.Second)) {
+		t.Errorf("expected the first reading to be identified by its position, got %+v", first)
+	}
+
+	// Une mesure identifiée par l'appareil garde son identification.
+	if second := recorder.next(t); second.DeviceID != "ESP.002" || second.QueueID != "" || *second.Sequence != 3 {
+		t.Errorf("unexpected second reading %+v", second)
+	}
+
+	if third := recorder.next(t); third.DeviceID != "ESP_003" || third.Gateway != "mqtt" {
+		t.Errorf("expected the gateway of the third reading to be kept, got %+v", third)
+	}
+
+	waitForLag(t, queue, 0)
+}
+
+func TestQueueRetriesWhileDatabaseIsUnavailable(t *testing.T) {
+	var mu sync.Mutex
+	databaseErr := errors.New("connection refused")
+
+	queue := newTestQueue(t, WithHealthCheck(func() error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return databaseErr
+	}))
+
+	for _, reading := range []*sensormanager.ReadingParams{testReading("ESP_001", 10), testReading("ESP_001", 11)} {
+		if err := queue.Append(reading); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	recorder := newFakeRecorder(databaseErr, databaseErr)
+	if err := queue.Consume(recorder); err != nil {
+		t.Fatal(err)
+	}
+
+	// La base revient après deux échecs : les mesures sont enregistrées dans l'ordre.
+	time.Sleep(200 * time.Millisecond)
+	mu.Lock()
+	databaseErr = nil
+	mu.Unlock()
+
+	if first := recorder.next(t); first.Value != 10 {
+		t.Errorf("expected the first reading to be recorded first, got %v", first.Value)
+	}
+	if second := recorder.next(t); second.Value != 11 {
+		t.Errorf("expected the second reading to be recorded next, got %v", second.Value)
+	}
+
+	waitForLag(t, queue, 0)
+}
+
+func TestQueueDropsRejectedReadings(t *testing.T) {
+	queue := newTestQueue(t, WithHealthCheck(func() error { return nil }))
+
+	for _, reading := range []*sensormanager.ReadingParams{testReading("ESP_001", 10), testReading("ESP_001", 11)} {
+		if err := queue.Append(reading); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	recorder := newFakeRecorder(sensormanager.ErrInvalidSignature)
+	if err := queue.Consume(recorder); err != nil {
+		t.Fatal(err)
+	}
+
+	if reading := recorder.next(t); reading.Value != 11 {
+		t.Errorf("expected the rejected reading to be dropped, got %v", reading.Value)
+	}
+
+	waitForLag(t, queue, 0)
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if len(recorder.calls) != 2 {
+		t.Errorf("expected the rejected reading not to be delivered again, got %d calls", len(recorder.calls))
+	}
+}
+
+func TestQueueRefusesReadingsWhenFull(t *testing.T) {
+	queue := newTestQueue(t, WithConfig(Config{Stream: "READINGS", Consumer: "sensormanager", MaxMsgs: 1, MaxBytes: -1}))
+
+	if err := queue.Append(testReading("ESP_001", 10)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := queue.Append(testReading("ESP_001", 11)); !errors.Is(err, sensormanager.ErrIngestionUnavailable) {
+		t.Errorf("expected the full stream to refuse the reading, got %v", err)
+	}
+}
+
+func waitForLag(t *testing.T, queue *Queue, lag uint64) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stats, err := queue.Stats()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if stats.Lag == lag {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("expected a lag of %d readings, got %+v", lag, stats)
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}
+}
@@ -0,0 +1,143 @@
+// Package motion turns raw PIR readings into debounced motion events.
+package motion
+
+import (
+	"sync"
+	"time"
+)
+
+type EventType string
+
+const (
+	// EventStarted is emitted once enough detections happened within the debounce window.
+	EventStarted EventType = "unexpected_motion"
+
+	// EventContinuous is emitted once per motion when it lasts longer than the configured duration.
+	EventContinuous EventType = "continuous_motion"
+
+	// EventEnded is emitted when no motion has been detected during the quiet period, or with the first reading after
+	// the sensor went silent. When that reading starts a new motion, only EventStarted is emitted.
+	EventEnded EventType = "motion_ended"
+)
+
+type Event struct {
+	Type     EventType
+	DeviceID string
+	At       time.Time
+
+	// Duration is the time elapsed since the motion started.
+	Duration time.Duration
+}
+
+type Config struct {
+	// DebounceCount detections within DebounceWindow are required to start a motion.
+	DebounceCount  int
+	DebounceWindow time.Duration
+
+	// ContinuousAfter is the duration after which a motion is considered continuous. Zero disables the detection.
+	ContinuousAfter time.Duration
+
+	// QuietPeriod is the duration without detection after which a motion ends.
+	QuietPeriod time.Duration
+
+	// ExpireAfter is the duration without any reading after which a motion ends, for the sensors that go silent or
+	// only report changes. It is checked with the next reading of the device. Zero disables the expiry.
+	ExpireAfter time.Duration
+}
+
+// Detector keeps the motion state of each device.
+type Detector struct {
+	config Config
+
+	mu      sync.Mutex
+	devices map[string]*deviceState
+}
+
+type deviceState struct {
+	detections []time.Time
+
+	active             bool
+	startedAt          time.Time
+	lastDetectedAt     time.Time
+	lastObservedAt     time.Time
+	continuousReported bool
+}
+
+func New(config Config) *Detector {
+	if config.DebounceCount < 1 {
+		config.DebounceCount = 1
+	}
+
+	return &Detector{
+		config:  config,
+		devices: make(map[string]*deviceState),
+	}
+}
+
+// Observe feeds a reading of the given device to the detector. It returns the resulting event, if any.
+func (d *Detector) Observe(deviceID string, detected bool, at time.Time) *Event {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, exists := d.devices[deviceID]
+	if !exists {
+		state = &deviceState{}
+		d.devices[deviceID] = state
+	}
+
+	expired := d.config.ExpireAfter > 0 && at.Sub(state.lastObservedAt) >= d.config.ExpireAfter
+	state.lastObservedAt = at
+
+	var ended *Event
+	if state.active && (expired || !detected && at.Sub(state.lastDetectedAt) >= d.config.QuietPeriod) {
+		state.active = false
+		ended = &Event{
+			Type:     EventEnded,
+			DeviceID: deviceID,
+			At:       at,
+			Duration: state.lastDetectedAt.Sub(state.startedAt),
+		}
+	}
+
+	if !detected {
+		return ended
+	}
+
+	state.lastDetectedAt = at
+
+	if state.active {
+		if d.config.ContinuousAfter > 0 && !state.continuousReported && at.Sub(state.startedAt) >= d.config.ContinuousAfter {
+			state.continuousReported = true
+
+			return &Event{
+				Type:     EventContinuous,
+				DeviceID: deviceID,
+				At:       at,
+				Duration: at.Sub(state.startedAt),
+			}
+		}
+
+		return nil
+	}
+
+	state.detections = append(state.detections, at)
+	for len(state.detections) > 0 && at.Sub(state.detections[0]) > d.config.DebounceWindow {
+		state.detections = state.detections[1:]
+	}
+
+	if len(state.detections) < d.config.DebounceCount {
+		return ended
+	}
+
+	state.active = true
+	state.startedAt = state.detections[0]
+	state.detections = nil
+	state.continuousReported = false
+
+	return &Event{
+		Type:     EventStarted,
+		DeviceID: deviceID,
+		At:       at,
+		Duration: at.Sub(state.startedAt),
+	}
+}
@@ -0,0 +1,172 @@
+package motion
+
+import (
+	"testing"
+	"time"
+)
+
+type observation struct {
+	offset   time.Duration
+	detected bool
+	want     EventType // Empty when no event is expected.
+}
+
+func TestDetector(t *testing.T) {
+	config := Config{
+		DebounceCount:   3,
+		DebounceWindow:  2 * time.Second,
+		ContinuousAfter: 10 * time.Second,
+		QuietPeriod:     5 * time.Second,
+	}
+
+	tests := map[string][]observation{
+		"single detection is debounced": {
+			{0, true, ""},
+			{time.Second, false, ""},
+			{10 * time.Second, false, ""},
+		},
+		"detections spread over more than the window are debounced": {
+			{0, true, ""},
+			{1500 * time.Millisecond, true, ""},
+			{3 * time.Second, true, ""},
+			{4 * time.Second, true, ""},
+		},
+		"detections within the window start a motion": {
+			{0, true, ""},
+			{500 * time.Millisecond, true, ""},
+			{time.Second, true, EventStarted},
+			{1500 * time.Millisecond, true, ""},
+		},
+		"motion ends after the quiet period": {
+			{0, true, ""},
+			{500 * time.Millisecond, true, ""},
+			{time.Second, true, EventStarted},
+			{2 * time.Second, false, ""},
+			{5 * time.Second, false, ""},
+			{6 * time.Second, false, EventEnded},
+			{7 * time.Second, false, ""},
+		},
+		"continuous motion is reported once": {
+			{0, true, ""},
+			{500 * time.Millisecond, true, ""},
+			{time.Second, true, EventStarted},
+			{5 * time.Second, true, ""},
+			{10 * time.Second, true, EventContinuous},
+			{12 * time.Second, true, ""},
+			{20 * time.Second, false, EventEnded},
+		},
+		"motion can start again once ended": {
+			{0, true, ""},
+			{500 * time.Millisecond, true, ""},
+			{time.Second, true, EventStarted},
+			{10 * time.Second, false, EventEnded},
+			{11 * time.Second, true, ""},
+			{11500 * time.Millisecond, true, ""},
+			{12 * time.Second, true, EventStarted},
+		},
+	}
+
+	for name, observations := range tests {
+		t.Run(name, func(t *testing.T) {
+			detector := New(config)
+			start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+			for i, o := range observations {
+				event := detector.Observe("ESP_004", o.detected, start.Add(o.offset))
+
+				var got EventType
+				if event != nil {
+					got = event.Type
+				}
+
+				if got != o.want {
+					t.Fatalf("observation %d: got event %q, want %q", i, got, o.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectorEventDuration(t *testing.T) {
+	detector := New(Config{DebounceCount: 1, QuietPeriod: time.Second})
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	detector.Observe("ESP_004", true, start)
+	detector.Observe("ESP_004", true, start.Add(3*time.Second))
+
+	event := detector.Observe("ESP_004", false, start.Add(5*time.Second))
+	if event == nil || event.Type != EventEnded {
+		t.Fatalf("got %v, want an ended event", event)
+	}
+
+	if event.Duration != 3*time.Second {
+		t.Errorf("got duration %s, want 3s", event.Duration)
+	}
+}
+
+func TestDetectorDevicesAreIndependent(t *testing.T) {
+	detector := New(Config{DebounceCount: 2, DebounceWindow: time.Second})
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	detector.Observe("ESP_004", true, start)
+
+	if event := detector.Observe("ESP_005", true, start.Add(100*time.Millisecond)); event != nil {
+		t.Fatalf("got %v, want no event", event)
+	}
+
+	if event := detector.Observe("ESP_004", true, start.Add(200*time.Millisecond)); event == nil || event.Type != EventStarted {
+		t.Fatalf("got %v, want a started event", event)
+	}
+}
+
+func TestDetectorExpiresSilentMotion(t *testing.T) {
+	config := Config{
+		DebounceCount:   2,
+		DebounceWindow:  2 * time.Second,
+		ContinuousAfter: 10 * time.Second,
+		QuietPeriod:     5 * time.Second,
+		ExpireAfter:     time.Minute,
+	}
+
+	tests := map[string][]observation{
+		"sensor reporting only changes ends its motion with its next reading": {
+			{0, true, ""},
+			{time.Second, true, EventStarted},
+			{2 * time.Hour, true, EventEnded},
+			{2*time.Hour + time.Second, true, EventStarted},
+			{2*time.Hour + 5*time.Second, true, ""},
+		},
+		"silent sensor ends its motion with its next reading": {
+			{0, true, ""},
+			{time.Second, true, EventStarted},
+			{2 * time.Hour, false, EventEnded},
+			{2*time.Hour + time.Second, false, ""},
+		},
+		"readings within the expiry keep the motion": {
+			{0, true, ""},
+			{time.Second, true, EventStarted},
+			{50 * time.Second, true, EventContinuous},
+			{100 * time.Second, true, ""},
+		},
+	}
+
+	for name, observations := range tests {
+		t.Run(name, func(t *testing.T) {
+			detector := New(config)
+			start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+			for i, o := range observations {
+				event := detector.Observe("ESP_004", o.detected, start.Add(o.offset))
+
+				var got EventType
+				if event != nil {
+					got = event.Type
+				}
+
+				if got != o.want {
+					t.Fatalf("observation %d: got event %q, want %q", i, got, o.want)
+				}
+			}
+		})
+	}
+}
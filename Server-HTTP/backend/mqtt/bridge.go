@@ -0,0 +1,227 @@
+// Package mqtt bridges the sensors publishing over MQTT (Tasmota, Zigbee2MQTT...) to the readings of the
+// SensorManager: the topics and the fields of their JSON payloads are mapped to devices, sensor types and values.
+package mqtt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sensormanager"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+// Gateway is the gateway of the readings received by the bridge.
+const Gateway = "mqtt"
+
+const (
+	connectTimeout = 10 * time.Second
+	queueSize      = 1024
+)
+
+var errConnectTimeout = errors.New("mqtt: connection to the broker timed out")
+
+// Recorder records the readings through the path of the record calls, alerts and notifications included.
+type Recorder interface {
+	Ingest(params *sensormanager.ReadingParams) (*sensormanager.AlertResponse, error)
+}
+
+type Config struct {
+	BrokerURL string // ex: "tcp://mosquitto:1883"
+	ClientID  string
+	Username  string
+	Password  string
+}
+
+type Bridge struct {
+	config   Config
+	recorder Recorder
+	mappings []*Mapping
+
+	client   paho.Client
+	messages chan *message
+	done     chan struct{}
+}
+
+type message struct {
+	mapping *Mapping
+	topic   string
+	payload []byte
+}
+
+type Option func(*Bridge)
+
+func New(options ...Option) *Bridge {
+	result := &Bridge{config: Config{ClientID: "sensormanager"}}
+
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.config.BrokerURL == "" {
+		panic("could not create MQTT bridge without broker URL")
+	}
+
+	if result.recorder == nil {
+		panic("could not create MQTT bridge without Recorder")
+	}
+
+	return result
+}
+
+func WithBroker(config Config) Option {
+	return func(b *Bridge) {
+		clientID := b.config.ClientID
+		b.config = config
+
+		if b.config.ClientID == "" {
+			b.config.ClientID = clientID
+		}
+	}
+}
+
+func WithRecorder(recorder Recorder) Option { return func(b *Bridge) { b.recorder = recorder } }
+
+func WithMappings(mappings []*Mapping) Option { return func(b *Bridge) { b.mappings = mappings } }
+
+// Start connects to the broker and subscribes to the topics of the mappings. The subscriptions are renewed when the
+// connection is restored. The messages are recorded in the order they are received.
+func (b *Bridge) Start() error {
+	b.messages = make(chan *message, queueSize)
+	b.done = make(chan struct{})
+
+	subscribed := make(chan error, 1)
+
+	options := paho.NewClientOptions().
+		AddBroker(b.config.BrokerURL).
+		SetClientID(b.config.ClientID).
+		SetUsername(b.config.Username).
+		SetPassword(b.config.Password).
+		SetAutoReconnect(true).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			fmt.Printf("❌ MQTT connection lost: %v\n", err)
+		}).
+		SetOnConnectHandler(func(client paho.Client) {
+			err := b.subscribe(client)
+			if err != nil {
+				fmt.Printf("❌ MQTT subscription: %v\n", err)
+			}
+
+			// Seule la première connexion est attendue par Start
+			select {
+			case subscribed <- err:
+			default:
+			}
+		})
+
+	b.client = paho.NewClient(options)
+
+	token := b.client.Connect()
+	if !token.WaitTimeout(connectTimeout) {
+		return errConnectTimeout
+	}
+	if err := token.Error(); err != nil {
+		return fmt.Errorf("mqtt: could not connect to %s: %w", b.config.BrokerURL, err)
+	}
+
+	if err := <-subscribed; err != nil {
+		b.client.Disconnect(250)
+		return err
+	}
+
+	go b.run()
+
+	return nil
+}
+
+// Stop disconnects from the broker. The messages that are not recorded yet are dropped.
+func (b *Bridge) Stop() {
+	b.client.Disconnect(250)
+	close(b.done)
+}
+
+func (b *Bridge) subscribe(client paho.Client) error {
+	for _, mapping := range b.mappings {
+		token := client.Subscribe(mapping.Topic, 1, func(_ paho.Client, m paho.Message) {
+			select {
+			case b.messages <- &message{mapping: mapping, topic: m.Topic(), payload: m.Payload()}:
+			case <-b.done:
+			}
+		})
+		if !token.WaitTimeout(connectTimeout) {
+			return errConnectTimeout
+		}
+		if err := token.Error(); err != nil {
+			return fmt.Errorf("could not subscribe to %s: %w", mapping.Topic, err)
+		}
+	}
+
+	return nil
+}
+
+// run records the received messages one by one, so that the readings of a device keep their order.
+func (b *Bridge) run() {
+	for {
+		select {
+		case m := <-b.messages:
+			b.handle(m.mapping, m.topic, m.payload)
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// handle records the readings of a message. A missing field is skipped: Zigbee2MQTT only publishes the fields that
+// changed.
+func (b *Bridge) handle(mapping *Mapping, topic string, payload []byte) {
+	var fields map[string]interface{}
+	if mapping.DeviceIDField != "" || hasFields(mapping) {
+		if err := json.Unmarshal(payload, &fields); err != nil {
+			fmt.Printf("❌ MQTT %s: invalid JSON payload: %v\n", topic, err)
+			return
+		}
+	}
+
+	deviceID, err := mapping.deviceID(topic, fields)
+	if err != nil {
+		fmt.Printf("❌ MQTT %s: %v\n", topic, err)
+		return
+	}
+
+	for _, reading := range mapping.Readings {
+		var raw interface{} = string(payload)
+		if reading.Field != "" {
+			var found bool
+			if raw, found = lookupField(fields, reading.Field); !found {
+				continue
+			}
+		}
+
+		value, err := reading.value(raw)
+		if err != nil {
+			fmt.Printf("❌ MQTT %s: field %q: %v\n", topic, reading.Field, err)
+			continue
+		}
+
+		if _, err := b.recorder.Ingest(&sensormanager.ReadingParams{
+			SiteID:        mapping.SiteID,
+			SensorType:    reading.SensorType,
+			DeviceID:      deviceID,
+			Value:         value,
+			ReadingSource: sensormanager.ReadingSource{Gateway: Gateway},
+		}); err != nil {
+			fmt.Printf("❌ MQTT %s: could not record the %s reading of %s: %v\n", topic, reading.SensorType, deviceID, err)
+		}
+	}
+}
+
+func hasFields(mapping *Mapping) bool {
+	for _, reading := range mapping.Readings {
+		if reading.Field != "" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,263 @@
+package mqtt
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sensormanager"
+	"strings"
+	"testing"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+)
+
+// fakeRecorder collects the ingested readings.
+type fakeRecorder struct {
+	readings chan *sensormanager.ReadingParams
+}
+
+func (r *fakeRecorder) Ingest(params *sensormanager.ReadingParams) (*sensormanager.AlertResponse, error) {
+	r.readings <- params
+
+	return &sensormanager.AlertResponse{SiteID: params.SiteID, DeviceID: params.DeviceID, Value: params.Value}, nil
+}
+
+// newTestBroker starts an embedded broker on a random local port.
+func newTestBroker(t *testing.T) (*mochi.Server, string) {
+	broker := mochi.New(&mochi.Options{
+		InlineClient: true,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+
+	if err := broker.AddHook(new(auth.AllowHook), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	listener := listeners.NewTCP(listeners.Config{ID: "test", Address: "127.0.0.1:0"})
+	if err := broker.AddListener(listener); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := broker.Serve(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { broker.Close() })
+
+	return broker, "tcp://" + listener.Address()
+}
+
+func newTestBridge(t *testing.T, mappings []*Mapping) (*mochi.Server, *fakeRecorder) {
+	broker, url := newTestBroker(t)
+
+	for _, mapping := range mappings {
+		if err := mapping.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	recorder := &fakeRecorder{readings: make(chan *sensormanager.ReadingParams, 16)}
+
+	bridge := New(
+		WithBroker(Config{BrokerURL: url, ClientID: "bridge-test"}),
+		WithRecorder(recorder),
+		WithMappings(mappings),
+	)
+	if err := bridge.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(bridge.Stop)
+
+	return broker, recorder
+}
+
+func (r *fakeRecorder) next(t *testing.T) *sensormanager.ReadingParams {
+	t.Helper()
+
+	select {
+	case reading := <-r.readings:
+		return reading
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reading was recorded")
+		return nil
+	}
+}
+
+func (r *fakeRecorder) expectNone(t *testing.T) {
+	t.Helper()
+
+	select {
+	case reading := <-r.readings:
+		t.Fatalf("unexpected reading %+v", reading)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestBridgeZigbee2MQTT(t *testing.T) {
+	broker, recorder := newTestBridge(t, []*Mapping{{
+		Topic:    "zigbee2mqtt/+",
+		SiteID:   "home",
+		DeviceID: "ZB_{1}",
+		Readings: []*FieldMapping{
+			{Field: "temperature", SensorType: sensormanager.SensorTypeTemperature},
+			{Field: "occupancy", SensorType: sensormanager.SensorTypeMotion},
+		},
+	}})
+
+	if err := broker.Publish("zigbee2mqtt/living_room", []byte(`{"temperature":21.5,"occupancy":true,"battery":97}`), false, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	temperature := recorder.next(t)
+	if temperature.SiteID != "home" || temperature.DeviceID != "ZB_living_room" ||
+		temperature.SensorType != sensormanager.SensorTypeTemperature || temperature.Value != 21.5 {
+		t.Fatalf("unexpected temperature reading %+v", temperature)
+	}
+	if temperature.Gateway != Gateway {
+		t.Errorf("expected the reading to come from the %q gateway, got %q", Gateway, temperature.Gateway)
+	}
+
+	motion := recorder.next(t)
+	if motion.SensorType != sensormanager.SensorTypeMotion || motion.Value != 1 {
+		t.Fatalf("unexpected motion reading %+v", motion)
+	}
+
+	// Zigbee2MQTT ne publie que les champs modifiés : les champs absents sont ignorés.
+	if err := broker.Publish("zigbee2mqtt/living_room", []byte(`{"occupancy":false}`), false, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if motion := recorder.next(t); motion.SensorType != sensormanager.SensorTypeMotion || motion.Value != 0 {
+		t.Fatalf("unexpected motion reading %+v", motion)
+	}
+	recorder.expectNone(t)
+}
+
+func TestBridgeTasmota(t *testing.T) {
+	broker, recorder := newTestBridge(t, []*Mapping{
+		{
+			Topic:    "tele/+/SENSOR",
+			SiteID:   "home",
+			DeviceID: "TASMOTA_{1}",
+			Readings: []*FieldMapping{
+				{Field: "AM2301.Temperature", SensorType: sensormanager.SensorTypeTemperature},
+				{Field: "AM2301.Humidity", SensorType: sensormanager.SensorTypeHumidity},
+			},
+		},
+		{
+			Topic:    "stat/+/POWER",
+			SiteID:   "home",
+			DeviceID: "TASMOTA_{1}",
+			Readings: []*FieldMapping{{SensorType: sensormanager.SensorTypeMotion}},
+		},
+	})
+
+	if err := broker.Publish("tele/kitchen/SENSOR", []byte(`{"Time":"2024-01-01T12:00:00","AM2301":{"Temperature":19.2,"Humidity":"48"}}`), false, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if reading := recorder.next(t); reading.DeviceID != "TASMOTA_kitchen" || reading.Value != 19.2 {
+		t.Fatalf("unexpected temperature reading %+v", reading)
+	}
+	if reading := recorder.next(t); reading.SensorType != sensormanager.SensorTypeHumidity || reading.Value != 48 {
+		t.Fatalf("unexpected humidity reading %+v", reading)
+	}
+
+	if err := broker.Publish("stat/kitchen/POWER", []byte("ON"), false, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if reading := recorder.next(t); reading.DeviceID != "TASMOTA_kitchen" || reading.Value != 1 {
+		t.Fatalf("unexpected power reading %+v", reading)
+	}
+}
+
+func TestBridgeDeviceIDField(t *testing.T) {
+	broker, recorder := newTestBridge(t, []*Mapping{{
+		Topic:         "sensors/#",
+		SiteID:        "home",
+		DeviceIDField: "device.id",
+		Readings:      []*FieldMapping{{Field: "lux", SensorType: sensormanager.SensorTypeIlluminance, Scale: 0.1}},
+	}})
+
+	// Les messages invalides sont ignorés sans interrompre le pont.
+	for _, payload := range []string{`not json`, `{"lux":100}`, `{"device":{"id":42},"lux":100}`, `{"device":{"id":"LUX_1"},"lux":"bright"}`} {
+		if err := broker.Publish("sensors/garden/light", []byte(payload), false, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := broker.Publish("sensors/garden/light", []byte(`{"device":{"id":"LUX_1"},"lux":1250}`), false, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if reading := recorder.next(t); reading.DeviceID != "LUX_1" || reading.Value != 125 {
+		t.Fatalf("unexpected illuminance reading %+v", reading)
+	}
+	recorder.expectNone(t)
+}
+
+func TestMappingValidate(t *testing.T) {
+	readings := []*FieldMapping{{Field: "temperature", SensorType: sensormanager.SensorTypeTemperature}}
+
+	tests := []struct {
+		name    string
+		mapping *Mapping
+		err     string
+	}{
+		{"valid", &Mapping{Topic: "zigbee2mqtt/+", SiteID: "home", DeviceID: "ZB_{1}", Readings: readings}, ""},
+		{"no topic", &Mapping{SiteID: "home", DeviceID: "ZB", Readings: readings}, "topic is required"},
+		{"misplaced #", &Mapping{Topic: "a/#/b", SiteID: "home", DeviceID: "ZB", Readings: readings}, "must be the last level"},
+		{"partial wildcard", &Mapping{Topic: "a/b+", SiteID: "home", DeviceID: "ZB", Readings: readings}, "whole level"},
+		{"no site", &Mapping{Topic: "a", DeviceID: "ZB", Readings: readings}, "siteId is required"},
+		{"no device", &Mapping{Topic: "a", SiteID: "home", Readings: readings}, "deviceId or deviceIdField"},
+		{"two devices", &Mapping{Topic: "a", SiteID: "home", DeviceID: "ZB", DeviceIDField: "id", Readings: readings}, "cannot be both set"},
+		{"placeholder", &Mapping{Topic: "zigbee2mqtt/+", SiteID: "home", DeviceID: "ZB_{2}", Readings: readings}, "does not match"},
+		{"no readings", &Mapping{Topic: "a", SiteID: "home", DeviceID: "ZB"}, "readings are required"},
+		{"unknown type", &Mapping{Topic: "a", SiteID: "home", DeviceID: "ZB", Readings: []*FieldMapping{{SensorType: "pressure"}}}, "unknown sensor type"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.mapping.Validate()
+			if test.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected an error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestLoadMappings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mappings.json")
+
+	mapping := `{"topic": "zigbee2mqtt/+", "siteId": "home", "deviceId": "ZB_{1}", "readings": [{"field": "temperature", "sensorType": "temperature"}]}`
+	if err := os.WriteFile(path, []byte("["+mapping+"]"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	mappings, err := LoadMappings(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mappings) != 1 || mappings[0].Readings[0].SensorType != sensormanager.SensorTypeTemperature {
+		t.Fatalf("unexpected mappings %+v", mappings)
+	}
+
+	if err := os.WriteFile(path, []byte("["+mapping+","+mapping+"]"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadMappings(path); err == nil || !strings.Contains(err.Error(), "already mapped") {
+		t.Fatalf("expected the duplicated topic to be rejected, got %v", err)
+	}
+}
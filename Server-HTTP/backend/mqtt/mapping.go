@@ -0,0 +1,231 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sensormanager"
+	"strconv"
+	"strings"
+)
+
+// Mapping maps the messages published on a topic to the readings of a device. For example, the mapping of the
+// Zigbee2MQTT sensors of a room:
+//
+//	{
+//	  "topic": "zigbee2mqtt/+",
+//	  "siteId": "home",
+//	  "deviceId": "ZB_{1}",
+//	  "readings": [
+//	    {"field": "temperature", "sensorType": "temperature"},
+//	    {"field": "occupancy", "sensorType": "motion"}
+//	  ]
+//	}
+type Mapping struct {
+	// Topic is the topic filter subscribed to, with the MQTT + and # wildcards.
+	Topic  string `json:"topic"`
+	SiteID string `json:"siteId"`
+
+	// DeviceID is the ID of the device, in which {1}, {2}... are replaced by the topic levels matched by the +
+	// wildcards of the topic, in order. DeviceIDField is the field of the payload holding the ID instead.
+	DeviceID      string `json:"deviceId,omitempty"`
+	DeviceIDField string `json:"deviceIdField,omitempty"`
+
+	Readings []*FieldMapping `json:"readings"`
+}
+
+// FieldMapping maps a field of the JSON payload to a reading.
+type FieldMapping struct {
+	// Field is the path of the value in the payload, the nested fields being separated by dots, e.g.
+	// "AM2301.Temperature" for Tasmota. Empty means the whole payload is the value, e.g. "ON" or "21.5".
+	Field      string                   `json:"field,omitempty"`
+	SensorType sensormanager.SensorType `json:"sensorType"`
+
+	// Scale multiplies the numeric values, e.g. 0.1 for a value sent in tenths. Zero means 1.
+	Scale float64 `json:"scale,omitempty"`
+}
+
+var placeholderPattern = regexp.MustCompile(`\{(\d+)\}`)
+
+// Validate checks the topic filter, the device ID and the sensor types of the mapping.
+func (m *Mapping) Validate() error {
+	if err := validateFilter(m.Topic); err != nil {
+		return err
+	}
+	if m.SiteID == "" {
+		return errors.New("siteId is required")
+	}
+
+	switch {
+	case m.DeviceID == "" && m.DeviceIDField == "":
+		return errors.New("deviceId or deviceIdField is required")
+	case m.DeviceID != "" && m.DeviceIDField != "":
+		return errors.New("deviceId and deviceIdField cannot be both set")
+	}
+
+	wildcards := strings.Count(m.Topic, "+")
+	for _, match := range placeholderPattern.FindAllStringSubmatch(m.DeviceID, -1) {
+		if index, _ := strconv.Atoi(match[1]); index < 1 || index > wildcards {
+			return fmt.Errorf("deviceId placeholder %s does not match a + wildcard of %q", match[0], m.Topic)
+		}
+	}
+
+	if len(m.Readings) == 0 {
+		return errors.New("readings are required")
+	}
+
+	for _, reading := range m.Readings {
+		if _, err := sensormanager.LookupSensorType(reading.SensorType); err != nil {
+			return fmt.Errorf("%w: %q", err, reading.SensorType)
+		}
+	}
+
+	return nil
+}
+
+func validateFilter(filter string) error {
+	if filter == "" {
+		return errors.New("topic is required")
+	}
+
+	levels := strings.Split(filter, "/")
+	for i, level := range levels {
+		switch {
+		case level == "#" && i != len(levels)-1:
+			return fmt.Errorf("topic %q: # must be the last level", filter)
+		case level != "+" && level != "#" && strings.ContainsAny(level, "+#"):
+			return fmt.Errorf("topic %q: wildcards must occupy a whole level", filter)
+		}
+	}
+
+	return nil
+}
+
+// LoadMappings reads the JSON array of mappings of the file.
+func LoadMappings(path string) ([]*Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Mapping
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid mappings file %s: %w", path, err)
+	}
+
+	topics := make(map[string]bool, len(result))
+	for i, mapping := range result {
+		if err := mapping.Validate(); err != nil {
+			return nil, fmt.Errorf("mapping %d: %w", i+1, err)
+		}
+
+		if topics[mapping.Topic] {
+			return nil, fmt.Errorf("mapping %d: topic %q is already mapped", i+1, mapping.Topic)
+		}
+		topics[mapping.Topic] = true
+	}
+
+	return result, nil
+}
+
+// matchTopic returns the levels of the topic matched by the + wildcards of the filter, or false when the topic does
+// not match the filter.
+func matchTopic(filter, topic string) ([]string, bool) {
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+
+	var result []string
+	for i, level := range filterLevels {
+		switch {
+		case level == "#":
+			return result, true
+		case i >= len(topicLevels):
+			return nil, false
+		case level == "+":
+			result = append(result, topicLevels[i])
+		case level != topicLevels[i]:
+			return nil, false
+		}
+	}
+
+	return result, len(filterLevels) == len(topicLevels)
+}
+
+// deviceID returns the ID of the device that published the payload on the topic.
+func (m *Mapping) deviceID(topic string, payload map[string]interface{}) (string, error) {
+	if m.DeviceIDField != "" {
+		value, found := lookupField(payload, m.DeviceIDField)
+		if !found {
+			return "", fmt.Errorf("missing field %q", m.DeviceIDField)
+		}
+
+		if id, ok := value.(string); ok && id != "" {
+			return id, nil
+		}
+
+		return "", fmt.Errorf("field %q is not a device ID", m.DeviceIDField)
+	}
+
+	levels, matched := matchTopic(m.Topic, topic)
+	if !matched {
+		return "", fmt.Errorf("topic %q does not match %q", topic, m.Topic)
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(m.DeviceID, func(placeholder string) string {
+		index, _ := strconv.Atoi(placeholder[1 : len(placeholder)-1])
+		return levels[index-1]
+	}), nil
+}
+
+func lookupField(payload map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = payload
+
+	for _, name := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		if current, ok = object[name]; !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// value converts a value of the payload to the value of a reading. Booleans and the "ON"/"OFF" states are 1 or 0.
+func (f *FieldMapping) value(raw interface{}) (float64, error) {
+	scale := f.Scale
+	if scale == 0 {
+		scale = 1
+	}
+
+	switch v := raw.(type) {
+	case float64:
+		return v * scale, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "on", "true":
+			return 1, nil
+		case "off", "false":
+			return 0, nil
+		}
+
+		number, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number", v)
+		}
+
+		return number * scale, nil
+	default:
+		return 0, fmt.Errorf("unsupported value %v", raw)
+	}
+}
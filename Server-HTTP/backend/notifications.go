@@ -17,18 +17,22 @@ const (
 
 type PushToken struct {
 	ID         int64
+	SiteID     string
 	Token      string
 	Platform   Platform
 	DeviceInfo null.JSON
+	Locale     Locale // Optionnel - langue par défaut des notifications sinon
 	IsActive   bool
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 }
 
 type PushTokenParams struct {
+	SiteID     string
 	Token      string
 	Platform   string
 	DeviceInfo map[string]interface{}
+	Locale     string // Optionnel - étiquette de langue, ex: "fr-FR"
 }
 
 type NotificationLog struct {
@@ -42,10 +46,14 @@ type NotificationLog struct {
 	ErrorMessage null.String
 }
 
+// NotificationParams is a notification to send. With a kind, its title and body are rendered from the template of
+// the kind in the locale of each push token, Title and Body being used when the template fails.
 type NotificationParams struct {
-	Title string
-	Body  string
-	Data  map[string]interface{}
+	Title  string
+	Body   string
+	Data   map[string]interface{}
+	Kind   NotificationKind    // Optionnel
+	Values *NotificationValues // Valeurs du modèle, avec Kind
 }
 
 func (p Platform) Validate() error {
@@ -58,6 +66,10 @@ func (p Platform) Validate() error {
 }
 
 func (p *PushTokenParams) Sanitize() error {
+	if p.SiteID == "" {
+		return errors.New("siteId is required")
+	}
+
 	p.Token = strings.TrimSpace(p.Token)
 	if p.Token == "" {
 		return errors.New("token cannot be empty")
@@ -67,12 +79,16 @@ func (p *PushTokenParams) Sanitize() error {
 		return errors.New("invalid platform")
 	}
 
+	// Une langue non prise en charge reçoit les notifications dans la langue par défaut.
+	p.Locale = string(ParseLocale(p.Locale))
+
 	return nil
 }
 
 type NotificationManager interface {
 	RegisterPushToken(params *PushTokenParams) (*PushToken, error)
-	GetActivePushTokens() ([]*PushToken, error)
+	GetActivePushTokens(siteID string) ([]*PushToken, error)
 	DeactivatePushToken(token string) error
-	SendNotificationToAll(params *NotificationParams) error
+	SendNotificationToSite(siteID string, params *NotificationParams) error
+	SendNotificationToTokens(tokens []string, params *NotificationParams) error
 }
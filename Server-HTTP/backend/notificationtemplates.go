@@ -0,0 +1,380 @@
+package sensormanager
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Locale is the language of the notifications sent to a push token.
+type Locale string
+
+const (
+	LocaleFR Locale = "fr"
+	LocaleEN Locale = "en"
+)
+
+// Locales are the supported locales, the default one first.
+var Locales = []Locale{LocaleFR, LocaleEN}
+
+func (l Locale) Validate() error {
+	switch l {
+	case LocaleFR, LocaleEN:
+		return nil
+	default:
+		return errors.New("invalid locale")
+	}
+}
+
+// ParseLocale returns the supported locale of a language tag such as "fr-FR" or "en_US", or an empty string when
+// its language is not supported.
+func ParseLocale(tag string) Locale {
+	language, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	language, _, _ = strings.Cut(language, "_")
+
+	locale := Locale(strings.ToLower(language))
+	if locale.Validate() != nil {
+		return ""
+	}
+
+	return locale
+}
+
+// NotificationKind identifies the event a notification is sent for, and its template.
+type NotificationKind string
+
+const (
+	// NotificationKindAlert is the template of the alerts of the sensor types without their own template.
+	NotificationKindAlert       NotificationKind = "alert"
+	NotificationKindSensorFault NotificationKind = "sensor_fault"
+	NotificationKindTelemetry   NotificationKind = "telemetry"
+	NotificationKindEscalation  NotificationKind = "escalation"
+)
+
+// AlertNotificationKind returns the kind of the notification of an alert: "alert.<sensor type>" for the alerts of
+// the sensor types.
+func AlertNotificationKind(alertType AlertType) NotificationKind {
+	switch alertType {
+	case AlertTypeSensorFault:
+		return NotificationKindSensorFault
+	case AlertTypeTelemetry:
+		return NotificationKindTelemetry
+	default:
+		return NotificationKindAlert + "." + NotificationKind(alertType)
+	}
+}
+
+// Validate accepts the kinds of NotificationKinds.
+func (k NotificationKind) Validate() error {
+	switch k {
+	case NotificationKindAlert, NotificationKindSensorFault, NotificationKindTelemetry, NotificationKindEscalation:
+		return nil
+	}
+
+	if sensorType, found := strings.CutPrefix(string(k), string(NotificationKindAlert)+"."); found {
+		if _, err := LookupSensorType(SensorType(sensorType)); err == nil {
+			return nil
+		}
+	}
+
+	return errors.New("invalid notification kind")
+}
+
+// Parent returns the kind whose template is used when the kind has none, or an empty string.
+func (k NotificationKind) Parent() NotificationKind {
+	if strings.HasPrefix(string(k), string(NotificationKindAlert)+".") {
+		return NotificationKindAlert
+	}
+
+	return ""
+}
+
+// NotificationKinds returns the kinds of notification: the generic ones, then one per registered sensor type.
+func NotificationKinds() []NotificationKind {
+	result := []NotificationKind{
+		NotificationKindAlert,
+		NotificationKindSensorFault,
+		NotificationKindTelemetry,
+		NotificationKindEscalation,
+	}
+
+	for _, definition := range SensorTypes() {
+		result = append(result, AlertNotificationKind(AlertType(definition.Name)))
+	}
+
+	return result
+}
+
+// NotificationValues are the placeholders of the notification templates, e.g. {{.DeviceName}} or
+// {{printf "%.1f" .Value}}.
+type NotificationValues struct {
+	AlertType  AlertType
+	Label      string // Libellé du type de capteur, ex: "Température"
+	Unit       string
+	DeviceID   string
+	DeviceName string // Emplacement de l'appareil, à défaut son identifiant
+	Value      float64
+	Threshold  float64
+	Reason     string // Règle ou événement à l'origine de l'alerte, ex: "weak_signal"
+	Message    string // Message de l'alerte, en anglais
+	Time       time.Time
+	Elapsed    time.Duration // Depuis la levée de l'alerte, pour les rappels
+}
+
+// NewNotificationValues returns the values of a notification about a device, with the label and unit of its sensor
+// type. Its device name is its device ID until the location of the device is known.
+func NewNotificationValues(alertType AlertType, sensorType SensorType, deviceID string, at time.Time) *NotificationValues {
+	values := &NotificationValues{
+		AlertType:  alertType,
+		Label:      string(sensorType),
+		DeviceID:   deviceID,
+		DeviceName: deviceID,
+		Time:       at,
+	}
+
+	if definition, err := LookupSensorType(sensorType); err == nil {
+		values.Label = definition.Label
+		values.Unit = definition.Unit
+	}
+
+	if values.Time.IsZero() {
+		values.Time = time.Now()
+	}
+
+	return values
+}
+
+// NewAlertNotificationValues returns the values of the notification of an alert.
+func NewAlertNotificationValues(alertType AlertType, alert *AlertResponse) *NotificationValues {
+	values := NewNotificationValues(alertType, SensorType(alertType), alert.DeviceID, alert.RecordedAt)
+	values.Value = alert.Value
+	values.Threshold = alert.Threshold
+	values.Reason = alert.Event
+	values.Message = alert.Message
+
+	return values
+}
+
+// NotificationTemplate is the title and body of the notifications of a kind in a locale, as Go text/template.
+type NotificationTemplate struct {
+	Kind      NotificationKind
+	Locale    Locale
+	Title     string
+	Body      string
+	Custom    bool       // Modèle enregistré, sinon modèle par défaut
+	UpdatedAt *time.Time // Optionnel - pour les modèles enregistrés
+}
+
+// Render executes the title and body templates with the values.
+func (t *NotificationTemplate) Render(values *NotificationValues) (string, string, error) {
+	title, err := renderNotificationTemplate("title", t.Title, values)
+	if err != nil {
+		return "", "", err
+	}
+
+	body, err := renderNotificationTemplate("body", t.Body, values)
+	if err != nil {
+		return "", "", err
+	}
+
+	return title, body, nil
+}
+
+func renderNotificationTemplate(name, text string, values *NotificationValues) (string, error) {
+	parsed, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var result bytes.Buffer
+	if err := parsed.Execute(&result, values); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(result.String()), nil
+}
+
+// Longueurs maximales des modèles
+const (
+	MaxNotificationTitleLength = 200
+	MaxNotificationBodyLength  = 2000
+)
+
+type NotificationTemplateParams struct {
+	Kind   NotificationKind
+	Locale Locale
+	Title  string
+	Body   string
+}
+
+// Sanitize checks that the templates parse and render a non-empty title with sample values, so that a template
+// cannot fail once an alert is raised.
+func (p *NotificationTemplateParams) Sanitize() error {
+	if err := p.Kind.Validate(); err != nil {
+		return err
+	}
+
+	if err := p.Locale.Validate(); err != nil {
+		return err
+	}
+
+	p.Title = strings.TrimSpace(p.Title)
+	if p.Title == "" {
+		return errors.New("title is required")
+	}
+	if len(p.Title) > MaxNotificationTitleLength {
+		return fmt.Errorf("title must be at most %d characters", MaxNotificationTitleLength)
+	}
+
+	p.Body = strings.TrimSpace(p.Body)
+	if p.Body == "" {
+		return errors.New("body is required")
+	}
+	if len(p.Body) > MaxNotificationBodyLength {
+		return fmt.Errorf("body must be at most %d characters", MaxNotificationBodyLength)
+	}
+
+	saved := &NotificationTemplate{Kind: p.Kind, Locale: p.Locale, Title: p.Title, Body: p.Body}
+
+	title, _, err := saved.Render(sampleNotificationValues())
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	if title == "" {
+		return errors.New("title renders empty")
+	}
+
+	return nil
+}
+
+func sampleNotificationValues() *NotificationValues {
+	return &NotificationValues{
+		AlertType:  AlertTypeMicrophone,
+		Label:      "Microphone",
+		Unit:       "dB",
+		DeviceID:   "ESP_001",
+		DeviceName: "Salon",
+		Value:      72.5,
+		Threshold:  MicrophoneThresholdDB,
+		Reason:     "high_noise",
+		Message:    "High noise level detected: 72.5 dB",
+		Time:       time.Now(),
+		Elapsed:    15 * time.Minute,
+	}
+}
+
+// defaultNotificationTemplates are the templates used for the kinds and locales without a saved template.
+var defaultNotificationTemplates = map[NotificationKind]map[Locale][2]string{
+	NotificationKindAlert: {
+		LocaleFR: {
+			"⚠️ Alerte {{.Label}}",
+			`{{.DeviceName}} : {{.Label}} de {{printf "%.1f" .Value}} {{.Unit}} (seuil {{printf "%.1f" .Threshold}} {{.Unit}}) à {{.Time.Format "15:04"}}`,
+		},
+		LocaleEN: {
+			"⚠️ Sensor alert",
+			`{{.DeviceName}}: {{.Message}} at {{.Time.Format "15:04"}}`,
+		},
+	},
+	AlertNotificationKind(AlertTypeDistance): {
+		LocaleFR: {
+			"⚠️ Alerte Distance",
+			`{{.DeviceName}} : changement de distance important, {{printf "%.1f" .Value}} cm à {{.Time.Format "15:04"}}`,
+		},
+		LocaleEN: {
+			"⚠️ Distance alert",
+			`{{.DeviceName}}: large distance change, {{printf "%.1f" .Value}} cm at {{.Time.Format "15:04"}}`,
+		},
+	},
+	AlertNotificationKind(AlertTypeMicrophone): {
+		LocaleFR: {
+			"⚠️ Alerte Microphone",
+			`{{.DeviceName}} : niveau sonore élevé, {{printf "%.1f" .Value}} dB (seuil {{printf "%.0f" .Threshold}} dB) à {{.Time.Format "15:04"}}`,
+		},
+		LocaleEN: {
+			"⚠️ Noise alert",
+			`{{.DeviceName}}: high noise level, {{printf "%.1f" .Value}} dB (threshold {{printf "%.0f" .Threshold}} dB) at {{.Time.Format "15:04"}}`,
+		},
+	},
+	AlertNotificationKind(AlertTypeMotion): {
+		LocaleFR: {
+			"⚠️ Alerte Mouvement",
+			`{{.DeviceName}} : {{if eq .Reason "continuous_motion"}}mouvement continu{{else}}mouvement détecté{{end}} à {{.Time.Format "15:04"}}`,
+		},
+		LocaleEN: {
+			"⚠️ Motion alert",
+			`{{.DeviceName}}: {{if eq .Reason "continuous_motion"}}continuous motion{{else}}motion detected{{end}} at {{.Time.Format "15:04"}}`,
+		},
+	},
+	NotificationKindSensorFault: {
+		LocaleFR: {
+			"🔧 Défaut Capteur",
+			`{{.DeviceName}} : le capteur semble défaillant ({{.Reason}}) à {{.Time.Format "15:04"}}`,
+		},
+		LocaleEN: {
+			"🔧 Sensor fault",
+			`Sensor {{.DeviceName}} looks faulty ({{.Reason}}): {{.Message}}`,
+		},
+	},
+	NotificationKindTelemetry: {
+		LocaleFR: {
+			"📶 Alerte Appareil",
+			`{{.DeviceName}} : {{if eq .Reason "weak_signal"}}signal Wi-Fi faible, {{printf "%.0f" .Value}} dBm{{else if eq .Reason "reboot_loop"}}redémarrages en boucle, {{printf "%.0f" .Value}} redémarrages{{else}}{{.Message}}{{end}} à {{.Time.Format "15:04"}}`,
+		},
+		LocaleEN: {
+			"📶 Device alert",
+			`{{.DeviceName}}: {{.Message}} at {{.Time.Format "15:04"}}`,
+		},
+	},
+	NotificationKindEscalation: {
+		LocaleFR: {
+			"⏰ Rappel Alerte",
+			`Alerte {{.Label}} de {{.DeviceName}} toujours non acquittée après {{.Elapsed}}`,
+		},
+		LocaleEN: {
+			"⏰ Alert reminder",
+			`{{.AlertType}} alert from {{.DeviceName}} is still not acknowledged after {{.Elapsed}}`,
+		},
+	},
+}
+
+// DefaultNotificationTemplate returns the built-in template of the kind in the locale, or nil when it has none.
+func DefaultNotificationTemplate(kind NotificationKind, locale Locale) *NotificationTemplate {
+	texts, exists := defaultNotificationTemplates[kind][locale]
+	if !exists {
+		return nil
+	}
+
+	return &NotificationTemplate{Kind: kind, Locale: locale, Title: texts[0], Body: texts[1]}
+}
+
+// ResolveNotificationTemplate returns the template of the kind in the locale among the saved templates: the saved
+// template of the kind, its default one, then those of its parent kind. It returns nil when none applies.
+func ResolveNotificationTemplate(saved []*NotificationTemplate, kind NotificationKind, locale Locale) *NotificationTemplate {
+	for ; kind != ""; kind = kind.Parent() {
+		for _, candidate := range saved {
+			if candidate.Kind == kind && candidate.Locale == locale {
+				return candidate
+			}
+		}
+
+		if candidate := DefaultNotificationTemplate(kind, locale); candidate != nil {
+			return candidate
+		}
+	}
+
+	return nil
+}
+
+type NotificationTemplateManager interface {
+	// GetNotificationTemplates returns the saved templates.
+	GetNotificationTemplates() ([]*NotificationTemplate, error)
+
+	// SetNotificationTemplate saves the template of a kind in a locale, replacing its default one.
+	SetNotificationTemplate(params *NotificationTemplateParams) (*NotificationTemplate, error)
+
+	// ResetNotificationTemplate deletes the saved template: the default one is used again.
+	ResetNotificationTemplate(kind NotificationKind, locale Locale) error
+}
@@ -0,0 +1,133 @@
+// Package ota serves the firmware updates to the ESP8266 httpUpdate client and advances the staged rollouts from the
+// telemetry of the devices.
+package ota
+
+import (
+	"fmt"
+	"net/http"
+	"sensormanager"
+	"sensormanager/store"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Updater struct {
+	store         *store.Store
+	interval      time.Duration
+	healthTimeout time.Duration
+}
+
+type Option func(*Updater)
+
+func New(options ...Option) *Updater {
+	result := &Updater{interval: 30 * time.Second, healthTimeout: 10 * time.Minute}
+
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.store == nil {
+		panic("could not create OTA updater without Store configuration")
+	}
+
+	return result
+}
+
+func WithStore(store *store.Store) Option { return func(u *Updater) { u.store = store } }
+
+// WithRollouts sets how often the rollouts are advanced, and how long a device may go without reporting telemetry
+// after downloading a firmware before its rollout halts.
+func WithRollouts(interval, healthTimeout time.Duration) Option {
+	return func(u *Updater) {
+		u.interval = interval
+		u.healthTimeout = healthTimeout
+	}
+}
+
+// Run advances the active rollouts at every interval. It never returns.
+func (u *Updater) Run() {
+	ticker := time.NewTicker(u.interval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		if _, err := u.store.Firmware.AdvanceRollouts(now, u.healthTimeout); err != nil {
+			fmt.Printf("❌ OTA: %v\n", err)
+		}
+	}
+}
+
+// ServeHTTP answers the update checks of ESPhttpUpdate on GET /firmware/<deviceId>. The device authenticates with its
+// ID and secret as HTTP basic auth, and sends its current version in the x-ESP8266-version header. A device without
+// secret is recognized by its MAC address, in the x-ESP8266-STA-MAC header. The binary is returned with its MD5 in the
+// x-MD5 header, and 304 Not Modified when there is nothing to install.
+func (u *Updater) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	deviceID, found := strings.CutPrefix(r.URL.Path, "/firmware/")
+	if !found || deviceID == "" || strings.Contains(deviceID, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Seuls les sketchs sont mis à jour, pas le système de fichiers.
+	if mode := r.Header.Get("x-ESP8266-mode"); mode != "" && mode != "sketch" {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	username, secret, hasAuth := r.BasicAuth()
+	if hasAuth && username != deviceID {
+		http.Error(w, sensormanager.ErrInvalidDeviceSecret.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	request := &sensormanager.FirmwareUpdateRequest{
+		DeviceID:       deviceID,
+		Secret:         secret,
+		CurrentVersion: r.Header.Get("x-ESP8266-version"),
+		MAC:            r.Header.Get("x-ESP8266-STA-MAC"),
+	}
+
+	if value := r.Header.Get("x-ESP8266-free-space"); value != "" {
+		if freeSpace, err := strconv.ParseInt(value, 10, 64); err == nil {
+			request.FreeSpace = &freeSpace
+		}
+	}
+
+	firmware, binary, err := u.store.Firmware.CheckUpdate(request)
+	switch err {
+	case nil:
+	case sensormanager.ErrNoFirmwareUpdate:
+		w.WriteHeader(http.StatusNotModified)
+		return
+	case sensormanager.ErrUnknownDevice:
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case sensormanager.ErrInvalidDeviceSecret:
+		w.Header().Set("WWW-Authenticate", `Basic realm="firmware"`)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case sensormanager.ErrDeviceDisabled:
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	case sensormanager.ErrFirmwareTooLarge:
+		http.Error(w, err.Error(), http.StatusInsufficientStorage)
+		return
+	default:
+		fmt.Printf("❌ OTA update check of %s: %v\n", deviceID, err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.Itoa(len(binary)))
+	w.Header().Set("Content-Disposition", `attachment; filename="firmware-`+firmware.Version+`.bin"`)
+	w.Header().Set("x-MD5", firmware.MD5)
+	w.WriteHeader(http.StatusOK)
+	w.Write(binary)
+}
@@ -0,0 +1,144 @@
+package ota
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sensormanager"
+	"sensormanager/store"
+	"testing"
+	"time"
+)
+
+// fakeFirmware serves one firmware to the device "ESP_002" with the secret "secret".
+type fakeFirmware struct {
+	sensormanager.FirmwareManager
+
+	checked *sensormanager.FirmwareUpdateRequest
+}
+
+func (f *fakeFirmware) CheckUpdate(request *sensormanager.FirmwareUpdateRequest) (*sensormanager.Firmware, []byte, error) {
+	f.checked = request
+
+	if request.DeviceID != "ESP_002" {
+		return nil, nil, sensormanager.ErrUnknownDevice
+	}
+	if request.Secret != "secret" {
+		return nil, nil, sensormanager.ErrInvalidDeviceSecret
+	}
+	if request.CurrentVersion == "1.1.0" {
+		return nil, nil, sensormanager.ErrNoFirmwareUpdate
+	}
+	if request.FreeSpace != nil && *request.FreeSpace < 4 {
+		return nil, nil, sensormanager.ErrFirmwareTooLarge
+	}
+
+	return &sensormanager.Firmware{Version: "1.1.0", MD5: "08d6c05a21512a79a1dfeb9d2a8f262f"}, []byte{0xe9, 0x01, 0x02, 0x03}, nil
+}
+
+func (f *fakeFirmware) AdvanceRollouts(time.Time, time.Duration) ([]*sensormanager.FirmwareRollout, error) {
+	return nil, nil
+}
+
+func newTestUpdater() (*Updater, *fakeFirmware) {
+	firmware := &fakeFirmware{}
+
+	return New(WithStore(&store.Store{Firmware: firmware})), firmware
+}
+
+func updateRequest(path, version string) *http.Request {
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	request.SetBasicAuth("ESP_002", "secret")
+	request.Header.Set("User-Agent", "ESP8266-http-Update")
+	request.Header.Set("x-ESP8266-mode", "sketch")
+	request.Header.Set("x-ESP8266-version", version)
+	request.Header.Set("x-ESP8266-STA-MAC", "AA:BB:CC:DD:EE:FF")
+
+	return request
+}
+
+func TestServeHTTP(t *testing.T) {
+	updater, firmware := newTestUpdater()
+
+	request := updateRequest("/firmware/ESP_002", "1.0.0")
+	request.Header.Set("x-ESP8266-free-space", "1048576")
+
+	recorder := httptest.NewRecorder()
+	updater.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if got := recorder.Header().Get("x-MD5"); got != "08d6c05a21512a79a1dfeb9d2a8f262f" {
+		t.Errorf("unexpected x-MD5 header %q", got)
+	}
+	if got := recorder.Header().Get("Content-Length"); got != "4" {
+		t.Errorf("unexpected Content-Length %q", got)
+	}
+	if recorder.Body.Len() != 4 {
+		t.Errorf("expected the 4 bytes of the binary, got %d", recorder.Body.Len())
+	}
+
+	if firmware.checked.CurrentVersion != "1.0.0" {
+		t.Errorf("expected the current version to be forwarded, got %q", firmware.checked.CurrentVersion)
+	}
+	if firmware.checked.MAC != "AA:BB:CC:DD:EE:FF" {
+		t.Errorf("expected the MAC address to be forwarded, got %q", firmware.checked.MAC)
+	}
+	if firmware.checked.FreeSpace == nil || *firmware.checked.FreeSpace != 1048576 {
+		t.Errorf("expected the free space to be forwarded, got %v", firmware.checked.FreeSpace)
+	}
+}
+
+func TestServeHTTPStatuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  func() *http.Request
+		expected int
+	}{
+		{"up to date", func() *http.Request { return updateRequest("/firmware/ESP_002", "1.1.0") }, http.StatusNotModified},
+		{"unknown device", func() *http.Request {
+			request := updateRequest("/firmware/ESP_404", "1.0.0")
+			request.SetBasicAuth("ESP_404", "secret")
+			return request
+		}, http.StatusNotFound},
+		{"wrong secret", func() *http.Request {
+			request := updateRequest("/firmware/ESP_002", "1.0.0")
+			request.SetBasicAuth("ESP_002", "wrong")
+			return request
+		}, http.StatusUnauthorized},
+		{"other device credentials", func() *http.Request {
+			request := updateRequest("/firmware/ESP_002", "1.0.0")
+			request.SetBasicAuth("ESP_003", "secret")
+			return request
+		}, http.StatusUnauthorized},
+		{"not enough space", func() *http.Request {
+			request := updateRequest("/firmware/ESP_002", "1.0.0")
+			request.Header.Set("x-ESP8266-free-space", "2")
+			return request
+		}, http.StatusInsufficientStorage},
+		{"filesystem update", func() *http.Request {
+			request := updateRequest("/firmware/ESP_002", "1.0.0")
+			request.Header.Set("x-ESP8266-mode", "spiffs")
+			return request
+		}, http.StatusNotModified},
+		{"no device", func() *http.Request { return updateRequest("/firmware/", "1.0.0") }, http.StatusNotFound},
+		{"post", func() *http.Request {
+			request := updateRequest("/firmware/ESP_002", "1.0.0")
+			request.Method = http.MethodPost
+			return request
+		}, http.StatusMethodNotAllowed},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			updater, _ := newTestUpdater()
+
+			recorder := httptest.NewRecorder()
+			updater.ServeHTTP(recorder, test.request())
+
+			if recorder.Code != test.expected {
+				t.Errorf("expected %d, got %d", test.expected, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Package quality detects sensors whose readings cannot be trusted anymore: stuck values, flat variance or a slowly
+// drifting baseline.
+package quality
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+type Health string
+
+const (
+	HealthOK    Health = "ok"
+	HealthStuck Health = "stuck"
+	HealthFlat  Health = "flat"
+	HealthDrift Health = "drift"
+)
+
+// Config describes the checks applied to the readings of a sensor type. A zero threshold disables the related check.
+type Config struct {
+	// StuckCount is the number of identical consecutive readings after which a sensor is considered stuck.
+	StuckCount int
+
+	// A sensor is considered flat when the variance of its last VarianceWindow readings is below MinVariance.
+	VarianceWindow int
+	MinVariance    float64
+
+	// The baseline is an exponential moving average of the readings using DriftAlpha as smoothing factor. Its value
+	// after DriftWarmup readings is used as reference, and the sensor is considered drifting when the baseline moves
+	// more than MaxDrift away from it.
+	DriftAlpha  float64
+	DriftWarmup int
+	MaxDrift    float64
+}
+
+type Report struct {
+	Health Health
+
+	// Changed is set when the health differs from the one reported for the previous reading, a device being healthy
+	// before its first reading.
+	Changed bool
+
+	Details string
+}
+
+// Checker keeps the quality state of each device.
+type Checker struct {
+	config Config
+
+	mu      sync.Mutex
+	devices map[string]*deviceState
+}
+
+type deviceState struct {
+	health Health
+
+	seen      int
+	last      float64
+	identical int
+	window    []float64
+
+	baseline     float64
+	reference    float64
+	referenceSet bool
+}
+
+func New(config Config) *Checker {
+	return &Checker{
+		config:  config,
+		devices: make(map[string]*deviceState),
+	}
+}
+
+// Observe feeds a raw reading of the given device to the checker.
+func (c *Checker) Observe(deviceID string, value float64) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state, exists := c.devices[deviceID]
+	if !exists {
+		// Un appareil est sain jusqu'à preuve du contraire : sa première mesure saine ne change rien.
+		state = &deviceState{health: HealthOK}
+		c.devices[deviceID] = state
+	}
+
+	state.seen++
+
+	if state.seen > 1 && value == state.last {
+		state.identical++
+	} else {
+		state.identical = 1
+	}
+	state.last = value
+
+	if c.config.VarianceWindow > 1 {
+		state.window = append(state.window, value)
+		if len(state.window) > c.config.VarianceWindow {
+			state.window = state.window[len(state.window)-c.config.VarianceWindow:]
+		}
+	}
+
+	if state.seen == 1 {
+		state.baseline = value
+	} else {
+		state.baseline += c.config.DriftAlpha * (value - state.baseline)
+	}
+
+	if !state.referenceSet && state.seen >= c.config.DriftWarmup {
+		state.reference = state.baseline
+		state.referenceSet = true
+	}
+
+	health, details := c.evaluate(state)
+
+	report := Report{
+		Health:  health,
+		Changed: health != state.health,
+		Details: details,
+	}
+	state.health = health
+
+	return report
+}
+
+func (c *Checker) evaluate(state *deviceState) (Health, string) {
+	if c.config.StuckCount > 0 && state.identical >= c.config.StuckCount {
+		return HealthStuck, fmt.Sprintf("identical value %.2f for %d readings", state.last, state.identical)
+	}
+
+	if c.config.VarianceWindow > 1 && len(state.window) == c.config.VarianceWindow {
+		if v := variance(state.window); v < c.config.MinVariance {
+			return HealthFlat, fmt.Sprintf("variance %.4f over %d readings", v, len(state.window))
+		}
+	}
+
+	if c.config.MaxDrift > 0 && c.config.DriftAlpha > 0 && state.referenceSet {
+		if math.Abs(state.baseline-state.reference) > c.config.MaxDrift {
+			return HealthDrift, fmt.Sprintf("baseline drifted from %.2f to %.2f", state.reference, state.baseline)
+		}
+	}
+
+	return HealthOK, ""
+}
+
+func variance(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+
+	return squares / float64(len(values))
+}
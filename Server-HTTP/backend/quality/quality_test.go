@@ -0,0 +1,112 @@
+package quality
+
+import "testing"
+
+type reading struct {
+	value   float64
+	health  Health
+	changed bool
+}
+
+func TestChecker(t *testing.T) {
+	tests := map[string]struct {
+		config   Config
+		readings []reading
+	}{
+		"first healthy reading is not a change": {
+			Config{StuckCount: 3},
+			[]reading{
+				{10, HealthOK, false},
+				{11, HealthOK, false},
+			},
+		},
+		"identical readings get stuck then recover": {
+			Config{StuckCount: 3},
+			[]reading{
+				{10, HealthOK, false},
+				{10, HealthOK, false},
+				{10, HealthStuck, true},
+				{10, HealthStuck, false},
+				{12, HealthOK, true},
+			},
+		},
+		"low variance over the window is flat": {
+			Config{VarianceWindow: 3, MinVariance: 0.5},
+			[]reading{
+				{10, HealthOK, false},
+				{10.1, HealthOK, false},
+				{10.2, HealthFlat, true},
+				{14, HealthOK, true},
+			},
+		},
+		"window is not evaluated until full": {
+			Config{VarianceWindow: 4, MinVariance: 200},
+			[]reading{
+				{10, HealthOK, false},
+				{20, HealthOK, false},
+				{30, HealthOK, false},
+				{40, HealthFlat, true},
+			},
+		},
+		"baseline drifting from its reference": {
+			Config{DriftAlpha: 0.5, DriftWarmup: 2, MaxDrift: 5},
+			[]reading{
+				{100, HealthOK, false},
+				{100, HealthOK, false},
+				{108, HealthOK, false},
+				{112, HealthDrift, true},
+			},
+		},
+		"drift is disabled without smoothing factor": {
+			Config{DriftWarmup: 1, MaxDrift: 1},
+			[]reading{
+				{100, HealthOK, false},
+				{200, HealthOK, false},
+			},
+		},
+		"stuck takes precedence over flat": {
+			Config{StuckCount: 2, VarianceWindow: 2, MinVariance: 1},
+			[]reading{
+				{5, HealthOK, false},
+				{5, HealthStuck, true},
+			},
+		},
+		"zero config accepts everything": {
+			Config{},
+			[]reading{
+				{5, HealthOK, false},
+				{5, HealthOK, false},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			checker := New(test.config)
+
+			for i, r := range test.readings {
+				report := checker.Observe("ESP_001", r.value)
+				if report.Health != r.health || report.Changed != r.changed {
+					t.Errorf("reading %d: got %s (changed %t), want %s (changed %t)", i, report.Health, report.Changed, r.health, r.changed)
+				}
+				if (report.Details == "") != (report.Health == HealthOK) {
+					t.Errorf("reading %d: unexpected details %q for %s", i, report.Details, report.Health)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckerKeepsDevicesApart(t *testing.T) {
+	checker := New(Config{StuckCount: 2})
+
+	checker.Observe("ESP_001", 10)
+	checker.Observe("ESP_002", 10)
+
+	if report := checker.Observe("ESP_002", 11); report.Health != HealthOK {
+		t.Errorf("expected ESP_002 to be healthy, got %s", report.Health)
+	}
+	if report := checker.Observe("ESP_001", 10); report.Health != HealthStuck {
+		t.Errorf("expected ESP_001 to be stuck, got %s", report.Health)
+	}
+}
@@ -0,0 +1,360 @@
+package sensormanager
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// ValueKind is the kind of value reported by a sensor type. Boolean values are stored as 1 or 0.
+type ValueKind string
+
+const (
+	ValueKindFloat ValueKind = "float"
+	ValueKindBool  ValueKind = "bool"
+)
+
+type AlertCondition string
+
+const (
+	AlertConditionAbove     AlertCondition = "above"     // Valeur supérieure ou égale au seuil
+	AlertConditionBelow     AlertCondition = "below"     // Valeur inférieure ou égale au seuil
+	AlertConditionVariation AlertCondition = "variation" // Écart avec la mesure précédente supérieur ou égal au seuil
+)
+
+// AlertRule is a default alert rule of a sensor type.
+type AlertRule struct {
+	Name      string
+	Condition AlertCondition
+	Threshold float64
+
+	// For is how long the condition must hold before the alert is raised. Zero raises it on the first matching reading.
+	// The duration is counted by the running server: it starts over when the server restarts.
+	For time.Duration
+
+	// Cooldown is the minimum delay between two alerts while the condition holds. Zero raises a single alert until
+	// the condition stops holding.
+	Cooldown time.Duration
+
+	Message string // Format fmt recevant la valeur, ou l'écart pour une règle de variation, ex: "Frost risk: %.1f °C"
+}
+
+// SensorTypeDefinition declares a sensor type of the registry.
+type SensorTypeDefinition struct {
+	Name  SensorType
+	Label string // Nom affiché dans les notifications, ex: "Distance"
+	Unit  string
+	Kind  ValueKind
+
+	// Field is the name of the value in the RES parameters and results, "value" when empty. The distance, microphone
+	// and motion types keep the names of their former calls, e.g. "distanceCm".
+	Field string
+
+	// Min and Max bound the accepted values. Nil means unbounded.
+	Min *float64
+	Max *float64
+
+	Rules []AlertRule
+}
+
+// ValueField returns the name of the value in the RES parameters and results.
+func (d *SensorTypeDefinition) ValueField() string {
+	if d.Field == "" {
+		return "value"
+	}
+
+	return d.Field
+}
+
+// Validate checks that the value is of the kind and within the range of the sensor type. The field is the name of the
+// value in the RES parameters.
+func (d *SensorTypeDefinition) Validate(field string, value float64) error {
+	if d.Kind == ValueKindBool {
+		if value != 0 && value != 1 {
+			return fmt.Errorf("%s must be a boolean", field)
+		}
+
+		return nil
+	}
+
+	switch {
+	case d.Min != nil && d.Max != nil && (value < *d.Min || value > *d.Max):
+		return fmt.Errorf("%s must be between %g and %g", field, *d.Min, *d.Max)
+	case d.Min != nil && value < *d.Min && *d.Min == 0:
+		return fmt.Errorf("%s must be positive", field)
+	case d.Min != nil && value < *d.Min:
+		return fmt.Errorf("%s must be at least %g", field, *d.Min)
+	case d.Max != nil && value > *d.Max:
+		return fmt.Errorf("%s must be at most %g", field, *d.Max)
+	}
+
+	return nil
+}
+
+func (d *SensorTypeDefinition) validateDefinition() error {
+	if !sensorTypeNamePattern.MatchString(string(d.Name)) {
+		return errors.New("sensor type name must be lowercase letters, digits or underscores")
+	}
+	if alertType := AlertType(d.Name); alertType == AlertTypeSensorFault || alertType == AlertTypeTelemetry {
+		return fmt.Errorf("sensor type name %q is reserved", d.Name)
+	}
+	if d.Kind != ValueKindFloat && d.Kind != ValueKindBool {
+		return fmt.Errorf("invalid value kind %q", d.Kind)
+	}
+	if d.Min != nil && d.Max != nil && *d.Min > *d.Max {
+		return errors.New("min cannot be greater than max")
+	}
+
+	if d.Field != "" && !fieldNamePattern.MatchString(d.Field) {
+		return errors.New("field name must be letters or digits, starting with a lowercase letter")
+	}
+
+	names := make(map[string]struct{}, len(d.Rules))
+	for _, rule := range d.Rules {
+		if rule.Name == "" {
+			return errors.New("alert rule name is required")
+		}
+		if _, exists := names[rule.Name]; exists {
+			return fmt.Errorf("alert rule %q is declared twice", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+
+		switch rule.Condition {
+		case AlertConditionAbove, AlertConditionBelow, AlertConditionVariation:
+		default:
+			return fmt.Errorf("invalid condition %q for alert rule %q", rule.Condition, rule.Name)
+		}
+
+		if rule.For < 0 || rule.Cooldown < 0 {
+			return fmt.Errorf("alert rule %q durations must be positive", rule.Name)
+		}
+	}
+
+	return nil
+}
+
+// Seuils des alertes par défaut des capteurs historiques
+const (
+	MicrophoneThresholdDB        = 50.0
+	DistanceVariationThresholdCM = 30.0
+	AlertCooldown                = 10 * time.Second
+)
+
+// Seuils des alertes par défaut des capteurs d'ambiance
+const (
+	FrostThresholdC       = 3.0
+	OverheatingThresholdC = 35.0
+	HumidityThreshold     = 70.0
+	HumidityDuration      = time.Hour
+)
+
+var (
+	ErrUnknownSensorType = errors.New("unknown sensor type")
+
+	sensorTypeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+	fieldNamePattern      = regexp.MustCompile(`^[a-z][a-zA-Z0-9]{0,31}$`)
+)
+
+var sensorTypes = struct {
+	sync.RWMutex
+	byName  map[SensorType]*SensorTypeDefinition
+	ordered []*SensorTypeDefinition
+}{byName: make(map[SensorType]*SensorTypeDefinition)}
+
+func init() {
+	for _, definition := range []*SensorTypeDefinition{
+		{
+			Name:  SensorTypeDistance,
+			Label: "Distance",
+			Unit:  "cm",
+			Kind:  ValueKindFloat,
+			Field: "distanceCm",
+			Min:   floatPtr(0),
+			Rules: []AlertRule{{
+				Name:      AlertRuleLargeVariation,
+				Condition: AlertConditionVariation,
+				Threshold: DistanceVariationThresholdCM,
+				Cooldown:  AlertCooldown,
+				Message:   "Large distance change detected: %.1f cm variation",
+			}},
+		},
+		{
+			Name:  SensorTypeMicrophone,
+			Label: "Microphone",
+			Unit:  "dB",
+			Kind:  ValueKindFloat,
+			Field: "decibels",
+			Min:   floatPtr(0),
+			Max:   floatPtr(115),
+			Rules: []AlertRule{{
+				Name:      AlertRuleHighNoise,
+				Condition: AlertConditionAbove,
+				Threshold: MicrophoneThresholdDB,
+				Cooldown:  AlertCooldown,
+				Message:   "High noise level detected: %.1f dB",
+			}},
+		},
+		{
+			// Les alertes de mouvement sont produites par motion.Detector (anti-rebond, mouvement continu).
+			Name:  SensorTypeMotion,
+			Label: "Mouvement",
+			Kind:  ValueKindBool,
+			Field: "motionDetected",
+		},
+		{
+			// Plage de mesure du DHT22
+			Name:  SensorTypeTemperature,
+			Label: "Température",
+			Unit:  "°C",
+			Kind:  ValueKindFloat,
+			Min:   floatPtr(-40),
+			Max:   floatPtr(80),
+			Rules: []AlertRule{
+				{
+					Name:      "frost",
+					Condition: AlertConditionBelow,
+					Threshold: FrostThresholdC,
+					Message:   "Frost risk: %.1f °C",
+				},
+				{
+					Name:      "overheating",
+					Condition: AlertConditionAbove,
+					Threshold: OverheatingThresholdC,
+					Message:   "Overheating detected: %.1f °C",
+				},
+			},
+		},
+		{
+			Name:  SensorTypeHumidity,
+			Label: "Humidité",
+			Unit:  "%",
+			Kind:  ValueKindFloat,
+			Min:   floatPtr(0),
+			Max:   floatPtr(100),
+			Rules: []AlertRule{{
+				Name:      "high_humidity",
+				Condition: AlertConditionAbove,
+				Threshold: HumidityThreshold,
+				For:       HumidityDuration,
+				Message:   "High humidity for over an hour: %.0f %%",
+			}},
+		},
+		{
+			// Éclairement estimé à partir de la photorésistance, sans alerte par défaut
+			Name:  SensorTypeIlluminance,
+			Label: "Luminosité",
+			Unit:  "lx",
+			Kind:  ValueKindFloat,
+			Min:   floatPtr(0),
+			Max:   floatPtr(120000),
+		},
+	} {
+		if err := RegisterSensorType(definition); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterSensorType adds a sensor type to the registry. It must be called before the server is created, for the
+// type to be exposed over RES.
+func RegisterSensorType(definition *SensorTypeDefinition) error {
+	if err := definition.validateDefinition(); err != nil {
+		return fmt.Errorf("invalid sensor type %q: %w", definition.Name, err)
+	}
+
+	sensorTypes.Lock()
+	defer sensorTypes.Unlock()
+
+	if _, exists := sensorTypes.byName[definition.Name]; exists {
+		return fmt.Errorf("sensor type %q is already registered", definition.Name)
+	}
+
+	sensorTypes.byName[definition.Name] = definition
+	sensorTypes.ordered = append(sensorTypes.ordered, definition)
+
+	return nil
+}
+
+func LookupSensorType(name SensorType) (*SensorTypeDefinition, error) {
+	sensorTypes.RLock()
+	defer sensorTypes.RUnlock()
+
+	definition, exists := sensorTypes.byName[name]
+	if !exists {
+		return nil, ErrUnknownSensorType
+	}
+
+	return definition, nil
+}
+
+// SensorTypes returns the registered sensor types, in the order of their registration.
+func SensorTypes() []*SensorTypeDefinition {
+	sensorTypes.RLock()
+	defer sensorTypes.RUnlock()
+
+	return append([]*SensorTypeDefinition(nil), sensorTypes.ordered...)
+}
+
+func floatPtr(value float64) *float64 { return &value }
+
+// ReadingParams is a reading of a sensor type of the registry.
+type ReadingParams struct {
+	SiteID     string     `json:"siteId"`
+	SensorType SensorType `json:"sensorType"`
+	DeviceID   string     `json:"deviceId"`
+	Value      float64    `json:"value"`
+
+	ReadingID
+	ReadingTime
+	SignedPayload
+	ReadingSource
+}
+
+func (p *ReadingParams) Sanitize() error {
+	if p.SiteID == "" {
+		return errors.New("siteId is required")
+	}
+	if p.DeviceID == "" {
+		return errors.New("deviceId is required")
+	}
+	definition, err := LookupSensorType(p.SensorType)
+	if err != nil {
+		return err
+	}
+	if err := definition.Validate(definition.ValueField(), p.Value); err != nil {
+		return err
+	}
+	if err := p.ReadingTime.Sanitize(); err != nil {
+		return err
+	}
+	return p.ReadingSource.Sanitize()
+}
+
+type ReadingData struct {
+	ID            int64
+	SensorType    SensorType
+	DeviceID      string
+	Value         float64
+	FilteredValue *float64 // nil quand le type n'est pas filtré ou que la mesure brute a été rejetée
+	RecordedAt    time.Time
+	ReceivedAt    time.Time
+	Sequence      *int64
+}
+
+// SensorAlert is an alert raised by a rule of a sensor type of the registry.
+type SensorAlert struct {
+	ID             int64
+	SensorType     SensorType
+	DeviceID       string
+	DataID         *int64
+	Rule           string
+	Value          float64
+	Threshold      *float64 // nil pour les alertes de mouvement, qui n'ont pas de seuil
+	Reference      *float64 // Mesure précédente, pour les règles de variation
+	AlertStatus    AlertStatus
+	AcknowledgedAt *time.Time
+	ResolvedAt     *time.Time
+	CreatedAt      time.Time
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"fmt"
+	"sensormanager"
+	"time"
+
+	"github.com/jirenius/go-res"
+)
+
+var (
+	errInvalidActionToken = &res.Error{Code: "sensormanager.invalidActionToken", Message: "Invalid action token"}
+	errActionTokenExpired = &res.Error{Code: "sensormanager.actionTokenExpired", Message: "Action token expired"}
+	errActionTokenUsed    = &res.Error{Code: "sensormanager.actionTokenUsed", Message: "Action token already used"}
+)
+
+func (s *Server) addAlertActionsHandler() {
+	provider := &alertActionsProvider{s}
+
+	// Le jeton d'action tient lieu de connexion : l'appel est ouvert aux connexions anonymes.
+	s.service.Handle("alerts.actions",
+		res.Access(res.AccessGranted),
+		res.Call("execute", provider.Execute),
+	)
+
+	s.service.Handle("sites.$siteId.alerts.audit",
+		alertPermissions.siteAccess(),
+		res.Call("get", provider.GetAudit),
+	)
+}
+
+type alertActionsProvider struct{ server *Server }
+
+// Execute acquitte, résout ou met en sourdine l'alerte du jeton reçu avec sa notification.
+func (p *alertActionsProvider) Execute(request res.CallRequest) {
+	var params struct {
+		Token         string `json:"token"`
+		Action        string `json:"action"`
+		SnoozeMinutes int    `json:"snoozeMinutes,omitempty"`
+	}
+	request.ParseParams(&params)
+
+	if params.SnoozeMinutes == 0 {
+		params.SnoozeMinutes = 30
+	}
+
+	executeParams := &sensormanager.ExecuteAlertActionParams{
+		Token:  params.Token,
+		Action: sensormanager.AlertAction(params.Action),
+		Snooze: time.Duration(params.SnoozeMinutes) * time.Minute,
+		Actor:  "notification",
+	}
+	if err := executeParams.Sanitize(); err != nil {
+		request.InvalidParams(err.Error())
+		return
+	}
+
+	entry, err := p.server.store.AlertActions.ExecuteAlertAction(executeParams)
+	switch err {
+	case nil:
+	case sensormanager.ErrInvalidAlertActionToken:
+		request.Error(errInvalidActionToken)
+		return
+	case sensormanager.ErrAlertActionTokenExpired:
+		request.Error(errActionTokenExpired)
+		return
+	case sensormanager.ErrAlertActionTokenUsed:
+		request.Error(errActionTokenUsed)
+		return
+	default:
+		request.Error(err)
+		return
+	}
+
+	fmt.Printf("👆 %s alert %d: %s from notification\n", entry.AlertType, entry.AlertID, entry.Action)
+
+	request.OK(alertAuditEntryToMap(entry))
+}
+
+func (p *alertActionsProvider) GetAudit(request res.CallRequest) {
+	var params struct {
+		AlertType string `json:"alertType"`
+		AlertID   int64  `json:"alertId"`
+	}
+	request.ParseParams(&params)
+
+	if err := sensormanager.AlertType(params.AlertType).Validate(); err != nil {
+		request.InvalidParams(err.Error())
+		return
+	}
+
+	entries, err := p.server.store.AlertActions.GetAlertAudit(
+		request.PathParam("siteId"), sensormanager.AlertType(params.AlertType), params.AlertID,
+	)
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(entries))
+	for i, entry := range entries {
+		result[i] = alertAuditEntryToMap(entry)
+	}
+
+	request.OK(result)
+}
+
+// withActionToken ajoute à la notification d'une alerte un jeton permettant d'agir dessus sans connexion. La
+// notification part sans jeton s'il ne peut être émis.
+func (s *Server) withActionToken(data map[string]interface{}, siteID string, alertType sensormanager.AlertType, alertID int64) {
+	if alertID == 0 {
+		return
+	}
+
+	token, err := s.store.AlertActions.IssueAlertActionToken(siteID, alertType, alertID)
+	if err != nil {
+		fmt.Printf("❌ Could not issue action token of %s alert %d: %v\n", alertType, alertID, err)
+		return
+	}
+
+	data["actionToken"] = token.Token
+	data["actionTokenExpiresAt"] = token.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+func alertAuditEntryToMap(entry *sensormanager.AlertAuditEntry) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":        entry.ID,
+		"siteId":    entry.SiteID,
+		"alertType": string(entry.AlertType),
+		"alertId":   entry.AlertID,
+		"action":    string(entry.Action),
+		"actor":     entry.Actor,
+		"createdAt": entry.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if entry.SnoozedUntil != nil {
+		result["snoozedUntil"] = entry.SnoozedUntil.Format("2006-01-02T15:04:05Z")
+	}
+
+	return result
+}
@@ -0,0 +1,440 @@
+package server
+
+import (
+	"fmt"
+	"sensormanager"
+	"strconv"
+	"strings"
+
+	"github.com/jirenius/go-res"
+)
+
+// token est le jeton de connexion conservé par resgate et transmis à chaque requête d'accès.
+type token struct {
+	UserID int64                `json:"userId"`
+	Name   string               `json:"name"`
+	Role   sensormanager.Role   `json:"role"`
+	Locale sensormanager.Locale `json:"locale,omitempty"`
+	Sites  []string             `json:"sites,omitempty"`
+	KeyID  int64                `json:"keyId,omitempty"` // Clé d'API de la connexion, 0 pour la clé d'amorçage
+}
+
+// memberOf indique si le jeton donne accès au site. Les administrateurs ont accès à tous les sites.
+func (t *token) memberOf(siteID string) bool {
+	if t.Role == sensormanager.RoleAdmin {
+		return true
+	}
+
+	for _, site := range t.Sites {
+		if site == siteID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// permissions lists, for each role, the call methods allowed on a resource, in the res.AccessRequest.Access format.
+// Admins are allowed every method and connections without a token are denied.
+type permissions map[sensormanager.Role]string
+
+func (p permissions) access() res.Option {
+	return res.Access(func(request res.AccessRequest) {
+		var t token
+		request.ParseToken(&t)
+
+		if t.Role == sensormanager.RoleAdmin {
+			request.Access(false, "*")
+			return
+		}
+
+		request.Access(false, p[t.Role])
+	})
+}
+
+// siteAccess is access for resources under sites.$siteId: the user must also be a member of the site.
+func (p permissions) siteAccess() res.Option {
+	return res.Access(func(request res.AccessRequest) {
+		var t token
+		request.ParseToken(&t)
+
+		if !t.memberOf(request.PathParam("siteId")) {
+			request.AccessDenied()
+			return
+		}
+
+		if t.Role == sensormanager.RoleAdmin {
+			request.Access(false, "*")
+			return
+		}
+
+		request.Access(false, p[t.Role])
+	})
+}
+
+var (
+	sensorPermissions = permissions{
+		sensormanager.RoleDevice: "record,recordBatch",
+		sensormanager.RoleViewer: "history",
+		sensormanager.RoleMember: "history",
+	}
+
+	sensorTypePermissions = permissions{
+		sensormanager.RoleDevice: "get",
+		sensormanager.RoleViewer: "get",
+		sensormanager.RoleMember: "get",
+	}
+
+	telemetryPermissions = permissions{
+		sensormanager.RoleDevice: "record",
+		sensormanager.RoleViewer: "history",
+		sensormanager.RoleMember: "history",
+	}
+
+	alertPermissions = permissions{
+		sensormanager.RoleViewer: "get",
+		sensormanager.RoleMember: "get,updateStatus",
+	}
+
+	incidentPermissions = permissions{
+		sensormanager.RoleViewer: "get",
+		sensormanager.RoleMember: "get,update",
+	}
+
+	devicePermissions = permissions{
+		sensormanager.RoleViewer: "get",
+		sensormanager.RoleMember: "get",
+	}
+
+	commandPermissions = permissions{
+		sensormanager.RoleViewer: "commands",
+		sensormanager.RoleMember: "command,commands",
+	}
+
+	shadowPermissions = permissions{
+		sensormanager.RoleDevice: "report",
+		sensormanager.RoleViewer: "get",
+		sensormanager.RoleMember: "get,setDesired",
+	}
+
+	// Le registre et la création des déploiements sont réservés aux administrateurs.
+	firmwarePermissions = permissions{
+		sensormanager.RoleViewer: "get,deployments",
+		sensormanager.RoleMember: "get,deployments",
+	}
+
+	escalationPermissions = permissions{
+		sensormanager.RoleViewer: "get,history",
+		sensormanager.RoleMember: "get,history",
+	}
+
+	// Les seuils s'appliquent aux alertes de tout le site : leur modification est réservée aux administrateurs.
+	thresholdPermissions = permissions{
+		sensormanager.RoleViewer: "get",
+		sensormanager.RoleMember: "get",
+	}
+
+	// La gestion des webhooks est réservée aux administrateurs : leurs URL reçoivent les événements du site.
+	webhookPermissions = permissions{
+		sensormanager.RoleViewer: "get,deliveries",
+		sensormanager.RoleMember: "get,deliveries",
+	}
+
+	sitePermissions = permissions{
+		sensormanager.RoleViewer: "get",
+		sensormanager.RoleMember: "get",
+	}
+
+	alarmPermissions = permissions{
+		sensormanager.RoleViewer: "get",
+		sensormanager.RoleMember: "get,setArmed",
+	}
+
+	notificationPermissions = permissions{
+		sensormanager.RoleViewer: "register",
+		sensormanager.RoleMember: "register",
+	}
+
+	adminPermissions = permissions{}
+)
+
+var errInvalidAPIKey = &res.Error{Code: res.CodeAccessDenied, Message: "Invalid API key"}
+
+func (s *Server) addAuthHandler() {
+	provider := &authProvider{s}
+
+	s.service.Handle("auth",
+		res.Auth("login", provider.Login),
+		res.Auth("header", provider.Header),
+		res.Auth("logout", provider.Logout),
+		res.Auth("refresh", provider.Refresh),
+	)
+
+	s.service.Handle("users",
+		adminPermissions.access(),
+		res.Call("get", provider.GetUsers),
+		res.Call("create", provider.CreateUser),
+		res.Call("keys", provider.GetAPIKeys),
+		res.Call("createKey", provider.CreateAPIKey),
+		res.Call("revokeKey", provider.RevokeAPIKey),
+	)
+}
+
+type authProvider struct{ server *Server }
+
+// Login authentifie une connexion WebSocket avec la clé passée en paramètre.
+func (p *authProvider) Login(request res.AuthRequest) {
+	var params struct {
+		APIKey string `json:"apiKey"`
+	}
+	request.ParseParams(&params)
+
+	p.authenticate(request, params.APIKey)
+}
+
+// Header authentifie les requêtes HTTP (capteurs, passerelle CoAP) avec l'en-tête "Authorization: Bearer <clé>".
+// resgate doit être lancé avec --headauth=sensormanager.auth.header.
+func (p *authProvider) Header(request res.AuthRequest) {
+	authorization := ""
+	if values := request.Header()["Authorization"]; len(values) > 0 {
+		authorization = values[0]
+	}
+
+	key, found := strings.CutPrefix(authorization, "Bearer ")
+	if !found {
+		// Pas d'en-tête : la connexion reste anonyme et pourra se connecter avec auth.login.
+		request.OK(nil)
+		return
+	}
+
+	p.authenticate(request, key)
+}
+
+func (p *authProvider) Logout(request res.AuthRequest) {
+	request.TokenEvent(nil)
+	request.OK(nil)
+}
+
+// Refresh reçoit, via resgate, les connexions dont le jeton a été réinitialisé (clé révoquée, sites modifiés) : le
+// jeton est recalculé à partir de la clé, ou effacé si elle a été révoquée.
+func (p *authProvider) Refresh(request res.AuthRequest) {
+	var t token
+	request.ParseToken(&t)
+
+	if t.KeyID == 0 {
+		request.OK(nil)
+		return
+	}
+
+	user, err := p.server.store.Auth.AuthenticateKeyID(t.KeyID)
+	if err == sensormanager.ErrInvalidAPIKey {
+		p.server.service.TokenEventWithID(request.CID(), apiKeyTokenID(t.KeyID), nil)
+		request.OK(nil)
+		return
+	}
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	p.tokenEvent(request, user)
+	request.OK(nil)
+}
+
+func (p *authProvider) authenticate(request res.AuthRequest, key string) {
+	user, err := p.server.store.Auth.Authenticate(key)
+	if err == sensormanager.ErrInvalidAPIKey {
+		request.TokenEvent(nil)
+		request.Error(errInvalidAPIKey)
+		return
+	}
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	p.tokenEvent(request, user)
+	request.OK(map[string]interface{}{
+		"userId": user.ID,
+		"name":   user.Name,
+		"role":   string(user.Role),
+		"sites":  user.Sites,
+	})
+}
+
+// tokenEvent donne à la connexion le jeton de l'utilisateur, identifié par sa clé d'API pour pouvoir être réinitialisé.
+func (p *authProvider) tokenEvent(request res.AuthRequest, user *sensormanager.User) {
+	t := &token{UserID: user.ID, Name: user.Name, Role: user.Role, Locale: user.Locale, Sites: user.Sites, KeyID: user.KeyID}
+
+	// 🔑 La clé d'amorçage ne peut pas être révoquée : son jeton n'a pas d'identifiant.
+	if user.KeyID == 0 {
+		request.TokenEvent(t)
+		return
+	}
+
+	p.server.service.TokenEventWithID(request.CID(), apiKeyTokenID(user.KeyID), t)
+}
+
+// resetTokens demande à resgate de recalculer le jeton des connexions authentifiées avec ces clés.
+func (s *Server) resetTokens(keyIDs ...int64) {
+	tokenIDs := make([]string, len(keyIDs))
+	for i, keyID := range keyIDs {
+		tokenIDs[i] = apiKeyTokenID(keyID)
+	}
+
+	s.service.TokenReset("auth."+s.service.FullPath()+".auth.refresh", tokenIDs...)
+}
+
+// resetUserTokens recalcule le jeton des connexions de l'utilisateur, dont les sites ont changé.
+func (s *Server) resetUserTokens(userID int64) {
+	keys, err := s.store.Auth.GetAPIKeys(userID)
+	if err != nil {
+		fmt.Printf("❌ Could not reset the tokens of user %d: %v\n", userID, err)
+		return
+	}
+
+	var keyIDs []int64
+	for _, key := range keys {
+		if key.RevokedAt == nil {
+			keyIDs = append(keyIDs, key.ID)
+		}
+	}
+
+	s.resetTokens(keyIDs...)
+}
+
+func apiKeyTokenID(keyID int64) string {
+	return strconv.FormatInt(keyID, 10)
+}
+
+func (p *authProvider) GetUsers(request res.CallRequest) {
+	users, err := p.server.store.Auth.GetUsers()
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(users))
+	for i, user := range users {
+		result[i] = userToMap(user)
+	}
+
+	request.OK(result)
+}
+
+func (p *authProvider) CreateUser(request res.CallRequest) {
+	var params struct {
+		Name   string `json:"name"`
+		Role   string `json:"role"`
+		Locale string `json:"locale,omitempty"`
+	}
+	request.ParseParams(&params)
+
+	user, err := p.server.store.Auth.CreateUser(&sensormanager.CreateUserParams{
+		Name:   params.Name,
+		Role:   params.Role,
+		Locale: params.Locale,
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(userToMap(user))
+}
+
+func (p *authProvider) GetAPIKeys(request res.CallRequest) {
+	var params struct {
+		UserID int64 `json:"userId"`
+	}
+	request.ParseParams(&params)
+
+	keys, err := p.server.store.Auth.GetAPIKeys(params.UserID)
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(keys))
+	for i, key := range keys {
+		result[i] = apiKeyToMap(key)
+	}
+
+	request.OK(result)
+}
+
+func (p *authProvider) CreateAPIKey(request res.CallRequest) {
+	var params struct {
+		UserID int64  `json:"userId"`
+		Name   string `json:"name"`
+	}
+	request.ParseParams(&params)
+
+	key, secret, err := p.server.store.Auth.CreateAPIKey(&sensormanager.CreateAPIKeyParams{
+		UserID: params.UserID,
+		Name:   params.Name,
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	// ⚠️ La clé n'est retournée qu'une seule fois
+	result := apiKeyToMap(key)
+	result["apiKey"] = secret
+
+	request.OK(result)
+}
+
+func (p *authProvider) RevokeAPIKey(request res.CallRequest) {
+	var params struct {
+		KeyID int64 `json:"keyId"`
+	}
+	request.ParseParams(&params)
+
+	if err := p.server.store.Auth.RevokeAPIKey(params.KeyID); err != nil {
+		request.Error(err)
+		return
+	}
+
+	fmt.Printf("🔒 API key %d revoked\n", params.KeyID)
+
+	// 🚪 Les connexions ouvertes avec la clé perdent leur accès sans attendre de se reconnecter.
+	p.server.resetTokens(params.KeyID)
+
+	request.OK(map[string]interface{}{
+		"success": true,
+		"message": "API key revoked",
+	})
+}
+
+func userToMap(user *sensormanager.User) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":        user.ID,
+		"name":      user.Name,
+		"role":      string(user.Role),
+		"createdAt": user.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if user.Locale != "" {
+		result["locale"] = string(user.Locale)
+	}
+
+	return result
+}
+
+func apiKeyToMap(key *sensormanager.APIKey) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":        key.ID,
+		"userId":    key.UserID,
+		"name":      key.Name,
+		"prefix":    key.Prefix,
+		"createdAt": key.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if key.RevokedAt != nil {
+		result["revokedAt"] = key.RevokedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	return result
+}
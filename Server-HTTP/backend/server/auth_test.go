@@ -0,0 +1,401 @@
+package server
+
+import (
+	"encoding/json"
+	"regexp"
+	"sensormanager"
+	"sensormanager/store"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jirenius/go-res"
+	"github.com/jirenius/go-res/restest"
+)
+
+const (
+	testAdminKey = "test-admin-key"
+	testSiteID   = "home"
+)
+
+func newTestSession(t *testing.T) *restest.Session {
+	service := res.NewService("sensormanager")
+
+	New(
+		WithService(service),
+		WithStore(store.New(store.WithBootstrapAdminKey(testAdminKey))),
+	)
+
+	session := restest.NewSession(t, service)
+	t.Cleanup(func() { session.Close() })
+
+	return session
+}
+
+// newTestSessionWithDB is newTestSession with a store backed by sqlmock.
+func newTestSessionWithDB(t *testing.T, options ...store.Option) (*restest.Session, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	service := res.NewService("sensormanager")
+
+	New(
+		WithService(service),
+		WithStore(store.New(append([]store.Option{store.WithDB(db), store.WithBootstrapAdminKey(testAdminKey)}, options...)...)),
+	)
+
+	session := restest.NewSession(t, service)
+	t.Cleanup(func() { session.Close() })
+
+	return session, mock
+}
+
+func accessRequest(role sensormanager.Role) *restest.Request {
+	request := restest.DefaultAccessRequest()
+	if role != "" {
+		request.Token, _ = json.Marshal(&token{UserID: 1, Name: "test", Role: role, Sites: []string{testSiteID}})
+	}
+
+	return request
+}
+
+func TestAccess(t *testing.T) {
+	// The expected call methods for each role, an empty string meaning the access is denied.
+	tests := map[string]map[sensormanager.Role]string{
+		"sites.home.sensor.distance": {
+			"":                       "",
+			sensormanager.RoleDevice: "record,recordBatch",
+			sensormanager.RoleViewer: "history",
+			sensormanager.RoleMember: "history",
+			sensormanager.RoleAdmin:  "*",
+		},
+		"sites.home.sensor.motion": {
+			sensormanager.RoleDevice: "record,recordBatch",
+			sensormanager.RoleViewer: "history",
+		},
+		"sites.home.alerts.distance": {
+			"":                       "",
+			sensormanager.RoleDevice: "",
+			sensormanager.RoleViewer: "get",
+			sensormanager.RoleMember: "get,updateStatus",
+			sensormanager.RoleAdmin:  "*",
+		},
+		"sites.home.alerts.sensorFault": {
+			sensormanager.RoleViewer: "get",
+			sensormanager.RoleMember: "get,updateStatus",
+		},
+		"sites.home.incidents": {
+			sensormanager.RoleDevice: "",
+			sensormanager.RoleViewer: "get",
+			sensormanager.RoleMember: "get,update",
+		},
+		"sites.home.devices": {
+			sensormanager.RoleMember: "get",
+			sensormanager.RoleAdmin:  "*",
+		},
+		"sites.home.escalations": {
+			sensormanager.RoleViewer: "get,history",
+			sensormanager.RoleMember: "get,history",
+			sensormanager.RoleAdmin:  "*",
+		},
+		"sites.home.thresholds": {
+			sensormanager.RoleDevice: "",
+			sensormanager.RoleMember: "get",
+			sensormanager.RoleAdmin:  "*",
+		},
+		"sites.home.notifications": {
+			"":                       "",
+			sensormanager.RoleDevice: "",
+			sensormanager.RoleViewer: "register",
+			sensormanager.RoleMember: "register",
+			sensormanager.RoleAdmin:  "*",
+		},
+		"sites": {
+			sensormanager.RoleDevice: "",
+			sensormanager.RoleViewer: "get",
+			sensormanager.RoleMember: "get",
+			sensormanager.RoleAdmin:  "*",
+		},
+		"notifications.templates": {
+			sensormanager.RoleDevice: "",
+			sensormanager.RoleMember: "",
+			sensormanager.RoleAdmin:  "*",
+		},
+		"users": {
+			sensormanager.RoleDevice: "",
+			sensormanager.RoleMember: "",
+			sensormanager.RoleAdmin:  "*",
+		},
+	}
+
+	for resource, roles := range tests {
+		for role, want := range roles {
+			t.Run(resource+"/"+string(role), func(t *testing.T) {
+				session := newTestSession(t)
+
+				response := session.Access("sensormanager."+resource, accessRequest(role)).Response()
+				if want == "" {
+					response.AssertErrorCode(res.CodeAccessDenied)
+				} else {
+					response.AssertAccess(false, want)
+				}
+			})
+		}
+	}
+}
+
+// TestSiteIsolation checks that the resources of a site are denied to the members of other sites.
+func TestSiteIsolation(t *testing.T) {
+	resources := []string{
+		"sensor.distance",
+		"sensor.microphone",
+		"sensor.motion",
+		"alerts.distance",
+		"alerts.microphone",
+		"alerts.motion",
+		"alerts.sensorFault",
+		"incidents",
+		"devices",
+		"escalations",
+		"thresholds",
+		"notifications",
+	}
+	roles := []sensormanager.Role{
+		"",
+		sensormanager.RoleDevice,
+		sensormanager.RoleViewer,
+		sensormanager.RoleMember,
+	}
+
+	for _, resource := range resources {
+		for _, role := range roles {
+			t.Run(resource+"/"+string(role), func(t *testing.T) {
+				session := newTestSession(t)
+
+				session.Access("sensormanager.sites.other."+resource, accessRequest(role)).
+					Response().
+					AssertErrorCode(res.CodeAccessDenied)
+			})
+		}
+
+		t.Run(resource+"/admin", func(t *testing.T) {
+			session := newTestSession(t)
+
+			session.Access("sensormanager.sites.other."+resource, accessRequest(sensormanager.RoleAdmin)).
+				Response().
+				AssertAccess(false, "*")
+		})
+	}
+}
+
+func TestSiteIsolationWithSeveralSites(t *testing.T) {
+	session := newTestSession(t)
+
+	request := restest.DefaultAccessRequest()
+	request.Token, _ = json.Marshal(&token{
+		UserID: 1,
+		Name:   "test",
+		Role:   sensormanager.RoleMember,
+		Sites:  []string{testSiteID, "cottage"},
+	})
+
+	session.Access("sensormanager.sites.cottage.alerts.motion", request).Response().AssertAccess(false, "get,updateStatus")
+	session.Access("sensormanager.sites.office.alerts.motion", request).Response().AssertErrorCode(res.CodeAccessDenied)
+}
+
+func TestLogin(t *testing.T) {
+	session := newTestSession(t)
+
+	request := restest.DefaultAuthRequest()
+	request.Params = json.RawMessage(`{"apiKey":"` + testAdminKey + `"}`)
+
+	call := session.Auth("sensormanager.auth", "login", request)
+
+	session.GetMsg().AssertTokenEvent(request.CID, &token{Name: "bootstrap", Role: sensormanager.RoleAdmin})
+	call.Response().AssertResult(map[string]interface{}{
+		"userId": 0,
+		"name":   "bootstrap",
+		"role":   "admin",
+		"sites":  nil,
+	})
+}
+
+func TestLoginWithoutKey(t *testing.T) {
+	session := newTestSession(t)
+
+	request := restest.DefaultAuthRequest()
+	request.Params = json.RawMessage(`{}`)
+
+	call := session.Auth("sensormanager.auth", "login", request)
+
+	session.GetMsg().AssertTokenEvent(request.CID, nil)
+	call.Response().AssertErrorCode(res.CodeAccessDenied)
+}
+
+func TestHeaderAuth(t *testing.T) {
+	t.Run("bearer key", func(t *testing.T) {
+		session := newTestSession(t)
+
+		request := restest.DefaultAuthRequest()
+		request.Header = map[string][]string{"Authorization": {"Bearer " + testAdminKey}}
+
+		call := session.Auth("sensormanager.auth", "header", request)
+
+		session.GetMsg().AssertTokenEvent(request.CID, &token{Name: "bootstrap", Role: sensormanager.RoleAdmin})
+		call.Response().AssertResult(map[string]interface{}{
+			"userId": 0,
+			"name":   "bootstrap",
+			"role":   "admin",
+			"sites":  nil,
+		})
+	})
+
+	t.Run("no header keeps the connection anonymous", func(t *testing.T) {
+		session := newTestSession(t)
+
+		session.Auth("sensormanager.auth", "header", nil).Response().AssertResult(nil)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	session := newTestSession(t)
+
+	call := session.Auth("sensormanager.auth", "logout", nil)
+
+	session.GetMsg().AssertTokenEvent("testcid", nil)
+	call.Response().AssertResult(nil)
+}
+
+// TestRevokedKeyIsDenied checks that the connections opened with a key lose their access once it is revoked.
+func TestRevokedKeyIsDenied(t *testing.T) {
+	session, mock := newTestSessionWithDB(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM api_keys k JOIN users u ON u.id = k.user_id WHERE k.key_hash = $1")).
+		WillReturnRows(sqlmock.NewRows([]string{"key_id", "id", "name", "role", "locale", "created_at"}).
+			AddRow(5, 2, "alice", "member", nil, nil))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM sites s JOIN site_members m")).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "armed", "created_at"}).AddRow(testSiteID, "Maison", true, nil))
+
+	login := restest.DefaultAuthRequest()
+	login.Params = json.RawMessage(`{"apiKey":"smk_alice"}`)
+
+	call := session.Auth("sensormanager.auth", "login", login)
+
+	memberToken := &token{UserID: 2, Name: "alice", Role: sensormanager.RoleMember, Sites: []string{testSiteID}, KeyID: 5}
+	session.GetMsg().AssertTokenEventWithID(login.CID, "5", memberToken)
+	call.Response()
+
+	access := restest.DefaultAccessRequest()
+	access.Token, _ = json.Marshal(memberToken)
+	session.Access("sensormanager.sites.home.alerts.distance", access).Response().AssertAccess(false, "get,updateStatus")
+
+	// 🔒 La révocation demande à resgate de rafraîchir le jeton des connexions ouvertes avec la clé.
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE api_keys SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	revoke := restest.DefaultCallRequest()
+	revoke.Token, _ = json.Marshal(&token{Name: "bootstrap", Role: sensormanager.RoleAdmin})
+	revoke.Params = json.RawMessage(`{"keyId":5}`)
+
+	call = session.Call("sensormanager.users", "revokeKey", revoke)
+
+	session.GetMsg().
+		AssertSubject("system.tokenReset").
+		AssertPayload(json.RawMessage(`{"tids":["5"],"subject":"auth.sensormanager.auth.refresh"}`))
+	call.Response().AssertResult(map[string]interface{}{"success": true, "message": "API key revoked"})
+
+	// resgate envoie le jeton de chaque connexion concernée à auth.refresh : la clé révoquée efface le jeton.
+	mock.ExpectQuery(regexp.QuoteMeta("FROM api_keys k JOIN users u ON u.id = k.user_id WHERE k.id = $1 AND k.revoked_at IS NULL")).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"key_id", "id", "name", "role", "locale", "created_at"}))
+
+	refresh := restest.DefaultAuthRequest()
+	refresh.Token, _ = json.Marshal(memberToken)
+
+	call = session.Auth("sensormanager.auth", "refresh", refresh)
+
+	session.GetMsg().AssertTokenEventWithID(refresh.CID, "5", nil)
+	call.Response().AssertResult(nil)
+
+	session.Access("sensormanager.sites.home.alerts.distance", restest.DefaultAccessRequest()).
+		Response().
+		AssertErrorCode(res.CodeAccessDenied)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSiteMembershipResetsTokens checks that adding or removing a member applies to the connections already open with
+// their keys.
+func TestSiteMembershipResetsTokens(t *testing.T) {
+	session, mock := newTestSessionWithDB(t)
+
+	admin := restest.DefaultCallRequest()
+	admin.Token, _ = json.Marshal(&token{Name: "bootstrap", Role: sensormanager.RoleAdmin})
+	admin.Params = json.RawMessage(`{"siteId":"home","userId":2}`)
+
+	// Seules les clés non révoquées de l'utilisateur sont réinitialisées.
+	expectKeys := func() {
+		mock.ExpectQuery(regexp.QuoteMeta("FROM api_keys WHERE user_id = $1")).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "prefix", "created_at", "revoked_at"}).
+				AddRow(5, 2, "phone", "smk_12345678", nil, nil).
+				AddRow(6, 2, "old phone", "smk_87654321", nil, time.Now()))
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO site_members (site_id, user_id)")).
+		WithArgs(testSiteID, int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectKeys()
+
+	call := session.Call("sensormanager.sites", "addMember", admin)
+
+	session.GetMsg().
+		AssertSubject("system.tokenReset").
+		AssertPayload(json.RawMessage(`{"tids":["5"],"subject":"auth.sensormanager.auth.refresh"}`))
+	call.Response().AssertResult(map[string]interface{}{"success": true, "message": "Site member added"})
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM site_members WHERE site_id = $1 AND user_id = $2")).
+		WithArgs(testSiteID, int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectKeys()
+
+	call = session.Call("sensormanager.sites", "removeMember", admin)
+
+	session.GetMsg().
+		AssertSubject("system.tokenReset").
+		AssertPayload(json.RawMessage(`{"tids":["5"],"subject":"auth.sensormanager.auth.refresh"}`))
+	call.Response().AssertResult(map[string]interface{}{"success": true, "message": "Site member removed"})
+
+	// resgate rafraîchit le jeton de la connexion : le site retiré n'y figure plus.
+	mock.ExpectQuery(regexp.QuoteMeta("FROM api_keys k JOIN users u ON u.id = k.user_id WHERE k.id = $1 AND k.revoked_at IS NULL")).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"key_id", "id", "name", "role", "locale", "created_at"}).
+			AddRow(5, 2, "alice", "member", nil, nil))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM sites s JOIN site_members m")).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "armed", "created_at"}))
+
+	refresh := restest.DefaultAuthRequest()
+	refresh.Token, _ = json.Marshal(&token{UserID: 2, Name: "alice", Role: sensormanager.RoleMember, Sites: []string{testSiteID}, KeyID: 5})
+
+	call = session.Auth("sensormanager.auth", "refresh", refresh)
+
+	refreshed := &token{UserID: 2, Name: "alice", Role: sensormanager.RoleMember, KeyID: 5}
+	session.GetMsg().AssertTokenEventWithID(refresh.CID, "5", refreshed)
+	call.Response().AssertResult(nil)
+
+	access := restest.DefaultAccessRequest()
+	access.Token, _ = json.Marshal(refreshed)
+	session.Access("sensormanager.sites.home.alerts.distance", access).Response().AssertErrorCode(res.CodeAccessDenied)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
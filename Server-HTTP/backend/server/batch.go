@@ -0,0 +1,64 @@
+package server
+
+import (
+	"sensormanager"
+	"sensormanager/server/models"
+)
+
+// recordBatchResponse builds the response of a recordBatch call and sends the notifications of its alerts and sensor
+// faults, in the order of the batch.
+func (s *Server) recordBatchResponse(alertType sensormanager.AlertType, results []*sensormanager.RecordResult) *models.BatchResponseModel {
+	response := &models.BatchResponseModel{Items: make([]*models.BatchItemModel, len(results))}
+
+	var notified []*sensormanager.AlertResponse
+	for i, result := range results {
+		if result.Err != nil {
+			response.Rejected++
+			response.Items[i] = &models.BatchItemModel{Accepted: false, Error: result.Err.Error()}
+			continue
+		}
+
+		alertResponse := result.Response
+		s.readingRecorded(sensormanager.SensorType(alertType), alertResponse)
+
+		response.Accepted++
+		// 🔁 Une retransmission n'a déclenché aucune nouvelle alerte
+		if alertResponse.Alert && !alertResponse.Duplicate {
+			response.Alerts++
+		}
+		if (alertResponse.Alert || alertResponse.SensorFault != nil) && !alertResponse.Duplicate {
+			notified = append(notified, alertResponse)
+		}
+
+		response.Items[i] = &models.BatchItemModel{
+			Accepted: true,
+			AlertResponseModel: &models.AlertResponseModel{
+				Alert:       alertResponse.Alert,
+				Message:     alertResponse.Message,
+				Value:       alertResponse.Value,
+				Threshold:   alertResponse.Threshold,
+				DeviceID:    alertResponse.DeviceID,
+				Event:       alertResponse.Event,
+				RecordedAt:  alertResponse.RecordedAt.Format("2006-01-02T15:04:05Z"),
+				Duplicate:   alertResponse.Duplicate,
+				Quarantined: alertResponse.Quarantined,
+			},
+		}
+	}
+
+	// Envoi asynchrone, mais dans l'ordre du lot pour que les incidents se forment comme en temps réel
+	if len(notified) > 0 {
+		go func() {
+			for _, alertResponse := range notified {
+				if alertResponse.Alert {
+					s.notifyAlert(alertType, alertResponse)
+				}
+				if alertResponse.SensorFault != nil {
+					s.notifySensorFault(alertResponse.SiteID, alertResponse.SensorFault)
+				}
+			}
+		}()
+	}
+
+	return response
+}
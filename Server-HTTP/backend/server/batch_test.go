@@ -0,0 +1,77 @@
+package server
+
+import (
+	"encoding/json"
+	"regexp"
+	"sensormanager"
+	"sensormanager/store"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jirenius/go-res/restest"
+)
+
+// TestRecordBatchPublishesMotionEvents checks that a batch of motion readings publishes the start and the end of the
+// motion, like the same readings recorded one by one. The site is disarmed: the motion joins an incident without being
+// pushed.
+func TestRecordBatchPublishesMotionEvents(t *testing.T) {
+	session, mock := newTestSessionWithDB(t, store.WithDeviceSignatures(false, time.Minute))
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM devices WHERE device_id = $1")).
+		WithArgs("ESP_MOTION").
+		WillReturnRows(sqlmock.NewRows([]string{"site_id", "device_id", "status"}).AddRow(testSiteID, "ESP_MOTION", "active"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT status FROM devices WHERE device_id = $1")).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("active"))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM devices WHERE device_id = $1")).
+		WithArgs("ESP_MOTION").
+		WillReturnRows(sqlmock.NewRows([]string{"site_id", "device_id", "status"}).AddRow(testSiteID, "ESP_MOTION", "active"))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO sensor_readings")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO sensor_alerts")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT location FROM devices WHERE device_id = $1")).
+		WillReturnRows(sqlmock.NewRows([]string{"location"}))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock(hashtext($1))")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM incidents WHERE site_id = $1 AND group_key = $2")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO incidents")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "site_id", "group_key", "status", "alert_count"}).
+			AddRow(20, testSiteID, "device:ESP_MOTION", "open", 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO incident_alerts")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM incident_alerts WHERE incident_id = $1")).
+		WillReturnRows(sqlmock.NewRows([]string{"incident_id"}))
+	mock.ExpectCommit()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM sites WHERE id = $1")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "armed"}).AddRow(testSiteID, "Maison", false))
+
+	request := restest.DefaultCallRequest()
+	request.Token, _ = json.Marshal(&token{Name: "ESP_MOTION", Role: sensormanager.RoleDevice, Sites: []string{testSiteID}})
+	now := time.Now().UTC().Truncate(time.Second)
+	request.Params, _ = json.Marshal(map[string]interface{}{"readings": []map[string]interface{}{
+		{"deviceID": "ESP_MOTION", "motionDetected": true, "recordedAt": now.Add(-30 * time.Second)},
+		{"deviceID": "ESP_MOTION", "motionDetected": false, "recordedAt": now},
+	}})
+
+	call := session.Call("sensormanager.sites.home.sensor.motion", "recordBatch", request)
+
+	session.GetMsg().AssertEventName("sensormanager.sites.home.sensor.motion", "unexpected_motion")
+	session.GetMsg().AssertEventName("sensormanager.sites.home.sensor.motion", "motion_ended")
+	call.Response()
+
+	// Les notifications partent après la réponse
+	deadline := time.Now().Add(5 * time.Second)
+	for mock.ExpectationsWereMet() != nil {
+		if time.Now().After(deadline) {
+			t.Fatal(mock.ExpectationsWereMet())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"fmt"
+	"sensormanager"
+
+	"github.com/jirenius/go-res"
+)
+
+func (s *Server) addCommandsHandler() {
+	provider := &commandsProvider{s}
+
+	s.service.Handle("sites.$siteId.devices.$deviceId",
+		commandPermissions.siteAccess(),
+		res.Call("command", provider.SendCommand),
+		res.Call("commands", provider.GetCommands),
+	)
+}
+
+type commandsProvider struct{ server *Server }
+
+// SendCommand envoie une commande à l'appareil. Elle est retournée en attente : son acquittement est suivi par
+// l'appel "commands".
+func (p *commandsProvider) SendCommand(request res.CallRequest) {
+	var params struct {
+		Command         string `json:"command"`
+		IntervalSeconds *int   `json:"intervalSeconds,omitempty"`
+	}
+	request.ParseParams(&params)
+
+	var t token
+	request.ParseToken(&t)
+
+	command, err := p.server.store.Commands.SendCommand(&sensormanager.SendCommandParams{
+		SiteID:          request.PathParam("siteId"),
+		DeviceID:        request.PathParam("deviceId"),
+		Kind:            sensormanager.CommandKind(params.Command),
+		IntervalSeconds: params.IntervalSeconds,
+		RequestedBy:     fmt.Sprintf("user:%d", t.UserID),
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(commandToMap(command))
+}
+
+func (p *commandsProvider) GetCommands(request res.CallRequest) {
+	var params struct {
+		Limit int `json:"limit,omitempty"`
+	}
+	request.ParseParams(&params)
+
+	if params.Limit == 0 {
+		params.Limit = 20
+	}
+
+	commands, err := p.server.store.Commands.GetCommands(request.PathParam("siteId"), request.PathParam("deviceId"), params.Limit)
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(commands))
+	for i, command := range commands {
+		result[i] = commandToMap(command)
+	}
+
+	request.OK(result)
+}
+
+// beepOnAlert fait biper l'appareil à l'origine d'une alerte, quand son adresse est connue.
+func (s *Server) beepOnAlert(alertType sensormanager.AlertType, alert *sensormanager.AlertResponse) {
+	_, err := s.store.Commands.SendCommand(&sensormanager.SendCommandParams{
+		SiteID:      alert.SiteID,
+		DeviceID:    alert.DeviceID,
+		Kind:        sensormanager.CommandBeep,
+		RequestedBy: fmt.Sprintf("alert:%s:%d", alertType, alert.AlertID),
+	})
+	if err != nil && err != sensormanager.ErrDeviceUnreachable {
+		fmt.Printf("❌ Could not beep device %s for %s alert %d: %v\n", alert.DeviceID, alertType, alert.AlertID, err)
+	}
+}
+
+func commandToMap(c *sensormanager.DeviceCommand) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":        c.ID,
+		"deviceId":  c.DeviceID,
+		"command":   string(c.Kind),
+		"address":   c.Address,
+		"status":    string(c.Status),
+		"createdAt": c.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if c.IntervalSeconds != nil {
+		result["intervalSeconds"] = *c.IntervalSeconds
+	}
+	if c.Attempts != 0 {
+		result["attempts"] = c.Attempts
+	}
+	if c.ResponseCode != "" {
+		result["responseCode"] = c.ResponseCode
+	}
+	if c.Error != "" {
+		result["error"] = c.Error
+	}
+	if c.RequestedBy != "" {
+		result["requestedBy"] = c.RequestedBy
+	}
+	if c.CompletedAt != nil {
+		result["completedAt"] = c.CompletedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	return result
+}
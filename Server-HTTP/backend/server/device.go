@@ -0,0 +1,296 @@
+package server
+
+import (
+	"fmt"
+	"sensormanager"
+
+	"github.com/jirenius/go-res"
+)
+
+func (s *Server) addDevicesHandler() {
+	provider := &devicesProvider{s}
+
+	s.service.Handle("sites.$siteId.devices",
+		devicePermissions.siteAccess(),
+		res.Call("get", provider.GetDevices),
+		res.Call("setLocation", provider.SetLocation),
+		res.Call("provision", provider.Provision),
+		res.Call("rotateSecret", provider.RotateSecret),
+		res.Call("revoke", provider.Revoke),
+	)
+
+	// Appareils inconnus en attente d'approbation
+	s.service.Handle("sites.$siteId.devices.pending",
+		devicePermissions.siteAccess(),
+		res.Call("get", provider.GetPendingDevices),
+		res.Call("approve", provider.Approve),
+		res.Call("reject", provider.Reject),
+	)
+}
+
+// errUnregisteredDevice rejects the readings of a device that is not registered, in strict mode.
+var errUnregisteredDevice = &res.Error{Code: "sensormanager.unregisteredDevice", Message: "Device is not registered"}
+
+// recordError returns the RES error of a reading that could not be recorded.
+func recordError(err error) error {
+	if err == sensormanager.ErrUnregisteredDevice {
+		return errUnregisteredDevice
+	}
+
+	return err
+}
+
+type devicesProvider struct{ server *Server }
+
+func (p *devicesProvider) GetDevices(request res.CallRequest) {
+	var params struct {
+		DeviceID string `json:"deviceId,omitempty"`
+	}
+	request.ParseParams(&params)
+
+	if params.DeviceID != "" {
+		device, err := p.server.store.Devices.GetDevice(request.PathParam("siteId"), params.DeviceID)
+		if err != nil {
+			request.Error(err)
+			return
+		}
+
+		request.OK(deviceToMap(device))
+		return
+	}
+
+	devices, err := p.server.store.Devices.GetDevices(request.PathParam("siteId"))
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(devices))
+	for i, d := range devices {
+		result[i] = deviceToMap(d)
+	}
+
+	request.OK(result)
+}
+
+// SetLocation range l'appareil dans une pièce : ses alertes sont alors groupées avec celles de la pièce.
+func (p *devicesProvider) SetLocation(request res.CallRequest) {
+	var params struct {
+		DeviceID string `json:"deviceId"`
+		Location string `json:"location"`
+	}
+	request.ParseParams(&params)
+
+	if err := p.server.store.Devices.SetDeviceLocation(request.PathParam("siteId"), params.DeviceID, params.Location); err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(map[string]interface{}{
+		"success": true,
+		"message": "Device location updated",
+	})
+}
+
+// Provision émet le secret d'un appareil : il n'est retourné qu'une seule fois.
+func (p *devicesProvider) Provision(request res.CallRequest) {
+	var params struct {
+		DeviceID   string `json:"deviceId"`
+		SensorType string `json:"sensorType"`
+	}
+	request.ParseParams(&params)
+
+	device, secret, err := p.server.store.Devices.ProvisionDevice(&sensormanager.ProvisionDeviceParams{
+		SiteID:     request.PathParam("siteId"),
+		DeviceID:   params.DeviceID,
+		SensorType: params.SensorType,
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := deviceToMap(device)
+	result["secret"] = secret
+
+	request.OK(result)
+}
+
+func (p *devicesProvider) RotateSecret(request res.CallRequest) {
+	var params struct {
+		DeviceID string `json:"deviceId"`
+	}
+	request.ParseParams(&params)
+
+	secret, err := p.server.store.Devices.RotateDeviceSecret(request.PathParam("siteId"), params.DeviceID)
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(map[string]interface{}{
+		"deviceId": params.DeviceID,
+		"secret":   secret,
+	})
+}
+
+func (p *devicesProvider) Revoke(request res.CallRequest) {
+	var params struct {
+		DeviceID string `json:"deviceId"`
+	}
+	request.ParseParams(&params)
+
+	if err := p.server.store.Devices.RevokeDevice(request.PathParam("siteId"), params.DeviceID); err != nil {
+		request.Error(err)
+		return
+	}
+
+	fmt.Printf("🔒 Device %s revoked\n", params.DeviceID)
+
+	request.OK(map[string]interface{}{
+		"success": true,
+		"message": "Device revoked",
+	})
+}
+
+func (p *devicesProvider) GetPendingDevices(request res.CallRequest) {
+	devices, err := p.server.store.Devices.GetPendingDevices(request.PathParam("siteId"))
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(devices))
+	for i, d := range devices {
+		item := deviceToMap(d.Device)
+		item["quarantinedReadings"] = d.QuarantinedReadings
+		if d.LastReadingAt != nil {
+			item["lastReadingAt"] = d.LastReadingAt.Format("2006-01-02T15:04:05Z")
+		}
+
+		result[i] = item
+	}
+
+	request.OK(result)
+}
+
+// Approve active un appareil en attente : ses mesures en quarantaine rejoignent l'historique.
+func (p *devicesProvider) Approve(request res.CallRequest) {
+	var params struct {
+		DeviceID string `json:"deviceId"`
+	}
+	request.ParseParams(&params)
+
+	siteID := request.PathParam("siteId")
+
+	device, err := p.server.store.Devices.ApproveDevice(siteID, params.DeviceID)
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	p.server.pendingDeviceChanged(siteID, device.DeviceID, device.Status)
+
+	request.OK(deviceToMap(device))
+}
+
+// Reject refuse un appareil inconnu (ex: identifiant mal saisi) : ses mesures en quarantaine sont supprimées.
+func (p *devicesProvider) Reject(request res.CallRequest) {
+	var params struct {
+		DeviceID string `json:"deviceId"`
+	}
+	request.ParseParams(&params)
+
+	siteID := request.PathParam("siteId")
+
+	if err := p.server.store.Devices.RejectDevice(siteID, params.DeviceID); err != nil {
+		request.Error(err)
+		return
+	}
+
+	p.server.pendingDeviceChanged(siteID, params.DeviceID, sensormanager.DeviceStatusRejected)
+
+	request.OK(map[string]interface{}{
+		"success": true,
+		"message": "Device rejected",
+	})
+}
+
+// pendingDeviceChanged prévient les clients qu'un appareil a quitté la liste des appareils en attente.
+func (s *Server) pendingDeviceChanged(siteID, deviceID string, status sensormanager.DeviceStatus) {
+	s.service.With("sites."+siteID+".devices.pending", func(r res.Resource) {
+		r.Event("removed", map[string]interface{}{
+			"deviceId": deviceID,
+			"status":   string(status),
+		})
+	})
+}
+
+func deviceToMap(d *sensormanager.Device) map[string]interface{} {
+	result := map[string]interface{}{
+		"siteId":      d.SiteID,
+		"deviceId":    d.DeviceID,
+		"sensorType":  string(d.SensorType),
+		"status":      string(d.Status),
+		"provisioned": d.Provisioned,
+		"health":      string(d.Health),
+		"createdAt":   d.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if d.SecretRotatedAt != nil {
+		result["secretRotatedAt"] = d.SecretRotatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if d.Location != "" {
+		result["location"] = d.Location
+	}
+	if d.HealthReason != "" {
+		result["healthReason"] = d.HealthReason
+	}
+	if d.HealthUpdatedAt != nil {
+		result["healthUpdatedAt"] = d.HealthUpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if d.Address != "" {
+		result["address"] = d.Address
+	}
+	if d.AddressUpdatedAt != nil {
+		result["addressUpdatedAt"] = d.AddressUpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if d.LastSeenAt != nil {
+		result["lastSeenAt"] = d.LastSeenAt.Format("2006-01-02T15:04:05Z")
+	}
+	if d.OfflineSince != nil {
+		result["offlineSince"] = d.OfflineSince.Format("2006-01-02T15:04:05Z")
+	}
+	if d.Telemetry != nil {
+		result["telemetry"] = telemetryToMap(d.Telemetry)
+	}
+
+	return result
+}
+
+// notifySensorFault prévient les utilisateurs du site d'un défaut capteur, séparément des alertes de sécurité.
+func (s *Server) notifySensorFault(siteID string, fault *sensormanager.SensorFaultAlert) {
+	values := sensormanager.NewNotificationValues(sensormanager.AlertTypeSensorFault, fault.SensorType, fault.DeviceID, fault.CreatedAt)
+	values.Value = fault.Value
+	values.Reason = string(fault.FaultType)
+	values.Message = fault.Details
+
+	notifParams := &sensormanager.NotificationParams{
+		Title: "🔧 Sensor fault",
+		Body:  fmt.Sprintf("Sensor %s looks faulty (%s): %s", fault.DeviceID, fault.FaultType, fault.Details),
+		Data: map[string]interface{}{
+			"type":       "sensor_fault",
+			"siteId":     siteID,
+			"alertId":    fault.ID,
+			"deviceId":   fault.DeviceID,
+			"sensorType": string(fault.SensorType),
+			"faultType":  string(fault.FaultType),
+			"value":      fault.Value,
+		},
+		Kind:   sensormanager.NotificationKindSensorFault,
+		Values: values,
+	}
+	s.withActionToken(notifParams.Data, siteID, sensormanager.AlertTypeSensorFault, fault.ID)
+
+	s.store.Notifications.SendNotificationToSite(siteID, notifParams)
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"sensormanager"
+
+	"github.com/jirenius/go-res"
+)
+
+func (s *Server) addEscalationsHandler() {
+	provider := &escalationsProvider{s}
+
+	s.service.Handle("sites.$siteId.escalations",
+		escalationPermissions.siteAccess(),
+		res.Call("get", provider.GetPolicies),
+		res.Call("create", provider.CreatePolicy),
+		res.Call("delete", provider.DeletePolicy),
+		res.Call("history", provider.GetHistory),
+	)
+}
+
+type escalationsProvider struct{ server *Server }
+
+func (p *escalationsProvider) GetPolicies(request res.CallRequest) {
+	policies, err := p.server.store.Escalations.GetEscalationPolicies(request.PathParam("siteId"))
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(policies))
+	for i, policy := range policies {
+		result[i] = escalationPolicyToMap(policy)
+	}
+
+	request.OK(result)
+}
+
+func (p *escalationsProvider) CreatePolicy(request res.CallRequest) {
+	var params struct {
+		Name      string `json:"name"`
+		AlertType string `json:"alertType,omitempty"`
+		Steps     []struct {
+			DelayMinutes       int      `json:"delayMinutes"`
+			Target             string   `json:"target"`
+			PushTokens         []string `json:"pushTokens,omitempty"`
+			RepeatEveryMinutes int      `json:"repeatEveryMinutes,omitempty"`
+			RepeatLimit        int      `json:"repeatLimit,omitempty"`
+		} `json:"steps"`
+	}
+	request.ParseParams(&params)
+
+	policyParams := &sensormanager.EscalationPolicyParams{
+		SiteID:    request.PathParam("siteId"),
+		Name:      params.Name,
+		AlertType: params.AlertType,
+		Steps:     make([]*sensormanager.EscalationStepParams, len(params.Steps)),
+	}
+	for i, step := range params.Steps {
+		policyParams.Steps[i] = &sensormanager.EscalationStepParams{
+			DelayMinutes:       step.DelayMinutes,
+			Target:             step.Target,
+			PushTokens:         step.PushTokens,
+			RepeatEveryMinutes: step.RepeatEveryMinutes,
+			RepeatLimit:        step.RepeatLimit,
+		}
+	}
+
+	policy, err := p.server.store.Escalations.CreateEscalationPolicy(policyParams)
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(escalationPolicyToMap(policy))
+}
+
+func (p *escalationsProvider) DeletePolicy(request res.CallRequest) {
+	var params struct {
+		PolicyID int64 `json:"policyId"`
+	}
+	request.ParseParams(&params)
+
+	if err := p.server.store.Escalations.DeleteEscalationPolicy(request.PathParam("siteId"), params.PolicyID); err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(map[string]interface{}{
+		"success": true,
+		"message": "Escalation policy deleted",
+	})
+}
+
+func (p *escalationsProvider) GetHistory(request res.CallRequest) {
+	var params struct {
+		AlertType string `json:"alertType"`
+		AlertID   int64  `json:"alertId"`
+	}
+	request.ParseParams(&params)
+
+	if err := sensormanager.AlertType(params.AlertType).Validate(); err != nil {
+		request.InvalidParams(err.Error())
+		return
+	}
+
+	records, err := p.server.store.Escalations.GetEscalationRecords(
+		request.PathParam("siteId"), sensormanager.AlertType(params.AlertType), params.AlertID,
+	)
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(records))
+	for i, r := range records {
+		item := map[string]interface{}{
+			"id":         r.ID,
+			"alertType":  string(r.AlertType),
+			"alertId":    r.AlertID,
+			"policyId":   r.PolicyID,
+			"stepOrder":  r.StepOrder,
+			"repetition": r.Repetition,
+			"recipients": r.Recipients,
+			"success":    r.Success,
+			"executedAt": r.ExecutedAt.Format("2006-01-02T15:04:05Z"),
+		}
+
+		if r.ErrorMessage != "" {
+			item["errorMessage"] = r.ErrorMessage
+		}
+
+		result[i] = item
+	}
+
+	request.OK(result)
+}
+
+func escalationPolicyToMap(policy *sensormanager.EscalationPolicy) map[string]interface{} {
+	steps := make([]map[string]interface{}, len(policy.Steps))
+	for i, step := range policy.Steps {
+		steps[i] = map[string]interface{}{
+			"id":                 step.ID,
+			"stepOrder":          step.StepOrder,
+			"delayMinutes":       step.DelayMinutes,
+			"target":             string(step.Target),
+			"pushTokens":         step.PushTokens,
+			"repeatEveryMinutes": step.RepeatEveryMinutes,
+			"repeatLimit":        step.RepeatLimit,
+		}
+	}
+
+	return map[string]interface{}{
+		"id":        policy.ID,
+		"siteId":    policy.SiteID,
+		"name":      policy.Name,
+		"alertType": string(policy.AlertType),
+		"isActive":  policy.IsActive,
+		"steps":     steps,
+		"createdAt": policy.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -0,0 +1,230 @@
+package server
+
+import (
+	"fmt"
+	"sensormanager"
+
+	"github.com/jirenius/go-res"
+)
+
+func (s *Server) addFirmwareHandlers() {
+	provider := &firmwareProvider{s}
+
+	// Registre des binaires, commun à tous les sites
+	s.service.Handle("firmware",
+		adminPermissions.access(),
+		res.Call("get", provider.GetFirmwares),
+		res.Call("upload", provider.Upload),
+	)
+
+	s.service.Handle("sites.$siteId.firmware.rollouts",
+		firmwarePermissions.siteAccess(),
+		res.Call("get", provider.GetRollouts),
+		res.Call("create", provider.CreateRollout),
+		res.Call("deployments", provider.GetDeployments),
+		res.Call("setStatus", provider.SetStatus),
+	)
+}
+
+type firmwareProvider struct{ server *Server }
+
+func (p *firmwareProvider) GetFirmwares(request res.CallRequest) {
+	firmwares, err := p.server.store.Firmware.GetFirmwares()
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(firmwares))
+	for i, f := range firmwares {
+		result[i] = firmwareToMap(f)
+	}
+
+	request.OK(result)
+}
+
+// Upload ajoute un binaire au registre. Le binaire est encodé en base64 et son SHA256 doit correspondre.
+func (p *firmwareProvider) Upload(request res.CallRequest) {
+	var params struct {
+		Version string `json:"version"`
+		SHA256  string `json:"sha256"`
+		Binary  []byte `json:"binary"`
+		Notes   string `json:"notes,omitempty"`
+	}
+	request.ParseParams(&params)
+
+	var t token
+	request.ParseToken(&t)
+
+	firmware, err := p.server.store.Firmware.UploadFirmware(&sensormanager.UploadFirmwareParams{
+		Version:    params.Version,
+		SHA256:     params.SHA256,
+		Binary:     params.Binary,
+		Notes:      params.Notes,
+		UploadedBy: fmt.Sprintf("user:%d", t.UserID),
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(firmwareToMap(firmware))
+}
+
+func (p *firmwareProvider) GetRollouts(request res.CallRequest) {
+	rollouts, err := p.server.store.Firmware.GetRollouts(request.PathParam("siteId"))
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(rollouts))
+	for i, rollout := range rollouts {
+		result[i] = rolloutToMap(rollout)
+	}
+
+	request.OK(result)
+}
+
+// CreateRollout déploie un firmware sur des appareils ou sur un emplacement, par étapes, ex: "stages": [10, 50, 100].
+func (p *firmwareProvider) CreateRollout(request res.CallRequest) {
+	var params struct {
+		FirmwareID int64    `json:"firmwareId"`
+		DeviceIDs  []string `json:"deviceIds,omitempty"`
+		Location   string   `json:"location,omitempty"`
+		Stages     []int    `json:"stages,omitempty"`
+	}
+	request.ParseParams(&params)
+
+	var t token
+	request.ParseToken(&t)
+
+	rollout, err := p.server.store.Firmware.CreateRollout(&sensormanager.CreateRolloutParams{
+		SiteID:     request.PathParam("siteId"),
+		FirmwareID: params.FirmwareID,
+		DeviceIDs:  params.DeviceIDs,
+		Location:   params.Location,
+		Stages:     params.Stages,
+		CreatedBy:  fmt.Sprintf("user:%d", t.UserID),
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(rolloutToMap(rollout))
+}
+
+func (p *firmwareProvider) GetDeployments(request res.CallRequest) {
+	var params struct {
+		RolloutID int64 `json:"rolloutId"`
+	}
+	request.ParseParams(&params)
+
+	deployments, err := p.server.store.Firmware.GetDeployments(request.PathParam("siteId"), params.RolloutID)
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(deployments))
+	for i, d := range deployments {
+		item := map[string]interface{}{
+			"deviceId": d.DeviceID,
+			"stage":    d.Stage + 1,
+			"status":   string(d.Status),
+		}
+
+		if d.PreviousVersion != "" {
+			item["previousVersion"] = d.PreviousVersion
+		}
+		if d.OfferedAt != nil {
+			item["offeredAt"] = d.OfferedAt.Format("2006-01-02T15:04:05Z")
+		}
+		if d.DownloadedAt != nil {
+			item["downloadedAt"] = d.DownloadedAt.Format("2006-01-02T15:04:05Z")
+		}
+		if d.CompletedAt != nil {
+			item["completedAt"] = d.CompletedAt.Format("2006-01-02T15:04:05Z")
+		}
+		if d.Error != "" {
+			item["error"] = d.Error
+		}
+
+		result[i] = item
+	}
+
+	request.OK(result)
+}
+
+// SetStatus met en pause ("paused"), reprend ("active") ou abandonne ("cancelled") un déploiement.
+func (p *firmwareProvider) SetStatus(request res.CallRequest) {
+	var params struct {
+		RolloutID int64  `json:"rolloutId"`
+		Status    string `json:"status"`
+	}
+	request.ParseParams(&params)
+
+	rollout, err := p.server.store.Firmware.SetRolloutStatus(
+		request.PathParam("siteId"), params.RolloutID, sensormanager.RolloutStatus(params.Status),
+	)
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	fmt.Printf("🚀 Rollout %d is now %s\n", rollout.ID, rollout.Status)
+
+	request.OK(rolloutToMap(rollout))
+}
+
+func firmwareToMap(f *sensormanager.Firmware) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":        f.ID,
+		"version":   f.Version,
+		"sha256":    f.SHA256,
+		"md5":       f.MD5,
+		"size":      f.Size,
+		"createdAt": f.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if f.Notes != "" {
+		result["notes"] = f.Notes
+	}
+	if f.UploadedBy != "" {
+		result["uploadedBy"] = f.UploadedBy
+	}
+
+	return result
+}
+
+func rolloutToMap(rollout *sensormanager.FirmwareRollout) map[string]interface{} {
+	deployments := make(map[string]interface{}, len(rollout.Deployments))
+	for status, count := range rollout.Deployments {
+		deployments[string(status)] = count
+	}
+
+	result := map[string]interface{}{
+		"id":           rollout.ID,
+		"firmwareId":   rollout.Firmware.ID,
+		"version":      rollout.Firmware.Version,
+		"stages":       rollout.Stages,
+		"currentStage": rollout.CurrentStage + 1,
+		"status":       string(rollout.Status),
+		"deployments":  deployments,
+		"createdAt":    rollout.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		"updatedAt":    rollout.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if rollout.Location != "" {
+		result["location"] = rollout.Location
+	}
+	if rollout.HaltReason != "" {
+		result["haltReason"] = rollout.HaltReason
+	}
+	if rollout.CreatedBy != "" {
+		result["createdBy"] = rollout.CreatedBy
+	}
+
+	return result
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"sensormanager"
+
+	"github.com/jirenius/go-res"
+)
+
+func (s *Server) addIncidentsHandler() {
+	provider := &incidentsProvider{s}
+
+	s.service.Handle("sites.$siteId.incidents",
+		incidentPermissions.siteAccess(),
+		res.Call("get", provider.GetIncidents),
+		res.Call("update", provider.UpdateStatus),
+	)
+}
+
+type incidentsProvider struct{ server *Server }
+
+func (p *incidentsProvider) GetIncidents(request res.CallRequest) {
+	var params struct {
+		IncidentID int64  `json:"incidentId,omitempty"`
+		Status     string `json:"status,omitempty"`
+		Limit      int    `json:"limit,omitempty"`
+	}
+	request.ParseParams(&params)
+
+	if params.IncidentID != 0 {
+		incident, err := p.server.store.Incidents.GetIncident(request.PathParam("siteId"), params.IncidentID)
+		if err != nil {
+			request.Error(err)
+			return
+		}
+
+		request.OK(incidentToMap(incident))
+		return
+	}
+
+	if params.Limit == 0 {
+		params.Limit = 50
+	}
+
+	incidents, err := p.server.store.Incidents.GetIncidents(&sensormanager.GetIncidentsParams{
+		SiteID: request.PathParam("siteId"),
+		Status: sensormanager.IncidentStatus(params.Status),
+		Limit:  params.Limit,
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(incidents))
+	for i, incident := range incidents {
+		result[i] = incidentToMap(incident)
+	}
+
+	request.OK(result)
+}
+
+func (p *incidentsProvider) UpdateStatus(request res.CallRequest) {
+	var params struct {
+		IncidentID int64  `json:"incidentId"`
+		Status     string `json:"status"`
+	}
+	request.ParseParams(&params)
+
+	updateParams := &sensormanager.UpdateIncidentStatusParams{
+		SiteID:     request.PathParam("siteId"),
+		IncidentID: params.IncidentID,
+		Status:     sensormanager.IncidentStatus(params.Status),
+	}
+
+	if err := p.server.store.Incidents.UpdateIncidentStatus(updateParams); err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(map[string]interface{}{
+		"success": true,
+		"message": "Incident status updated",
+	})
+}
+
+func incidentToMap(incident *sensormanager.Incident) map[string]interface{} {
+	alerts := make([]map[string]interface{}, len(incident.Alerts))
+	for i, alert := range incident.Alerts {
+		alerts[i] = map[string]interface{}{
+			"type":      string(alert.AlertType),
+			"alertId":   alert.AlertID,
+			"deviceId":  alert.DeviceID,
+			"message":   alert.Message,
+			"createdAt": alert.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+	}
+
+	result := map[string]interface{}{
+		"id":          incident.ID,
+		"siteId":      incident.SiteID,
+		"groupKey":    incident.GroupKey,
+		"status":      string(incident.Status),
+		"alertCount":  incident.AlertCount,
+		"alerts":      alerts,
+		"openedAt":    incident.OpenedAt.Format("2006-01-02T15:04:05Z"),
+		"lastAlertAt": incident.LastAlertAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if incident.AcknowledgedAt != nil {
+		result["acknowledgedAt"] = incident.AcknowledgedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if incident.ClosedAt != nil {
+		result["closedAt"] = incident.ClosedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	return result
+}
+
+// notifyAlert notifie une alerte déjà rattachée à son incident par le store, et seulement à l'ouverture de celui-ci :
+// les alertes suivantes du même groupe mettent seulement l'incident à jour.
+func (s *Server) notifyAlert(alertType sensormanager.AlertType, alert *sensormanager.AlertResponse) {
+	data := map[string]interface{}{
+		"type":     string(alertType),
+		"siteId":   alert.SiteID,
+		"alertId":  alert.AlertID,
+		"deviceId": alert.DeviceID,
+		"value":    alert.Value,
+	}
+	if alert.Event != "" {
+		data["reason"] = alert.Event
+	}
+
+	// Sans incident, mieux vaut une notification en double qu'une alerte silencieuse.
+	if incident := alert.Incident; incident != nil {
+		data["incidentId"] = incident.ID
+
+		if !alert.IncidentOpened {
+			s.service.With("sites."+alert.SiteID+".incidents", func(r res.Resource) {
+				r.Event("updated", incidentToMap(incident))
+			})
+			return
+		}
+	}
+
+	// 🔓 Alarme désarmée : les mouvements des occupants ne sont pas des intrusions, l'incident reste à jour sans push
+	if alertType == sensormanager.AlertTypeMotion {
+		if site, err := s.store.Sites.GetSite(alert.SiteID); err == nil && !site.Armed {
+			return
+		}
+	}
+
+	// 🔊 Bip local, sauf pour les alertes de santé de l'appareil
+	if s.alertBeep && alertType != sensormanager.AlertTypeTelemetry {
+		go s.beepOnAlert(alertType, alert)
+	}
+
+	s.withActionToken(data, alert.SiteID, alertType, alert.AlertID)
+
+	// Titre et corps rendus par le modèle du type d'alerte, le message de l'alerte n'étant qu'un repli
+	s.store.Notifications.SendNotificationToSite(alert.SiteID, &sensormanager.NotificationParams{
+		Title:  "⚠️ " + string(alertType) + " alert",
+		Body:   alert.Message,
+		Data:   data,
+		Kind:   sensormanager.AlertNotificationKind(alertType),
+		Values: sensormanager.NewAlertNotificationValues(alertType, alert),
+	})
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"sensormanager"
+	"sensormanager/server/models"
+	"time"
+
+	"github.com/jirenius/go-res"
+)
+
+// ReadingQueue is the ingestion queue of the record calls: the readings are appended to it, then recorded with Ingest
+// by its consumer.
+type ReadingQueue interface {
+	Append(reading *sensormanager.ReadingParams) error
+	Stats() (*sensormanager.IngestionStats, error)
+}
+
+func (s *Server) addIngestionHandler() {
+	s.service.Handle("ingestion",
+		adminPermissions.access(),
+		res.Call("get", s.getIngestionStats),
+	)
+}
+
+// getIngestionStats returns the backlog of the ingestion queue, the lag of its consumer included.
+func (s *Server) getIngestionStats(request res.CallRequest) {
+	if s.queue == nil {
+		request.OK(map[string]interface{}{"enabled": false})
+		return
+	}
+
+	stats, err := s.queue.Stats()
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := map[string]interface{}{
+		"enabled":     true,
+		"stream":      stats.Stream,
+		"consumer":    stats.Consumer,
+		"messages":    stats.Messages,
+		"bytes":       stats.Bytes,
+		"pending":     stats.Pending,
+		"ackPending":  stats.AckPending,
+		"redelivered": stats.Redelivered,
+		"lag":         stats.Lag,
+	}
+
+	if stats.OldestPendingAt != nil {
+		result["oldestPendingAt"] = stats.OldestPendingAt.Format("2006-01-02T15:04:05Z")
+		result["lagSeconds"] = int(time.Since(*stats.OldestPendingAt).Seconds())
+	}
+
+	request.OK(result)
+}
+
+// enqueue appends the reading of a record call to the ingestion queue and answers the call. It returns false when the
+// queue is not enabled, the reading being then recorded by the call.
+func (s *Server) enqueue(request res.CallRequest, reading *sensormanager.ReadingParams) bool {
+	if s.queue == nil {
+		return false
+	}
+
+	if err := s.appendReading(reading); err != nil {
+		request.Error(recordError(err))
+		return true
+	}
+
+	request.OK(queuedResponse(reading))
+
+	return true
+}
+
+// enqueueBatch appends the readings of a recordBatch call to the ingestion queue and answers the call. The readings
+// whose error is already set, e.g. with a value of the wrong type, and the invalid readings are rejected individually.
+func (s *Server) enqueueBatch(request res.CallRequest, readings []*sensormanager.ReadingParams, errs []error) {
+	if len(readings) == 0 {
+		request.Error(sensormanager.ErrEmptyBatch)
+		return
+	}
+	if len(readings) > sensormanager.MaxBatchSize {
+		request.Error(sensormanager.ErrBatchTooLarge)
+		return
+	}
+
+	response := &models.BatchResponseModel{Items: make([]*models.BatchItemModel, len(readings))}
+
+	for i, reading := range readings {
+		var err error
+		if errs != nil {
+			err = errs[i]
+		}
+		if err == nil {
+			err = s.appendReading(reading)
+		}
+
+		if err != nil {
+			response.Rejected++
+			response.Items[i] = &models.BatchItemModel{Accepted: false, Error: err.Error()}
+			continue
+		}
+
+		response.Accepted++
+		response.Items[i] = &models.BatchItemModel{Accepted: true, AlertResponseModel: queuedResponse(reading)}
+	}
+
+	request.OK(response)
+}
+
+// appendReading checks the reading before appending it to the queue. The signature and the device are checked when
+// the reading is recorded.
+func (s *Server) appendReading(reading *sensormanager.ReadingParams) error {
+	if err := reading.Sanitize(); err != nil {
+		return err
+	}
+
+	if len(reading.DedupKey(&reading.ReadingTime)) > sensormanager.MaxDedupKeyLength {
+		return sensormanager.ErrInvalidMessageID
+	}
+
+	return s.queue.Append(reading)
+}
+
+func queuedResponse(reading *sensormanager.ReadingParams) *models.AlertResponseModel {
+	recordedAt := time.Now()
+	if reading.RecordedAt != nil {
+		recordedAt = *reading.RecordedAt
+	}
+
+	return &models.AlertResponseModel{
+		Value:      reading.Value,
+		DeviceID:   reading.DeviceID,
+		RecordedAt: recordedAt.Format("2006-01-02T15:04:05Z"),
+		Queued:     true,
+	}
+}
+
+// Ingest records a reading received by a gateway of the backend rather than by a record call, e.g. the MQTT bridge, or
+// appended to the ingestion queue. The reading goes through the same path as the record calls: its alerts, sensor
+// faults and motion events are notified and published.
+func (s *Server) Ingest(params *sensormanager.ReadingParams) (*sensormanager.AlertResponse, error) {
+	definition, err := sensormanager.LookupSensorType(params.SensorType)
+	if err != nil {
+		return nil, err
+	}
+
+	alertResponse, err := s.store.Sensors.RecordReading(params)
+	if err != nil {
+		return nil, err
+	}
+
+	s.readingRecorded(params.SensorType, alertResponse)
+
+	// 🔁 Une retransmission a déjà été notifiée
+	if alertResponse.Duplicate {
+		return alertResponse, nil
+	}
+
+	// 📣 Publier l'événement de mouvement comme l'appel record
+	if params.SensorType == sensormanager.SensorTypeMotion && alertResponse.Event != "" {
+		s.service.With("sites."+params.SiteID+".sensor.motion", func(r res.Resource) {
+			publishMotionEvent(r, params.SensorType, alertResponse)
+		})
+	}
+
+	if alertResponse.Alert {
+		go s.notifyAlert(sensormanager.AlertType(definition.Name), alertResponse)
+	}
+
+	if alertResponse.SensorFault != nil {
+		go s.notifySensorFault(alertResponse.SiteID, alertResponse.SensorFault)
+	}
+
+	return alertResponse, nil
+}
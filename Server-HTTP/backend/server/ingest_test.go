@@ -0,0 +1,140 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sensormanager"
+	"sensormanager/ingestion"
+	"sensormanager/store"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jirenius/go-res"
+	natsserver "github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+// recordedObserver receives the readings recorded by the server.
+type recordedObserver struct {
+	recorded chan *sensormanager.AlertResponse
+}
+
+func (o *recordedObserver) ReadingRecorded(_ sensormanager.SensorType, reading *sensormanager.AlertResponse) {
+	o.recorded <- reading
+}
+
+func (o *recordedObserver) SiteArmedChanged(*sensormanager.Site) {}
+
+func newTestConn(t *testing.T) *nats.Conn {
+	natsServer, err := natsserver.NewServer(&natsserver.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go natsServer.Start()
+	t.Cleanup(natsServer.Shutdown)
+
+	if !natsServer.ReadyForConnections(5 * time.Second) {
+		t.Fatal("NATS server is not ready")
+	}
+
+	conn, err := nats.Connect(natsServer.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(conn.Close)
+
+	return conn
+}
+
+// TestIngestSignedReading checks that the signature of a microphone reading appended to the ingestion queue reaches the
+// store, which requires it.
+func TestIngestSignedReading(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	observer := &recordedObserver{recorded: make(chan *sensormanager.AlertResponse, 1)}
+	server := New(
+		WithService(res.NewService("sensormanager")),
+		WithStore(store.New(store.WithDB(db), store.WithDeviceSignatures(true, time.Minute))),
+		WithObserver(observer),
+	)
+
+	queue := ingestion.New(ingestion.WithConn(newTestConn(t)), ingestion.WithRetryDelay(50*time.Millisecond))
+	if err := queue.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(queue.Stop)
+
+	now := time.Now()
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(sensormanager.SignedMessage(sensormanager.SensorTypeMicrophone, "ESP_SIGNED", "30", nil, nil, now.Unix(), "n-1")))
+
+	// 🔐 Sans sa signature, la mesure d'un appareil provisionné serait refusée.
+	mock.ExpectQuery(regexp.QuoteMeta("FROM devices WHERE device_id = $1")).
+		WithArgs("ESP_SIGNED").
+		WillReturnRows(sqlmock.NewRows([]string{"site_id", "status", "secret"}).AddRow("home", "active", "secret"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT status FROM devices WHERE device_id = $1")).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("active"))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM reading_receipts")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO reading_receipts")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM device_nonces")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO device_nonces")).
+		WithArgs("ESP_SIGNED", "n-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO sensor_readings")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM alert_thresholds WHERE site_id = $1 AND sensor_type = $2")).
+		WillReturnRows(sqlmock.NewRows([]string{"rule", "threshold"}))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE reading_receipts SET response = $1")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := queue.Consume(server); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := queue.Append(&sensormanager.ReadingParams{
+		SiteID:     "home",
+		SensorType: sensormanager.SensorTypeMicrophone,
+		DeviceID:   "ESP_SIGNED",
+		Value:      30,
+		SignedPayload: sensormanager.SignedPayload{
+			Timestamp: now.Unix(),
+			Nonce:     "n-1",
+			Signature: hex.EncodeToString(mac.Sum(nil)),
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case reading := <-observer.recorded:
+		if reading.DeviceID != "ESP_SIGNED" || reading.Value != 30 {
+			t.Errorf("unexpected recorded reading: %+v", reading)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the signed reading was not recorded")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,29 +1,160 @@
 package models
 
-import "github.com/jirenius/go-res"
+import (
+	"encoding/json"
+	"fmt"
+	"sensormanager"
+	"strings"
+	"time"
 
-type MicrophoneParams struct {
-	DeviceID string  `json:"deviceID"`
-	Decibels float64 `json:"decibels"`
+	"github.com/jirenius/go-res"
+)
+
+// ReadingParams is a reading of a sensor type of the registry. Its value is a number, or a boolean for the boolean
+// sensor types, named after the value field of the sensor type.
+type ReadingParams struct {
+	DeviceID string `json:"deviceID"`
+	ReadingIDParams
+	ReadingTimeParams
+	SignedPayloadParams
+	ReadingSourceParams
+
+	fields map[string]json.RawMessage
+}
+
+type readingParams ReadingParams
+
+func (p *ReadingParams) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*readingParams)(p)); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, &p.fields)
+}
+
+// ToValue decodes the value of the field according to the kind of the sensor type. Like the other parameters, the
+// field name is matched case-insensitively.
+func (p ReadingParams) ToValue(field string, kind sensormanager.ValueKind) (float64, error) {
+	var raw json.RawMessage
+	for name, value := range p.fields {
+		if strings.EqualFold(name, field) {
+			raw = value
+			break
+		}
+	}
+
+	if kind == sensormanager.ValueKindBool {
+		var value bool
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return 0, fmt.Errorf("%s must be a boolean", field)
+		}
+		if value {
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	var value float64
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return 0, fmt.Errorf("%s must be a number", field)
+	}
+
+	return value, nil
 }
 
-type DistanceParams struct {
-	DeviceID   string  `json:"deviceID"`
-	DistanceCm float64 `json:"distanceCm"`
+// TelemetryParams is a diagnostic report of a device. The missing metrics keep their last known value.
+type TelemetryParams struct {
+	DeviceID        string `json:"deviceID"`
+	RSSI            *int   `json:"rssi,omitempty"`
+	UptimeSeconds   *int64 `json:"uptimeSeconds,omitempty"`
+	FreeHeap        *int64 `json:"freeHeap,omitempty"`
+	ResetReason     string `json:"resetReason,omitempty"`
+	IPAddress       string `json:"ipAddress,omitempty"`
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+	ReadingTimeParams
+	SignedPayloadParams
+	ReadingSourceParams
 }
 
-type MotionParams struct {
-	DeviceID       string `json:"deviceID"`
-	MotionDetected bool   `json:"motionDetected"`
+// ReadingIDParams optionally identify a reading, see sensormanager.ReadingID.
+type ReadingIDParams struct {
+	MessageID string `json:"messageId,omitempty"`
 }
 
+func (p ReadingIDParams) ToReadingID() sensormanager.ReadingID {
+	return sensormanager.ReadingID{MessageID: p.MessageID}
+}
+
+// ReadingTimeParams are the optional timing of a reading, see sensormanager.ReadingTime.
+type ReadingTimeParams struct {
+	RecordedAt *time.Time `json:"recordedAt,omitempty"`
+	Sequence   *int64     `json:"sequence,omitempty"`
+}
+
+func (p ReadingTimeParams) ToReadingTime() sensormanager.ReadingTime {
+	return sensormanager.ReadingTime{RecordedAt: p.RecordedAt, Sequence: p.Sequence}
+}
+
+// SignedPayloadParams are the optional fields authenticating a reading, see sensormanager.SignedMessage.
+type SignedPayloadParams struct {
+	Timestamp int64  `json:"timestamp,omitempty"`
+	Nonce     string `json:"nonce,omitempty"`
+	Signature string `json:"signature,omitempty"`
+}
+
+func (p SignedPayloadParams) ToSignedPayload() sensormanager.SignedPayload {
+	return sensormanager.SignedPayload{Timestamp: p.Timestamp, Nonce: p.Nonce, Signature: p.Signature}
+}
+
+// ShadowReportParams is the configuration applied by a device, see sensormanager.ReportShadowParams.
+type ShadowReportParams struct {
+	DeviceID string                       `json:"deviceID"`
+	Reported sensormanager.ShadowDocument `json:"reported"`
+	SignedPayloadParams
+	ReadingSourceParams
+}
+
+// ReadingSourceParams is the address of the device forwarded by the CoAP gateway, see sensormanager.ReadingSource.
+type ReadingSourceParams struct {
+	SourceAddress string `json:"sourceAddress,omitempty"`
+}
+
+func (p ReadingSourceParams) ToReadingSource() sensormanager.ReadingSource {
+	return sensormanager.ReadingSource{SourceAddress: p.SourceAddress}
+}
+
+// AlertResponseModel is the result of a recorded reading. A reading appended to the ingestion queue is only Queued:
+// its alerts are evaluated when it is recorded.
 type AlertResponseModel struct {
-	Alert      bool    `json:"alert"`
-	Message    string  `json:"message,omitempty"`
-	Value      float64 `json:"value"`
-	Threshold  float64 `json:"threshold,omitempty"`
-	DeviceID   string  `json:"deviceID"`
-	RecordedAt string  `json:"recordedAt"`
+	Alert       bool    `json:"alert"`
+	Message     string  `json:"message,omitempty"`
+	Value       float64 `json:"value"`
+	Threshold   float64 `json:"threshold,omitempty"`
+	DeviceID    string  `json:"deviceID"`
+	Event       string  `json:"event,omitempty"`
+	RecordedAt  string  `json:"recordedAt"`
+	Duplicate   bool    `json:"duplicate,omitempty"`
+	Quarantined bool    `json:"quarantined,omitempty"`
+	Queued      bool    `json:"queued,omitempty"`
+}
+
+type ReadingBatchParams struct {
+	Readings []ReadingParams `json:"readings"`
+}
+
+type BatchResponseModel struct {
+	Accepted int               `json:"accepted"`
+	Rejected int               `json:"rejected"`
+	Alerts   int               `json:"alerts"`
+	Items    []*BatchItemModel `json:"items"`
+}
+
+// BatchItemModel is the result of a reading of a batch, in the order of the batch. The alert fields are only set for
+// accepted readings.
+type BatchItemModel struct {
+	Accepted bool   `json:"accepted"`
+	Error    string `json:"error,omitempty"`
+	*AlertResponseModel
 }
 
 type SensorHistoryModel struct {
@@ -39,6 +170,7 @@ type SensorReading struct {
 type PushTokenParams struct {
 	PushToken string `json:"pushToken"`
 	Platform  string `json:"platform"`
+	Locale    string `json:"locale,omitempty"` // Étiquette de langue du téléphone, ex: "fr-FR"
 }
 
 type NotificationParams struct {
@@ -10,8 +10,8 @@ import (
 func (s *Server) addNotificationHandler() {
 	provider := &notificationProvider{s}
 
-	s.service.Handle("notifications",
-		res.Access(res.AccessGranted),
+	s.service.Handle("sites.$siteId.notifications",
+		notificationPermissions.siteAccess(),
 		res.Call("register", provider.RegisterToken),
 		res.Call("send", provider.SendNotification),
 	)
@@ -23,10 +23,19 @@ func (p *notificationProvider) RegisterToken(request res.CallRequest) {
 	var params models.PushTokenParams
 	request.ParseParams(&params)
 
+	// 🌐 Sans langue transmise par le téléphone, celle de l'utilisateur connecté
+	if params.Locale == "" {
+		var t token
+		request.ParseToken(&t)
+		params.Locale = string(t.Locale)
+	}
+
 	tokenParams := &sensormanager.PushTokenParams{
+		SiteID:     request.PathParam("siteId"),
 		Token:      params.PushToken,
 		Platform:   params.Platform,
 		DeviceInfo: map[string]interface{}{},
+		Locale:     params.Locale,
 	}
 
 	token, err := p.server.store.Notifications.RegisterPushToken(tokenParams)
@@ -51,7 +60,7 @@ func (p *notificationProvider) SendNotification(request res.CallRequest) {
 		Data:  params.Data,
 	}
 
-	err := p.server.store.Notifications.SendNotificationToAll(notifParams)
+	err := p.server.store.Notifications.SendNotificationToSite(request.PathParam("siteId"), notifParams)
 	if err != nil {
 		request.Error(err)
 		return
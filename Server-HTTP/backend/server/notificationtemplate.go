@@ -0,0 +1,125 @@
+package server
+
+import (
+	"sensormanager"
+
+	"github.com/jirenius/go-res"
+)
+
+func (s *Server) addNotificationTemplatesHandler() {
+	provider := &notificationTemplatesProvider{s}
+
+	s.service.Handle("notifications.templates",
+		adminPermissions.access(),
+		res.Call("get", provider.GetTemplates),
+		res.Call("set", provider.SetTemplate),
+		res.Call("reset", provider.ResetTemplate),
+	)
+}
+
+type notificationTemplatesProvider struct{ server *Server }
+
+// GetTemplates retourne le modèle utilisé pour chaque type de notification et chaque langue : le modèle enregistré,
+// à défaut le modèle par défaut ou celui du type parent.
+func (p *notificationTemplatesProvider) GetTemplates(request res.CallRequest) {
+	saved, err := p.server.store.NotificationTemplates.GetNotificationTemplates()
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := []map[string]interface{}{}
+	for _, kind := range sensormanager.NotificationKinds() {
+		for _, locale := range sensormanager.Locales {
+			template := sensormanager.ResolveNotificationTemplate(saved, kind, locale)
+			if template == nil {
+				continue
+			}
+
+			entry := notificationTemplateToMap(template)
+			entry["kind"] = string(kind)
+			if template.Kind != kind {
+				entry["inheritedFrom"] = string(template.Kind)
+			}
+
+			result = append(result, entry)
+		}
+	}
+
+	request.OK(result)
+}
+
+// SetTemplate enregistre le modèle après avoir vérifié qu'il se rend avec des valeurs d'exemple.
+func (p *notificationTemplatesProvider) SetTemplate(request res.CallRequest) {
+	var params struct {
+		Kind   string `json:"kind"`
+		Locale string `json:"locale"`
+		Title  string `json:"title"`
+		Body   string `json:"body"`
+	}
+	request.ParseParams(&params)
+
+	templateParams := &sensormanager.NotificationTemplateParams{
+		Kind:   sensormanager.NotificationKind(params.Kind),
+		Locale: sensormanager.Locale(params.Locale),
+		Title:  params.Title,
+		Body:   params.Body,
+	}
+	if err := templateParams.Sanitize(); err != nil {
+		request.InvalidParams(err.Error())
+		return
+	}
+
+	template, err := p.server.store.NotificationTemplates.SetNotificationTemplate(templateParams)
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(notificationTemplateToMap(template))
+}
+
+// ResetTemplate supprime le modèle enregistré : le modèle par défaut est de nouveau utilisé.
+func (p *notificationTemplatesProvider) ResetTemplate(request res.CallRequest) {
+	var params struct {
+		Kind   string `json:"kind"`
+		Locale string `json:"locale"`
+	}
+	request.ParseParams(&params)
+
+	kind, locale := sensormanager.NotificationKind(params.Kind), sensormanager.Locale(params.Locale)
+	if err := kind.Validate(); err != nil {
+		request.InvalidParams(err.Error())
+		return
+	}
+	if err := locale.Validate(); err != nil {
+		request.InvalidParams(err.Error())
+		return
+	}
+
+	if err := p.server.store.NotificationTemplates.ResetNotificationTemplate(kind, locale); err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(map[string]interface{}{
+		"success": true,
+		"message": "Template reset",
+	})
+}
+
+func notificationTemplateToMap(template *sensormanager.NotificationTemplate) map[string]interface{} {
+	result := map[string]interface{}{
+		"kind":   string(template.Kind),
+		"locale": string(template.Locale),
+		"title":  template.Title,
+		"body":   template.Body,
+		"custom": template.Custom,
+	}
+
+	if template.UpdatedAt != nil {
+		result["updatedAt"] = template.UpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	return result
+}
@@ -0,0 +1,375 @@
+package server
+
+import (
+	"sensormanager"
+	"sensormanager/server/models"
+	"strings"
+
+	"github.com/jirenius/go-res"
+)
+
+// addSensorTypesHandlers expose le registre des types de capteurs, ainsi que les mesures et les alertes de chaque type.
+func (s *Server) addSensorTypesHandlers() {
+	s.service.Handle("sensorTypes",
+		sensorTypePermissions.access(),
+		res.Call("get", getSensorTypes),
+	)
+
+	for _, definition := range sensormanager.SensorTypes() {
+		provider := &sensorProvider{server: s, definition: definition}
+
+		s.service.Handle("sites.$siteId.sensor."+string(definition.Name),
+			sensorPermissions.siteAccess(),
+			res.Call("record", provider.RecordData),
+			res.Call("recordBatch", provider.RecordBatch),
+			res.Call("history", provider.GetHistory),
+		)
+
+		s.service.Handle("sites.$siteId.alerts."+string(definition.Name),
+			alertPermissions.siteAccess(),
+			res.Call("get", provider.GetAlerts),
+			res.Call("updateStatus", provider.UpdateStatus),
+		)
+	}
+}
+
+func getSensorTypes(request res.CallRequest) {
+	definitions := sensormanager.SensorTypes()
+
+	result := make([]map[string]interface{}, len(definitions))
+	for i, definition := range definitions {
+		rules := make([]map[string]interface{}, len(definition.Rules))
+		for j, rule := range definition.Rules {
+			rules[j] = map[string]interface{}{
+				"name":            rule.Name,
+				"condition":       string(rule.Condition),
+				"threshold":       rule.Threshold,
+				"forSeconds":      int(rule.For.Seconds()),
+				"cooldownSeconds": int(rule.Cooldown.Seconds()),
+			}
+		}
+
+		item := map[string]interface{}{
+			"name":  string(definition.Name),
+			"label": definition.Label,
+			"unit":  definition.Unit,
+			"kind":  string(definition.Kind),
+			"field": definition.ValueField(),
+			"rules": rules,
+		}
+
+		if definition.Min != nil {
+			item["min"] = *definition.Min
+		}
+		if definition.Max != nil {
+			item["max"] = *definition.Max
+		}
+
+		result[i] = item
+	}
+
+	request.OK(result)
+}
+
+type sensorProvider struct {
+	server     *Server
+	definition *sensormanager.SensorTypeDefinition
+}
+
+func (p *sensorProvider) readingParams(request res.CallRequest, reading models.ReadingParams) (*sensormanager.ReadingParams, error) {
+	value, err := reading.ToValue(p.definition.ValueField(), p.definition.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sensormanager.ReadingParams{
+		SiteID:        request.PathParam("siteId"),
+		SensorType:    p.definition.Name,
+		DeviceID:      reading.DeviceID,
+		Value:         value,
+		ReadingID:     reading.ToReadingID(),
+		ReadingTime:   reading.ToReadingTime(),
+		SignedPayload: reading.ToSignedPayload(),
+		ReadingSource: reading.ToReadingSource(),
+	}, nil
+}
+
+func (p *sensorProvider) RecordData(request res.CallRequest) {
+	var params models.ReadingParams
+	request.ParseParams(&params)
+
+	sensorParams, err := p.readingParams(request, params)
+	if err != nil {
+		request.InvalidParams(err.Error())
+		return
+	}
+
+	// 📥 Avec la file d'ingestion, la mesure est enregistrée plus tard par son consommateur
+	if p.server.enqueue(request, sensorParams) {
+		return
+	}
+
+	alertResponse, err := p.server.store.Sensors.RecordReading(sensorParams)
+	if err != nil {
+		request.Error(recordError(err))
+		return
+	}
+
+	p.server.readingRecorded(p.definition.Name, alertResponse)
+
+	publishMotionEvent(request, p.definition.Name, alertResponse)
+
+	// 🔔 Envoyer une notification si alerte déclenchée (une retransmission a déjà été notifiée)
+	if alertResponse.Alert && !alertResponse.Duplicate {
+		// Envoi asynchrone pour ne pas bloquer la réponse
+		go p.server.notifyAlert(sensormanager.AlertType(p.definition.Name), alertResponse)
+	}
+
+	// 🔧 Défaut capteur détecté par cette mesure
+	if alertResponse.SensorFault != nil && !alertResponse.Duplicate {
+		go p.server.notifySensorFault(alertResponse.SiteID, alertResponse.SensorFault)
+	}
+
+	request.OK(&models.AlertResponseModel{
+		Alert:       alertResponse.Alert,
+		Message:     alertResponse.Message,
+		Value:       alertResponse.Value,
+		Threshold:   alertResponse.Threshold,
+		DeviceID:    alertResponse.DeviceID,
+		Event:       alertResponse.Event,
+		RecordedAt:  alertResponse.RecordedAt.Format("2006-01-02T15:04:05Z"),
+		Duplicate:   alertResponse.Duplicate,
+		Quarantined: alertResponse.Quarantined,
+	})
+}
+
+// RecordBatch enregistre plusieurs mesures horodatées en une seule requête. Les mesures dont la valeur n'est pas du
+// type attendu sont rejetées individuellement, comme les mesures invalides.
+func (p *sensorProvider) RecordBatch(request res.CallRequest) {
+	var params models.ReadingBatchParams
+	request.ParseParams(&params)
+
+	if len(params.Readings) == 0 {
+		request.Error(sensormanager.ErrEmptyBatch)
+		return
+	}
+
+	if p.server.queue != nil {
+		readings := make([]*sensormanager.ReadingParams, len(params.Readings))
+		errs := make([]error, len(params.Readings))
+		for i, reading := range params.Readings {
+			readings[i], errs[i] = p.readingParams(request, reading)
+		}
+
+		p.server.enqueueBatch(request, readings, errs)
+		return
+	}
+
+	results := make([]*sensormanager.RecordResult, len(params.Readings))
+	positions := make([]int, 0, len(params.Readings))
+	sensorParams := make([]*sensormanager.ReadingParams, 0, len(params.Readings))
+	for i, reading := range params.Readings {
+		readingParams, err := p.readingParams(request, reading)
+		if err != nil {
+			results[i] = &sensormanager.RecordResult{Err: err}
+			continue
+		}
+
+		positions = append(positions, i)
+		sensorParams = append(sensorParams, readingParams)
+	}
+
+	if len(sensorParams) > 0 {
+		recorded, err := p.server.store.Sensors.RecordReadingBatch(sensorParams)
+		if err != nil {
+			request.Error(err)
+			return
+		}
+
+		for i, result := range recorded {
+			results[positions[i]] = result
+		}
+	}
+
+	// Dans l'ordre du lot, comme si les mesures avaient été envoyées une à une
+	for _, result := range results {
+		if result.Err == nil {
+			publishMotionEvent(request, p.definition.Name, result.Response)
+		}
+	}
+
+	request.OK(p.server.recordBatchResponse(sensormanager.AlertType(p.definition.Name), results))
+}
+
+func (p *sensorProvider) GetHistory(request res.CallRequest) {
+	var params struct {
+		DeviceID string `json:"deviceId"`
+		Limit    int    `json:"limit"`
+	}
+	request.ParseParams(&params)
+
+	if params.Limit == 0 {
+		params.Limit = 20
+	}
+
+	data, err := p.server.store.Sensors.GetReadingHistory(request.PathParam("siteId"), p.definition.Name, params.DeviceID, params.Limit)
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	field := p.definition.ValueField()
+
+	result := make([]map[string]interface{}, len(data))
+	for i, d := range data {
+		result[i] = map[string]interface{}{
+			"id":         d.ID,
+			"deviceId":   d.DeviceID,
+			field:        p.readingValue(d.Value),
+			"unit":       p.definition.Unit,
+			"recordedAt": d.RecordedAt.Format("2006-01-02T15:04:05Z"),
+			"receivedAt": d.ReceivedAt.Format("2006-01-02T15:04:05Z"),
+		}
+
+		if d.Sequence != nil {
+			result[i]["sequence"] = *d.Sequence
+		}
+
+		// Valeur filtrée, ex: filteredDistanceCm
+		if d.FilteredValue != nil {
+			result[i]["filtered"+strings.ToUpper(field[:1])+field[1:]] = *d.FilteredValue
+		}
+	}
+
+	request.OK(result)
+}
+
+func (p *sensorProvider) GetAlerts(request res.CallRequest) {
+	var params struct {
+		DeviceID string `json:"deviceId"`
+		Status   string `json:"status,omitempty"`
+		Limit    int    `json:"limit,omitempty"`
+	}
+	request.ParseParams(&params)
+
+	if params.Limit == 0 {
+		params.Limit = 50
+	}
+
+	alerts, err := p.server.store.Sensors.GetSensorAlerts(p.definition.Name, &sensormanager.GetAlertsParams{
+		SiteID:   request.PathParam("siteId"),
+		DeviceID: params.DeviceID,
+		Status:   sensormanager.AlertStatus(params.Status),
+		Limit:    params.Limit,
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(alerts))
+	for i, a := range alerts {
+		item := map[string]interface{}{
+			"id":                      a.ID,
+			"deviceId":                a.DeviceID,
+			"rule":                    a.Rule,
+			p.definition.ValueField(): p.readingValue(a.Value),
+			"unit":                    p.definition.Unit,
+			"alertStatus":             string(a.AlertStatus),
+			"createdAt":               a.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+
+		if a.Threshold != nil {
+			item["threshold"] = *a.Threshold
+		}
+		if a.Reference != nil {
+			item["reference"] = *a.Reference
+		}
+		historicAlertFields(a, item)
+
+		if a.DataID != nil {
+			item["dataId"] = *a.DataID
+		}
+		if a.AcknowledgedAt != nil {
+			item["acknowledgedAt"] = a.AcknowledgedAt.Format("2006-01-02T15:04:05Z")
+		}
+		if a.ResolvedAt != nil {
+			item["resolvedAt"] = a.ResolvedAt.Format("2006-01-02T15:04:05Z")
+		}
+
+		result[i] = item
+	}
+
+	request.OK(result)
+}
+
+func (p *sensorProvider) UpdateStatus(request res.CallRequest) {
+	var params struct {
+		AlertID int64  `json:"alertId"`
+		Status  string `json:"status"`
+	}
+	request.ParseParams(&params)
+
+	err := p.server.store.Sensors.UpdateSensorAlertStatus(p.definition.Name, &sensormanager.UpdateAlertStatusParams{
+		SiteID:  request.PathParam("siteId"),
+		AlertID: params.AlertID,
+		Status:  sensormanager.AlertStatus(params.Status),
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(map[string]interface{}{
+		"success": true,
+		"message": "Alert status updated",
+	})
+}
+
+// readingValue retourne la valeur telle que l'appareil l'a envoyée : un booléen pour les types booléens.
+func (p *sensorProvider) readingValue(value float64) interface{} {
+	if p.definition.Kind == sensormanager.ValueKindBool {
+		return value == 1
+	}
+
+	return value
+}
+
+// historicAlertFields ajoute aux alertes de distance, de microphone et de mouvement les champs de leurs anciens appels,
+// que les applications affichent.
+func historicAlertFields(alert *sensormanager.SensorAlert, item map[string]interface{}) {
+	switch alert.SensorType {
+	case sensormanager.SensorTypeMicrophone:
+		if alert.Threshold != nil {
+			item["thresholdExceeded"] = *alert.Threshold
+		}
+	case sensormanager.SensorTypeDistance:
+		if alert.Reference != nil {
+			item["thresholdValue"] = *alert.Reference
+			item["thresholdType"] = "too_close"
+			if alert.Value > *alert.Reference {
+				item["thresholdType"] = "too_far"
+			}
+		}
+	case sensormanager.SensorTypeMotion:
+		item["alertReason"] = alert.Rule
+	}
+}
+
+// publishMotionEvent publie l'événement de mouvement (début, continu, fin) d'une mesure pour les autres services. Une
+// retransmission a déjà été publiée.
+func publishMotionEvent(r res.Resource, sensorType sensormanager.SensorType, response *sensormanager.AlertResponse) {
+	if sensorType != sensormanager.SensorTypeMotion || response.Event == "" || response.Duplicate {
+		return
+	}
+
+	r.Event(response.Event, motionEvent(response))
+}
+
+func motionEvent(response *sensormanager.AlertResponse) map[string]interface{} {
+	return map[string]interface{}{
+		"deviceId":   response.DeviceID,
+		"message":    response.Message,
+		"recordedAt": response.RecordedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
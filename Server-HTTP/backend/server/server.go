@@ -1,6 +1,7 @@
 package server
 
 import (
+	"sensormanager"
 	"sensormanager/store"
 
 	"github.com/jirenius/go-res"
@@ -9,6 +10,24 @@ import (
 type Server struct {
 	service *res.Service
 	store   *store.Store
+
+	// Les alertes font biper l'appareil qui les a levées
+	alertBeep bool
+
+	observers []Observer
+
+	// File d'ingestion des appels record, nil pour enregistrer les mesures pendant l'appel
+	queue ReadingQueue
+
+	// Envoi des pings de webhooks, nil quand les webhooks sont désactivés
+	webhooks WebhookDispatcher
+}
+
+// Observer is told of the recorded readings and of the arming of the sites, e.g. to mirror them in Home Assistant.
+// Its methods are called on the RES workers and must not block.
+type Observer interface {
+	ReadingRecorded(sensorType sensormanager.SensorType, reading *sensormanager.AlertResponse)
+	SiteArmedChanged(site *sensormanager.Site)
 }
 
 type Option func(*Server)
@@ -37,10 +56,47 @@ func WithService(service *res.Service) Option { return func(s *Server) { s.servi
 
 func WithStore(store *store.Store) Option { return func(s *Server) { s.store = store } }
 
+func WithAlertBeep(enabled bool) Option { return func(s *Server) { s.alertBeep = enabled } }
+
+// WithQueue appends the readings of the record calls to the queue instead of recording them during the call.
+func WithQueue(queue ReadingQueue) Option { return func(s *Server) { s.queue = queue } }
+
+// WithWebhooks sets the dispatcher of the test calls of the webhooks.
+func WithWebhooks(dispatcher WebhookDispatcher) Option {
+	return func(s *Server) { s.webhooks = dispatcher }
+}
+
+func WithObserver(observer Observer) Option {
+	return func(s *Server) { s.observers = append(s.observers, observer) }
+}
+
+// readingRecorded tells the observers of a new reading. Retransmissions and quarantined readings are not observed.
+func (s *Server) readingRecorded(sensorType sensormanager.SensorType, reading *sensormanager.AlertResponse) {
+	if reading.Duplicate || reading.Quarantined {
+		return
+	}
+
+	for _, observer := range s.observers {
+		observer.ReadingRecorded(sensorType, reading)
+	}
+}
+
 func (s *Server) addRESHandlers() {
-	s.addMicrophoneHandler()
-	s.addDistanceHandler()
-	s.addMotionHandler()
+	s.addAuthHandler()
+	s.addSitesHandler()
+	s.addSensorTypesHandlers()
+	s.addTelemetryHandler()
 	s.addAlertsHandlers()
+	s.addAlertActionsHandler()
 	s.addNotificationHandler()
+	s.addNotificationTemplatesHandler()
+	s.addDevicesHandler()
+	s.addCommandsHandler()
+	s.addShadowsHandler()
+	s.addFirmwareHandlers()
+	s.addEscalationsHandler()
+	s.addThresholdsHandler()
+	s.addIncidentsHandler()
+	s.addIngestionHandler()
+	s.addWebhooksHandler()
 }
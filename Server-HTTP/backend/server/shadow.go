@@ -0,0 +1,152 @@
+package server
+
+import (
+	"fmt"
+	"sensormanager"
+	"sensormanager/server/models"
+
+	"github.com/jirenius/go-res"
+)
+
+func (s *Server) addShadowsHandler() {
+	provider := &shadowsProvider{s}
+
+	s.service.Handle("sites.$siteId.shadows",
+		shadowPermissions.siteAccess(),
+		res.Call("get", provider.GetShadows),
+		res.Call("setDesired", provider.SetDesired),
+		res.Call("report", provider.Report),
+	)
+}
+
+type shadowsProvider struct{ server *Server }
+
+func (p *shadowsProvider) GetShadows(request res.CallRequest) {
+	var params struct {
+		DeviceID string `json:"deviceId,omitempty"`
+	}
+	request.ParseParams(&params)
+
+	if params.DeviceID != "" {
+		shadow, err := p.server.store.Shadows.GetShadow(request.PathParam("siteId"), params.DeviceID)
+		if err != nil {
+			request.Error(err)
+			return
+		}
+
+		request.OK(shadowToMap(shadow))
+		return
+	}
+
+	shadows, err := p.server.store.Shadows.GetShadows(request.PathParam("siteId"))
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(shadows))
+	for i, shadow := range shadows {
+		result[i] = shadowToMap(shadow)
+	}
+
+	request.OK(result)
+}
+
+// SetDesired modifie la configuration désirée de l'appareil, puis lui envoie le delta s'il est joignable. Sinon,
+// l'appareil le recevra en réponse à son prochain rapport.
+func (p *shadowsProvider) SetDesired(request res.CallRequest) {
+	var params struct {
+		DeviceID        string                       `json:"deviceId"`
+		Desired         sensormanager.ShadowDocument `json:"desired"`
+		ExpectedVersion *int64                       `json:"expectedVersion,omitempty"`
+	}
+	request.ParseParams(&params)
+
+	siteID := request.PathParam("siteId")
+
+	shadow, err := p.server.store.Shadows.UpdateDesiredShadow(&sensormanager.UpdateDesiredShadowParams{
+		SiteID:          siteID,
+		DeviceID:        params.DeviceID,
+		Desired:         params.Desired,
+		ExpectedVersion: params.ExpectedVersion,
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	p.server.shadowChanged(siteID, shadow)
+
+	if shadow.PendingSync() {
+		go p.server.pushShadowDelta(siteID, shadow)
+	}
+
+	request.OK(shadowToMap(shadow))
+}
+
+// Report enregistre la configuration appliquée par l'appareil et lui retourne le delta restant à appliquer.
+func (p *shadowsProvider) Report(request res.CallRequest) {
+	var params models.ShadowReportParams
+	request.ParseParams(&params)
+
+	siteID := request.PathParam("siteId")
+
+	shadow, err := p.server.store.Shadows.ReportShadow(&sensormanager.ReportShadowParams{
+		SiteID:        siteID,
+		DeviceID:      params.DeviceID,
+		Reported:      params.Reported,
+		SignedPayload: params.ToSignedPayload(),
+		ReadingSource: params.ToReadingSource(),
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	p.server.shadowChanged(siteID, shadow)
+
+	request.OK(map[string]interface{}{
+		"version": shadow.Version,
+		"delta":   shadow.Delta,
+	})
+}
+
+// shadowChanged prévient le tableau de bord, qui affiche les appareils en attente de synchronisation.
+func (s *Server) shadowChanged(siteID string, shadow *sensormanager.DeviceShadow) {
+	s.service.With("sites."+siteID+".shadows", func(r res.Resource) {
+		r.Event("changed", shadowToMap(shadow))
+	})
+}
+
+func (s *Server) pushShadowDelta(siteID string, shadow *sensormanager.DeviceShadow) {
+	_, err := s.store.Commands.SendCommand(&sensormanager.SendCommandParams{
+		SiteID:      siteID,
+		DeviceID:    shadow.DeviceID,
+		Kind:        sensormanager.CommandConfig,
+		Config:      shadow.Delta,
+		RequestedBy: fmt.Sprintf("shadow:%d", shadow.Version),
+	})
+	if err != nil && err != sensormanager.ErrDeviceUnreachable {
+		fmt.Printf("❌ Could not send shadow version %d to device %s: %v\n", shadow.Version, shadow.DeviceID, err)
+	}
+}
+
+func shadowToMap(shadow *sensormanager.DeviceShadow) map[string]interface{} {
+	result := map[string]interface{}{
+		"deviceId":    shadow.DeviceID,
+		"desired":     shadow.Desired,
+		"reported":    shadow.Reported,
+		"delta":       shadow.Delta,
+		"version":     shadow.Version,
+		"pendingSync": shadow.PendingSync(),
+	}
+
+	if shadow.DesiredUpdatedAt != nil {
+		result["desiredUpdatedAt"] = shadow.DesiredUpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if shadow.ReportedUpdatedAt != nil {
+		result["reportedUpdatedAt"] = shadow.ReportedUpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	return result
+}
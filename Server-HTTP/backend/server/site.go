@@ -0,0 +1,177 @@
+package server
+
+import (
+	"fmt"
+	"sensormanager"
+
+	"github.com/jirenius/go-res"
+)
+
+func (s *Server) addSitesHandler() {
+	provider := &sitesProvider{s}
+
+	s.service.Handle("sites",
+		sitePermissions.access(),
+		res.Call("get", provider.GetSites),
+		res.Call("create", provider.CreateSite),
+		res.Call("addMember", provider.AddMember),
+		res.Call("removeMember", provider.RemoveMember),
+	)
+
+	s.service.Handle("sites.$siteId.alarm",
+		alarmPermissions.siteAccess(),
+		res.Call("get", provider.GetAlarm),
+		res.Call("setArmed", provider.SetArmed),
+	)
+}
+
+type sitesProvider struct{ server *Server }
+
+// GetSites retourne les sites de l'utilisateur, ou tous les sites pour un administrateur.
+func (p *sitesProvider) GetSites(request res.CallRequest) {
+	var t token
+	request.ParseToken(&t)
+
+	var (
+		sites []*sensormanager.Site
+		err   error
+	)
+	if t.Role == sensormanager.RoleAdmin {
+		sites, err = p.server.store.Sites.GetSites()
+	} else {
+		sites, err = p.server.store.Sites.GetUserSites(t.UserID)
+	}
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(sites))
+	for i, site := range sites {
+		result[i] = siteToMap(site)
+	}
+
+	request.OK(result)
+}
+
+func (p *sitesProvider) CreateSite(request res.CallRequest) {
+	var params struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	request.ParseParams(&params)
+
+	site, err := p.server.store.Sites.CreateSite(&sensormanager.CreateSiteParams{
+		ID:   params.ID,
+		Name: params.Name,
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(siteToMap(site))
+}
+
+// AddMember donne accès au site à un utilisateur, y compris aux connexions déjà ouvertes avec ses clés.
+func (p *sitesProvider) AddMember(request res.CallRequest) {
+	var params struct {
+		SiteID string `json:"siteId"`
+		UserID int64  `json:"userId"`
+	}
+	request.ParseParams(&params)
+
+	if err := p.server.store.Sites.AddSiteMember(params.SiteID, params.UserID); err != nil {
+		request.Error(err)
+		return
+	}
+
+	p.server.resetUserTokens(params.UserID)
+
+	request.OK(map[string]interface{}{
+		"success": true,
+		"message": "Site member added",
+	})
+}
+
+func (p *sitesProvider) RemoveMember(request res.CallRequest) {
+	var params struct {
+		SiteID string `json:"siteId"`
+		UserID int64  `json:"userId"`
+	}
+	request.ParseParams(&params)
+
+	if err := p.server.store.Sites.RemoveSiteMember(params.SiteID, params.UserID); err != nil {
+		request.Error(err)
+		return
+	}
+
+	fmt.Printf("🔒 User %d removed from site %s\n", params.UserID, params.SiteID)
+
+	// 🚪 Le site disparaît du jeton des connexions ouvertes : l'accès est refusé sans attendre une reconnexion.
+	p.server.resetUserTokens(params.UserID)
+
+	request.OK(map[string]interface{}{
+		"success": true,
+		"message": "Site member removed",
+	})
+}
+
+func (p *sitesProvider) GetAlarm(request res.CallRequest) {
+	site, err := p.server.store.Sites.GetSite(request.PathParam("siteId"))
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(map[string]interface{}{"armed": site.Armed})
+}
+
+// SetArmed arme ou désarme l'alarme du site. Désarmée, les alertes de mouvement ne sont plus notifiées.
+func (p *sitesProvider) SetArmed(request res.CallRequest) {
+	var params struct {
+		Armed bool `json:"armed"`
+	}
+	request.ParseParams(&params)
+
+	site, err := p.server.SetSiteArmed(request.PathParam("siteId"), params.Armed)
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(map[string]interface{}{"armed": site.Armed})
+}
+
+// SetSiteArmed arms or disarms the alarm of the site, and tells the RES clients and the observers.
+func (s *Server) SetSiteArmed(siteID string, armed bool) (*sensormanager.Site, error) {
+	site, err := s.store.Sites.SetSiteArmed(siteID, armed)
+	if err != nil {
+		return nil, err
+	}
+
+	if site.Armed {
+		fmt.Printf("🛡️ Site %s armed\n", site.ID)
+	} else {
+		fmt.Printf("🔓 Site %s disarmed\n", site.ID)
+	}
+
+	s.service.With("sites."+site.ID+".alarm", func(r res.Resource) {
+		r.Event("changed", map[string]interface{}{"armed": site.Armed})
+	})
+
+	for _, observer := range s.observers {
+		observer.SiteArmedChanged(site)
+	}
+
+	return site, nil
+}
+
+func siteToMap(site *sensormanager.Site) map[string]interface{} {
+	return map[string]interface{}{
+		"id":        site.ID,
+		"name":      site.Name,
+		"armed":     site.Armed,
+		"createdAt": site.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
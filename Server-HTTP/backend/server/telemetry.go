@@ -0,0 +1,197 @@
+package server
+
+import (
+	"sensormanager"
+	"sensormanager/server/models"
+
+	"github.com/jirenius/go-res"
+)
+
+func (s *Server) addTelemetryHandler() {
+	provider := &telemetryProvider{s}
+
+	s.service.Handle("sites.$siteId.device.telemetry",
+		telemetryPermissions.siteAccess(),
+		res.Call("record", provider.RecordTelemetry),
+		res.Call("history", provider.GetHistory),
+	)
+
+	s.service.Handle("sites.$siteId.alerts.telemetry",
+		alertPermissions.siteAccess(),
+		res.Call("get", provider.GetAlerts),
+		res.Call("updateStatus", provider.UpdateStatus),
+	)
+}
+
+type telemetryProvider struct{ server *Server }
+
+func (p *telemetryProvider) RecordTelemetry(request res.CallRequest) {
+	var params models.TelemetryParams
+	request.ParseParams(&params)
+
+	response, err := p.server.store.Telemetry.RecordTelemetry(&sensormanager.TelemetryParams{
+		SiteID:          request.PathParam("siteId"),
+		DeviceID:        params.DeviceID,
+		RSSI:            params.RSSI,
+		UptimeSeconds:   params.UptimeSeconds,
+		FreeHeap:        params.FreeHeap,
+		ResetReason:     params.ResetReason,
+		IPAddress:       params.IPAddress,
+		FirmwareVersion: params.FirmwareVersion,
+		ReadingTime:     params.ToReadingTime(),
+		SignedPayload:   params.ToSignedPayload(),
+		ReadingSource:   params.ToReadingSource(),
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	// 📶 Boucle de redémarrage ou signal faible
+	for _, alert := range response.Alerts {
+		go p.server.notifyAlert(sensormanager.AlertTypeTelemetry, alert)
+	}
+
+	alerts := make([]string, len(response.Alerts))
+	for i, alert := range response.Alerts {
+		alerts[i] = alert.Message
+	}
+
+	request.OK(map[string]interface{}{
+		"success":  true,
+		"rebooted": response.Rebooted,
+		"alerts":   alerts,
+	})
+}
+
+func (p *telemetryProvider) GetHistory(request res.CallRequest) {
+	var params struct {
+		DeviceID string `json:"deviceId"`
+		Limit    int    `json:"limit"`
+	}
+	request.ParseParams(&params)
+
+	if params.Limit == 0 {
+		params.Limit = 20
+	}
+
+	history, err := p.server.store.Telemetry.GetTelemetryHistory(request.PathParam("siteId"), params.DeviceID, params.Limit)
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(history))
+	for i, t := range history {
+		result[i] = telemetryToMap(t)
+	}
+
+	request.OK(result)
+}
+
+func (p *telemetryProvider) GetAlerts(request res.CallRequest) {
+	var params struct {
+		DeviceID string `json:"deviceId"`
+		Status   string `json:"status,omitempty"`
+		Limit    int    `json:"limit,omitempty"`
+	}
+	request.ParseParams(&params)
+
+	if params.Limit == 0 {
+		params.Limit = 50
+	}
+
+	alerts, err := p.server.store.Telemetry.GetTelemetryAlerts(&sensormanager.GetAlertsParams{
+		SiteID:   request.PathParam("siteId"),
+		DeviceID: params.DeviceID,
+		Status:   sensormanager.AlertStatus(params.Status),
+		Limit:    params.Limit,
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(alerts))
+	for i, a := range alerts {
+		item := map[string]interface{}{
+			"id":          a.ID,
+			"deviceId":    a.DeviceID,
+			"kind":        string(a.Kind),
+			"value":       a.Value,
+			"threshold":   a.Threshold,
+			"details":     a.Details,
+			"alertStatus": string(a.AlertStatus),
+			"createdAt":   a.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+
+		if a.AcknowledgedAt != nil {
+			item["acknowledgedAt"] = a.AcknowledgedAt.Format("2006-01-02T15:04:05Z")
+		}
+		if a.ResolvedAt != nil {
+			item["resolvedAt"] = a.ResolvedAt.Format("2006-01-02T15:04:05Z")
+		}
+
+		result[i] = item
+	}
+
+	request.OK(result)
+}
+
+func (p *telemetryProvider) UpdateStatus(request res.CallRequest) {
+	var params struct {
+		AlertID int64  `json:"alertId"`
+		Status  string `json:"status"`
+	}
+	request.ParseParams(&params)
+
+	err := p.server.store.Telemetry.UpdateTelemetryAlertStatus(&sensormanager.UpdateAlertStatusParams{
+		SiteID:  request.PathParam("siteId"),
+		AlertID: params.AlertID,
+		Status:  sensormanager.AlertStatus(params.Status),
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(map[string]interface{}{
+		"success": true,
+		"message": "Alert status updated",
+	})
+}
+
+func telemetryToMap(t *sensormanager.DeviceTelemetry) map[string]interface{} {
+	result := map[string]interface{}{
+		"recordedAt": t.RecordedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	// Les dernières valeurs d'un appareil n'ont ni identifiant ni date de réception
+	if t.ID != 0 {
+		result["id"] = t.ID
+		result["deviceId"] = t.DeviceID
+		result["rebooted"] = t.Rebooted
+		result["receivedAt"] = t.ReceivedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	if t.RSSI != nil {
+		result["rssi"] = *t.RSSI
+	}
+	if t.UptimeSeconds != nil {
+		result["uptimeSeconds"] = *t.UptimeSeconds
+	}
+	if t.FreeHeap != nil {
+		result["freeHeap"] = *t.FreeHeap
+	}
+	if t.ResetReason != "" {
+		result["resetReason"] = t.ResetReason
+	}
+	if t.IPAddress != "" {
+		result["ipAddress"] = t.IPAddress
+	}
+	if t.FirmwareVersion != "" {
+		result["firmwareVersion"] = t.FirmwareVersion
+	}
+
+	return result
+}
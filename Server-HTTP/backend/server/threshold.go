@@ -0,0 +1,113 @@
+package server
+
+import (
+	"sensormanager"
+
+	"github.com/jirenius/go-res"
+)
+
+func (s *Server) addThresholdsHandler() {
+	provider := &thresholdsProvider{s}
+
+	s.service.Handle("sites.$siteId.thresholds",
+		thresholdPermissions.siteAccess(),
+		res.Call("get", provider.GetThresholds),
+		res.Call("set", provider.SetThreshold),
+		res.Call("reset", provider.ResetThreshold),
+	)
+}
+
+type thresholdsProvider struct{ server *Server }
+
+// GetThresholds retourne le seuil appliqué à chaque règle d'alerte pour le site : le seuil du site, à défaut le seuil
+// par défaut.
+func (p *thresholdsProvider) GetThresholds(request res.CallRequest) {
+	saved, err := p.server.store.Thresholds.GetAlertThresholds(request.PathParam("siteId"))
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := []map[string]interface{}{}
+	for _, rule := range sensormanager.AlertThresholdRules() {
+		entry := map[string]interface{}{
+			"sensorType":       string(rule.SensorType),
+			"rule":             rule.Rule,
+			"threshold":        rule.Default,
+			"defaultThreshold": rule.Default,
+			"custom":           false,
+		}
+
+		for _, threshold := range saved {
+			if threshold.SensorType == rule.SensorType && threshold.Rule == rule.Rule {
+				entry["threshold"] = threshold.Threshold
+				entry["custom"] = true
+				entry["updatedAt"] = threshold.UpdatedAt.Format("2006-01-02T15:04:05Z")
+			}
+		}
+
+		result = append(result, entry)
+	}
+
+	request.OK(result)
+}
+
+func (p *thresholdsProvider) SetThreshold(request res.CallRequest) {
+	var params struct {
+		SensorType string  `json:"sensorType"`
+		Rule       string  `json:"rule"`
+		Threshold  float64 `json:"threshold"`
+	}
+	request.ParseParams(&params)
+
+	thresholdParams := &sensormanager.AlertThresholdParams{
+		SiteID:     request.PathParam("siteId"),
+		SensorType: sensormanager.SensorType(params.SensorType),
+		Rule:       params.Rule,
+		Threshold:  params.Threshold,
+	}
+	if err := thresholdParams.Sanitize(); err != nil {
+		request.InvalidParams(err.Error())
+		return
+	}
+
+	threshold, err := p.server.store.Thresholds.SetAlertThreshold(thresholdParams)
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(map[string]interface{}{
+		"sensorType": string(threshold.SensorType),
+		"rule":       threshold.Rule,
+		"threshold":  threshold.Threshold,
+		"custom":     true,
+		"updatedAt":  threshold.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	})
+}
+
+// ResetThreshold supprime le seuil du site : le seuil par défaut est de nouveau utilisé.
+func (p *thresholdsProvider) ResetThreshold(request res.CallRequest) {
+	var params struct {
+		SensorType string `json:"sensorType"`
+		Rule       string `json:"rule"`
+	}
+	request.ParseParams(&params)
+
+	if _, err := sensormanager.DefaultAlertThreshold(sensormanager.SensorType(params.SensorType), params.Rule); err != nil {
+		request.InvalidParams(err.Error())
+		return
+	}
+
+	if err := p.server.store.Thresholds.ResetAlertThreshold(
+		request.PathParam("siteId"), sensormanager.SensorType(params.SensorType), params.Rule,
+	); err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(map[string]interface{}{
+		"success": true,
+		"message": "Threshold reset",
+	})
+}
@@ -0,0 +1,255 @@
+package server
+
+import (
+	"sensormanager"
+	"time"
+
+	"github.com/jirenius/go-res"
+)
+
+// WebhookDispatcher makes an attempt to deliver a claimed delivery, see package webhooks.
+type WebhookDispatcher interface {
+	Deliver(delivery *sensormanager.WebhookDelivery) *sensormanager.WebhookAttempt
+	Lease() time.Duration
+}
+
+var errWebhooksDisabled = &res.Error{Code: "sensormanager.webhooksDisabled", Message: "Webhooks are not enabled"}
+
+func (s *Server) addWebhooksHandler() {
+	provider := &webhooksProvider{s}
+
+	s.service.Handle("sites.$siteId.webhooks",
+		webhookPermissions.siteAccess(),
+		res.Call("get", provider.GetWebhooks),
+		res.Call("create", provider.CreateWebhook),
+		res.Call("update", provider.UpdateWebhook),
+		res.Call("delete", provider.DeleteWebhook),
+		res.Call("deliveries", provider.GetDeliveries),
+		res.Call("redeliver", provider.Redeliver),
+		res.Call("test", provider.Test),
+	)
+}
+
+type webhooksProvider struct{ server *Server }
+
+type webhookParams struct {
+	WebhookID int64    `json:"webhookId,omitempty"`
+	Name      string   `json:"name"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	IsActive  *bool    `json:"isActive,omitempty"`
+}
+
+func (params *webhookParams) toWebhookParams(siteID string) *sensormanager.WebhookParams {
+	// Un webhook est actif par défaut.
+	isActive := params.IsActive == nil || *params.IsActive
+
+	return &sensormanager.WebhookParams{
+		SiteID:   siteID,
+		Name:     params.Name,
+		URL:      params.URL,
+		Events:   params.Events,
+		IsActive: isActive,
+	}
+}
+
+func (p *webhooksProvider) GetWebhooks(request res.CallRequest) {
+	webhooks, err := p.server.store.Webhooks.GetWebhooks(request.PathParam("siteId"))
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(webhooks))
+	for i, webhook := range webhooks {
+		result[i] = webhookToMap(webhook)
+	}
+
+	request.OK(result)
+}
+
+// CreateWebhook enregistre le webhook : sa clé de signature n'est retournée qu'une seule fois.
+func (p *webhooksProvider) CreateWebhook(request res.CallRequest) {
+	var params webhookParams
+	request.ParseParams(&params)
+
+	webhook, secret, err := p.server.store.Webhooks.CreateWebhook(params.toWebhookParams(request.PathParam("siteId")))
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := webhookToMap(webhook)
+	result["secret"] = secret
+
+	request.OK(result)
+}
+
+func (p *webhooksProvider) UpdateWebhook(request res.CallRequest) {
+	var params webhookParams
+	request.ParseParams(&params)
+
+	webhook, err := p.server.store.Webhooks.UpdateWebhook(params.WebhookID, params.toWebhookParams(request.PathParam("siteId")))
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(webhookToMap(webhook))
+}
+
+func (p *webhooksProvider) DeleteWebhook(request res.CallRequest) {
+	var params struct {
+		WebhookID int64 `json:"webhookId"`
+	}
+	request.ParseParams(&params)
+
+	if err := p.server.store.Webhooks.DeleteWebhook(request.PathParam("siteId"), params.WebhookID); err != nil {
+		request.Error(err)
+		return
+	}
+
+	request.OK(map[string]interface{}{
+		"success": true,
+		"message": "Webhook deleted",
+	})
+}
+
+func (p *webhooksProvider) GetDeliveries(request res.CallRequest) {
+	var params struct {
+		WebhookID int64  `json:"webhookId,omitempty"`
+		Status    string `json:"status,omitempty"`
+		Limit     int    `json:"limit,omitempty"`
+	}
+	request.ParseParams(&params)
+
+	if params.Limit <= 0 || params.Limit > 500 {
+		params.Limit = 100
+	}
+
+	deliveries, err := p.server.store.Webhooks.GetWebhookDeliveries(&sensormanager.GetWebhookDeliveriesParams{
+		SiteID:    request.PathParam("siteId"),
+		WebhookID: params.WebhookID,
+		Status:    sensormanager.WebhookDeliveryStatus(params.Status),
+		Limit:     params.Limit,
+	})
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(deliveries))
+	for i, delivery := range deliveries {
+		result[i] = webhookDeliveryToMap(delivery)
+	}
+
+	request.OK(result)
+}
+
+// Redeliver relance une livraison abandonnée pour une nouvelle série de tentatives.
+func (p *webhooksProvider) Redeliver(request res.CallRequest) {
+	var params struct {
+		DeliveryID int64 `json:"deliveryId"`
+	}
+	request.ParseParams(&params)
+
+	if err := p.server.store.Webhooks.RedeliverWebhookDelivery(request.PathParam("siteId"), params.DeliveryID); err != nil {
+		if err == sensormanager.ErrWebhookDeliveryNotDead {
+			request.InvalidParams(err.Error())
+			return
+		}
+
+		request.Error(err)
+		return
+	}
+
+	request.OK(map[string]interface{}{
+		"success": true,
+		"message": "Delivery scheduled",
+	})
+}
+
+// Test envoie un ping au webhook pendant l'appel et retourne le résultat de la tentative. Un ping en échec n'est pas
+// retenté.
+func (p *webhooksProvider) Test(request res.CallRequest) {
+	var params struct {
+		WebhookID int64 `json:"webhookId"`
+	}
+	request.ParseParams(&params)
+
+	if p.server.webhooks == nil {
+		request.Error(errWebhooksDisabled)
+		return
+	}
+
+	delivery, err := p.server.store.Webhooks.AddTestDelivery(request.PathParam("siteId"), params.WebhookID, p.server.webhooks.Lease())
+	if err != nil {
+		request.Error(err)
+		return
+	}
+
+	attempt := p.server.webhooks.Deliver(delivery)
+	attempt.NextAttemptAt = nil
+
+	if err := p.server.store.Webhooks.RecordWebhookAttempt(delivery.ID, attempt); err != nil {
+		request.Error(err)
+		return
+	}
+
+	result := map[string]interface{}{
+		"deliveryId": delivery.ID,
+		"delivered":  attempt.Delivered,
+	}
+	if attempt.StatusCode != nil {
+		result["statusCode"] = *attempt.StatusCode
+	}
+	if attempt.Error != "" {
+		result["error"] = attempt.Error
+	}
+
+	request.OK(result)
+}
+
+func webhookToMap(webhook *sensormanager.Webhook) map[string]interface{} {
+	events := make([]string, len(webhook.Events))
+	for i, event := range webhook.Events {
+		events[i] = string(event)
+	}
+
+	return map[string]interface{}{
+		"id":        webhook.ID,
+		"siteId":    webhook.SiteID,
+		"name":      webhook.Name,
+		"url":       webhook.URL,
+		"events":    events,
+		"isActive":  webhook.IsActive,
+		"createdAt": webhook.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func webhookDeliveryToMap(delivery *sensormanager.WebhookDelivery) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":            delivery.ID,
+		"webhookId":     delivery.WebhookID,
+		"event":         string(delivery.Event),
+		"version":       delivery.Version,
+		"data":          delivery.Data,
+		"occurredAt":    delivery.OccurredAt.Format("2006-01-02T15:04:05Z"),
+		"status":        string(delivery.Status),
+		"attempts":      delivery.Attempts,
+		"nextAttemptAt": delivery.NextAttemptAt.Format("2006-01-02T15:04:05Z"),
+		"createdAt":     delivery.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if delivery.LastStatusCode != nil {
+		result["lastStatusCode"] = *delivery.LastStatusCode
+	}
+	if delivery.LastError != "" {
+		result["lastError"] = delivery.LastError
+	}
+	if delivery.DeliveredAt != nil {
+		result["deliveredAt"] = delivery.DeliveredAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	return result
+}
@@ -0,0 +1,179 @@
+package sensormanager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// ShadowSignatureType is the sensor type of the signed message of a shadow report, whose value is returned by
+// ReportShadowParams.SignedValue.
+const ShadowSignatureType SensorType = "shadow"
+
+// ShadowDocument is a flat configuration document, e.g. {"reportIntervalSeconds": 5, "distanceThresholdCm": 30}.
+// Values are numbers, booleans or strings.
+type ShadowDocument map[string]interface{}
+
+const (
+	MaxShadowKeys        = 32
+	maxShadowValueLength = 64
+)
+
+var (
+	ErrShadowVersionConflict = errors.New("shadow has been updated since the expected version")
+
+	shadowKeyPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,31}$`)
+)
+
+// validate checks the keys and values of the document. A null value removes the key when the document is merged.
+func (d ShadowDocument) validate(field string) error {
+	if len(d) == 0 {
+		return fmt.Errorf("%s is required", field)
+	}
+	if len(d) > MaxShadowKeys {
+		return fmt.Errorf("%s cannot have more than %d keys", field, MaxShadowKeys)
+	}
+
+	for key, value := range d {
+		if !shadowKeyPattern.MatchString(key) {
+			return fmt.Errorf("invalid %s key %q", field, key)
+		}
+
+		switch v := value.(type) {
+		case nil, float64, bool:
+		case string:
+			if len(v) > maxShadowValueLength {
+				return fmt.Errorf("%s value of %q cannot be longer than %d characters", field, key, maxShadowValueLength)
+			}
+		default:
+			return fmt.Errorf("%s value of %q must be a number, a boolean or a string", field, key)
+		}
+	}
+
+	return nil
+}
+
+// Merge returns the document with the changes applied: keys with a null value are removed, the others are set.
+func (d ShadowDocument) Merge(changes ShadowDocument) ShadowDocument {
+	result := make(ShadowDocument, len(d)+len(changes))
+	for key, value := range d {
+		result[key] = value
+	}
+
+	for key, value := range changes {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = value
+	}
+
+	return result
+}
+
+// Equal reports whether both documents have the same keys and values.
+func (d ShadowDocument) Equal(other ShadowDocument) bool {
+	if len(d) != len(other) {
+		return false
+	}
+
+	for key, value := range d {
+		if otherValue, exists := other[key]; !exists || otherValue != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Delta returns the desired values that the device has not reported yet.
+func (d ShadowDocument) Delta(reported ShadowDocument) ShadowDocument {
+	result := make(ShadowDocument)
+	for key, value := range d {
+		if reportedValue, exists := reported[key]; !exists || reportedValue != value {
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
+// DeviceShadow is the desired configuration of a device, set by the users, and the configuration it reported.
+type DeviceShadow struct {
+	DeviceID string
+	Desired  ShadowDocument
+	Reported ShadowDocument
+	Delta    ShadowDocument // Valeurs désirées pas encore appliquées par l'appareil
+
+	// Version is incremented at each change of the desired or reported configuration.
+	Version int64
+
+	DesiredUpdatedAt  *time.Time
+	ReportedUpdatedAt *time.Time
+}
+
+// PendingSync reports whether the device has not applied its desired configuration yet.
+func (s *DeviceShadow) PendingSync() bool { return len(s.Delta) > 0 }
+
+type UpdateDesiredShadowParams struct {
+	SiteID   string
+	DeviceID string
+	Desired  ShadowDocument // Modifications, une valeur nulle supprime la clé
+
+	// ExpectedVersion optionally rejects the update when the shadow has changed since it was read.
+	ExpectedVersion *int64
+}
+
+func (p *UpdateDesiredShadowParams) Sanitize() error {
+	if p.SiteID == "" {
+		return errors.New("siteId is required")
+	}
+	if p.DeviceID == "" {
+		return errors.New("deviceId is required")
+	}
+
+	return p.Desired.validate("desired")
+}
+
+// ReportShadowParams is the configuration applied by a device. It replaces the reported values of the given keys.
+type ReportShadowParams struct {
+	SiteID   string         `json:"siteId"`
+	DeviceID string         `json:"deviceId"`
+	Reported ShadowDocument `json:"reported"`
+
+	SignedPayload
+	ReadingSource
+}
+
+func (p *ReportShadowParams) Sanitize() error {
+	if p.SiteID == "" {
+		return errors.New("siteId is required")
+	}
+	if p.DeviceID == "" {
+		return errors.New("deviceId is required")
+	}
+	if err := p.Reported.validate("reported"); err != nil {
+		return err
+	}
+
+	return p.ReadingSource.Sanitize()
+}
+
+// SignedValue returns the value signed by the device: the reported document as JSON with sorted keys and no spaces,
+// e.g. {"reportIntervalSeconds":5}.
+func (p *ReportShadowParams) SignedValue() string {
+	value, _ := json.Marshal(p.Reported)
+	return string(value)
+}
+
+type ShadowManager interface {
+	GetShadows(siteID string) ([]*DeviceShadow, error)
+
+	// GetShadow returns the shadow of the device, empty when no configuration has been set or reported yet.
+	GetShadow(siteID, deviceID string) (*DeviceShadow, error)
+
+	UpdateDesiredShadow(params *UpdateDesiredShadowParams) (*DeviceShadow, error)
+	ReportShadow(params *ReportShadowParams) (*DeviceShadow, error)
+}
@@ -0,0 +1,58 @@
+package sensormanager
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultSiteID is the site of the data recorded before sites were introduced.
+const DefaultSiteID = "default"
+
+// Site is a house whose devices, push tokens, alerts and incidents are isolated from the other sites.
+type Site struct {
+	ID   string
+	Name string
+
+	// Armed is the state of the alarm of the site. Motion alerts are still recorded while it is disarmed, but they
+	// are not notified.
+	Armed     bool
+	CreatedAt time.Time
+}
+
+type CreateSiteParams struct {
+	ID   string
+	Name string
+}
+
+// Les identifiants de site apparaissent dans les noms de ressources RES : pas de point ni de joker.
+var siteIDPattern = regexp.MustCompile(`^[a-z0-9_-]{1,50}$`)
+
+func (p *CreateSiteParams) Sanitize() error {
+	p.ID = strings.ToLower(strings.TrimSpace(p.ID))
+	if !siteIDPattern.MatchString(p.ID) {
+		return errors.New("id must only contain lowercase letters, digits, '-' and '_'")
+	}
+
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+
+	return nil
+}
+
+type SiteManager interface {
+	CreateSite(params *CreateSiteParams) (*Site, error)
+	GetSites() ([]*Site, error)
+	GetSite(siteID string) (*Site, error)
+
+	// GetUserSites returns the sites the user is a member of.
+	GetUserSites(userID int64) ([]*Site, error)
+
+	AddSiteMember(siteID string, userID int64) error
+	RemoveSiteMember(siteID string, userID int64) error
+
+	SetSiteArmed(siteID string, armed bool) (*Site, error)
+}
@@ -0,0 +1,202 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"sensormanager"
+	"time"
+
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+type alertActionsStore struct{ baseStore *Store }
+
+var _ sensormanager.AlertActionManager = (*alertActionsStore)(nil)
+
+type alertAuditRow struct {
+	ID           int64     `boil:"id"`
+	SiteID       string    `boil:"site_id"`
+	AlertType    string    `boil:"alert_type"`
+	AlertID      int64     `boil:"alert_id"`
+	Action       string    `boil:"action"`
+	Actor        string    `boil:"actor"`
+	SnoozedUntil null.Time `boil:"snoozed_until"`
+	CreatedAt    null.Time `boil:"created_at"`
+}
+
+const alertAuditColumns = "id, site_id, alert_type, alert_id, action, actor, snoozed_until, created_at"
+
+func (as *alertActionsStore) IssueAlertActionToken(
+	siteID string,
+	alertType sensormanager.AlertType,
+	alertID int64,
+) (*sensormanager.AlertActionToken, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(as.baseStore.alertActionTTL).Truncate(time.Second)
+
+	token, err := sensormanager.SignAlertActionToken(as.baseStore.alertActionSecret, &sensormanager.AlertActionClaims{
+		SiteID:    siteID,
+		AlertType: alertType,
+		AlertID:   alertID,
+		Nonce:     hex.EncodeToString(nonce),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &sensormanager.AlertActionToken{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// ExecuteAlertAction consumes the token, changes the alert and records the action in a single transaction: a token
+// whose action failed can be used again until it expires.
+func (as *alertActionsStore) ExecuteAlertAction(params *sensormanager.ExecuteAlertActionParams) (*sensormanager.AlertAuditEntry, error) {
+	if err := params.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	claims, err := sensormanager.ParseAlertActionToken(as.baseStore.alertActionSecret, params.Token, now)
+	if err != nil {
+		return nil, err
+	}
+
+	table, exists := alertTable(claims.AlertType)
+	if !exists {
+		return nil, sensormanager.ErrInvalidAlertActionToken
+	}
+
+	ctx := context.TODO()
+
+	tx, err := as.baseStore.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+	defer tx.Rollback()
+
+	// 🎟️ Le nonce n'est inséré qu'une fois : un second usage du jeton ne modifie aucune ligne.
+	result, err := queries.Raw(
+		"INSERT INTO alert_action_tokens (nonce, expires_at, used_at) VALUES ($1, $2, $3) ON CONFLICT (nonce) DO NOTHING",
+		claims.Nonce, time.Unix(claims.ExpiresAt, 0), now,
+	).ExecContext(ctx, tx)
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, sensormanager.ErrAlertActionTokenUsed
+	}
+
+	var events []*sensormanager.Event
+	var snoozedUntil null.Time
+
+	switch params.Action {
+	case sensormanager.AlertActionAcknowledge, sensormanager.AlertActionResolve:
+		status := params.Action.Status()
+
+		query := "UPDATE %s SET alert_status = $1, acknowledged_at = $2 WHERE id = $3 AND alert_status = 'active' AND " + siteDevicesCondition(4)
+		if status == sensormanager.AlertStatusResolved {
+			query = "UPDATE %s SET alert_status = $1, resolved_at = $2 WHERE id = $3 AND alert_status <> 'resolved' AND " + siteDevicesCondition(4)
+		}
+
+		var deviceID string
+		if err := queries.Raw(
+			fmt.Sprintf(query, table)+" RETURNING device_id",
+			string(status), now, claims.AlertID, claims.SiteID,
+		).QueryRowContext(ctx, tx).Scan(&deviceID); err != nil {
+			// Une alerte déjà acquittée ou résolue n'est pas modifiée.
+			return nil, errors.MapSQLError(err)
+		}
+
+		events = append(events, sensormanager.NewAlertStatusEvent(claims.AlertType, claims.SiteID, claims.AlertID, deviceID, status))
+	case sensormanager.AlertActionSnooze:
+		var alertStatus null.String
+		if err := queries.Raw(
+			fmt.Sprintf("SELECT alert_status FROM %s WHERE id = $1 AND ", table)+siteDevicesCondition(2),
+			claims.AlertID, claims.SiteID,
+		).QueryRowContext(ctx, tx).Scan(&alertStatus); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+		if alertStatus.String != string(sensormanager.AlertStatusActive) {
+			return nil, errors.MapSQLError(sql.ErrNoRows)
+		}
+
+		snoozedUntil = null.TimeFrom(now.Add(params.Snooze))
+		if _, err := queries.Raw(
+			"INSERT INTO alert_snoozes (alert_type, alert_id, snoozed_until) VALUES ($1, $2, $3) "+
+				"ON CONFLICT (alert_type, alert_id) DO UPDATE SET snoozed_until = EXCLUDED.snoozed_until",
+			string(claims.AlertType), claims.AlertID, snoozedUntil,
+		).ExecContext(ctx, tx); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+	}
+
+	row := &alertAuditRow{}
+	if err := queries.Raw(
+		"INSERT INTO alert_audit (site_id, alert_type, alert_id, action, actor, snoozed_until, created_at) "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING "+alertAuditColumns,
+		claims.SiteID, string(claims.AlertType), claims.AlertID, string(params.Action), params.Actor, snoozedUntil, now,
+	).Bind(ctx, tx, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if err := as.baseStore.events.add(ctx, tx, events...); err != nil {
+		return nil, err
+	}
+
+	// 🧹 Les jetons expirés ne peuvent plus servir : leurs nonces sont oubliés.
+	if _, err := queries.Raw("DELETE FROM alert_action_tokens WHERE expires_at < $1", now).ExecContext(ctx, tx); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return row.toAlertAuditEntry(), nil
+}
+
+// GetAlertAudit returns the actions taken on the alert, the most recent first.
+func (as *alertActionsStore) GetAlertAudit(siteID string, alertType sensormanager.AlertType, alertID int64) ([]*sensormanager.AlertAuditEntry, error) {
+	var rows []*alertAuditRow
+	if err := queries.Raw(
+		"SELECT "+alertAuditColumns+" FROM alert_audit WHERE site_id = $1 AND alert_type = $2 AND alert_id = $3 ORDER BY id DESC",
+		siteID, string(alertType), alertID,
+	).Bind(context.TODO(), as.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.AlertAuditEntry, len(rows))
+	for i, row := range rows {
+		result[i] = row.toAlertAuditEntry()
+	}
+
+	return result, nil
+}
+
+func (row *alertAuditRow) toAlertAuditEntry() *sensormanager.AlertAuditEntry {
+	var snoozedUntil *time.Time
+	if row.SnoozedUntil.Valid {
+		snoozedUntil = &row.SnoozedUntil.Time
+	}
+
+	return &sensormanager.AlertAuditEntry{
+		ID:           row.ID,
+		SiteID:       row.SiteID,
+		AlertType:    sensormanager.AlertType(row.AlertType),
+		AlertID:      row.AlertID,
+		Action:       sensormanager.AlertAction(row.Action),
+		Actor:        row.Actor,
+		SnoozedUntil: snoozedUntil,
+		CreatedAt:    row.CreatedAt.Time,
+	}
+}
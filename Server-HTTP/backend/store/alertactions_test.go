@@ -0,0 +1,107 @@
+package store
+
+import (
+	"sensormanager"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestExecuteAlertAction(t *testing.T) {
+	store, mock := newMockStore(t, WithAlertActionTokens("s3cr3t", time.Minute), WithEventOutbox(true))
+
+	token, err := store.AlertActions.IssueAlertActionToken("home", sensormanager.AlertTypeDistance, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectBegin()
+	expectExec(mock, "INSERT INTO alert_action_tokens").WillReturnResult(sqlmock.NewResult(0, 1))
+	expectQuery(mock, "UPDATE sensor_alerts SET alert_status = $1, acknowledged_at = $2 WHERE id = $3 AND alert_status = 'active'").
+		WithArgs("acknowledged", sqlmock.AnyArg(), int64(7), "home").
+		WillReturnRows(sqlmock.NewRows([]string{"device_id"}).AddRow("ESP_002"))
+	expectQuery(mock, "INSERT INTO alert_audit").
+		WithArgs("home", "distance", int64(7), "acknowledge", "notification", nil, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "site_id", "alert_type", "alert_id", "action", "actor", "snoozed_until", "created_at"}).
+			AddRow(1, "home", "distance", 7, "acknowledge", "notification", nil, time.Now()))
+	expectExec(mock, "INSERT INTO event_outbox").
+		WithArgs(sensormanager.EventVersion, "alert.status_changed", "events.alert.acknowledged", "home", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectExec(mock, "DELETE FROM alert_action_tokens WHERE expires_at < $1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	entry, err := store.AlertActions.ExecuteAlertAction(&sensormanager.ExecuteAlertActionParams{
+		Token:  token.Token,
+		Action: sensormanager.AlertActionAcknowledge,
+		Actor:  "notification",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.AlertType != sensormanager.AlertTypeDistance || entry.AlertID != 7 || entry.Action != sensormanager.AlertActionAcknowledge {
+		t.Errorf("unexpected audit entry %+v", entry)
+	}
+
+	// Le nonce est déjà enregistré : le jeton ne sert qu'une fois.
+	mock.ExpectBegin()
+	expectExec(mock, "INSERT INTO alert_action_tokens").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	if _, err := store.AlertActions.ExecuteAlertAction(&sensormanager.ExecuteAlertActionParams{
+		Token:  token.Token,
+		Action: sensormanager.AlertActionResolve,
+		Actor:  "notification",
+	}); err != sensormanager.ErrAlertActionTokenUsed {
+		t.Errorf("expected the token to be refused once used, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExecuteAlertActionRefusesInvalidTokens(t *testing.T) {
+	store, mock := newMockStore(t, WithAlertActionTokens("s3cr3t", time.Minute))
+
+	expired, err := sensormanager.SignAlertActionToken([]byte("s3cr3t"), &sensormanager.AlertActionClaims{
+		SiteID:    "home",
+		AlertType: sensormanager.AlertTypeMotion,
+		AlertID:   3,
+		Nonce:     "0123456789abcdef",
+		ExpiresAt: time.Now().Add(-time.Second).Unix(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := store.AlertActions.IssueAlertActionToken("home", sensormanager.AlertTypeMotion, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Des revendications modifiées ne correspondent plus à la signature.
+	payload, signature, _ := strings.Cut(valid.Token, ".")
+	forged := payload[:len(payload)-2] + "xx." + signature
+
+	for token, expected := range map[string]error{
+		expired:  sensormanager.ErrAlertActionTokenExpired,
+		forged:   sensormanager.ErrInvalidAlertActionToken,
+		"abc":    sensormanager.ErrInvalidAlertActionToken,
+		"abc.de": sensormanager.ErrInvalidAlertActionToken,
+	} {
+		if _, err := store.AlertActions.ExecuteAlertAction(&sensormanager.ExecuteAlertActionParams{
+			Token:  token,
+			Action: sensormanager.AlertActionSnooze,
+			Snooze: time.Hour,
+		}); err != expected {
+			t.Errorf("expected %v for token %q, got %v", expected, token, err)
+		}
+	}
+
+	// Aucune requête : les jetons sont refusés avant la base.
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
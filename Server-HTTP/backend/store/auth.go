@@ -0,0 +1,218 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"sensormanager"
+	"strings"
+	"time"
+
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+type authStore struct{ baseStore *Store }
+
+var _ sensormanager.AuthManager = (*authStore)(nil)
+
+type userRow struct {
+	ID        int64       `boil:"id"`
+	Name      string      `boil:"name"`
+	Role      string      `boil:"role"`
+	Locale    null.String `boil:"locale"`
+	CreatedAt null.Time   `boil:"created_at"`
+}
+
+type keyOwnerRow struct {
+	KeyID   int64 `boil:"key_id"`
+	userRow `boil:",bind"`
+}
+
+type apiKeyRow struct {
+	ID        int64     `boil:"id"`
+	UserID    int64     `boil:"user_id"`
+	Name      string    `boil:"name"`
+	Prefix    string    `boil:"prefix"`
+	CreatedAt null.Time `boil:"created_at"`
+	RevokedAt null.Time `boil:"revoked_at"`
+}
+
+const (
+	userColumns   = "id, name, role, locale, created_at"
+	apiKeyColumns = "id, user_id, name, prefix, created_at, revoked_at"
+
+	apiKeyPrefix = "smk_"
+)
+
+func (as *authStore) CreateUser(params *sensormanager.CreateUserParams) (*sensormanager.User, error) {
+	if err := params.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	row := &userRow{}
+	if err := queries.Raw(
+		"INSERT INTO users (name, role, locale) VALUES ($1, $2, $3) RETURNING "+userColumns,
+		params.Name, params.Role, null.NewString(params.Locale, params.Locale != ""),
+	).Bind(context.TODO(), as.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return row.toUser(), nil
+}
+
+func (as *authStore) GetUsers() ([]*sensormanager.User, error) {
+	var rows []*userRow
+	if err := queries.Raw(
+		"SELECT "+userColumns+" FROM users ORDER BY id",
+	).Bind(context.TODO(), as.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.User, len(rows))
+	for i, row := range rows {
+		result[i] = row.toUser()
+	}
+
+	return result, nil
+}
+
+func (as *authStore) CreateAPIKey(params *sensormanager.CreateAPIKeyParams) (*sensormanager.APIKey, string, error) {
+	if err := params.Sanitize(); err != nil {
+		return nil, "", err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+
+	key := apiKeyPrefix + hex.EncodeToString(secret)
+
+	row := &apiKeyRow{}
+	if err := queries.Raw(
+		"INSERT INTO api_keys (user_id, name, prefix, key_hash) VALUES ($1, $2, $3, $4) RETURNING "+apiKeyColumns,
+		params.UserID, params.Name, key[:len(apiKeyPrefix)+8], hashAPIKey(key),
+	).Bind(context.TODO(), as.baseStore.db, row); err != nil {
+		return nil, "", errors.MapSQLError(err)
+	}
+
+	return row.toAPIKey(), key, nil
+}
+
+func (as *authStore) GetAPIKeys(userID int64) ([]*sensormanager.APIKey, error) {
+	var rows []*apiKeyRow
+	if err := queries.Raw(
+		"SELECT "+apiKeyColumns+" FROM api_keys WHERE user_id = $1 ORDER BY id",
+		userID,
+	).Bind(context.TODO(), as.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.APIKey, len(rows))
+	for i, row := range rows {
+		result[i] = row.toAPIKey()
+	}
+
+	return result, nil
+}
+
+func (as *authStore) RevokeAPIKey(keyID int64) error {
+	result, err := queries.Raw(
+		"UPDATE api_keys SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL",
+		time.Now(), keyID,
+	).ExecContext(context.TODO(), as.baseStore.db)
+	if err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.MapSQLError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (as *authStore) Authenticate(key string) (*sensormanager.User, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, sensormanager.ErrInvalidAPIKey
+	}
+
+	// 🔑 Clé d'amorçage : permet de créer les premiers utilisateurs sur une base vide.
+	if adminKey := as.baseStore.bootstrapAdminKey; adminKey != "" &&
+		subtle.ConstantTimeCompare([]byte(key), []byte(adminKey)) == 1 {
+		return &sensormanager.User{Name: "bootstrap", Role: sensormanager.RoleAdmin}, nil
+	}
+
+	return as.keyOwner("k.key_hash = $1", hashAPIKey(key))
+}
+
+func (as *authStore) AuthenticateKeyID(keyID int64) (*sensormanager.User, error) {
+	return as.keyOwner("k.id = $1", keyID)
+}
+
+// keyOwner retourne le propriétaire de la clé non révoquée répondant à la condition, avec ses sites.
+func (as *authStore) keyOwner(condition string, arg interface{}) (*sensormanager.User, error) {
+	row := &keyOwnerRow{}
+	err := queries.Raw(
+		"SELECT k.id AS key_id, u.id, u.name, u.role, u.locale, u.created_at FROM api_keys k JOIN users u ON u.id = k.user_id WHERE "+condition+" AND k.revoked_at IS NULL",
+		arg,
+	).Bind(context.TODO(), as.baseStore.db, row)
+	if err == sql.ErrNoRows {
+		return nil, sensormanager.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	user := row.toUser()
+	user.KeyID = row.KeyID
+
+	sites, err := as.baseStore.Sites.GetUserSites(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, site := range sites {
+		user.Sites = append(user.Sites, site.ID)
+	}
+
+	return user, nil
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func (row *userRow) toUser() *sensormanager.User {
+	return &sensormanager.User{
+		ID:        row.ID,
+		Name:      row.Name,
+		Role:      sensormanager.Role(row.Role),
+		Locale:    sensormanager.Locale(row.Locale.String),
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
+
+func (row *apiKeyRow) toAPIKey() *sensormanager.APIKey {
+	var revokedAt *time.Time
+	if row.RevokedAt.Valid {
+		revokedAt = &row.RevokedAt.Time
+	}
+
+	return &sensormanager.APIKey{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		Name:      row.Name,
+		Prefix:    row.Prefix,
+		CreatedAt: row.CreatedAt.Time,
+		RevokedAt: revokedAt,
+	}
+}
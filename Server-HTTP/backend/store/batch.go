@@ -0,0 +1,335 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sensormanager"
+	"sensormanager/filter"
+	"strings"
+	"time"
+
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// pendingReading is a reading of a batch, with what its insertion and its alert evaluation need.
+type pendingReading struct {
+	index       int // Position de la mesure dans le lot
+	sensorType  sensormanager.SensorType
+	siteID      string
+	deviceID    string
+	value       float64
+	signedValue string // Valeur telle que signée par l'appareil
+	dedupKey    string // Vide quand l'appareil n'identifie pas ses mesures
+	claimed     bool
+	quarantined bool // Appareil en attente d'approbation
+
+	sanitize func() error
+	timing   *sensormanager.ReadingTime
+	id       *sensormanager.ReadingID
+	signed   *sensormanager.SignedPayload
+	source   *sensormanager.ReadingSource
+
+	filtered   *filter.Result // nil quand le type n'est pas filtré
+	recordedAt time.Time
+	receivedAt time.Time
+	dataID     int64
+}
+
+// readingStorage describes how the readings of a sensor type are inserted and evaluated.
+type readingStorage struct {
+	table    string
+	columns  []string // Colonnes insérées, dans l'ordre des valeurs retournées par values
+	values   func(*pendingReading) []interface{}
+	evaluate func(*pendingReading) (*sensormanager.AlertResponse, error)
+}
+
+type insertedReadingRow struct {
+	ID int64 `boil:"id"`
+}
+
+// recordBatch verifies each reading, inserts the accepted ones in a single statement, then evaluates their alerts in
+// the order of the batch. A reading that fails verification is rejected without failing the batch, and a reading
+// already recorded within the dedup window gets its original result.
+func (ss *sensorsStore) recordBatch(readings []*pendingReading, storage *readingStorage) ([]*sensormanager.RecordResult, error) {
+	if len(readings) == 0 {
+		return nil, sensormanager.ErrEmptyBatch
+	}
+	if len(readings) > sensormanager.MaxBatchSize {
+		return nil, sensormanager.ErrBatchTooLarge
+	}
+
+	results := make([]*sensormanager.RecordResult, len(readings))
+	accepted := make([]*pendingReading, 0, len(readings))
+	receivedAt := time.Now()
+
+	// Les doublons au sein du lot reçoivent le résultat de leur première occurrence.
+	firstOccurrences := make(map[receiptKey]int)
+	repeated := make(map[int]int)
+
+	for i, reading := range readings {
+		reading.index = i
+
+		key := reading.receiptKey()
+		if first, exists := firstOccurrences[key]; exists && reading.dedupKey != "" {
+			repeated[i] = first
+			continue
+		}
+		firstOccurrences[key] = i
+
+		// 🔐 Seule une mesure authentifiée et admise peut réserver son identifiant.
+		if err := ss.prepareReading(reading, receivedAt); err != nil {
+			results[i] = &sensormanager.RecordResult{Err: err}
+			continue
+		}
+
+		original, err := ss.claimReading(reading)
+		if err != nil {
+			results[i] = &sensormanager.RecordResult{Err: err}
+			continue
+		}
+		if original != nil {
+			results[i] = &sensormanager.RecordResult{Response: original}
+			continue
+		}
+
+		if err := ss.acceptReading(reading); err != nil {
+			ss.releaseReading(reading)
+			results[i] = &sensormanager.RecordResult{Err: err}
+			continue
+		}
+
+		if reading.quarantined {
+			response, err := ss.quarantineReading(reading)
+			if err == nil {
+				err = ss.completeReading(reading, response)
+			}
+			if err != nil {
+				ss.releaseReading(reading)
+				results[i] = &sensormanager.RecordResult{Err: err}
+				continue
+			}
+
+			results[i] = &sensormanager.RecordResult{Response: response}
+			continue
+		}
+
+		accepted = append(accepted, reading)
+	}
+
+	if err := ss.insertReadings(storage, accepted); err != nil {
+		ss.releaseReadings(accepted)
+		return nil, err
+	}
+
+	for j, reading := range accepted {
+		response, err := storage.evaluate(reading)
+		if err == nil {
+			response.SiteID = reading.siteID
+			err = ss.addAlertEvents(reading, response)
+		}
+		if err == nil {
+			err = ss.completeReading(reading, response)
+		}
+		if err != nil {
+			// Les mesures restantes sont enregistrées mais pas évaluées : elles pourront être renvoyées.
+			ss.releaseReadings(accepted[j:])
+			return nil, err
+		}
+
+		if response.Alert && response.AlertID != 0 {
+			ss.baseStore.attachIncident(sensormanager.AlertType(reading.sensorType), response)
+		}
+
+		results[reading.index] = &sensormanager.RecordResult{Response: response}
+	}
+
+	for i, first := range repeated {
+		results[i] = duplicateResult(results[first])
+	}
+
+	return results, nil
+}
+
+func (ss *sensorsStore) releaseReadings(readings []*pendingReading) {
+	for _, reading := range readings {
+		ss.releaseReading(reading)
+	}
+}
+
+// duplicateResult returns the result of a retransmission of the reading whose result is given.
+func duplicateResult(result *sensormanager.RecordResult) *sensormanager.RecordResult {
+	if result.Err != nil {
+		return result
+	}
+
+	response := *result.Response
+	response.Duplicate = true
+
+	return &sensormanager.RecordResult{Response: &response}
+}
+
+// prepareReading authenticates the reading, sanitizes it and applies the unknown device policy, without recording
+// anything about the reading itself: a retransmission goes through the same checks before getting its original result.
+func (ss *sensorsStore) prepareReading(reading *pendingReading, receivedAt time.Time) error {
+	// 📥 Une mesure mise en file est datée de sa réception, pas de son enregistrement.
+	if !reading.source.ReceivedAt.IsZero() {
+		receivedAt = reading.source.ReceivedAt
+	}
+
+	// 🔐 La signature porte sur les valeurs reçues : elle est vérifiée avant toute normalisation.
+	if reading.source.Gateway != "" {
+		if err := ss.baseStore.devices.verifyGateway(reading.siteID, reading.deviceID); err != nil {
+			return err
+		}
+	} else if _, err := ss.baseStore.devices.authenticate(
+		reading.siteID, reading.sensorType, reading.deviceID, reading.signedValue, reading.timing, reading.id, reading.signed,
+		receivedAt,
+	); err != nil {
+		return err
+	}
+
+	if err := reading.sanitize(); err != nil {
+		return err
+	}
+
+	recordedAt, err := ss.readingTime(reading.timing, receivedAt)
+	if err != nil {
+		return err
+	}
+
+	// Un appareil inconnu est laissé à la politique d'admission, même quand les signatures sont exigées.
+	quarantined, err := ss.baseStore.devices.admit(reading.siteID, reading.deviceID, reading.sensorType)
+	if err != nil {
+		return err
+	}
+
+	reading.recordedAt = recordedAt
+	reading.receivedAt = receivedAt
+	reading.quarantined = quarantined
+
+	return nil
+}
+
+// acceptReading records the nonce of a prepared reading that is not a retransmission, the presence of its device, and
+// applies the filter of its sensor type.
+func (ss *sensorsStore) acceptReading(reading *pendingReading) error {
+	if reading.source.Gateway == "" {
+		if err := ss.baseStore.devices.useNonce(reading.deviceID, reading.signed, reading.receivedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := ss.baseStore.devices.updateAddress(reading.deviceID, reading.source); err != nil {
+		return err
+	}
+
+	// Les mesures en quarantaine ne passent pas par le filtre : elles ne doivent pas influencer les suivantes.
+	if reading.quarantined {
+		return nil
+	}
+
+	if err := ss.baseStore.devices.seen(reading.deviceID, reading.receivedAt); err != nil {
+		return err
+	}
+
+	if readingFilter := ss.readingFilter(reading.sensorType); readingFilter != nil {
+		filtered := readingFilter.Apply(reading.deviceID, reading.value)
+		reading.filtered = &filtered
+	}
+
+	return nil
+}
+
+func (ss *sensorsStore) readingFilter(sensorType sensormanager.SensorType) *filter.Filter {
+	switch sensorType {
+	case sensormanager.SensorTypeDistance:
+		return ss.baseStore.distanceFilter
+	case sensormanager.SensorTypeMicrophone:
+		return ss.baseStore.microphoneFilter
+	default:
+		return nil
+	}
+}
+
+// insertReadings inserts the readings with one multi-row INSERT in a transaction and sets their IDs.
+func (ss *sensorsStore) insertReadings(storage *readingStorage, readings []*pendingReading) error {
+	if len(readings) == 0 {
+		return nil
+	}
+
+	ctx := context.TODO()
+
+	tx, err := ss.baseStore.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.MapSQLError(err)
+	}
+	defer tx.Rollback()
+
+	if err := insertReadingRows(ctx, tx, storage, readings); err != nil {
+		return err
+	}
+
+	events := make([]*sensormanager.Event, len(readings))
+	for i, reading := range readings {
+		events[i] = sensormanager.NewReadingEvent(reading.siteID, reading.sensorType, reading.deviceID, reading.value, reading.recordedAt)
+	}
+
+	if err := ss.baseStore.events.add(ctx, tx, events...); err != nil {
+		return err
+	}
+
+	return errors.MapSQLError(tx.Commit())
+}
+
+// addAlertEvents writes the events of the alerts raised by a reading. The alerts are inserted while the reading is
+// evaluated: when the events cannot be written, the reading is released so that its retransmission records them.
+func (ss *sensorsStore) addAlertEvents(reading *pendingReading, response *sensormanager.AlertResponse) error {
+	var events []*sensormanager.Event
+
+	if response.Alert && response.AlertID != 0 {
+		events = append(events, sensormanager.NewAlertCreatedEvent(sensormanager.AlertType(reading.sensorType), reading.siteID, response))
+	}
+
+	if response.SensorFault != nil {
+		events = append(events, sensormanager.NewSensorFaultEvent(reading.siteID, response.SensorFault))
+	}
+
+	return ss.baseStore.events.add(context.TODO(), ss.baseStore.db, events...)
+}
+
+func insertReadingRows(ctx context.Context, exec boil.ContextExecutor, storage *readingStorage, readings []*pendingReading) error {
+	var (
+		query strings.Builder
+		args  = make([]interface{}, 0, len(readings)*len(storage.columns))
+	)
+
+	query.WriteString("INSERT INTO " + storage.table + " (" + strings.Join(storage.columns, ", ") + ") VALUES ")
+	for i, reading := range readings {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+
+		placeholders := make([]string, len(storage.columns))
+		for j, value := range storage.values(reading) {
+			args = append(args, value)
+			placeholders[j] = fmt.Sprintf("$%d", len(args))
+		}
+
+		query.WriteString("(" + strings.Join(placeholders, ", ") + ")")
+	}
+	query.WriteString(" RETURNING id")
+
+	var rows []*insertedReadingRow
+	if err := queries.Raw(query.String(), args...).Bind(ctx, exec, &rows); err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	// PostgreSQL retourne les lignes d'un INSERT ... VALUES dans l'ordre des valeurs.
+	for i, row := range rows {
+		readings[i].dataID = row.ID
+	}
+
+	return nil
+}
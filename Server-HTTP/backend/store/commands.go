@@ -0,0 +1,165 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sensormanager"
+	"sensormanager/coap"
+	"time"
+
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+type commandsStore struct{ baseStore *Store }
+
+var _ sensormanager.CommandManager = (*commandsStore)(nil)
+
+type commandRow struct {
+	ID              int64       `boil:"id"`
+	DeviceID        string      `boil:"device_id"`
+	Command         string      `boil:"command"`
+	IntervalSeconds null.Int    `boil:"interval_seconds"`
+	Address         string      `boil:"address"`
+	Status          string      `boil:"status"`
+	Attempts        null.Int    `boil:"attempts"`
+	ResponseCode    null.String `boil:"response_code"`
+	Error           null.String `boil:"error"`
+	RequestedBy     null.String `boil:"requested_by"`
+	CreatedAt       null.Time   `boil:"created_at"`
+	CompletedAt     null.Time   `boil:"completed_at"`
+}
+
+type commandTargetRow struct {
+	Address null.String `boil:"address"`
+	Status  null.String `boil:"status"`
+}
+
+const commandColumns = "id, device_id, command, interval_seconds, address, status, attempts, response_code, error, requested_by, created_at, completed_at"
+
+func (cs *commandsStore) SendCommand(params *sensormanager.SendCommandParams) (*sensormanager.DeviceCommand, error) {
+	if err := params.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	ctx := context.TODO()
+
+	target := &commandTargetRow{}
+	err := queries.Raw(
+		"SELECT address, status FROM devices WHERE site_id = $1 AND device_id = $2",
+		params.SiteID, params.DeviceID,
+	).Bind(ctx, cs.baseStore.db, target)
+	if err == sql.ErrNoRows {
+		return nil, sensormanager.ErrUnknownDevice
+	}
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if target.Status.String == string(sensormanager.DeviceStatusDisabled) {
+		return nil, sensormanager.ErrDeviceDisabled
+	}
+	if !target.Address.Valid {
+		return nil, sensormanager.ErrDeviceUnreachable
+	}
+
+	row := &commandRow{}
+	if err := queries.Raw(
+		"INSERT INTO device_commands (device_id, command, interval_seconds, address, status, requested_by) VALUES ($1, $2, $3, $4, $5, $6) RETURNING "+commandColumns,
+		params.DeviceID, string(params.Kind), null.IntFromPtr(params.IntervalSeconds), target.Address.String,
+		string(sensormanager.CommandStatusPending), null.NewString(params.RequestedBy, params.RequestedBy != ""),
+	).Bind(ctx, cs.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	// 📡 Envoi en arrière-plan : les retransmissions CoAP peuvent durer plus d'une minute.
+	go cs.deliver(row.ID, row.Address, params.Payload())
+
+	return row.toDeviceCommand(), nil
+}
+
+// deliver sends the command to the device and records the outcome of its delivery.
+func (cs *commandsStore) deliver(commandID int64, address string, payload []byte) {
+	response, err := cs.baseStore.coapClient.Put(context.TODO(), address, sensormanager.CommandResource, payload)
+
+	var (
+		status       sensormanager.CommandStatus
+		attempts     null.Int
+		responseCode null.String
+		message      null.String
+	)
+
+	switch {
+	case err == nil:
+		attempts = null.IntFrom(response.Attempts)
+		status = sensormanager.CommandStatusAcknowledged
+
+		// Un ACK vide annonce une réponse séparée : la commande a bien été reçue.
+		if response.Code != coap.CodeEmpty {
+			responseCode = null.StringFrom(response.Code.String())
+
+			if !response.Code.Success() {
+				status = sensormanager.CommandStatusRejected
+			}
+		}
+	case err == coap.ErrReset:
+		status = sensormanager.CommandStatusRejected
+		message = null.StringFrom(err.Error())
+	case err == coap.ErrTimeout:
+		status = sensormanager.CommandStatusTimeout
+		message = null.StringFrom(err.Error())
+	default:
+		status = sensormanager.CommandStatusFailed
+		message = null.StringFrom(err.Error())
+	}
+
+	fmt.Printf("📡 Command %d to %s: %s\n", commandID, address, status)
+
+	if _, err := queries.Raw(
+		"UPDATE device_commands SET status = $1, attempts = $2, response_code = $3, error = $4, completed_at = $5 WHERE id = $6",
+		string(status), attempts, responseCode, message, time.Now(), commandID,
+	).ExecContext(context.TODO(), cs.baseStore.db); err != nil {
+		fmt.Printf("❌ Could not record the delivery of command %d: %v\n", commandID, err)
+	}
+}
+
+func (cs *commandsStore) GetCommands(siteID, deviceID string, limit int) ([]*sensormanager.DeviceCommand, error) {
+	var rows []*commandRow
+	if err := queries.Raw(
+		"SELECT "+commandColumns+" FROM device_commands WHERE device_id = $1 AND "+siteDevicesCondition(2)+" ORDER BY created_at DESC LIMIT $3",
+		deviceID, siteID, limit,
+	).Bind(context.TODO(), cs.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.DeviceCommand, len(rows))
+	for i, row := range rows {
+		result[i] = row.toDeviceCommand()
+	}
+
+	return result, nil
+}
+
+func (row *commandRow) toDeviceCommand() *sensormanager.DeviceCommand {
+	var completedAt *time.Time
+	if row.CompletedAt.Valid {
+		completedAt = &row.CompletedAt.Time
+	}
+
+	return &sensormanager.DeviceCommand{
+		ID:              row.ID,
+		DeviceID:        row.DeviceID,
+		Kind:            sensormanager.CommandKind(row.Command),
+		IntervalSeconds: row.IntervalSeconds.Ptr(),
+		Address:         row.Address,
+		Status:          sensormanager.CommandStatus(row.Status),
+		Attempts:        row.Attempts.Int,
+		ResponseCode:    row.ResponseCode.String,
+		Error:           row.Error.String,
+		RequestedBy:     row.RequestedBy.String,
+		CreatedAt:       row.CreatedAt.Time,
+		CompletedAt:     completedAt,
+	}
+}
@@ -0,0 +1,597 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"sensormanager"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+type devicesStore struct {
+	baseStore *Store
+
+	registered sync.Map // Statut des appareils déjà vus depuis le démarrage
+	addresses  sync.Map // Dernière adresse enregistrée de chaque appareil
+	lastSeen   sync.Map // Dernière présence enregistrée de chaque appareil
+}
+
+var _ sensormanager.DeviceManager = (*devicesStore)(nil)
+
+type deviceRow struct {
+	SiteID           string      `boil:"site_id"`
+	DeviceID         string      `boil:"device_id"`
+	SensorType       string      `boil:"sensor_type"`
+	Status           null.String `boil:"status"`
+	Secret           null.String `boil:"secret"`
+	SecretRotatedAt  null.Time   `boil:"secret_rotated_at"`
+	Location         null.String `boil:"location"`
+	Health           null.String `boil:"health"`
+	HealthReason     null.String `boil:"health_reason"`
+	HealthUpdatedAt  null.Time   `boil:"health_updated_at"`
+	RSSI             null.Int    `boil:"rssi"`
+	UptimeSeconds    null.Int64  `boil:"uptime_seconds"`
+	FreeHeap         null.Int64  `boil:"free_heap"`
+	ResetReason      null.String `boil:"reset_reason"`
+	IPAddress        null.String `boil:"ip_address"`
+	FirmwareVersion  null.String `boil:"firmware_version"`
+	TelemetryAt      null.Time   `boil:"telemetry_at"`
+	Address          null.String `boil:"address"`
+	AddressUpdatedAt null.Time   `boil:"address_updated_at"`
+	LastSeenAt       null.Time   `boil:"last_seen_at"`
+	OfflineSince     null.Time   `boil:"offline_since"`
+	CreatedAt        null.Time   `boil:"created_at"`
+}
+
+const deviceColumns = "site_id, device_id, sensor_type, status, secret, secret_rotated_at, location, health, health_reason, health_updated_at, " +
+	"rssi, uptime_seconds, free_heap, reset_reason, ip_address, firmware_version, telemetry_at, address, address_updated_at, " +
+	"last_seen_at, offline_since, created_at"
+
+func (ds *devicesStore) GetDevices(siteID string) ([]*sensormanager.Device, error) {
+	var rows []*deviceRow
+	if err := queries.Raw(
+		"SELECT "+deviceColumns+" FROM devices WHERE site_id = $1 ORDER BY device_id",
+		siteID,
+	).Bind(context.TODO(), ds.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.Device, len(rows))
+	for i, row := range rows {
+		result[i] = row.toDevice()
+	}
+
+	return result, nil
+}
+
+func (ds *devicesStore) GetDevice(siteID, deviceID string) (*sensormanager.Device, error) {
+	row := &deviceRow{}
+	if err := queries.Raw(
+		"SELECT "+deviceColumns+" FROM devices WHERE site_id = $1 AND device_id = $2",
+		siteID, deviceID,
+	).Bind(context.TODO(), ds.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return row.toDevice(), nil
+}
+
+func (ds *devicesStore) SetDeviceLocation(siteID, deviceID string, location string) error {
+	location = strings.TrimSpace(location)
+
+	result, err := queries.Raw(
+		"UPDATE devices SET location = $1 WHERE site_id = $2 AND device_id = $3",
+		null.NewString(location, location != ""), siteID, deviceID,
+	).ExecContext(context.TODO(), ds.baseStore.db)
+	if err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.MapSQLError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (ds *devicesStore) ProvisionDevice(params *sensormanager.ProvisionDeviceParams) (*sensormanager.Device, string, error) {
+	if err := params.Sanitize(); err != nil {
+		return nil, "", err
+	}
+
+	secret, err := newDeviceSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Un appareil déjà rattaché à un autre site n'est pas modifié : la requête ne retourne alors aucune ligne.
+	row := &deviceRow{}
+	if err := ds.baseStore.withEvents(func(ctx context.Context, exec boil.ContextExecutor) ([]*sensormanager.Event, error) {
+		if err := queries.Raw(
+			`INSERT INTO devices (site_id, device_id, sensor_type, status, secret, secret_rotated_at) VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (device_id) DO UPDATE SET sensor_type = $3, status = $4, secret = $5, secret_rotated_at = $6
+			WHERE devices.site_id = $1
+			RETURNING `+deviceColumns,
+			params.SiteID, params.DeviceID, params.SensorType, string(sensormanager.DeviceStatusActive), secret, time.Now(),
+		).Bind(ctx, exec, row); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+
+		return []*sensormanager.Event{
+			sensormanager.NewDeviceStatusEvent(params.SiteID, params.DeviceID, sensormanager.SensorType(params.SensorType), sensormanager.DeviceStatusActive),
+		}, nil
+	}); err != nil {
+		return nil, "", err
+	}
+
+	// Provisionner un appareil en attente l'approuve.
+	ds.registered.Delete(params.DeviceID)
+
+	return row.toDevice(), secret, nil
+}
+
+func (ds *devicesStore) RotateDeviceSecret(siteID, deviceID string) (string, error) {
+	secret, err := newDeviceSecret()
+	if err != nil {
+		return "", err
+	}
+
+	// Un appareil désactivé doit être provisionné à nouveau.
+	result, err := queries.Raw(
+		"UPDATE devices SET secret = $1, secret_rotated_at = $2 WHERE site_id = $3 AND device_id = $4 AND status = $5",
+		secret, time.Now(), siteID, deviceID, string(sensormanager.DeviceStatusActive),
+	).ExecContext(context.TODO(), ds.baseStore.db)
+	if err != nil {
+		return "", errors.MapSQLError(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return "", errors.MapSQLError(sql.ErrNoRows)
+	}
+
+	return secret, nil
+}
+
+func (ds *devicesStore) RevokeDevice(siteID, deviceID string) error {
+	if err := ds.baseStore.withEvents(func(ctx context.Context, exec boil.ContextExecutor) ([]*sensormanager.Event, error) {
+		var sensorType null.String
+		if err := queries.Raw(
+			"UPDATE devices SET status = $1, secret = NULL, secret_rotated_at = NULL WHERE site_id = $2 AND device_id = $3 RETURNING sensor_type",
+			string(sensormanager.DeviceStatusDisabled), siteID, deviceID,
+		).QueryRowContext(ctx, exec).Scan(&sensorType); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+
+		return []*sensormanager.Event{
+			sensormanager.NewDeviceStatusEvent(siteID, deviceID, sensormanager.SensorType(sensorType.String), sensormanager.DeviceStatusDisabled),
+		}, nil
+	}); err != nil {
+		return err
+	}
+
+	ds.registered.Delete(deviceID)
+
+	return nil
+}
+
+// verify authenticates a reading before it is sanitized and stored, then records the nonce of its signature.
+func (ds *devicesStore) verify(
+	siteID string,
+	sensorType sensormanager.SensorType,
+	deviceID string,
+	value string,
+	timing *sensormanager.ReadingTime,
+	id *sensormanager.ReadingID,
+	payload *sensormanager.SignedPayload,
+	receivedAt time.Time,
+) error {
+	known, err := ds.authenticate(siteID, sensorType, deviceID, value, timing, id, payload, receivedAt)
+	if err != nil {
+		return err
+	}
+
+	// Sans politique d'admission, un appareil inconnu ne peut envoyer que des données non signées facultatives.
+	if !known && (ds.baseStore.signatureRequired || payload.Signature != "") {
+		return sensormanager.ErrUnknownDevice
+	}
+
+	return ds.useNonce(deviceID, payload, receivedAt)
+}
+
+// authenticate checks the device and the signature of a reading, which covers its timing and ID when given. It returns false, without checking the signature,
+// when the device is unknown or pending approval without a secret: the unknown device policy then decides. Unsigned
+// readings of other devices are only accepted when signatures are not required and the device has not been
+// provisioned. The timestamp of the signature is checked against the time the reading was received. The nonce is not
+// recorded: a retransmission of the reading is authenticated again.
+func (ds *devicesStore) authenticate(
+	siteID string,
+	sensorType sensormanager.SensorType,
+	deviceID string,
+	value string,
+	timing *sensormanager.ReadingTime,
+	id *sensormanager.ReadingID,
+	payload *sensormanager.SignedPayload,
+	receivedAt time.Time,
+) (bool, error) {
+	ctx := context.TODO()
+	required := ds.baseStore.signatureRequired
+
+	row := &deviceRow{}
+	err := queries.Raw(
+		"SELECT "+deviceColumns+" FROM devices WHERE device_id = $1",
+		deviceID,
+	).Bind(ctx, ds.baseStore.db, row)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.MapSQLError(err)
+	}
+
+	// Un appareil d'un autre site est traité comme inconnu pour ne rien révéler de ce site.
+	if row.SiteID != siteID {
+		return false, sensormanager.ErrUnknownDevice
+	}
+
+	if row.Status.String == string(sensormanager.DeviceStatusDisabled) {
+		return false, sensormanager.ErrDeviceDisabled
+	}
+
+	// 🆕 Un appareil en attente n'a pas encore de secret : la signature sera vérifiée une fois l'appareil provisionné.
+	if row.Status.String == string(sensormanager.DeviceStatusPending) && !row.Secret.Valid {
+		return false, nil
+	}
+
+	if payload.Signature == "" {
+		if required || row.Secret.Valid {
+			return false, sensormanager.ErrSignatureRequired
+		}
+
+		return true, nil
+	}
+
+	if !row.Secret.Valid {
+		return false, sensormanager.ErrInvalidSignature
+	}
+
+	if err := payload.Verify(row.Secret.String, sensorType, deviceID, value, timing, id); err != nil {
+		return false, err
+	}
+
+	maxAge := ds.baseStore.signatureMaxAge
+
+	if at := time.Unix(payload.Timestamp, 0); at.Before(receivedAt.Add(-maxAge)) || at.After(receivedAt.Add(maxAge)) {
+		return false, sensormanager.ErrExpiredPayload
+	}
+
+	return true, nil
+}
+
+// useNonce records the nonce of an authenticated reading. Unsigned readings have no nonce.
+func (ds *devicesStore) useNonce(deviceID string, payload *sensormanager.SignedPayload, receivedAt time.Time) error {
+	if payload.Signature == "" {
+		return nil
+	}
+
+	ctx := context.TODO()
+	now := receivedAt
+	maxAge := ds.baseStore.signatureMaxAge
+
+	// 🔁 Anti-rejeu : un nonce n'est accepté qu'une fois pendant la fenêtre de validité des horodatages.
+	if _, err := queries.Raw(
+		"DELETE FROM device_nonces WHERE device_id = $1 AND received_at < $2",
+		deviceID, now.Add(-2*maxAge),
+	).ExecContext(ctx, ds.baseStore.db); err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	result, err := queries.Raw(
+		"INSERT INTO device_nonces (device_id, nonce, received_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING",
+		deviceID, payload.Nonce, now,
+	).ExecContext(ctx, ds.baseStore.db)
+	if err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return sensormanager.ErrReplayedPayload
+	}
+
+	return nil
+}
+
+// verifyGateway checks the device of a reading received by a gateway of the backend, which authenticated the reading
+// instead of a signature. Unknown devices are left to the unknown device policy.
+func (ds *devicesStore) verifyGateway(siteID, deviceID string) error {
+	row := &deviceRow{}
+	err := queries.Raw(
+		"SELECT "+deviceColumns+" FROM devices WHERE device_id = $1",
+		deviceID,
+	).Bind(context.TODO(), ds.baseStore.db, row)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	if row.SiteID != siteID {
+		return sensormanager.ErrUnknownDevice
+	}
+
+	if row.Status.String == string(sensormanager.DeviceStatusDisabled) {
+		return sensormanager.ErrDeviceDisabled
+	}
+
+	return nil
+}
+
+func newDeviceSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+// admit applies the unknown device policy to the device of a reading, adding the device to the pending devices the
+// first time it reports. It returns whether the reading must be quarantined.
+func (ds *devicesStore) admit(siteID, deviceID string, sensorType sensormanager.SensorType) (bool, error) {
+	policy := ds.baseStore.unknownDevicePolicy
+
+	status, known := ds.registered.Load(deviceID)
+	if !known {
+		var current null.String
+		err := queries.Raw(
+			"SELECT status FROM devices WHERE device_id = $1",
+			deviceID,
+		).QueryRowContext(context.TODO(), ds.baseStore.db).Scan(&current)
+
+		switch {
+		case err == sql.ErrNoRows:
+			if policy == sensormanager.UnknownDeviceReject {
+				return false, sensormanager.ErrUnregisteredDevice
+			}
+
+			// 🆕 Un identifiant inconnu (ex: faute de frappe dans le firmware) attend l'approbation d'un membre.
+			if err := ds.baseStore.withEvents(func(ctx context.Context, exec boil.ContextExecutor) ([]*sensormanager.Event, error) {
+				result, err := queries.Raw(
+					"INSERT INTO devices (site_id, device_id, sensor_type, status) VALUES ($1, $2, $3, $4) ON CONFLICT (device_id) DO NOTHING",
+					siteID, deviceID, string(sensorType), string(sensormanager.DeviceStatusPending),
+				).ExecContext(ctx, exec)
+				if err != nil {
+					return nil, errors.MapSQLError(err)
+				}
+
+				// Une autre mesure a déjà ajouté l'appareil et son événement.
+				if affected, _ := result.RowsAffected(); affected == 0 {
+					return nil, nil
+				}
+
+				return []*sensormanager.Event{
+					sensormanager.NewDeviceStatusEvent(siteID, deviceID, sensorType, sensormanager.DeviceStatusPending),
+				}, nil
+			}); err != nil {
+				return false, err
+			}
+
+			fmt.Printf("🆕 Unknown device %s is pending approval\n", deviceID)
+
+			current = null.StringFrom(string(sensormanager.DeviceStatusPending))
+		case err != nil:
+			return false, errors.MapSQLError(err)
+		}
+
+		status = sensormanager.DeviceStatus(current.String)
+		if current.String == "" {
+			status = sensormanager.DeviceStatusActive
+		}
+
+		ds.registered.Store(deviceID, status)
+	}
+
+	switch status {
+	case sensormanager.DeviceStatusPending:
+		switch policy {
+		case sensormanager.UnknownDeviceReject:
+			return false, sensormanager.ErrUnregisteredDevice
+		case sensormanager.UnknownDeviceQuarantine:
+			return true, nil
+		default:
+			return false, nil
+		}
+	case sensormanager.DeviceStatusRejected:
+		return false, sensormanager.ErrDeviceRejected
+	case sensormanager.DeviceStatusDisabled:
+		return false, sensormanager.ErrDeviceDisabled
+	default:
+		return false, nil
+	}
+}
+
+// updateAddress records the address the device reported from, where its commands are sent. The address is only
+// written when it changes.
+func (ds *devicesStore) updateAddress(deviceID string, source *sensormanager.ReadingSource) error {
+	if source.SourceAddress == "" {
+		return nil
+	}
+
+	if address, exists := ds.addresses.Load(deviceID); exists && address == source.SourceAddress {
+		return nil
+	}
+
+	if _, err := queries.Raw(
+		"UPDATE devices SET address = $1, address_updated_at = $2 WHERE device_id = $3",
+		source.SourceAddress, time.Now(), deviceID,
+	).ExecContext(context.TODO(), ds.baseStore.db); err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	ds.addresses.Store(deviceID, source.SourceAddress)
+
+	return nil
+}
+
+// seen records that the device sent a reading, at most once per minute, and brings it back online when it was marked
+// offline. It does nothing without offline detection.
+func (ds *devicesStore) seen(deviceID string, receivedAt time.Time) error {
+	offlineAfter := ds.baseStore.offlineAfter
+	if offlineAfter <= 0 {
+		return nil
+	}
+
+	// ⏱️ Un appareil est vu bien plus souvent qu'il ne peut être déclaré hors ligne.
+	interval := min(time.Minute, offlineAfter/4)
+	if last, exists := ds.lastSeen.Load(deviceID); exists && receivedAt.Sub(last.(time.Time)) < interval {
+		return nil
+	}
+
+	if err := ds.baseStore.withEvents(func(ctx context.Context, exec boil.ContextExecutor) ([]*sensormanager.Event, error) {
+		var offlineSince null.Time
+		var siteID, sensorType string
+		if err := queries.Raw(`
+			UPDATE devices d SET last_seen_at = $1, offline_since = NULL
+			FROM devices previous
+			WHERE d.device_id = $2 AND previous.device_id = d.device_id
+			RETURNING previous.offline_since, d.site_id, d.sensor_type`,
+			receivedAt, deviceID,
+		).QueryRowContext(ctx, exec).Scan(&offlineSince, &siteID, &sensorType); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+
+		if !offlineSince.Valid {
+			return nil, nil
+		}
+
+		return []*sensormanager.Event{
+			sensormanager.NewDeviceConnectivityEvent(siteID, deviceID, sensormanager.SensorType(sensorType), true, receivedAt),
+		}, nil
+	}); err != nil {
+		return err
+	}
+
+	ds.lastSeen.Store(deviceID, receivedAt)
+
+	return nil
+}
+
+func (ds *devicesStore) MarkOfflineDevices() (int, error) {
+	offlineAfter := ds.baseStore.offlineAfter
+	if offlineAfter <= 0 {
+		return 0, nil
+	}
+
+	var marked int
+	err := ds.baseStore.withEvents(func(ctx context.Context, exec boil.ContextExecutor) ([]*sensormanager.Event, error) {
+		now := time.Now()
+
+		rows, err := queries.Raw(`
+			UPDATE devices SET offline_since = $1
+			WHERE status = $2 AND offline_since IS NULL AND GREATEST(last_seen_at, telemetry_at) < $3
+			RETURNING device_id, site_id, sensor_type, GREATEST(last_seen_at, telemetry_at)`,
+			now, string(sensormanager.DeviceStatusActive), now.Add(-offlineAfter),
+		).QueryContext(ctx, exec)
+		if err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+		defer rows.Close()
+
+		var events []*sensormanager.Event
+		for rows.Next() {
+			var deviceID, siteID, sensorType string
+			var lastSeenAt time.Time
+			if err := rows.Scan(&deviceID, &siteID, &sensorType, &lastSeenAt); err != nil {
+				return nil, errors.MapSQLError(err)
+			}
+
+			events = append(events, sensormanager.NewDeviceConnectivityEvent(
+				siteID, deviceID, sensormanager.SensorType(sensorType), false, lastSeenAt,
+			))
+		}
+		if err := rows.Err(); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+
+		marked = len(events)
+
+		return events, nil
+	})
+
+	return marked, err
+}
+
+func (ds *devicesStore) updateHealth(deviceID string, health sensormanager.DeviceHealth, reason string) error {
+	_, err := queries.Raw(
+		"UPDATE devices SET health = $1, health_reason = $2, health_updated_at = $3 WHERE device_id = $4",
+		string(health), null.NewString(reason, reason != ""), time.Now(), deviceID,
+	).ExecContext(context.TODO(), ds.baseStore.db)
+
+	return errors.MapSQLError(err)
+}
+
+func (row *deviceRow) toDevice() *sensormanager.Device {
+	var healthUpdatedAt, secretRotatedAt, addressUpdatedAt, lastSeenAt, offlineSince *time.Time
+	if row.HealthUpdatedAt.Valid {
+		healthUpdatedAt = &row.HealthUpdatedAt.Time
+	}
+	if row.AddressUpdatedAt.Valid {
+		addressUpdatedAt = &row.AddressUpdatedAt.Time
+	}
+	if row.SecretRotatedAt.Valid {
+		secretRotatedAt = &row.SecretRotatedAt.Time
+	}
+	if row.LastSeenAt.Valid {
+		lastSeenAt = &row.LastSeenAt.Time
+	}
+	if row.OfflineSince.Valid {
+		offlineSince = &row.OfflineSince.Time
+	}
+
+	status := sensormanager.DeviceStatus(row.Status.String)
+	if status == "" {
+		status = sensormanager.DeviceStatusActive
+	}
+
+	var telemetry *sensormanager.DeviceTelemetry
+	if row.TelemetryAt.Valid {
+		telemetry = &sensormanager.DeviceTelemetry{
+			DeviceID:        row.DeviceID,
+			RSSI:            row.RSSI.Ptr(),
+			UptimeSeconds:   row.UptimeSeconds.Ptr(),
+			FreeHeap:        row.FreeHeap.Ptr(),
+			ResetReason:     row.ResetReason.String,
+			IPAddress:       row.IPAddress.String,
+			FirmwareVersion: row.FirmwareVersion.String,
+			RecordedAt:      row.TelemetryAt.Time,
+		}
+	}
+
+	return &sensormanager.Device{
+		SiteID:           row.SiteID,
+		DeviceID:         row.DeviceID,
+		SensorType:       sensormanager.SensorType(row.SensorType),
+		Status:           status,
+		Provisioned:      row.Secret.Valid,
+		SecretRotatedAt:  secretRotatedAt,
+		Location:         row.Location.String,
+		Health:           sensormanager.DeviceHealth(row.Health.String),
+		HealthReason:     row.HealthReason.String,
+		HealthUpdatedAt:  healthUpdatedAt,
+		Telemetry:        telemetry,
+		Address:          row.Address.String,
+		AddressUpdatedAt: addressUpdatedAt,
+		LastSeenAt:       lastSeenAt,
+		OfflineSince:     offlineSince,
+		CreatedAt:        row.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"sensormanager"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// signPayload signs a distance reading of ESP_001 with secret, as the firmware does.
+func signPayload(secret, value string, at time.Time, nonce string) *sensormanager.SignedPayload {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(sensormanager.SignedMessage(sensormanager.SensorTypeDistance, "ESP_001", value, nil, nil, at.Unix(), nonce)))
+
+	return &sensormanager.SignedPayload{Timestamp: at.Unix(), Nonce: nonce, Signature: hex.EncodeToString(mac.Sum(nil))}
+}
+
+func TestVerifySignedReading(t *testing.T) {
+	receivedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	device := func(siteID, status string, secret driver.Value) []driver.Value {
+		return []driver.Value{siteID, status, secret}
+	}
+	provisioned := device("home", "active", "secret")
+
+	tests := map[string]struct {
+		device  []driver.Value // site_id, status, secret
+		payload *sensormanager.SignedPayload
+		nonce   int64 // Lignes insérées dans device_nonces, -1 quand le nonce n'est pas enregistré
+		want    error
+	}{
+		"valid signature": {provisioned, signPayload("secret", "12.5", receivedAt, "n-1"), 1, nil},
+		"timestamp within the accepted window": {
+			provisioned, signPayload("secret", "12.5", receivedAt.Add(-4*time.Minute), "n-1"), 1, nil,
+		},
+		"expired timestamp": {
+			provisioned, signPayload("secret", "12.5", receivedAt.Add(-6*time.Minute), "n-1"), -1, sensormanager.ErrExpiredPayload,
+		},
+		"timestamp in the future": {
+			provisioned, signPayload("secret", "12.5", receivedAt.Add(6*time.Minute), "n-1"), -1, sensormanager.ErrExpiredPayload,
+		},
+		"replayed nonce": {provisioned, signPayload("secret", "12.5", receivedAt, "n-1"), 0, sensormanager.ErrReplayedPayload},
+		"invalid signature": {
+			provisioned, signPayload("other", "12.5", receivedAt, "n-1"), -1, sensormanager.ErrInvalidSignature,
+		},
+		"unsigned reading of a provisioned device": {
+			provisioned, &sensormanager.SignedPayload{}, -1, sensormanager.ErrSignatureRequired,
+		},
+		"device of another site": {
+			device("office", "active", "secret"),
+			signPayload("secret", "12.5", receivedAt, "n-1"), -1, sensormanager.ErrUnknownDevice,
+		},
+		"disabled device": {
+			device("home", "disabled", nil),
+			signPayload("secret", "12.5", receivedAt, "n-1"), -1, sensormanager.ErrDeviceDisabled,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			store, mock := newMockStore(t, WithDeviceSignatures(false, 5*time.Minute))
+
+			expectQuery(mock, "FROM devices WHERE device_id = $1").WithArgs("ESP_001").WillReturnRows(
+				sqlmock.NewRows([]string{"site_id", "status", "secret"}).AddRow(test.device...),
+			)
+			if test.nonce >= 0 {
+				expectExec(mock, "DELETE FROM device_nonces WHERE device_id = $1 AND received_at < $2").
+					WithArgs("ESP_001", receivedAt.Add(-10*time.Minute)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				expectExec(mock, "INSERT INTO device_nonces").
+					WithArgs("ESP_001", "n-1", receivedAt).
+					WillReturnResult(sqlmock.NewResult(0, test.nonce))
+			}
+
+			err := store.devices.verify("home", sensormanager.SensorTypeDistance, "ESP_001", "12.5", nil, nil, test.payload, receivedAt)
+			if err != test.want {
+				t.Errorf("got %v, want %v", err, test.want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestRecordReadingWithTamperedTime checks that a reading whose recordedAt was changed after signing is rejected.
+func TestRecordReadingWithTamperedTime(t *testing.T) {
+	store, mock := newMockStore(t, WithDeviceSignatures(true, 5*time.Minute))
+
+	now := time.Now()
+	recordedAt := now.Add(-time.Minute).Truncate(time.Millisecond)
+	params := &sensormanager.ReadingParams{
+		SiteID:      "home",
+		SensorType:  sensormanager.SensorTypeDistance,
+		DeviceID:    "ESP_001",
+		Value:       12.5,
+		ReadingTime: sensormanager.ReadingTime{RecordedAt: &recordedAt},
+	}
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(sensormanager.SignedMessage(
+		params.SensorType, params.DeviceID, "12.5", &params.ReadingTime, &params.ReadingID, now.Unix(), "n-1",
+	)))
+	params.SignedPayload = sensormanager.SignedPayload{Timestamp: now.Unix(), Nonce: "n-1", Signature: hex.EncodeToString(mac.Sum(nil))}
+
+	// ⏪ La mesure est antidatée après signature, pour échapper aux alertes d'une mesure récente.
+	tampered := recordedAt.Add(-time.Hour)
+	params.RecordedAt = &tampered
+
+	expectQuery(mock, "FROM devices WHERE device_id = $1").WithArgs("ESP_001").WillReturnRows(
+		sqlmock.NewRows([]string{"site_id", "status", "secret"}).AddRow("home", "active", "secret"),
+	)
+
+	if _, err := store.Sensors.RecordReading(params); err != sensormanager.ErrInvalidSignature {
+		t.Fatalf("got %v, want %v", err, sensormanager.ErrInvalidSignature)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
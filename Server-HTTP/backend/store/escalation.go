@@ -0,0 +1,226 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"sensormanager"
+	"time"
+
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type escalationsStore struct{ baseStore *Store }
+
+var _ sensormanager.EscalationManager = (*escalationsStore)(nil)
+
+type escalationPolicyRow struct {
+	ID        int64       `boil:"id"`
+	SiteID    string      `boil:"site_id"`
+	Name      string      `boil:"name"`
+	AlertType null.String `boil:"alert_type"`
+	IsActive  null.Bool   `boil:"is_active"`
+	CreatedAt null.Time   `boil:"created_at"`
+}
+
+type escalationStepRow struct {
+	ID                 int64             `boil:"id"`
+	PolicyID           int64             `boil:"policy_id"`
+	StepOrder          int               `boil:"step_order"`
+	DelayMinutes       int               `boil:"delay_minutes"`
+	Target             string            `boil:"target"`
+	PushTokens         types.StringArray `boil:"push_tokens"`
+	RepeatEveryMinutes null.Int          `boil:"repeat_every_minutes"`
+	RepeatLimit        null.Int          `boil:"repeat_limit"`
+}
+
+type escalationRecordRow struct {
+	ID           int64       `boil:"id"`
+	SiteID       string      `boil:"site_id"`
+	AlertType    string      `boil:"alert_type"`
+	AlertID      int64       `boil:"alert_id"`
+	PolicyID     null.Int64  `boil:"policy_id"`
+	StepID       null.Int64  `boil:"step_id"`
+	StepOrder    int         `boil:"step_order"`
+	Repetition   int         `boil:"repetition"`
+	Recipients   int         `boil:"recipients"`
+	Success      bool        `boil:"success"`
+	ErrorMessage null.String `boil:"error_message"`
+	ExecutedAt   null.Time   `boil:"executed_at"`
+}
+
+const (
+	escalationPolicyColumns = "id, site_id, name, alert_type, is_active, created_at"
+	escalationStepColumns   = "id, policy_id, step_order, delay_minutes, target, push_tokens, repeat_every_minutes, repeat_limit"
+	escalationRecordColumns = "id, site_id, alert_type, alert_id, policy_id, step_id, step_order, repetition, recipients, success, error_message, executed_at"
+)
+
+func (es *escalationsStore) CreateEscalationPolicy(params *sensormanager.EscalationPolicyParams) (*sensormanager.EscalationPolicy, error) {
+	if err := params.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	tx, err := es.baseStore.db.BeginTx(context.TODO(), nil)
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+	defer tx.Rollback()
+
+	policy := &escalationPolicyRow{}
+	if err := queries.Raw(
+		"INSERT INTO escalation_policies (site_id, name, alert_type) VALUES ($1, $2, $3) RETURNING "+escalationPolicyColumns,
+		params.SiteID, params.Name, null.NewString(params.AlertType, params.AlertType != ""),
+	).Bind(context.TODO(), tx, policy); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	steps := make([]*escalationStepRow, len(params.Steps))
+	for i, step := range params.Steps {
+		steps[i] = &escalationStepRow{}
+		if err := queries.Raw(
+			"INSERT INTO escalation_steps (policy_id, step_order, delay_minutes, target, push_tokens, repeat_every_minutes, repeat_limit) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING "+escalationStepColumns,
+			policy.ID, i+1, step.DelayMinutes, step.Target, types.StringArray(step.PushTokens), step.RepeatEveryMinutes, step.RepeatLimit,
+		).Bind(context.TODO(), tx, steps[i]); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return policy.toEscalationPolicy(steps), nil
+}
+
+// GetEscalationPolicies returns the active policies of the site with their steps in order.
+func (es *escalationsStore) GetEscalationPolicies(siteID string) ([]*sensormanager.EscalationPolicy, error) {
+	return es.getPolicies("SELECT "+escalationPolicyColumns+" FROM escalation_policies WHERE site_id = $1 AND is_active = TRUE ORDER BY id", siteID)
+}
+
+func (es *escalationsStore) GetActiveEscalationPolicies() ([]*sensormanager.EscalationPolicy, error) {
+	return es.getPolicies("SELECT " + escalationPolicyColumns + " FROM escalation_policies WHERE is_active = TRUE ORDER BY id")
+}
+
+func (es *escalationsStore) getPolicies(query string, args ...interface{}) ([]*sensormanager.EscalationPolicy, error) {
+	var policies []*escalationPolicyRow
+	if err := queries.Raw(query, args...).Bind(context.TODO(), es.baseStore.db, &policies); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if len(policies) == 0 {
+		return []*sensormanager.EscalationPolicy{}, nil
+	}
+
+	policyIDs := make([]int64, len(policies))
+	for i, policy := range policies {
+		policyIDs[i] = policy.ID
+	}
+
+	var steps []*escalationStepRow
+	if err := queries.Raw(
+		"SELECT "+escalationStepColumns+" FROM escalation_steps WHERE policy_id = ANY($1) ORDER BY policy_id, step_order",
+		types.Int64Array(policyIDs),
+	).Bind(context.TODO(), es.baseStore.db, &steps); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	stepsByPolicy := make(map[int64][]*escalationStepRow)
+	for _, step := range steps {
+		stepsByPolicy[step.PolicyID] = append(stepsByPolicy[step.PolicyID], step)
+	}
+
+	result := make([]*sensormanager.EscalationPolicy, len(policies))
+	for i, policy := range policies {
+		result[i] = policy.toEscalationPolicy(stepsByPolicy[policy.ID])
+	}
+
+	return result, nil
+}
+
+func (es *escalationsStore) DeleteEscalationPolicy(siteID string, policyID int64) error {
+	result, err := queries.Raw(
+		"DELETE FROM escalation_policies WHERE site_id = $1 AND id = $2",
+		siteID, policyID,
+	).ExecContext(context.TODO(), es.baseStore.db)
+	if err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.MapSQLError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (es *escalationsStore) GetEscalationRecords(siteID string, alertType sensormanager.AlertType, alertID int64) ([]*sensormanager.EscalationRecord, error) {
+	var rows []*escalationRecordRow
+	if err := queries.Raw(
+		"SELECT "+escalationRecordColumns+" FROM alert_escalations WHERE site_id = $1 AND alert_type = $2 AND alert_id = $3 ORDER BY executed_at",
+		siteID, string(alertType), alertID,
+	).Bind(context.TODO(), es.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.EscalationRecord, len(rows))
+	for i, row := range rows {
+		result[i] = &sensormanager.EscalationRecord{
+			ID:           row.ID,
+			SiteID:       row.SiteID,
+			AlertType:    sensormanager.AlertType(row.AlertType),
+			AlertID:      row.AlertID,
+			PolicyID:     row.PolicyID.Int64,
+			StepID:       row.StepID.Int64,
+			StepOrder:    row.StepOrder,
+			Repetition:   row.Repetition,
+			Recipients:   row.Recipients,
+			Success:      row.Success,
+			ErrorMessage: row.ErrorMessage.String,
+			ExecutedAt:   row.ExecutedAt.Time,
+		}
+	}
+
+	return result, nil
+}
+
+func (es *escalationsStore) RecordEscalation(record *sensormanager.EscalationRecord) error {
+	if record.ExecutedAt.IsZero() {
+		record.ExecutedAt = time.Now()
+	}
+
+	err := queries.Raw(
+		"INSERT INTO alert_escalations (site_id, alert_type, alert_id, policy_id, step_id, step_order, repetition, recipients, success, error_message, executed_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id",
+		record.SiteID, string(record.AlertType), record.AlertID, record.PolicyID, record.StepID, record.StepOrder, record.Repetition,
+		record.Recipients, record.Success, null.NewString(record.ErrorMessage, record.ErrorMessage != ""), record.ExecutedAt,
+	).QueryRowContext(context.TODO(), es.baseStore.db).Scan(&record.ID)
+
+	return errors.MapSQLError(err)
+}
+
+func (row *escalationPolicyRow) toEscalationPolicy(steps []*escalationStepRow) *sensormanager.EscalationPolicy {
+	result := &sensormanager.EscalationPolicy{
+		ID:        row.ID,
+		SiteID:    row.SiteID,
+		Name:      row.Name,
+		AlertType: sensormanager.AlertType(row.AlertType.String),
+		IsActive:  row.IsActive.Bool,
+		Steps:     make([]*sensormanager.EscalationStep, len(steps)),
+		CreatedAt: row.CreatedAt.Time,
+	}
+
+	for i, step := range steps {
+		result.Steps[i] = &sensormanager.EscalationStep{
+			ID:                 step.ID,
+			StepOrder:          step.StepOrder,
+			DelayMinutes:       step.DelayMinutes,
+			Target:             sensormanager.EscalationTarget(step.Target),
+			PushTokens:         step.PushTokens,
+			RepeatEveryMinutes: step.RepeatEveryMinutes.Int,
+			RepeatLimit:        step.RepeatLimit.Int,
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"sensormanager"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestEscalationPoliciesOfSite(t *testing.T) {
+	store, mock := newMockStore(t)
+
+	expectQuery(mock, "FROM escalation_policies WHERE site_id = $1 AND is_active = TRUE").
+		WithArgs("home").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "site_id", "name", "alert_type", "is_active", "created_at"}).
+			AddRow(1, "home", "Nuit", nil, true, time.Now()))
+	expectQuery(mock, "FROM escalation_steps WHERE policy_id = ANY($1)").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "policy_id", "step_order", "delay_minutes", "target", "push_tokens"}).
+			AddRow(10, 1, 1, 5, "all", nil))
+
+	policies, err := store.Escalations.GetEscalationPolicies("home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(policies) != 1 || policies[0].SiteID != "home" || len(policies[0].Steps) != 1 {
+		t.Fatalf("unexpected policies %+v", policies)
+	}
+
+	// Une politique d'un autre site n'est pas trouvée, même avec son identifiant.
+	expectExec(mock, "DELETE FROM escalation_policies WHERE site_id = $1 AND id = $2").
+		WithArgs("office", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := store.Escalations.DeleteEscalationPolicy("office", 1); err == nil {
+		t.Error("expected the policy of another site not to be deleted")
+	}
+
+	expectQuery(mock, "FROM alert_escalations WHERE site_id = $1 AND alert_type = $2 AND alert_id = $3").
+		WithArgs("office", "distance", int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	if records, err := store.Escalations.GetEscalationRecords("office", sensormanager.AlertTypeDistance, 7); err != nil || len(records) != 0 {
+		t.Errorf("expected no records of another site, got %+v, %v", records, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
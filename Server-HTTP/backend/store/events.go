@@ -0,0 +1,157 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sensormanager"
+	"time"
+
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type eventsStore struct{ baseStore *Store }
+
+var _ sensormanager.EventManager = (*eventsStore)(nil)
+
+type eventRow struct {
+	ID         int64     `boil:"id"`
+	Version    int       `boil:"version"`
+	EventType  string    `boil:"event_type"`
+	Subject    string    `boil:"subject"`
+	SiteID     string    `boil:"site_id"`
+	Data       null.JSON `boil:"data"`
+	OccurredAt time.Time `boil:"occurred_at"`
+}
+
+const eventColumns = "id, version, event_type, subject, site_id, data, occurred_at"
+
+// add writes the events to the outbox, and the deliveries of the webhooks subscribed to them, with the executor of
+// the change they describe, so that they are committed together. It does nothing when both are disabled.
+func (es *eventsStore) add(ctx context.Context, exec boil.ContextExecutor, events ...*sensormanager.Event) error {
+	if !es.baseStore.eventsEnabled && !es.baseStore.webhooksEnabled {
+		return nil
+	}
+
+	for _, event := range events {
+		data, err := json.Marshal(event.Data)
+		if err != nil {
+			return err
+		}
+
+		if es.baseStore.eventsEnabled {
+			if _, err := queries.Raw(
+				"INSERT INTO event_outbox (version, event_type, subject, site_id, data, occurred_at) VALUES ($1, $2, $3, $4, $5, $6)",
+				event.Version, string(event.Type), event.Subject, event.SiteID, null.JSONFrom(data), event.OccurredAt,
+			).ExecContext(ctx, exec); err != nil {
+				return errors.MapSQLError(err)
+			}
+		}
+
+		if es.baseStore.webhooksEnabled {
+			if err := es.baseStore.webhooks.addDeliveries(ctx, exec, event, data); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// withEvents runs a change and writes the events it returns in the same transaction, see add. Without outbox nor
+// webhooks, the change runs directly on the database.
+func (s *Store) withEvents(change func(ctx context.Context, exec boil.ContextExecutor) ([]*sensormanager.Event, error)) error {
+	ctx := context.TODO()
+
+	if !s.eventsEnabled && !s.webhooksEnabled {
+		_, err := change(ctx, s.db)
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.MapSQLError(err)
+	}
+	defer tx.Rollback()
+
+	events, err := change(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.events.add(ctx, tx, events...); err != nil {
+		return err
+	}
+
+	return errors.MapSQLError(tx.Commit())
+}
+
+// updateAlertStatus runs an UPDATE of the status of an alert and records its status change. The query must return
+// the device_id of the updated alert.
+func (s *Store) updateAlertStatus(alertType sensormanager.AlertType, params *sensormanager.UpdateAlertStatusParams, query string, args ...interface{}) error {
+	return s.withEvents(func(ctx context.Context, exec boil.ContextExecutor) ([]*sensormanager.Event, error) {
+		var deviceID string
+		if err := queries.Raw(query, args...).QueryRowContext(ctx, exec).Scan(&deviceID); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+
+		return []*sensormanager.Event{
+			sensormanager.NewAlertStatusEvent(alertType, params.SiteID, params.AlertID, deviceID, params.Status),
+		}, nil
+	})
+}
+
+func (es *eventsStore) GetPendingEvents(limit int) ([]*sensormanager.Event, error) {
+	var rows []*eventRow
+	if err := queries.Raw(
+		"SELECT "+eventColumns+" FROM event_outbox WHERE published_at IS NULL ORDER BY id LIMIT $1",
+		limit,
+	).Bind(context.TODO(), es.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.Event, len(rows))
+	for i, row := range rows {
+		result[i] = &sensormanager.Event{
+			ID:         row.ID,
+			Version:    row.Version,
+			Type:       sensormanager.EventType(row.EventType),
+			Subject:    row.Subject,
+			SiteID:     row.SiteID,
+			OccurredAt: row.OccurredAt.UTC(),
+			Data:       json.RawMessage(row.Data.JSON),
+		}
+	}
+
+	return result, nil
+}
+
+func (es *eventsStore) MarkEventsPublished(ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := queries.Raw(
+		"UPDATE event_outbox SET published_at = $1 WHERE id = ANY($2)",
+		time.Now(), types.Int64Array(ids),
+	).ExecContext(context.TODO(), es.baseStore.db)
+
+	return errors.MapSQLError(err)
+}
+
+func (es *eventsStore) DeletePublishedEvents(before time.Time) (int64, error) {
+	result, err := queries.Raw(
+		"DELETE FROM event_outbox WHERE published_at < $1",
+		before,
+	).ExecContext(context.TODO(), es.baseStore.db)
+	if err != nil {
+		return 0, errors.MapSQLError(err)
+	}
+
+	deleted, _ := result.RowsAffected()
+
+	return deleted, nil
+}
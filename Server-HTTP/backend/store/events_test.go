@@ -0,0 +1,89 @@
+package store
+
+import (
+	"encoding/json"
+	"sensormanager"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestUpdateAlertStatusWritesEvent(t *testing.T) {
+	store, mock := newMockStore(t, WithEventOutbox(true))
+
+	data := &captureArgument{}
+
+	mock.ExpectBegin()
+	expectQuery(mock, "UPDATE sensor_fault_alerts SET alert_status = $1, acknowledged_at = $4 WHERE id = $2").
+		WithArgs("acknowledged", int64(7), "home", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"device_id"}).AddRow("ESP_002"))
+	expectExec(mock, "INSERT INTO event_outbox").
+		WithArgs(sensormanager.EventVersion, "alert.status_changed", "events.alert.acknowledged", "home", data, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if err := store.Sensors.UpdateSensorFaultAlertStatus(&sensormanager.UpdateAlertStatusParams{
+		SiteID:  "home",
+		AlertID: 7,
+		Status:  sensormanager.AlertStatusAcknowledged,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+
+	var event sensormanager.AlertEventData
+	if err := json.Unmarshal(data.value.([]byte), &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.AlertType != sensormanager.AlertTypeSensorFault || event.AlertID != 7 || event.DeviceID != "ESP_002" {
+		t.Errorf("unexpected event data %+v", event)
+	}
+}
+
+func TestUpdateAlertStatusWithoutOutbox(t *testing.T) {
+	store, mock := newMockStore(t)
+
+	expectQuery(mock, "UPDATE sensor_fault_alerts SET alert_status = $1, resolved_at = $4 WHERE id = $2").
+		WillReturnRows(sqlmock.NewRows([]string{"device_id"}))
+
+	err := store.Sensors.UpdateSensorFaultAlertStatus(&sensormanager.UpdateAlertStatusParams{
+		SiteID:  "home",
+		AlertID: 7,
+		Status:  sensormanager.AlertStatusResolved,
+	})
+	if err == nil {
+		t.Fatal("expected the unknown alert to be reported")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateAlertStatusWritesWebhookDeliveries(t *testing.T) {
+	store, mock := newMockStore(t, WithWebhooks(true))
+
+	mock.ExpectBegin()
+	expectQuery(mock, "UPDATE sensor_fault_alerts SET alert_status = $1, resolved_at = $4 WHERE id = $2").
+		WillReturnRows(sqlmock.NewRows([]string{"device_id"}).AddRow("ESP_002"))
+	// Sans outbox, seules les livraisons des webhooks sont écrites.
+	expectExec(mock, "INSERT INTO webhook_deliveries").
+		WithArgs("alert.resolved", sensormanager.EventVersion, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "home").
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	if err := store.Sensors.UpdateSensorFaultAlertStatus(&sensormanager.UpdateAlertStatusParams{
+		SiteID:  "home",
+		AlertID: 7,
+		Status:  sensormanager.AlertStatusResolved,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,829 @@
+package store
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sensormanager"
+	"strings"
+	"time"
+
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type firmwareStore struct{ baseStore *Store }
+
+var _ sensormanager.FirmwareManager = (*firmwareStore)(nil)
+
+type firmwareRow struct {
+	ID         int64       `boil:"id"`
+	Version    string      `boil:"version"`
+	SHA256     string      `boil:"sha256"`
+	MD5        string      `boil:"md5"`
+	Size       int64       `boil:"size"`
+	Notes      null.String `boil:"notes"`
+	UploadedBy null.String `boil:"uploaded_by"`
+	CreatedAt  null.Time   `boil:"created_at"`
+}
+
+type firmwareBinaryRow struct {
+	BinaryData []byte      `boil:"binary_data"`
+	Path       null.String `boil:"path"`
+}
+
+type rolloutRow struct {
+	ID           int64            `boil:"id"`
+	SiteID       string           `boil:"site_id"`
+	FirmwareID   int64            `boil:"firmware_id"`
+	Location     null.String      `boil:"location"`
+	Stages       types.Int64Array `boil:"stages"`
+	CurrentStage int              `boil:"current_stage"`
+	Status       string           `boil:"status"`
+	HaltReason   null.String      `boil:"halt_reason"`
+	CreatedBy    null.String      `boil:"created_by"`
+	CreatedAt    null.Time        `boil:"created_at"`
+	UpdatedAt    null.Time        `boil:"updated_at"`
+
+	// Firmware déployé
+	Version           string      `boil:"version"`
+	SHA256            string      `boil:"sha256"`
+	MD5               string      `boil:"md5"`
+	Size              int64       `boil:"size"`
+	Notes             null.String `boil:"notes"`
+	UploadedBy        null.String `boil:"uploaded_by"`
+	FirmwareCreatedAt null.Time   `boil:"firmware_created_at"`
+}
+
+type deploymentRow struct {
+	RolloutID       int64       `boil:"rollout_id"`
+	DeviceID        string      `boil:"device_id"`
+	Stage           int         `boil:"stage"`
+	Status          string      `boil:"status"`
+	PreviousVersion null.String `boil:"previous_version"`
+	DeviceMAC       null.String `boil:"device_mac"`
+	OfferedAt       null.Time   `boil:"offered_at"`
+	DownloadedAt    null.Time   `boil:"downloaded_at"`
+	CompletedAt     null.Time   `boil:"completed_at"`
+	Error           null.String `boil:"error"`
+}
+
+type deviceIDRow struct {
+	DeviceID string `boil:"device_id"`
+}
+
+type deploymentCountRow struct {
+	RolloutID int64  `boil:"rollout_id"`
+	Status    string `boil:"status"`
+	Count     int    `boil:"count"`
+}
+
+const (
+	firmwareColumns = "id, version, sha256, md5, size, notes, uploaded_by, created_at"
+
+	rolloutColumns = "r.id, r.site_id, r.firmware_id, r.location, r.stages, r.current_stage, r.status, r.halt_reason, r.created_by, " +
+		"r.created_at, r.updated_at, f.version, f.sha256, f.md5, f.size, f.notes, f.uploaded_by, f.created_at AS firmware_created_at"
+	rolloutTables = "firmware_rollouts r JOIN firmwares f ON f.id = r.firmware_id"
+
+	deploymentColumns = "rollout_id, device_id, stage, status, previous_version, device_mac, offered_at, downloaded_at, completed_at, error"
+)
+
+// UploadFirmware adds the binary to the registry, on disk when a firmware directory is configured and in the database
+// otherwise.
+func (fs *firmwareStore) UploadFirmware(params *sensormanager.UploadFirmwareParams) (*sensormanager.Firmware, error) {
+	if err := params.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	ctx := context.TODO()
+
+	var exists bool
+	if err := queries.Raw(
+		"SELECT EXISTS (SELECT 1 FROM firmwares WHERE version = $1)",
+		params.Version,
+	).QueryRowContext(ctx, fs.baseStore.db).Scan(&exists); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+	if exists {
+		return nil, sensormanager.ErrFirmwareVersionExists
+	}
+
+	var (
+		binaryData []byte
+		path       null.String
+	)
+
+	if dir := fs.baseStore.firmwareDir; dir != "" {
+		// 💾 Le fichier est nommé par son empreinte : deux versions identiques partagent le même fichier.
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("could not create firmware directory: %w", err)
+		}
+
+		path = null.StringFrom(filepath.Join(dir, params.SHA256+".bin"))
+		if err := os.WriteFile(path.String, params.Binary, 0o644); err != nil {
+			return nil, fmt.Errorf("could not write firmware: %w", err)
+		}
+	} else {
+		binaryData = params.Binary
+	}
+
+	checksum := md5.Sum(params.Binary)
+
+	row := &firmwareRow{}
+	err := queries.Raw(
+		`INSERT INTO firmwares (version, sha256, md5, size, binary_data, path, notes, uploaded_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (version) DO NOTHING RETURNING `+firmwareColumns,
+		params.Version, params.SHA256, hex.EncodeToString(checksum[:]), len(params.Binary), binaryData, path,
+		nullString(params.Notes), nullString(params.UploadedBy),
+	).Bind(ctx, fs.baseStore.db, row)
+	if err == sql.ErrNoRows {
+		return nil, sensormanager.ErrFirmwareVersionExists
+	}
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	fmt.Printf("📦 Firmware %s uploaded (%d bytes)\n", row.Version, row.Size)
+
+	return row.toFirmware(), nil
+}
+
+func (fs *firmwareStore) GetFirmwares() ([]*sensormanager.Firmware, error) {
+	var rows []*firmwareRow
+	if err := queries.Raw(
+		"SELECT "+firmwareColumns+" FROM firmwares ORDER BY created_at DESC",
+	).Bind(context.TODO(), fs.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.Firmware, len(rows))
+	for i, row := range rows {
+		result[i] = row.toFirmware()
+	}
+
+	return result, nil
+}
+
+// CreateRollout resolves the target devices and spreads them between the stages. The devices of the first stage are
+// offered the firmware immediately.
+func (fs *firmwareStore) CreateRollout(params *sensormanager.CreateRolloutParams) (*sensormanager.FirmwareRollout, error) {
+	if err := params.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	ctx := context.TODO()
+
+	tx, err := fs.baseStore.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+	defer tx.Rollback()
+
+	var firmwareExists bool
+	if err := queries.Raw(
+		"SELECT EXISTS (SELECT 1 FROM firmwares WHERE id = $1)",
+		params.FirmwareID,
+	).QueryRowContext(ctx, tx).Scan(&firmwareExists); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+	if !firmwareExists {
+		return nil, sensormanager.ErrUnknownFirmware
+	}
+
+	deviceIDs, err := rolloutDevices(ctx, tx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var inProgress bool
+	if err := queries.Raw(
+		`SELECT EXISTS (SELECT 1 FROM firmware_deployments d JOIN firmware_rollouts r ON r.id = d.rollout_id
+		WHERE d.device_id = ANY($1) AND r.status IN ('active', 'paused', 'halted') AND d.status <> 'succeeded')`,
+		types.StringArray(deviceIDs),
+	).QueryRowContext(ctx, tx).Scan(&inProgress); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+	if inProgress {
+		return nil, sensormanager.ErrRolloutInProgress
+	}
+
+	stages := make(types.Int64Array, len(params.Stages))
+	for i, stage := range params.Stages {
+		stages[i] = int64(stage)
+	}
+
+	var rolloutID int64
+	if err := queries.Raw(
+		`INSERT INTO firmware_rollouts (site_id, firmware_id, location, stages, status, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		params.SiteID, params.FirmwareID, nullString(params.Location), stages,
+		string(sensormanager.RolloutStatusActive), nullString(params.CreatedBy),
+	).QueryRowContext(ctx, tx).Scan(&rolloutID); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	now := time.Now()
+	for i, deviceID := range deviceIDs {
+		stage := sensormanager.StageOf(params.Stages, i, len(deviceIDs))
+
+		status, offeredAt := sensormanager.DeploymentStatusScheduled, null.Time{}
+		if stage == 0 {
+			status, offeredAt = sensormanager.DeploymentStatusPending, null.TimeFrom(now)
+		}
+
+		if _, err := queries.Raw(
+			"INSERT INTO firmware_deployments (rollout_id, device_id, stage, status, offered_at) VALUES ($1, $2, $3, $4, $5)",
+			rolloutID, deviceID, stage, string(status), offeredAt,
+		).ExecContext(ctx, tx); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	fmt.Printf("🚀 Rollout %d of firmware %d created for %d device(s)\n", rolloutID, params.FirmwareID, len(deviceIDs))
+
+	return fs.getRollout(ctx, fs.baseStore.db, params.SiteID, rolloutID)
+}
+
+// rolloutDevices returns the listed devices, which must belong to the site, or the active devices of the location.
+func rolloutDevices(ctx context.Context, exec boil.ContextExecutor, params *sensormanager.CreateRolloutParams) ([]string, error) {
+	var rows []*deviceIDRow
+
+	if params.Location != "" {
+		if err := queries.Raw(
+			"SELECT device_id FROM devices WHERE site_id = $1 AND location = $2 AND COALESCE(status, $3) = $3 ORDER BY device_id",
+			params.SiteID, params.Location, string(sensormanager.DeviceStatusActive),
+		).Bind(ctx, exec, &rows); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+	} else {
+		if err := queries.Raw(
+			"SELECT device_id FROM devices WHERE site_id = $1 AND device_id = ANY($2) ORDER BY device_id",
+			params.SiteID, types.StringArray(params.DeviceIDs),
+		).Bind(ctx, exec, &rows); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+
+		if len(rows) != len(params.DeviceIDs) {
+			return nil, sensormanager.ErrUnknownDevice
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, sensormanager.ErrNoRolloutDevices
+	}
+
+	result := make([]string, len(rows))
+	for i, row := range rows {
+		result[i] = row.DeviceID
+	}
+
+	return result, nil
+}
+
+func (fs *firmwareStore) GetRollouts(siteID string) ([]*sensormanager.FirmwareRollout, error) {
+	return fs.getRollouts(context.TODO(), fs.baseStore.db, "r.site_id = $1 ORDER BY r.created_at DESC", siteID)
+}
+
+func (fs *firmwareStore) GetDeployments(siteID string, rolloutID int64) ([]*sensormanager.FirmwareDeployment, error) {
+	ctx := context.TODO()
+
+	if _, err := fs.getRollout(ctx, fs.baseStore.db, siteID, rolloutID); err != nil {
+		return nil, err
+	}
+
+	var rows []*deploymentRow
+	if err := queries.Raw(
+		"SELECT "+deploymentColumns+" FROM firmware_deployments WHERE rollout_id = $1 ORDER BY stage, device_id",
+		rolloutID,
+	).Bind(ctx, fs.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.FirmwareDeployment, len(rows))
+	for i, row := range rows {
+		result[i] = row.toFirmwareDeployment()
+	}
+
+	return result, nil
+}
+
+func (fs *firmwareStore) SetRolloutStatus(
+	siteID string,
+	rolloutID int64,
+	status sensormanager.RolloutStatus,
+) (*sensormanager.FirmwareRollout, error) {
+	ctx := context.TODO()
+
+	tx, err := fs.baseStore.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+	defer tx.Rollback()
+
+	var current string
+	err = queries.Raw(
+		"SELECT status FROM firmware_rollouts WHERE id = $1 AND site_id = $2 FOR UPDATE",
+		rolloutID, siteID,
+	).QueryRowContext(ctx, tx).Scan(&current)
+	if err == sql.ErrNoRows {
+		return nil, sensormanager.ErrUnknownRollout
+	}
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	switch sensormanager.RolloutStatus(current) {
+	case sensormanager.RolloutStatusActive:
+		if status != sensormanager.RolloutStatusPaused && status != sensormanager.RolloutStatusCancelled {
+			return nil, sensormanager.ErrInvalidRolloutStatus
+		}
+	case sensormanager.RolloutStatusPaused, sensormanager.RolloutStatusHalted:
+		if status != sensormanager.RolloutStatusActive && status != sensormanager.RolloutStatusCancelled {
+			return nil, sensormanager.ErrInvalidRolloutStatus
+		}
+	default:
+		return nil, sensormanager.ErrInvalidRolloutStatus
+	}
+
+	now := time.Now()
+
+	// 🔁 La reprise propose à nouveau le firmware aux appareils en échec, sinon le déploiement s'arrêterait aussitôt.
+	if status == sensormanager.RolloutStatusActive {
+		if _, err := queries.Raw(
+			`UPDATE firmware_deployments SET status = $1, offered_at = $2, downloaded_at = NULL, device_mac = NULL, completed_at = NULL, error = NULL
+			WHERE rollout_id = $3 AND status IN ('failed', 'unresponsive')`,
+			string(sensormanager.DeploymentStatusPending), now, rolloutID,
+		).ExecContext(ctx, tx); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+	}
+
+	if _, err := queries.Raw(
+		"UPDATE firmware_rollouts SET status = $1, halt_reason = NULL, updated_at = $2 WHERE id = $3",
+		string(status), now, rolloutID,
+	).ExecContext(ctx, tx); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return fs.getRollout(ctx, fs.baseStore.db, siteID, rolloutID)
+}
+
+// CheckUpdate authenticates the device with its secret, which is optional for devices that have not been provisioned
+// when signatures are not required. Without a secret, the requests of the device are tied to its MAC address: the
+// status of its deployment only changes on a request from the MAC address that downloaded the binary.
+func (fs *firmwareStore) CheckUpdate(request *sensormanager.FirmwareUpdateRequest) (*sensormanager.Firmware, []byte, error) {
+	ctx := context.TODO()
+
+	device := &deviceRow{}
+	err := queries.Raw(
+		"SELECT "+deviceColumns+" FROM devices WHERE device_id = $1",
+		request.DeviceID,
+	).Bind(ctx, fs.baseStore.db, device)
+	if err == sql.ErrNoRows {
+		return nil, nil, sensormanager.ErrUnknownDevice
+	}
+	if err != nil {
+		return nil, nil, errors.MapSQLError(err)
+	}
+
+	if device.Status.String == string(sensormanager.DeviceStatusDisabled) {
+		return nil, nil, sensormanager.ErrDeviceDisabled
+	}
+
+	if device.Secret.Valid {
+		if subtle.ConstantTimeCompare([]byte(request.Secret), []byte(device.Secret.String)) != 1 {
+			return nil, nil, sensormanager.ErrInvalidDeviceSecret
+		}
+	} else if fs.baseStore.signatureRequired || request.MAC == "" {
+		return nil, nil, sensormanager.ErrInvalidDeviceSecret
+	}
+
+	deployment := &deploymentRow{}
+	err = queries.Raw(
+		`SELECT d.rollout_id, d.device_id, d.stage, d.status, d.previous_version, d.device_mac, d.offered_at, d.downloaded_at, d.completed_at, d.error
+		FROM firmware_deployments d JOIN firmware_rollouts r ON r.id = d.rollout_id
+		WHERE d.device_id = $1 AND r.status = $2 AND d.status IN ('pending', 'installing')
+		ORDER BY r.created_at DESC LIMIT 1`,
+		request.DeviceID, string(sensormanager.RolloutStatusActive),
+	).Bind(ctx, fs.baseStore.db, deployment)
+	if err == sql.ErrNoRows {
+		return nil, nil, sensormanager.ErrNoFirmwareUpdate
+	}
+	if err != nil {
+		return nil, nil, errors.MapSQLError(err)
+	}
+
+	rollout, err := fs.getRollout(ctx, fs.baseStore.db, device.SiteID, deployment.RolloutID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	firmware := rollout.Firmware
+
+	// 🔐 Sans secret, seul l'appareil qui a téléchargé le binaire peut en rapporter l'installation : les requêtes
+	// d'une autre adresse MAC, ou d'avant le téléchargement, ne changent pas le statut du déploiement.
+	installing := deployment.Status == string(sensormanager.DeploymentStatusInstalling)
+	if !device.Secret.Valid && installing && !strings.EqualFold(request.MAC, deployment.DeviceMAC.String) {
+		return nil, nil, sensormanager.ErrNoFirmwareUpdate
+	}
+	reports := device.Secret.Valid || installing
+
+	// ✅ L'appareil annonce la version déployée : il a redémarré sur le nouveau firmware.
+	if request.CurrentVersion == firmware.Version {
+		if !reports {
+			return nil, nil, sensormanager.ErrNoFirmwareUpdate
+		}
+		if err := fs.completeDeployment(ctx, deployment, sensormanager.DeploymentStatusSucceeded, "", now); err != nil {
+			return nil, nil, err
+		}
+
+		return nil, nil, sensormanager.ErrNoFirmwareUpdate
+	}
+
+	// L'appareil a déjà téléchargé le binaire mais tourne toujours sur l'ancienne version : l'installation a échoué.
+	if installing {
+		message := "device still runs version " + request.CurrentVersion + " after the download"
+		if err := fs.completeDeployment(ctx, deployment, sensormanager.DeploymentStatusFailed, message, now); err != nil {
+			return nil, nil, err
+		}
+
+		return nil, nil, sensormanager.ErrNoFirmwareUpdate
+	}
+
+	if request.FreeSpace != nil && *request.FreeSpace < firmware.Size {
+		if !reports {
+			return nil, nil, sensormanager.ErrFirmwareTooLarge
+		}
+		message := fmt.Sprintf("%d bytes free for a %d bytes firmware", *request.FreeSpace, firmware.Size)
+		if err := fs.completeDeployment(ctx, deployment, sensormanager.DeploymentStatusFailed, message, now); err != nil {
+			return nil, nil, err
+		}
+
+		return nil, nil, sensormanager.ErrFirmwareTooLarge
+	}
+
+	binary, err := fs.loadBinary(ctx, firmware)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := queries.Raw(
+		"UPDATE firmware_deployments SET status = $1, previous_version = $2, device_mac = $3, downloaded_at = $4 WHERE rollout_id = $5 AND device_id = $6",
+		string(sensormanager.DeploymentStatusInstalling), nullString(request.CurrentVersion), nullString(request.MAC), now,
+		deployment.RolloutID, deployment.DeviceID,
+	).ExecContext(ctx, fs.baseStore.db); err != nil {
+		return nil, nil, errors.MapSQLError(err)
+	}
+
+	fmt.Printf("📥 Device %s downloads firmware %s (rollout %d)\n", request.DeviceID, firmware.Version, rollout.ID)
+
+	return firmware, binary, nil
+}
+
+func (fs *firmwareStore) completeDeployment(
+	ctx context.Context,
+	deployment *deploymentRow,
+	status sensormanager.DeploymentStatus,
+	message string,
+	now time.Time,
+) error {
+	if _, err := queries.Raw(
+		"UPDATE firmware_deployments SET status = $1, error = $2, completed_at = $3 WHERE rollout_id = $4 AND device_id = $5",
+		string(status), nullString(message), now, deployment.RolloutID, deployment.DeviceID,
+	).ExecContext(ctx, fs.baseStore.db); err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	return nil
+}
+
+// loadBinary reads the binary of the firmware. A file on disk is checked against the SHA256 of the upload.
+func (fs *firmwareStore) loadBinary(ctx context.Context, firmware *sensormanager.Firmware) ([]byte, error) {
+	row := &firmwareBinaryRow{}
+	if err := queries.Raw(
+		"SELECT binary_data, path FROM firmwares WHERE id = $1",
+		firmware.ID,
+	).Bind(ctx, fs.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if !row.Path.Valid {
+		return row.BinaryData, nil
+	}
+
+	binary, err := os.ReadFile(row.Path.String)
+	if err != nil {
+		return nil, fmt.Errorf("could not read firmware %s: %w", firmware.Version, err)
+	}
+
+	checksum := sha256.Sum256(binary)
+	if hex.EncodeToString(checksum[:]) != firmware.SHA256 {
+		return nil, fmt.Errorf("firmware %s: %w", firmware.Version, sensormanager.ErrFirmwareChecksumMismatch)
+	}
+
+	return binary, nil
+}
+
+// AdvanceRollouts completes the deployments from the telemetry of the devices: the firmware version reported after
+// the download tells whether the installation succeeded.
+func (fs *firmwareStore) AdvanceRollouts(now time.Time, healthTimeout time.Duration) ([]*sensormanager.FirmwareRollout, error) {
+	ctx := context.TODO()
+
+	rollouts, err := fs.getRollouts(ctx, fs.baseStore.db, "r.status = $1 ORDER BY r.id", string(sensormanager.RolloutStatusActive))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*sensormanager.FirmwareRollout
+	for _, rollout := range rollouts {
+		changed, err := fs.advanceRollout(ctx, rollout, now, healthTimeout)
+		if err != nil {
+			return result, fmt.Errorf("rollout %d: %w", rollout.ID, err)
+		}
+
+		if !changed {
+			continue
+		}
+
+		updated, err := fs.getRollout(ctx, fs.baseStore.db, rollout.SiteID, rollout.ID)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, updated)
+	}
+
+	return result, nil
+}
+
+func (fs *firmwareStore) advanceRollout(
+	ctx context.Context,
+	rollout *sensormanager.FirmwareRollout,
+	now time.Time,
+	healthTimeout time.Duration,
+) (bool, error) {
+	tx, err := fs.baseStore.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, errors.MapSQLError(err)
+	}
+	defer tx.Rollback()
+
+	// Télémétrie reçue depuis le téléchargement : la version rapportée indique le résultat de l'installation.
+	if _, err := queries.Raw(
+		`UPDATE firmware_deployments d SET
+			status = CASE WHEN dev.firmware_version = $2 THEN 'succeeded' ELSE 'failed' END,
+			error = CASE WHEN dev.firmware_version = $2 THEN NULL ELSE 'device reported version ' || COALESCE(dev.firmware_version, 'unknown') END,
+			completed_at = $3
+		FROM devices dev
+		WHERE d.rollout_id = $1 AND dev.device_id = d.device_id AND d.status = 'installing' AND dev.telemetry_at > d.downloaded_at`,
+		rollout.ID, rollout.Firmware.Version, now,
+	).ExecContext(ctx, tx); err != nil {
+		return false, errors.MapSQLError(err)
+	}
+
+	// Appareils déjà à jour avant de télécharger le firmware
+	if _, err := queries.Raw(
+		`UPDATE firmware_deployments d SET status = 'succeeded', completed_at = $3
+		FROM devices dev
+		WHERE d.rollout_id = $1 AND dev.device_id = d.device_id AND d.status = 'pending'
+		AND dev.firmware_version = $2 AND dev.telemetry_at > d.offered_at`,
+		rollout.ID, rollout.Firmware.Version, now,
+	).ExecContext(ctx, tx); err != nil {
+		return false, errors.MapSQLError(err)
+	}
+
+	// ⏱️ Un appareil sans nouvelles après le téléchargement n'a probablement pas redémarré correctement.
+	if _, err := queries.Raw(
+		`UPDATE firmware_deployments SET status = 'unresponsive', error = $2, completed_at = $3
+		WHERE rollout_id = $1 AND status = 'installing' AND downloaded_at < $4`,
+		rollout.ID, fmt.Sprintf("no report within %s of the download", healthTimeout), now, now.Add(-healthTimeout),
+	).ExecContext(ctx, tx); err != nil {
+		return false, errors.MapSQLError(err)
+	}
+
+	var failures []*deploymentRow
+	if err := queries.Raw(
+		"SELECT "+deploymentColumns+" FROM firmware_deployments WHERE rollout_id = $1 AND status IN ('failed', 'unresponsive') ORDER BY device_id",
+		rollout.ID,
+	).Bind(ctx, tx, &failures); err != nil {
+		return false, errors.MapSQLError(err)
+	}
+
+	if len(failures) > 0 {
+		reasons := make([]string, len(failures))
+		for i, failure := range failures {
+			reasons[i] = failure.DeviceID + ": " + failure.Error.String
+		}
+
+		if _, err := queries.Raw(
+			"UPDATE firmware_rollouts SET status = $1, halt_reason = $2, updated_at = $3 WHERE id = $4",
+			string(sensormanager.RolloutStatusHalted), strings.Join(reasons, "; "), now, rollout.ID,
+		).ExecContext(ctx, tx); err != nil {
+			return false, errors.MapSQLError(err)
+		}
+
+		fmt.Printf("🛑 Rollout %d halted: %s\n", rollout.ID, strings.Join(reasons, "; "))
+
+		if err := tx.Commit(); err != nil {
+			return false, errors.MapSQLError(err)
+		}
+
+		return true, nil
+	}
+
+	var remaining int
+	if err := queries.Raw(
+		"SELECT COUNT(*) FROM firmware_deployments WHERE rollout_id = $1 AND stage <= $2 AND status <> 'succeeded'",
+		rollout.ID, rollout.CurrentStage,
+	).QueryRowContext(ctx, tx).Scan(&remaining); err != nil {
+		return false, errors.MapSQLError(err)
+	}
+
+	if remaining > 0 {
+		if err := tx.Commit(); err != nil {
+			return false, errors.MapSQLError(err)
+		}
+
+		return false, nil
+	}
+
+	if next := rollout.CurrentStage + 1; next < len(rollout.Stages) {
+		if _, err := queries.Raw(
+			"UPDATE firmware_deployments SET status = $1, offered_at = $2 WHERE rollout_id = $3 AND stage = $4 AND status = 'scheduled'",
+			string(sensormanager.DeploymentStatusPending), now, rollout.ID, next,
+		).ExecContext(ctx, tx); err != nil {
+			return false, errors.MapSQLError(err)
+		}
+
+		if _, err := queries.Raw(
+			"UPDATE firmware_rollouts SET current_stage = $1, updated_at = $2 WHERE id = $3",
+			next, now, rollout.ID,
+		).ExecContext(ctx, tx); err != nil {
+			return false, errors.MapSQLError(err)
+		}
+
+		fmt.Printf("🚀 Rollout %d: stage %d opened (%d%%)\n", rollout.ID, next+1, rollout.Stages[next])
+	} else {
+		if _, err := queries.Raw(
+			"UPDATE firmware_rollouts SET status = $1, updated_at = $2 WHERE id = $3",
+			string(sensormanager.RolloutStatusCompleted), now, rollout.ID,
+		).ExecContext(ctx, tx); err != nil {
+			return false, errors.MapSQLError(err)
+		}
+
+		fmt.Printf("✅ Rollout %d completed\n", rollout.ID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, errors.MapSQLError(err)
+	}
+
+	return true, nil
+}
+
+func (fs *firmwareStore) getRollout(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	siteID string,
+	rolloutID int64,
+) (*sensormanager.FirmwareRollout, error) {
+	rollouts, err := fs.getRollouts(ctx, exec, "r.site_id = $1 AND r.id = $2", siteID, rolloutID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rollouts) == 0 {
+		return nil, sensormanager.ErrUnknownRollout
+	}
+
+	return rollouts[0], nil
+}
+
+// getRollouts returns the rollouts matching the condition, with the number of devices in each deployment status.
+func (fs *firmwareStore) getRollouts(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	condition string,
+	args ...interface{},
+) ([]*sensormanager.FirmwareRollout, error) {
+	var rows []*rolloutRow
+	if err := queries.Raw(
+		"SELECT "+rolloutColumns+" FROM "+rolloutTables+" WHERE "+condition,
+		args...,
+	).Bind(ctx, exec, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	ids := make(types.Int64Array, len(rows))
+	result := make([]*sensormanager.FirmwareRollout, len(rows))
+	byID := make(map[int64]*sensormanager.FirmwareRollout, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+		result[i] = row.toFirmwareRollout()
+		byID[row.ID] = result[i]
+	}
+
+	var counts []*deploymentCountRow
+	if err := queries.Raw(
+		"SELECT rollout_id, status, COUNT(*) AS count FROM firmware_deployments WHERE rollout_id = ANY($1) GROUP BY rollout_id, status",
+		ids,
+	).Bind(ctx, exec, &counts); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	for _, count := range counts {
+		byID[count.RolloutID].Deployments[sensormanager.DeploymentStatus(count.Status)] = count.Count
+	}
+
+	return result, nil
+}
+
+func (row *firmwareRow) toFirmware() *sensormanager.Firmware {
+	return &sensormanager.Firmware{
+		ID:         row.ID,
+		Version:    row.Version,
+		SHA256:     row.SHA256,
+		MD5:        row.MD5,
+		Size:       row.Size,
+		Notes:      row.Notes.String,
+		UploadedBy: row.UploadedBy.String,
+		CreatedAt:  row.CreatedAt.Time,
+	}
+}
+
+func (row *rolloutRow) toFirmwareRollout() *sensormanager.FirmwareRollout {
+	stages := make([]int, len(row.Stages))
+	for i, stage := range row.Stages {
+		stages[i] = int(stage)
+	}
+
+	firmware := &firmwareRow{
+		ID:         row.FirmwareID,
+		Version:    row.Version,
+		SHA256:     row.SHA256,
+		MD5:        row.MD5,
+		Size:       row.Size,
+		Notes:      row.Notes,
+		UploadedBy: row.UploadedBy,
+		CreatedAt:  row.FirmwareCreatedAt,
+	}
+
+	return &sensormanager.FirmwareRollout{
+		ID:           row.ID,
+		SiteID:       row.SiteID,
+		Firmware:     firmware.toFirmware(),
+		Location:     row.Location.String,
+		Stages:       stages,
+		CurrentStage: row.CurrentStage,
+		Status:       sensormanager.RolloutStatus(row.Status),
+		HaltReason:   row.HaltReason.String,
+		CreatedBy:    row.CreatedBy.String,
+		Deployments:  make(map[sensormanager.DeploymentStatus]int),
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+	}
+}
+
+func (row *deploymentRow) toFirmwareDeployment() *sensormanager.FirmwareDeployment {
+	result := &sensormanager.FirmwareDeployment{
+		RolloutID:       row.RolloutID,
+		DeviceID:        row.DeviceID,
+		Stage:           row.Stage,
+		Status:          sensormanager.DeploymentStatus(row.Status),
+		PreviousVersion: row.PreviousVersion.String,
+		Error:           row.Error.String,
+	}
+
+	if row.OfferedAt.Valid {
+		result.OfferedAt = &row.OfferedAt.Time
+	}
+	if row.DownloadedAt.Valid {
+		result.DownloadedAt = &row.DownloadedAt.Time
+	}
+	if row.CompletedAt.Valid {
+		result.CompletedAt = &row.CompletedAt.Time
+	}
+
+	return result
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"sensormanager"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// expectInstallingDeployment expects the update check of ESP_OTA, a device without secret that downloaded the firmware
+// 1.1.0 from the MAC address AA:BB:CC:DD:EE:FF.
+func expectInstallingDeployment(mock sqlmock.Sqlmock) {
+	expectQuery(mock, "FROM devices WHERE device_id = $1").
+		WithArgs("ESP_OTA").
+		WillReturnRows(sqlmock.NewRows([]string{"site_id", "device_id", "status"}).AddRow("home", "ESP_OTA", "active"))
+	expectQuery(mock, "FROM firmware_deployments d JOIN firmware_rollouts r").
+		WillReturnRows(sqlmock.NewRows([]string{"rollout_id", "device_id", "status", "previous_version", "device_mac"}).
+			AddRow(1, "ESP_OTA", "installing", "1.0.0", "AA:BB:CC:DD:EE:FF"))
+	expectQuery(mock, "FROM firmware_rollouts r JOIN firmwares f").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "site_id", "status", "version"}).AddRow(1, "home", "active", "1.1.0"))
+	expectQuery(mock, "FROM firmware_deployments WHERE rollout_id = ANY($1)").
+		WillReturnRows(sqlmock.NewRows([]string{"rollout_id", "status", "count"}))
+}
+
+func TestCheckUpdateTiedToTheDownloadingMAC(t *testing.T) {
+	store, mock := newMockStore(t, WithDeviceSignatures(false, time.Minute))
+
+	// 🕵️ Une autre adresse MAC annonce la nouvelle version : le déploiement ne change pas.
+	expectInstallingDeployment(mock)
+
+	_, _, err := store.Firmware.CheckUpdate(&sensormanager.FirmwareUpdateRequest{
+		DeviceID:       "ESP_OTA",
+		CurrentVersion: "1.1.0",
+		MAC:            "11:22:33:44:55:66",
+	})
+	if err != sensormanager.ErrNoFirmwareUpdate {
+		t.Fatalf("got error %v, want %v", err, sensormanager.ErrNoFirmwareUpdate)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+
+	// ✅ L'appareil qui a téléchargé le binaire rapporte l'installation.
+	expectInstallingDeployment(mock)
+	expectExec(mock, "UPDATE firmware_deployments SET status = $1, error = $2, completed_at = $3").
+		WithArgs("succeeded", nil, sqlmock.AnyArg(), 1, "ESP_OTA").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	_, _, err = store.Firmware.CheckUpdate(&sensormanager.FirmwareUpdateRequest{
+		DeviceID:       "ESP_OTA",
+		CurrentVersion: "1.1.0",
+		MAC:            "aa:bb:cc:dd:ee:ff",
+	})
+	if err != sensormanager.ErrNoFirmwareUpdate {
+		t.Fatalf("got error %v, want %v", err, sensormanager.ErrNoFirmwareUpdate)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckUpdateWithoutCredential(t *testing.T) {
+	store, mock := newMockStore(t, WithDeviceSignatures(false, time.Minute))
+
+	expectQuery(mock, "FROM devices WHERE device_id = $1").
+		WillReturnRows(sqlmock.NewRows([]string{"site_id", "device_id", "status"}).AddRow("home", "ESP_OTA", "active"))
+
+	_, _, err := store.Firmware.CheckUpdate(&sensormanager.FirmwareUpdateRequest{DeviceID: "ESP_OTA", CurrentVersion: "1.1.0"})
+	if err != sensormanager.ErrInvalidDeviceSecret {
+		t.Fatalf("got error %v, want %v", err, sensormanager.ErrInvalidDeviceSecret)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
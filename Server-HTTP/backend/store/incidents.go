@@ -0,0 +1,372 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sensormanager"
+	"time"
+
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type incidentsStore struct{ baseStore *Store }
+
+var _ sensormanager.IncidentManager = (*incidentsStore)(nil)
+
+type incidentRow struct {
+	ID             int64     `boil:"id"`
+	SiteID         string    `boil:"site_id"`
+	GroupKey       string    `boil:"group_key"`
+	Status         string    `boil:"status"`
+	AlertCount     int       `boil:"alert_count"`
+	OpenedAt       null.Time `boil:"opened_at"`
+	LastAlertAt    null.Time `boil:"last_alert_at"`
+	AcknowledgedAt null.Time `boil:"acknowledged_at"`
+	ClosedAt       null.Time `boil:"closed_at"`
+}
+
+type incidentAlertRow struct {
+	IncidentID int64       `boil:"incident_id"`
+	AlertType  string      `boil:"alert_type"`
+	AlertID    int64       `boil:"alert_id"`
+	DeviceID   string      `boil:"device_id"`
+	Message    null.String `boil:"message"`
+	CreatedAt  null.Time   `boil:"created_at"`
+}
+
+type cascadedAlertRow struct {
+	ID       int64  `boil:"id"`
+	DeviceID string `boil:"device_id"`
+}
+
+const (
+	incidentColumns      = "id, site_id, group_key, status, alert_count, opened_at, last_alert_at, acknowledged_at, closed_at"
+	incidentAlertColumns = "incident_id, alert_type, alert_id, device_id, message, created_at"
+)
+
+// alertTables associe les types d'alerte qui ne viennent pas du registre à leur table.
+var alertTables = map[sensormanager.AlertType]string{
+	sensormanager.AlertTypeSensorFault: "sensor_fault_alerts",
+	sensormanager.AlertTypeTelemetry:   "telemetry_alerts",
+}
+
+// alertTable retourne la table des alertes du type, sensor_alerts pour les types du registre.
+func alertTable(alertType sensormanager.AlertType) (string, bool) {
+	if table, exists := alertTables[alertType]; exists {
+		return table, true
+	}
+
+	if _, err := sensormanager.LookupSensorType(sensormanager.SensorType(alertType)); err == nil {
+		return "sensor_alerts", true
+	}
+
+	return "", false
+}
+
+func (is *incidentsStore) AttachAlert(params *sensormanager.AttachAlertParams) (*sensormanager.Incident, bool, error) {
+	ctx := context.TODO()
+
+	tx, err := is.baseStore.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, errors.MapSQLError(err)
+	}
+	defer tx.Rollback()
+
+	groupKey, err := incidentGroupKey(ctx, tx, params.DeviceID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Sérialise les alertes d'un même groupe pour ne pas ouvrir deux incidents en parallèle.
+	if _, err := queries.Raw("SELECT pg_advisory_xact_lock(hashtext($1))", params.SiteID+"|"+groupKey).ExecContext(ctx, tx); err != nil {
+		return nil, false, errors.MapSQLError(err)
+	}
+
+	now := time.Now()
+	created := false
+
+	row := &incidentRow{}
+	err = queries.Raw(
+		"SELECT "+incidentColumns+" FROM incidents WHERE site_id = $1 AND group_key = $2 AND status <> $3 AND last_alert_at >= $4 ORDER BY last_alert_at DESC LIMIT 1",
+		params.SiteID, groupKey, string(sensormanager.IncidentStatusClosed), now.Add(-is.baseStore.incidentWindow),
+	).Bind(ctx, tx, row)
+
+	switch {
+	case err == nil:
+		err = queries.Raw(
+			"UPDATE incidents SET alert_count = alert_count + 1, last_alert_at = $1 WHERE id = $2 RETURNING "+incidentColumns,
+			now, row.ID,
+		).Bind(ctx, tx, row)
+	case err == sql.ErrNoRows:
+		created = true
+		err = queries.Raw(
+			"INSERT INTO incidents (site_id, group_key, status, alert_count, opened_at, last_alert_at) VALUES ($1, $2, $3, 1, $4, $4) RETURNING "+incidentColumns,
+			params.SiteID, groupKey, string(sensormanager.IncidentStatusOpen), now,
+		).Bind(ctx, tx, row)
+	}
+	if err != nil {
+		return nil, false, errors.MapSQLError(err)
+	}
+
+	if _, err := queries.Raw(
+		"INSERT INTO incident_alerts (incident_id, alert_type, alert_id, device_id, message, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		row.ID, string(params.AlertType), params.AlertID, params.DeviceID, null.NewString(params.Message, params.Message != ""), now,
+	).ExecContext(ctx, tx); err != nil {
+		return nil, false, errors.MapSQLError(err)
+	}
+
+	alerts, err := getIncidentAlerts(ctx, tx, row.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, errors.MapSQLError(err)
+	}
+
+	return row.toIncident(alerts), created, nil
+}
+
+// attachIncident groups a recorded alert into an incident, whether or not it is notified afterwards. An alert that
+// cannot be grouped is still recorded: the error is only logged, and the alert is notified on its own.
+func (s *Store) attachIncident(alertType sensormanager.AlertType, alert *sensormanager.AlertResponse) {
+	incident, opened, err := s.Incidents.AttachAlert(&sensormanager.AttachAlertParams{
+		SiteID:    alert.SiteID,
+		AlertType: alertType,
+		AlertID:   alert.AlertID,
+		DeviceID:  alert.DeviceID,
+		Message:   alert.Message,
+	})
+	if err != nil {
+		fmt.Printf("❌ Could not attach %s alert %d to an incident: %v\n", alertType, alert.AlertID, err)
+		return
+	}
+
+	alert.Incident, alert.IncidentOpened = incident, opened
+}
+
+func (is *incidentsStore) GetIncidents(params *sensormanager.GetIncidentsParams) ([]*sensormanager.Incident, error) {
+	ctx := context.TODO()
+
+	query := "SELECT " + incidentColumns + " FROM incidents WHERE site_id = $1"
+	args := []interface{}{params.SiteID}
+
+	if params.Status != "" {
+		args = append(args, string(params.Status))
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+
+	query += " ORDER BY last_alert_at DESC"
+
+	if params.Limit > 0 {
+		args = append(args, params.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	var rows []*incidentRow
+	if err := queries.Raw(query, args...).Bind(ctx, is.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.Incident, len(rows))
+	for i, row := range rows {
+		alerts, err := getIncidentAlerts(ctx, is.baseStore.db, row.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = row.toIncident(alerts)
+	}
+
+	return result, nil
+}
+
+func (is *incidentsStore) GetIncident(siteID string, incidentID int64) (*sensormanager.Incident, error) {
+	ctx := context.TODO()
+
+	row := &incidentRow{}
+	if err := queries.Raw(
+		"SELECT "+incidentColumns+" FROM incidents WHERE site_id = $1 AND id = $2",
+		siteID, incidentID,
+	).Bind(ctx, is.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	alerts, err := getIncidentAlerts(ctx, is.baseStore.db, row.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return row.toIncident(alerts), nil
+}
+
+func (is *incidentsStore) UpdateIncidentStatus(params *sensormanager.UpdateIncidentStatusParams) error {
+	if err := params.Status.Validate(); err != nil {
+		return err
+	}
+
+	ctx := context.TODO()
+
+	tx, err := is.baseStore.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.MapSQLError(err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	query := "UPDATE incidents SET status = $1 WHERE id = $2 AND site_id = $3"
+	args := []interface{}{string(params.Status), params.IncidentID, params.SiteID}
+
+	switch params.Status {
+	case sensormanager.IncidentStatusAcknowledged:
+		query = "UPDATE incidents SET status = $1, acknowledged_at = $4 WHERE id = $2 AND site_id = $3"
+		args = append(args, now)
+	case sensormanager.IncidentStatusClosed:
+		query = "UPDATE incidents SET status = $1, closed_at = $4 WHERE id = $2 AND site_id = $3"
+		args = append(args, now)
+	}
+
+	result, err := queries.Raw(query, args...).ExecContext(ctx, tx)
+	if err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.MapSQLError(sql.ErrNoRows)
+	}
+
+	events, err := cascadeIncidentStatus(ctx, tx, params.SiteID, params.IncidentID, params.Status, now)
+	if err != nil {
+		return err
+	}
+
+	if err := is.baseStore.events.add(ctx, tx, events...); err != nil {
+		return err
+	}
+
+	return errors.MapSQLError(tx.Commit())
+}
+
+// cascadeIncidentStatus acquitte ou résout les alertes membres de l'incident, et retourne les événements de leurs
+// changements de statut.
+func cascadeIncidentStatus(
+	ctx context.Context,
+	exec boil.ContextExecutor,
+	siteID string,
+	incidentID int64,
+	status sensormanager.IncidentStatus,
+	now time.Time,
+) ([]*sensormanager.Event, error) {
+	var alertStatus sensormanager.AlertStatus
+	var query string
+
+	switch status {
+	case sensormanager.IncidentStatusAcknowledged:
+		alertStatus = sensormanager.AlertStatusAcknowledged
+		query = "UPDATE %s SET alert_status = $1, acknowledged_at = $2 WHERE id = ANY($3) AND alert_status = 'active' RETURNING id, device_id"
+	case sensormanager.IncidentStatusClosed:
+		alertStatus = sensormanager.AlertStatusResolved
+		query = "UPDATE %s SET alert_status = $1, resolved_at = $2 WHERE id = ANY($3) AND alert_status <> 'resolved' RETURNING id, device_id"
+	default:
+		return nil, nil
+	}
+
+	alerts, err := getIncidentAlerts(ctx, exec, incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	alertIDs := make(map[sensormanager.AlertType]types.Int64Array)
+	for _, alert := range alerts {
+		alertIDs[alert.AlertType] = append(alertIDs[alert.AlertType], alert.AlertID)
+	}
+
+	var events []*sensormanager.Event
+	for alertType, ids := range alertIDs {
+		table, exists := alertTable(alertType)
+		if !exists {
+			continue
+		}
+
+		var updated []*cascadedAlertRow
+		if err := queries.Raw(fmt.Sprintf(query, table), string(alertStatus), now, ids).Bind(ctx, exec, &updated); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+
+		for _, row := range updated {
+			events = append(events, sensormanager.NewAlertStatusEvent(alertType, siteID, row.ID, row.DeviceID, alertStatus))
+		}
+	}
+
+	return events, nil
+}
+
+// incidentGroupKey regroupe par emplacement lorsque l'appareil en a un, sinon par appareil.
+func incidentGroupKey(ctx context.Context, exec boil.ContextExecutor, deviceID string) (string, error) {
+	var location null.String
+	err := queries.Raw(
+		"SELECT location FROM devices WHERE device_id = $1",
+		deviceID,
+	).QueryRowContext(ctx, exec).Scan(&location)
+	if err != nil && err != sql.ErrNoRows {
+		return "", errors.MapSQLError(err)
+	}
+
+	if location.Valid && location.String != "" {
+		return "location:" + location.String, nil
+	}
+
+	return "device:" + deviceID, nil
+}
+
+func getIncidentAlerts(ctx context.Context, exec boil.ContextExecutor, incidentID int64) ([]*sensormanager.IncidentAlert, error) {
+	var rows []*incidentAlertRow
+	if err := queries.Raw(
+		"SELECT "+incidentAlertColumns+" FROM incident_alerts WHERE incident_id = $1 ORDER BY created_at",
+		incidentID,
+	).Bind(ctx, exec, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.IncidentAlert, len(rows))
+	for i, row := range rows {
+		result[i] = &sensormanager.IncidentAlert{
+			AlertType: sensormanager.AlertType(row.AlertType),
+			AlertID:   row.AlertID,
+			DeviceID:  row.DeviceID,
+			Message:   row.Message.String,
+			CreatedAt: row.CreatedAt.Time,
+		}
+	}
+
+	return result, nil
+}
+
+func (row *incidentRow) toIncident(alerts []*sensormanager.IncidentAlert) *sensormanager.Incident {
+	var ackAt, closedAt *time.Time
+	if row.AcknowledgedAt.Valid {
+		ackAt = &row.AcknowledgedAt.Time
+	}
+	if row.ClosedAt.Valid {
+		closedAt = &row.ClosedAt.Time
+	}
+
+	return &sensormanager.Incident{
+		ID:             row.ID,
+		SiteID:         row.SiteID,
+		GroupKey:       row.GroupKey,
+		Status:         sensormanager.IncidentStatus(row.Status),
+		AlertCount:     row.AlertCount,
+		Alerts:         alerts,
+		OpenedAt:       row.OpenedAt.Time,
+		LastAlertAt:    row.LastAlertAt.Time,
+		AcknowledgedAt: ackAt,
+		ClosedAt:       closedAt,
+	}
+}
@@ -7,11 +7,7 @@ import "testing"
 
 // TestToOne tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOne(t *testing.T) {
-	t.Run("DistanceAlertToDistanceDatumUsingDatum", testDistanceAlertToOneDistanceDatumUsingDatum)
-	t.Run("MicrophoneAlertToMicrophoneDatumUsingDatum", testMicrophoneAlertToOneMicrophoneDatumUsingDatum)
-	t.Run("MotionAlertToMotionDatumUsingDatum", testMotionAlertToOneMotionDatumUsingDatum)
-}
+func TestToOne(t *testing.T) {}
 
 // TestOneToOne tests cannot be run in parallel
 // or deadlocks can occur.
@@ -19,27 +15,15 @@ func TestOneToOne(t *testing.T) {}
 
 // TestToMany tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToMany(t *testing.T) {
-	t.Run("DistanceDatumToDatumDistanceAlerts", testDistanceDatumToManyDatumDistanceAlerts)
-	t.Run("MicrophoneDatumToDatumMicrophoneAlerts", testMicrophoneDatumToManyDatumMicrophoneAlerts)
-	t.Run("MotionDatumToDatumMotionAlerts", testMotionDatumToManyDatumMotionAlerts)
-}
+func TestToMany(t *testing.T) {}
 
 // TestToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOneSet(t *testing.T) {
-	t.Run("DistanceAlertToDistanceDatumUsingDatumDistanceAlerts", testDistanceAlertToOneSetOpDistanceDatumUsingDatum)
-	t.Run("MicrophoneAlertToMicrophoneDatumUsingDatumMicrophoneAlerts", testMicrophoneAlertToOneSetOpMicrophoneDatumUsingDatum)
-	t.Run("MotionAlertToMotionDatumUsingDatumMotionAlerts", testMotionAlertToOneSetOpMotionDatumUsingDatum)
-}
+func TestToOneSet(t *testing.T) {}
 
 // TestToOneRemove tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOneRemove(t *testing.T) {
-	t.Run("DistanceAlertToDistanceDatumUsingDatumDistanceAlerts", testDistanceAlertToOneRemoveOpDistanceDatumUsingDatum)
-	t.Run("MicrophoneAlertToMicrophoneDatumUsingDatumMicrophoneAlerts", testMicrophoneAlertToOneRemoveOpMicrophoneDatumUsingDatum)
-	t.Run("MotionAlertToMotionDatumUsingDatumMotionAlerts", testMotionAlertToOneRemoveOpMotionDatumUsingDatum)
-}
+func TestToOneRemove(t *testing.T) {}
 
 // TestOneToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
@@ -51,24 +35,12 @@ func TestOneToOneRemove(t *testing.T) {}
 
 // TestToManyAdd tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManyAdd(t *testing.T) {
-	t.Run("DistanceDatumToDatumDistanceAlerts", testDistanceDatumToManyAddOpDatumDistanceAlerts)
-	t.Run("MicrophoneDatumToDatumMicrophoneAlerts", testMicrophoneDatumToManyAddOpDatumMicrophoneAlerts)
-	t.Run("MotionDatumToDatumMotionAlerts", testMotionDatumToManyAddOpDatumMotionAlerts)
-}
+func TestToManyAdd(t *testing.T) {}
 
 // TestToManySet tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManySet(t *testing.T) {
-	t.Run("DistanceDatumToDatumDistanceAlerts", testDistanceDatumToManySetOpDatumDistanceAlerts)
-	t.Run("MicrophoneDatumToDatumMicrophoneAlerts", testMicrophoneDatumToManySetOpDatumMicrophoneAlerts)
-	t.Run("MotionDatumToDatumMotionAlerts", testMotionDatumToManySetOpDatumMotionAlerts)
-}
+func TestToManySet(t *testing.T) {}
 
 // TestToManyRemove tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManyRemove(t *testing.T) {
-	t.Run("DistanceDatumToDatumDistanceAlerts", testDistanceDatumToManyRemoveOpDatumDistanceAlerts)
-	t.Run("MicrophoneDatumToDatumMicrophoneAlerts", testMicrophoneDatumToManyRemoveOpDatumMicrophoneAlerts)
-	t.Run("MotionDatumToDatumMotionAlerts", testMotionDatumToManyRemoveOpDatumMotionAlerts)
-}
+func TestToManyRemove(t *testing.T) {}
@@ -4,19 +4,7 @@
 package models
 
 var TableNames = struct {
-	DistanceAlerts   string
-	DistanceData     string
-	MicrophoneAlerts string
-	MicrophoneData   string
-	MotionAlerts     string
-	MotionData       string
-	PushTokens       string
+	PushTokens string
 }{
-	DistanceAlerts:   "distance_alerts",
-	DistanceData:     "distance_data",
-	MicrophoneAlerts: "microphone_alerts",
-	MicrophoneData:   "microphone_data",
-	MotionAlerts:     "motion_alerts",
-	MotionData:       "motion_data",
-	PushTokens:       "push_tokens",
+	PushTokens: "push_tokens",
 }
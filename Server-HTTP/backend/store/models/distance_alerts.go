@@ -1,1409 +0,0 @@
-// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
-// This file is meant to be re-generated in place and/or deleted at any time.
-
-package models
-
-import (
-	"context"
-	"database/sql"
-	"fmt"
-	"reflect"
-	"strconv"
-	"strings"
-	"sync"
-	"time"
-
-	"github.com/friendsofgo/errors"
-	"github.com/volatiletech/null/v8"
-	"github.com/volatiletech/sqlboiler/v4/boil"
-	"github.com/volatiletech/sqlboiler/v4/queries"
-	"github.com/volatiletech/sqlboiler/v4/queries/qm"
-	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
-	"github.com/volatiletech/sqlboiler/v4/types"
-	"github.com/volatiletech/strmangle"
-)
-
-// DistanceAlert is an object representing the database table.
-type DistanceAlert struct {
-	ID             int64         `boil:"id" json:"id" toml:"id" yaml:"id"`
-	DeviceID       string        `boil:"device_id" json:"device_id" toml:"device_id" yaml:"device_id"`
-	DataID         null.Int64    `boil:"data_id" json:"data_id,omitempty" toml:"data_id" yaml:"data_id,omitempty"`
-	DistanceCM     types.Decimal `boil:"distance_cm" json:"distance_cm" toml:"distance_cm" yaml:"distance_cm"`
-	ThresholdType  string        `boil:"threshold_type" json:"threshold_type" toml:"threshold_type" yaml:"threshold_type"`
-	ThresholdValue types.Decimal `boil:"threshold_value" json:"threshold_value" toml:"threshold_value" yaml:"threshold_value"`
-	AlertStatus    null.String   `boil:"alert_status" json:"alert_status,omitempty" toml:"alert_status" yaml:"alert_status,omitempty"`
-	AcknowledgedAt null.Time     `boil:"acknowledged_at" json:"acknowledged_at,omitempty" toml:"acknowledged_at" yaml:"acknowledged_at,omitempty"`
-	ResolvedAt     null.Time     `boil:"resolved_at" json:"resolved_at,omitempty" toml:"resolved_at" yaml:"resolved_at,omitempty"`
-	CreatedAt      null.Time     `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
-
-	R *distanceAlertR `boil:"-" json:"-" toml:"-" yaml:"-"`
-	L distanceAlertL  `boil:"-" json:"-" toml:"-" yaml:"-"`
-}
-
-var DistanceAlertColumns = struct {
-	ID             string
-	DeviceID       string
-	DataID         string
-	DistanceCM     string
-	ThresholdType  string
-	ThresholdValue string
-	AlertStatus    string
-	AcknowledgedAt string
-	ResolvedAt     string
-	CreatedAt      string
-}{
-	ID:             "id",
-	DeviceID:       "device_id",
-	DataID:         "data_id",
-	DistanceCM:     "distance_cm",
-	ThresholdType:  "threshold_type",
-	ThresholdValue: "threshold_value",
-	AlertStatus:    "alert_status",
-	AcknowledgedAt: "acknowledged_at",
-	ResolvedAt:     "resolved_at",
-	CreatedAt:      "created_at",
-}
-
-var DistanceAlertTableColumns = struct {
-	ID             string
-	DeviceID       string
-	DataID         string
-	DistanceCM     string
-	ThresholdType  string
-	ThresholdValue string
-	AlertStatus    string
-	AcknowledgedAt string
-	ResolvedAt     string
-	CreatedAt      string
-}{
-	ID:             "distance_alerts.id",
-	DeviceID:       "distance_alerts.device_id",
-	DataID:         "distance_alerts.data_id",
-	DistanceCM:     "distance_alerts.distance_cm",
-	ThresholdType:  "distance_alerts.threshold_type",
-	ThresholdValue: "distance_alerts.threshold_value",
-	AlertStatus:    "distance_alerts.alert_status",
-	AcknowledgedAt: "distance_alerts.acknowledged_at",
-	ResolvedAt:     "distance_alerts.resolved_at",
-	CreatedAt:      "distance_alerts.created_at",
-}
-
-// Generated where
-
-type whereHelperint64 struct{ field string }
-
-func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
-func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
-func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
-func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelpernull_Int64 struct{ field string }
-
-func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-func (w whereHelpernull_Int64) IN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_Int64) NIN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelpertypes_Decimal struct{ field string }
-
-func (w whereHelpertypes_Decimal) EQ(x types.Decimal) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertypes_Decimal) NEQ(x types.Decimal) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertypes_Decimal) LT(x types.Decimal) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertypes_Decimal) LTE(x types.Decimal) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertypes_Decimal) GT(x types.Decimal) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertypes_Decimal) GTE(x types.Decimal) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-type whereHelpernull_String struct{ field string }
-
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" LIKE ?", x)
-}
-func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT LIKE ?", x)
-}
-func (w whereHelpernull_String) ILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" ILIKE ?", x)
-}
-func (w whereHelpernull_String) NILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT ILIKE ?", x)
-}
-func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelpernull_Time struct{ field string }
-
-func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-var DistanceAlertWhere = struct {
-	ID             whereHelperint64
-	DeviceID       whereHelperstring
-	DataID         whereHelpernull_Int64
-	DistanceCM     whereHelpertypes_Decimal
-	ThresholdType  whereHelperstring
-	ThresholdValue whereHelpertypes_Decimal
-	AlertStatus    whereHelpernull_String
-	AcknowledgedAt whereHelpernull_Time
-	ResolvedAt     whereHelpernull_Time
-	CreatedAt      whereHelpernull_Time
-}{
-	ID:             whereHelperint64{field: "\"distance_alerts\".\"id\""},
-	DeviceID:       whereHelperstring{field: "\"distance_alerts\".\"device_id\""},
-	DataID:         whereHelpernull_Int64{field: "\"distance_alerts\".\"data_id\""},
-	DistanceCM:     whereHelpertypes_Decimal{field: "\"distance_alerts\".\"distance_cm\""},
-	ThresholdType:  whereHelperstring{field: "\"distance_alerts\".\"threshold_type\""},
-	ThresholdValue: whereHelpertypes_Decimal{field: "\"distance_alerts\".\"threshold_value\""},
-	AlertStatus:    whereHelpernull_String{field: "\"distance_alerts\".\"alert_status\""},
-	AcknowledgedAt: whereHelpernull_Time{field: "\"distance_alerts\".\"acknowledged_at\""},
-	ResolvedAt:     whereHelpernull_Time{field: "\"distance_alerts\".\"resolved_at\""},
-	CreatedAt:      whereHelpernull_Time{field: "\"distance_alerts\".\"created_at\""},
-}
-
-// DistanceAlertRels is where relationship names are stored.
-var DistanceAlertRels = struct {
-	Datum string
-}{
-	Datum: "Datum",
-}
-
-// distanceAlertR is where relationships are stored.
-type distanceAlertR struct {
-	Datum *DistanceDatum `boil:"Datum" json:"Datum" toml:"Datum" yaml:"Datum"`
-}
-
-// NewStruct creates a new relationship struct
-func (*distanceAlertR) NewStruct() *distanceAlertR {
-	return &distanceAlertR{}
-}
-
-func (r *distanceAlertR) GetDatum() *DistanceDatum {
-	if r == nil {
-		return nil
-	}
-	return r.Datum
-}
-
-// distanceAlertL is where Load methods for each relationship are stored.
-type distanceAlertL struct{}
-
-var (
-	distanceAlertAllColumns            = []string{"id", "device_id", "data_id", "distance_cm", "threshold_type", "threshold_value", "alert_status", "acknowledged_at", "resolved_at", "created_at"}
-	distanceAlertColumnsWithoutDefault = []string{"device_id", "distance_cm", "threshold_type", "threshold_value"}
-	distanceAlertColumnsWithDefault    = []string{"id", "data_id", "alert_status", "acknowledged_at", "resolved_at", "created_at"}
-	distanceAlertPrimaryKeyColumns     = []string{"id"}
-	distanceAlertGeneratedColumns      = []string{}
-)
-
-type (
-	// DistanceAlertSlice is an alias for a slice of pointers to DistanceAlert.
-	// This should almost always be used instead of []DistanceAlert.
-	DistanceAlertSlice []*DistanceAlert
-	// DistanceAlertHook is the signature for custom DistanceAlert hook methods
-	DistanceAlertHook func(context.Context, boil.ContextExecutor, *DistanceAlert) error
-
-	distanceAlertQuery struct {
-		*queries.Query
-	}
-)
-
-// Cache for insert, update and upsert
-var (
-	distanceAlertType                 = reflect.TypeOf(&DistanceAlert{})
-	distanceAlertMapping              = queries.MakeStructMapping(distanceAlertType)
-	distanceAlertPrimaryKeyMapping, _ = queries.BindMapping(distanceAlertType, distanceAlertMapping, distanceAlertPrimaryKeyColumns)
-	distanceAlertInsertCacheMut       sync.RWMutex
-	distanceAlertInsertCache          = make(map[string]insertCache)
-	distanceAlertUpdateCacheMut       sync.RWMutex
-	distanceAlertUpdateCache          = make(map[string]updateCache)
-	distanceAlertUpsertCacheMut       sync.RWMutex
-	distanceAlertUpsertCache          = make(map[string]insertCache)
-)
-
-var (
-	// Force time package dependency for automated UpdatedAt/CreatedAt.
-	_ = time.Second
-	// Force qmhelper dependency for where clause generation (which doesn't
-	// always happen)
-	_ = qmhelper.Where
-)
-
-var distanceAlertAfterSelectMu sync.Mutex
-var distanceAlertAfterSelectHooks []DistanceAlertHook
-
-var distanceAlertBeforeInsertMu sync.Mutex
-var distanceAlertBeforeInsertHooks []DistanceAlertHook
-var distanceAlertAfterInsertMu sync.Mutex
-var distanceAlertAfterInsertHooks []DistanceAlertHook
-
-var distanceAlertBeforeUpdateMu sync.Mutex
-var distanceAlertBeforeUpdateHooks []DistanceAlertHook
-var distanceAlertAfterUpdateMu sync.Mutex
-var distanceAlertAfterUpdateHooks []DistanceAlertHook
-
-var distanceAlertBeforeDeleteMu sync.Mutex
-var distanceAlertBeforeDeleteHooks []DistanceAlertHook
-var distanceAlertAfterDeleteMu sync.Mutex
-var distanceAlertAfterDeleteHooks []DistanceAlertHook
-
-var distanceAlertBeforeUpsertMu sync.Mutex
-var distanceAlertBeforeUpsertHooks []DistanceAlertHook
-var distanceAlertAfterUpsertMu sync.Mutex
-var distanceAlertAfterUpsertHooks []DistanceAlertHook
-
-// doAfterSelectHooks executes all "after Select" hooks.
-func (o *DistanceAlert) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range distanceAlertAfterSelectHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doBeforeInsertHooks executes all "before insert" hooks.
-func (o *DistanceAlert) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range distanceAlertBeforeInsertHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doAfterInsertHooks executes all "after Insert" hooks.
-func (o *DistanceAlert) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range distanceAlertAfterInsertHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doBeforeUpdateHooks executes all "before Update" hooks.
-func (o *DistanceAlert) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range distanceAlertBeforeUpdateHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doAfterUpdateHooks executes all "after Update" hooks.
-func (o *DistanceAlert) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range distanceAlertAfterUpdateHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doBeforeDeleteHooks executes all "before Delete" hooks.
-func (o *DistanceAlert) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range distanceAlertBeforeDeleteHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doAfterDeleteHooks executes all "after Delete" hooks.
-func (o *DistanceAlert) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range distanceAlertAfterDeleteHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doBeforeUpsertHooks executes all "before Upsert" hooks.
-func (o *DistanceAlert) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range distanceAlertBeforeUpsertHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doAfterUpsertHooks executes all "after Upsert" hooks.
-func (o *DistanceAlert) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range distanceAlertAfterUpsertHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// AddDistanceAlertHook registers your hook function for all future operations.
-func AddDistanceAlertHook(hookPoint boil.HookPoint, distanceAlertHook DistanceAlertHook) {
-	switch hookPoint {
-	case boil.AfterSelectHook:
-		distanceAlertAfterSelectMu.Lock()
-		distanceAlertAfterSelectHooks = append(distanceAlertAfterSelectHooks, distanceAlertHook)
-		distanceAlertAfterSelectMu.Unlock()
-	case boil.BeforeInsertHook:
-		distanceAlertBeforeInsertMu.Lock()
-		distanceAlertBeforeInsertHooks = append(distanceAlertBeforeInsertHooks, distanceAlertHook)
-		distanceAlertBeforeInsertMu.Unlock()
-	case boil.AfterInsertHook:
-		distanceAlertAfterInsertMu.Lock()
-		distanceAlertAfterInsertHooks = append(distanceAlertAfterInsertHooks, distanceAlertHook)
-		distanceAlertAfterInsertMu.Unlock()
-	case boil.BeforeUpdateHook:
-		distanceAlertBeforeUpdateMu.Lock()
-		distanceAlertBeforeUpdateHooks = append(distanceAlertBeforeUpdateHooks, distanceAlertHook)
-		distanceAlertBeforeUpdateMu.Unlock()
-	case boil.AfterUpdateHook:
-		distanceAlertAfterUpdateMu.Lock()
-		distanceAlertAfterUpdateHooks = append(distanceAlertAfterUpdateHooks, distanceAlertHook)
-		distanceAlertAfterUpdateMu.Unlock()
-	case boil.BeforeDeleteHook:
-		distanceAlertBeforeDeleteMu.Lock()
-		distanceAlertBeforeDeleteHooks = append(distanceAlertBeforeDeleteHooks, distanceAlertHook)
-		distanceAlertBeforeDeleteMu.Unlock()
-	case boil.AfterDeleteHook:
-		distanceAlertAfterDeleteMu.Lock()
-		distanceAlertAfterDeleteHooks = append(distanceAlertAfterDeleteHooks, distanceAlertHook)
-		distanceAlertAfterDeleteMu.Unlock()
-	case boil.BeforeUpsertHook:
-		distanceAlertBeforeUpsertMu.Lock()
-		distanceAlertBeforeUpsertHooks = append(distanceAlertBeforeUpsertHooks, distanceAlertHook)
-		distanceAlertBeforeUpsertMu.Unlock()
-	case boil.AfterUpsertHook:
-		distanceAlertAfterUpsertMu.Lock()
-		distanceAlertAfterUpsertHooks = append(distanceAlertAfterUpsertHooks, distanceAlertHook)
-		distanceAlertAfterUpsertMu.Unlock()
-	}
-}
-
-// One returns a single distanceAlert record from the query.
-func (q distanceAlertQuery) One(ctx context.Context, exec boil.ContextExecutor) (*DistanceAlert, error) {
-	o := &DistanceAlert{}
-
-	queries.SetLimit(q.Query, 1)
-
-	err := q.Bind(ctx, exec, o)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, sql.ErrNoRows
-		}
-		return nil, errors.Wrap(err, "models: failed to execute a one query for distance_alerts")
-	}
-
-	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
-		return o, err
-	}
-
-	return o, nil
-}
-
-// All returns all DistanceAlert records from the query.
-func (q distanceAlertQuery) All(ctx context.Context, exec boil.ContextExecutor) (DistanceAlertSlice, error) {
-	var o []*DistanceAlert
-
-	err := q.Bind(ctx, exec, &o)
-	if err != nil {
-		return nil, errors.Wrap(err, "models: failed to assign all query results to DistanceAlert slice")
-	}
-
-	if len(distanceAlertAfterSelectHooks) != 0 {
-		for _, obj := range o {
-			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
-				return o, err
-			}
-		}
-	}
-
-	return o, nil
-}
-
-// Count returns the count of all DistanceAlert records in the query.
-func (q distanceAlertQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
-	var count int64
-
-	queries.SetSelect(q.Query, nil)
-	queries.SetCount(q.Query)
-
-	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
-	if err != nil {
-		return 0, errors.Wrap(err, "models: failed to count distance_alerts rows")
-	}
-
-	return count, nil
-}
-
-// Exists checks if the row exists in the table.
-func (q distanceAlertQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
-	var count int64
-
-	queries.SetSelect(q.Query, nil)
-	queries.SetCount(q.Query)
-	queries.SetLimit(q.Query, 1)
-
-	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
-	if err != nil {
-		return false, errors.Wrap(err, "models: failed to check if distance_alerts exists")
-	}
-
-	return count > 0, nil
-}
-
-// Datum pointed to by the foreign key.
-func (o *DistanceAlert) Datum(mods ...qm.QueryMod) distanceDatumQuery {
-	queryMods := []qm.QueryMod{
-		qm.Where("\"id\" = ?", o.DataID),
-	}
-
-	queryMods = append(queryMods, mods...)
-
-	return DistanceData(queryMods...)
-}
-
-// LoadDatum allows an eager lookup of values, cached into the
-// loaded structs of the objects. This is for an N-1 relationship.
-func (distanceAlertL) LoadDatum(ctx context.Context, e boil.ContextExecutor, singular bool, maybeDistanceAlert interface{}, mods queries.Applicator) error {
-	var slice []*DistanceAlert
-	var object *DistanceAlert
-
-	if singular {
-		var ok bool
-		object, ok = maybeDistanceAlert.(*DistanceAlert)
-		if !ok {
-			object = new(DistanceAlert)
-			ok = queries.SetFromEmbeddedStruct(&object, &maybeDistanceAlert)
-			if !ok {
-				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeDistanceAlert))
-			}
-		}
-	} else {
-		s, ok := maybeDistanceAlert.(*[]*DistanceAlert)
-		if ok {
-			slice = *s
-		} else {
-			ok = queries.SetFromEmbeddedStruct(&slice, maybeDistanceAlert)
-			if !ok {
-				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeDistanceAlert))
-			}
-		}
-	}
-
-	args := make(map[interface{}]struct{})
-	if singular {
-		if object.R == nil {
-			object.R = &distanceAlertR{}
-		}
-		if !queries.IsNil(object.DataID) {
-			args[object.DataID] = struct{}{}
-		}
-
-	} else {
-		for _, obj := range slice {
-			if obj.R == nil {
-				obj.R = &distanceAlertR{}
-			}
-
-			if !queries.IsNil(obj.DataID) {
-				args[obj.DataID] = struct{}{}
-			}
-
-		}
-	}
-
-	if len(args) == 0 {
-		return nil
-	}
-
-	argsSlice := make([]interface{}, len(args))
-	i := 0
-	for arg := range args {
-		argsSlice[i] = arg
-		i++
-	}
-
-	query := NewQuery(
-		qm.From(`distance_data`),
-		qm.WhereIn(`distance_data.id in ?`, argsSlice...),
-	)
-	if mods != nil {
-		mods.Apply(query)
-	}
-
-	results, err := query.QueryContext(ctx, e)
-	if err != nil {
-		return errors.Wrap(err, "failed to eager load DistanceDatum")
-	}
-
-	var resultSlice []*DistanceDatum
-	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice DistanceDatum")
-	}
-
-	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results of eager load for distance_data")
-	}
-	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for distance_data")
-	}
-
-	if len(distanceDatumAfterSelectHooks) != 0 {
-		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
-				return err
-			}
-		}
-	}
-
-	if len(resultSlice) == 0 {
-		return nil
-	}
-
-	if singular {
-		foreign := resultSlice[0]
-		object.R.Datum = foreign
-		if foreign.R == nil {
-			foreign.R = &distanceDatumR{}
-		}
-		foreign.R.DatumDistanceAlerts = append(foreign.R.DatumDistanceAlerts, object)
-		return nil
-	}
-
-	for _, local := range slice {
-		for _, foreign := range resultSlice {
-			if queries.Equal(local.DataID, foreign.ID) {
-				local.R.Datum = foreign
-				if foreign.R == nil {
-					foreign.R = &distanceDatumR{}
-				}
-				foreign.R.DatumDistanceAlerts = append(foreign.R.DatumDistanceAlerts, local)
-				break
-			}
-		}
-	}
-
-	return nil
-}
-
-// SetDatum of the distanceAlert to the related item.
-// Sets o.R.Datum to related.
-// Adds o to related.R.DatumDistanceAlerts.
-func (o *DistanceAlert) SetDatum(ctx context.Context, exec boil.ContextExecutor, insert bool, related *DistanceDatum) error {
-	var err error
-	if insert {
-		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
-		}
-	}
-
-	updateQuery := fmt.Sprintf(
-		"UPDATE \"distance_alerts\" SET %s WHERE %s",
-		strmangle.SetParamNames("\"", "\"", 1, []string{"data_id"}),
-		strmangle.WhereClause("\"", "\"", 2, distanceAlertPrimaryKeyColumns),
-	)
-	values := []interface{}{related.ID, o.ID}
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, updateQuery)
-		fmt.Fprintln(writer, values)
-	}
-	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-		return errors.Wrap(err, "failed to update local table")
-	}
-
-	queries.Assign(&o.DataID, related.ID)
-	if o.R == nil {
-		o.R = &distanceAlertR{
-			Datum: related,
-		}
-	} else {
-		o.R.Datum = related
-	}
-
-	if related.R == nil {
-		related.R = &distanceDatumR{
-			DatumDistanceAlerts: DistanceAlertSlice{o},
-		}
-	} else {
-		related.R.DatumDistanceAlerts = append(related.R.DatumDistanceAlerts, o)
-	}
-
-	return nil
-}
-
-// RemoveDatum relationship.
-// Sets o.R.Datum to nil.
-// Removes o from all passed in related items' relationships struct.
-func (o *DistanceAlert) RemoveDatum(ctx context.Context, exec boil.ContextExecutor, related *DistanceDatum) error {
-	var err error
-
-	queries.SetScanner(&o.DataID, nil)
-	if _, err = o.Update(ctx, exec, boil.Whitelist("data_id")); err != nil {
-		return errors.Wrap(err, "failed to update local table")
-	}
-
-	if o.R != nil {
-		o.R.Datum = nil
-	}
-	if related == nil || related.R == nil {
-		return nil
-	}
-
-	for i, ri := range related.R.DatumDistanceAlerts {
-		if queries.Equal(o.DataID, ri.DataID) {
-			continue
-		}
-
-		ln := len(related.R.DatumDistanceAlerts)
-		if ln > 1 && i < ln-1 {
-			related.R.DatumDistanceAlerts[i] = related.R.DatumDistanceAlerts[ln-1]
-		}
-		related.R.DatumDistanceAlerts = related.R.DatumDistanceAlerts[:ln-1]
-		break
-	}
-	return nil
-}
-
-// DistanceAlerts retrieves all the records using an executor.
-func DistanceAlerts(mods ...qm.QueryMod) distanceAlertQuery {
-	mods = append(mods, qm.From("\"distance_alerts\""))
-	q := NewQuery(mods...)
-	if len(queries.GetSelect(q)) == 0 {
-		queries.SetSelect(q, []string{"\"distance_alerts\".*"})
-	}
-
-	return distanceAlertQuery{q}
-}
-
-// FindDistanceAlert retrieves a single record by ID with an executor.
-// If selectCols is empty Find will return all columns.
-func FindDistanceAlert(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*DistanceAlert, error) {
-	distanceAlertObj := &DistanceAlert{}
-
-	sel := "*"
-	if len(selectCols) > 0 {
-		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
-	}
-	query := fmt.Sprintf(
-		"select %s from \"distance_alerts\" where \"id\"=$1", sel,
-	)
-
-	q := queries.Raw(query, iD)
-
-	err := q.Bind(ctx, exec, distanceAlertObj)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, sql.ErrNoRows
-		}
-		return nil, errors.Wrap(err, "models: unable to select from distance_alerts")
-	}
-
-	if err = distanceAlertObj.doAfterSelectHooks(ctx, exec); err != nil {
-		return distanceAlertObj, err
-	}
-
-	return distanceAlertObj, nil
-}
-
-// Insert a single record using an executor.
-// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
-func (o *DistanceAlert) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
-	if o == nil {
-		return errors.New("models: no distance_alerts provided for insertion")
-	}
-
-	var err error
-	if !boil.TimestampsAreSkipped(ctx) {
-		currTime := time.Now().In(boil.GetLocation())
-
-		if queries.MustTime(o.CreatedAt).IsZero() {
-			queries.SetScanner(&o.CreatedAt, currTime)
-		}
-	}
-
-	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
-		return err
-	}
-
-	nzDefaults := queries.NonZeroDefaultSet(distanceAlertColumnsWithDefault, o)
-
-	key := makeCacheKey(columns, nzDefaults)
-	distanceAlertInsertCacheMut.RLock()
-	cache, cached := distanceAlertInsertCache[key]
-	distanceAlertInsertCacheMut.RUnlock()
-
-	if !cached {
-		wl, returnColumns := columns.InsertColumnSet(
-			distanceAlertAllColumns,
-			distanceAlertColumnsWithDefault,
-			distanceAlertColumnsWithoutDefault,
-			nzDefaults,
-		)
-
-		cache.valueMapping, err = queries.BindMapping(distanceAlertType, distanceAlertMapping, wl)
-		if err != nil {
-			return err
-		}
-		cache.retMapping, err = queries.BindMapping(distanceAlertType, distanceAlertMapping, returnColumns)
-		if err != nil {
-			return err
-		}
-		if len(wl) != 0 {
-			cache.query = fmt.Sprintf("INSERT INTO \"distance_alerts\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
-		} else {
-			cache.query = "INSERT INTO \"distance_alerts\" %sDEFAULT VALUES%s"
-		}
-
-		var queryOutput, queryReturning string
-
-		if len(cache.retMapping) != 0 {
-			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
-		}
-
-		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
-	}
-
-	value := reflect.Indirect(reflect.ValueOf(o))
-	vals := queries.ValuesFromMapping(value, cache.valueMapping)
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, cache.query)
-		fmt.Fprintln(writer, vals)
-	}
-
-	if len(cache.retMapping) != 0 {
-		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
-	} else {
-		_, err = exec.ExecContext(ctx, cache.query, vals...)
-	}
-
-	if err != nil {
-		return errors.Wrap(err, "models: unable to insert into distance_alerts")
-	}
-
-	if !cached {
-		distanceAlertInsertCacheMut.Lock()
-		distanceAlertInsertCache[key] = cache
-		distanceAlertInsertCacheMut.Unlock()
-	}
-
-	return o.doAfterInsertHooks(ctx, exec)
-}
-
-// Update uses an executor to update the DistanceAlert.
-// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
-// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
-func (o *DistanceAlert) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
-	var err error
-	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
-		return 0, err
-	}
-	key := makeCacheKey(columns, nil)
-	distanceAlertUpdateCacheMut.RLock()
-	cache, cached := distanceAlertUpdateCache[key]
-	distanceAlertUpdateCacheMut.RUnlock()
-
-	if !cached {
-		wl := columns.UpdateColumnSet(
-			distanceAlertAllColumns,
-			distanceAlertPrimaryKeyColumns,
-		)
-
-		if !columns.IsWhitelist() {
-			wl = strmangle.SetComplement(wl, []string{"created_at"})
-		}
-		if len(wl) == 0 {
-			return 0, errors.New("models: unable to update distance_alerts, could not build whitelist")
-		}
-
-		cache.query = fmt.Sprintf("UPDATE \"distance_alerts\" SET %s WHERE %s",
-			strmangle.SetParamNames("\"", "\"", 1, wl),
-			strmangle.WhereClause("\"", "\"", len(wl)+1, distanceAlertPrimaryKeyColumns),
-		)
-		cache.valueMapping, err = queries.BindMapping(distanceAlertType, distanceAlertMapping, append(wl, distanceAlertPrimaryKeyColumns...))
-		if err != nil {
-			return 0, err
-		}
-	}
-
-	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, cache.query)
-		fmt.Fprintln(writer, values)
-	}
-	var result sql.Result
-	result, err = exec.ExecContext(ctx, cache.query, values...)
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to update distance_alerts row")
-	}
-
-	rowsAff, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "models: failed to get rows affected by update for distance_alerts")
-	}
-
-	if !cached {
-		distanceAlertUpdateCacheMut.Lock()
-		distanceAlertUpdateCache[key] = cache
-		distanceAlertUpdateCacheMut.Unlock()
-	}
-
-	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
-}
-
-// UpdateAll updates all rows with the specified column values.
-func (q distanceAlertQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
-	queries.SetUpdate(q.Query, cols)
-
-	result, err := q.Query.ExecContext(ctx, exec)
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to update all for distance_alerts")
-	}
-
-	rowsAff, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for distance_alerts")
-	}
-
-	return rowsAff, nil
-}
-
-// UpdateAll updates all rows with the specified column values, using an executor.
-func (o DistanceAlertSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
-	ln := int64(len(o))
-	if ln == 0 {
-		return 0, nil
-	}
-
-	if len(cols) == 0 {
-		return 0, errors.New("models: update all requires at least one column argument")
-	}
-
-	colNames := make([]string, len(cols))
-	args := make([]interface{}, len(cols))
-
-	i := 0
-	for name, value := range cols {
-		colNames[i] = name
-		args[i] = value
-		i++
-	}
-
-	// Append all of the primary key values for each column
-	for _, obj := range o {
-		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), distanceAlertPrimaryKeyMapping)
-		args = append(args, pkeyArgs...)
-	}
-
-	sql := fmt.Sprintf("UPDATE \"distance_alerts\" SET %s WHERE %s",
-		strmangle.SetParamNames("\"", "\"", 1, colNames),
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, distanceAlertPrimaryKeyColumns, len(o)))
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, sql)
-		fmt.Fprintln(writer, args...)
-	}
-	result, err := exec.ExecContext(ctx, sql, args...)
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to update all in distanceAlert slice")
-	}
-
-	rowsAff, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all distanceAlert")
-	}
-	return rowsAff, nil
-}
-
-// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
-// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
-func (o *DistanceAlert) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
-	if o == nil {
-		return errors.New("models: no distance_alerts provided for upsert")
-	}
-	if !boil.TimestampsAreSkipped(ctx) {
-		currTime := time.Now().In(boil.GetLocation())
-
-		if queries.MustTime(o.CreatedAt).IsZero() {
-			queries.SetScanner(&o.CreatedAt, currTime)
-		}
-	}
-
-	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
-		return err
-	}
-
-	nzDefaults := queries.NonZeroDefaultSet(distanceAlertColumnsWithDefault, o)
-
-	// Build cache key in-line uglily - mysql vs psql problems
-	buf := strmangle.GetBuffer()
-	if updateOnConflict {
-		buf.WriteByte('t')
-	} else {
-		buf.WriteByte('f')
-	}
-	buf.WriteByte('.')
-	for _, c := range conflictColumns {
-		buf.WriteString(c)
-	}
-	buf.WriteByte('.')
-	buf.WriteString(strconv.Itoa(updateColumns.Kind))
-	for _, c := range updateColumns.Cols {
-		buf.WriteString(c)
-	}
-	buf.WriteByte('.')
-	buf.WriteString(strconv.Itoa(insertColumns.Kind))
-	for _, c := range insertColumns.Cols {
-		buf.WriteString(c)
-	}
-	buf.WriteByte('.')
-	for _, c := range nzDefaults {
-		buf.WriteString(c)
-	}
-	key := buf.String()
-	strmangle.PutBuffer(buf)
-
-	distanceAlertUpsertCacheMut.RLock()
-	cache, cached := distanceAlertUpsertCache[key]
-	distanceAlertUpsertCacheMut.RUnlock()
-
-	var err error
-
-	if !cached {
-		insert, _ := insertColumns.InsertColumnSet(
-			distanceAlertAllColumns,
-			distanceAlertColumnsWithDefault,
-			distanceAlertColumnsWithoutDefault,
-			nzDefaults,
-		)
-
-		update := updateColumns.UpdateColumnSet(
-			distanceAlertAllColumns,
-			distanceAlertPrimaryKeyColumns,
-		)
-
-		if updateOnConflict && len(update) == 0 {
-			return errors.New("models: unable to upsert distance_alerts, could not build update column list")
-		}
-
-		ret := strmangle.SetComplement(distanceAlertAllColumns, strmangle.SetIntersect(insert, update))
-
-		conflict := conflictColumns
-		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
-			if len(distanceAlertPrimaryKeyColumns) == 0 {
-				return errors.New("models: unable to upsert distance_alerts, could not build conflict column list")
-			}
-
-			conflict = make([]string, len(distanceAlertPrimaryKeyColumns))
-			copy(conflict, distanceAlertPrimaryKeyColumns)
-		}
-		cache.query = buildUpsertQueryPostgres(dialect, "\"distance_alerts\"", updateOnConflict, ret, update, conflict, insert, opts...)
-
-		cache.valueMapping, err = queries.BindMapping(distanceAlertType, distanceAlertMapping, insert)
-		if err != nil {
-			return err
-		}
-		if len(ret) != 0 {
-			cache.retMapping, err = queries.BindMapping(distanceAlertType, distanceAlertMapping, ret)
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	value := reflect.Indirect(reflect.ValueOf(o))
-	vals := queries.ValuesFromMapping(value, cache.valueMapping)
-	var returns []interface{}
-	if len(cache.retMapping) != 0 {
-		returns = queries.PtrsFromMapping(value, cache.retMapping)
-	}
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, cache.query)
-		fmt.Fprintln(writer, vals)
-	}
-	if len(cache.retMapping) != 0 {
-		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
-		if errors.Is(err, sql.ErrNoRows) {
-			err = nil // Postgres doesn't return anything when there's no update
-		}
-	} else {
-		_, err = exec.ExecContext(ctx, cache.query, vals...)
-	}
-	if err != nil {
-		return errors.Wrap(err, "models: unable to upsert distance_alerts")
-	}
-
-	if !cached {
-		distanceAlertUpsertCacheMut.Lock()
-		distanceAlertUpsertCache[key] = cache
-		distanceAlertUpsertCacheMut.Unlock()
-	}
-
-	return o.doAfterUpsertHooks(ctx, exec)
-}
-
-// Delete deletes a single DistanceAlert record with an executor.
-// Delete will match against the primary key column to find the record to delete.
-func (o *DistanceAlert) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
-	if o == nil {
-		return 0, errors.New("models: no DistanceAlert provided for delete")
-	}
-
-	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
-		return 0, err
-	}
-
-	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), distanceAlertPrimaryKeyMapping)
-	sql := "DELETE FROM \"distance_alerts\" WHERE \"id\"=$1"
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, sql)
-		fmt.Fprintln(writer, args...)
-	}
-	result, err := exec.ExecContext(ctx, sql, args...)
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to delete from distance_alerts")
-	}
-
-	rowsAff, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for distance_alerts")
-	}
-
-	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
-		return 0, err
-	}
-
-	return rowsAff, nil
-}
-
-// DeleteAll deletes all matching rows.
-func (q distanceAlertQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
-	if q.Query == nil {
-		return 0, errors.New("models: no distanceAlertQuery provided for delete all")
-	}
-
-	queries.SetDelete(q.Query)
-
-	result, err := q.Query.ExecContext(ctx, exec)
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to delete all from distance_alerts")
-	}
-
-	rowsAff, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for distance_alerts")
-	}
-
-	return rowsAff, nil
-}
-
-// DeleteAll deletes all rows in the slice, using an executor.
-func (o DistanceAlertSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
-	if len(o) == 0 {
-		return 0, nil
-	}
-
-	if len(distanceAlertBeforeDeleteHooks) != 0 {
-		for _, obj := range o {
-			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
-				return 0, err
-			}
-		}
-	}
-
-	var args []interface{}
-	for _, obj := range o {
-		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), distanceAlertPrimaryKeyMapping)
-		args = append(args, pkeyArgs...)
-	}
-
-	sql := "DELETE FROM \"distance_alerts\" WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, distanceAlertPrimaryKeyColumns, len(o))
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, sql)
-		fmt.Fprintln(writer, args)
-	}
-	result, err := exec.ExecContext(ctx, sql, args...)
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to delete all from distanceAlert slice")
-	}
-
-	rowsAff, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for distance_alerts")
-	}
-
-	if len(distanceAlertAfterDeleteHooks) != 0 {
-		for _, obj := range o {
-			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
-				return 0, err
-			}
-		}
-	}
-
-	return rowsAff, nil
-}
-
-// Reload refetches the object from the database
-// using the primary keys with an executor.
-func (o *DistanceAlert) Reload(ctx context.Context, exec boil.ContextExecutor) error {
-	ret, err := FindDistanceAlert(ctx, exec, o.ID)
-	if err != nil {
-		return err
-	}
-
-	*o = *ret
-	return nil
-}
-
-// ReloadAll refetches every row with matching primary key column values
-// and overwrites the original object slice with the newly updated slice.
-func (o *DistanceAlertSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
-	if o == nil || len(*o) == 0 {
-		return nil
-	}
-
-	slice := DistanceAlertSlice{}
-	var args []interface{}
-	for _, obj := range *o {
-		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), distanceAlertPrimaryKeyMapping)
-		args = append(args, pkeyArgs...)
-	}
-
-	sql := "SELECT \"distance_alerts\".* FROM \"distance_alerts\" WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, distanceAlertPrimaryKeyColumns, len(*o))
-
-	q := queries.Raw(sql, args...)
-
-	err := q.Bind(ctx, exec, &slice)
-	if err != nil {
-		return errors.Wrap(err, "models: unable to reload all in DistanceAlertSlice")
-	}
-
-	*o = slice
-
-	return nil
-}
-
-// DistanceAlertExists checks if the DistanceAlert row exists.
-func DistanceAlertExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
-	var exists bool
-	sql := "select exists(select 1 from \"distance_alerts\" where \"id\"=$1 limit 1)"
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, sql)
-		fmt.Fprintln(writer, iD)
-	}
-	row := exec.QueryRowContext(ctx, sql, iD)
-
-	err := row.Scan(&exists)
-	if err != nil {
-		return false, errors.Wrap(err, "models: unable to check if distance_alerts exists")
-	}
-
-	return exists, nil
-}
-
-// Exists checks if the DistanceAlert row exists.
-func (o *DistanceAlert) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
-	return DistanceAlertExists(ctx, exec, o.ID)
-}
@@ -1,902 +0,0 @@
-// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
-// This file is meant to be re-generated in place and/or deleted at any time.
-
-package models
-
-import (
-	"bytes"
-	"context"
-	"reflect"
-	"testing"
-
-	"github.com/volatiletech/randomize"
-	"github.com/volatiletech/sqlboiler/v4/boil"
-	"github.com/volatiletech/sqlboiler/v4/queries"
-	"github.com/volatiletech/strmangle"
-)
-
-var (
-	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
-	// so force a package dependency in case they don't.
-	_ = queries.Equal
-)
-
-func testMicrophoneAlerts(t *testing.T) {
-	t.Parallel()
-
-	query := MicrophoneAlerts()
-
-	if query.Query == nil {
-		t.Error("expected a query, got nothing")
-	}
-}
-
-func testMicrophoneAlertsDelete(t *testing.T) {
-	t.Parallel()
-
-	seed := randomize.NewSeed()
-	var err error
-	o := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	if rowsAff, err := o.Delete(ctx, tx); err != nil {
-		t.Error(err)
-	} else if rowsAff != 1 {
-		t.Error("should only have deleted one row, but affected:", rowsAff)
-	}
-
-	count, err := MicrophoneAlerts().Count(ctx, tx)
-	if err != nil {
-		t.Error(err)
-	}
-
-	if count != 0 {
-		t.Error("want zero records, got:", count)
-	}
-}
-
-func testMicrophoneAlertsQueryDeleteAll(t *testing.T) {
-	t.Parallel()
-
-	seed := randomize.NewSeed()
-	var err error
-	o := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	if rowsAff, err := MicrophoneAlerts().DeleteAll(ctx, tx); err != nil {
-		t.Error(err)
-	} else if rowsAff != 1 {
-		t.Error("should only have deleted one row, but affected:", rowsAff)
-	}
-
-	count, err := MicrophoneAlerts().Count(ctx, tx)
-	if err != nil {
-		t.Error(err)
-	}
-
-	if count != 0 {
-		t.Error("want zero records, got:", count)
-	}
-}
-
-func testMicrophoneAlertsSliceDeleteAll(t *testing.T) {
-	t.Parallel()
-
-	seed := randomize.NewSeed()
-	var err error
-	o := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	slice := MicrophoneAlertSlice{o}
-
-	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
-		t.Error(err)
-	} else if rowsAff != 1 {
-		t.Error("should only have deleted one row, but affected:", rowsAff)
-	}
-
-	count, err := MicrophoneAlerts().Count(ctx, tx)
-	if err != nil {
-		t.Error(err)
-	}
-
-	if count != 0 {
-		t.Error("want zero records, got:", count)
-	}
-}
-
-func testMicrophoneAlertsExists(t *testing.T) {
-	t.Parallel()
-
-	seed := randomize.NewSeed()
-	var err error
-	o := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	e, err := MicrophoneAlertExists(ctx, tx, o.ID)
-	if err != nil {
-		t.Errorf("Unable to check if MicrophoneAlert exists: %s", err)
-	}
-	if !e {
-		t.Errorf("Expected MicrophoneAlertExists to return true, but got false.")
-	}
-}
-
-func testMicrophoneAlertsFind(t *testing.T) {
-	t.Parallel()
-
-	seed := randomize.NewSeed()
-	var err error
-	o := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	microphoneAlertFound, err := FindMicrophoneAlert(ctx, tx, o.ID)
-	if err != nil {
-		t.Error(err)
-	}
-
-	if microphoneAlertFound == nil {
-		t.Error("want a record, got nil")
-	}
-}
-
-func testMicrophoneAlertsBind(t *testing.T) {
-	t.Parallel()
-
-	seed := randomize.NewSeed()
-	var err error
-	o := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	if err = MicrophoneAlerts().Bind(ctx, tx, o); err != nil {
-		t.Error(err)
-	}
-}
-
-func testMicrophoneAlertsOne(t *testing.T) {
-	t.Parallel()
-
-	seed := randomize.NewSeed()
-	var err error
-	o := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	if x, err := MicrophoneAlerts().One(ctx, tx); err != nil {
-		t.Error(err)
-	} else if x == nil {
-		t.Error("expected to get a non nil record")
-	}
-}
-
-func testMicrophoneAlertsAll(t *testing.T) {
-	t.Parallel()
-
-	seed := randomize.NewSeed()
-	var err error
-	microphoneAlertOne := &MicrophoneAlert{}
-	microphoneAlertTwo := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, microphoneAlertOne, microphoneAlertDBTypes, false, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-	if err = randomize.Struct(seed, microphoneAlertTwo, microphoneAlertDBTypes, false, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = microphoneAlertOne.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-	if err = microphoneAlertTwo.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	slice, err := MicrophoneAlerts().All(ctx, tx)
-	if err != nil {
-		t.Error(err)
-	}
-
-	if len(slice) != 2 {
-		t.Error("want 2 records, got:", len(slice))
-	}
-}
-
-func testMicrophoneAlertsCount(t *testing.T) {
-	t.Parallel()
-
-	var err error
-	seed := randomize.NewSeed()
-	microphoneAlertOne := &MicrophoneAlert{}
-	microphoneAlertTwo := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, microphoneAlertOne, microphoneAlertDBTypes, false, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-	if err = randomize.Struct(seed, microphoneAlertTwo, microphoneAlertDBTypes, false, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = microphoneAlertOne.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-	if err = microphoneAlertTwo.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	count, err := MicrophoneAlerts().Count(ctx, tx)
-	if err != nil {
-		t.Error(err)
-	}
-
-	if count != 2 {
-		t.Error("want 2 records, got:", count)
-	}
-}
-
-func microphoneAlertBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *MicrophoneAlert) error {
-	*o = MicrophoneAlert{}
-	return nil
-}
-
-func microphoneAlertAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *MicrophoneAlert) error {
-	*o = MicrophoneAlert{}
-	return nil
-}
-
-func microphoneAlertAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *MicrophoneAlert) error {
-	*o = MicrophoneAlert{}
-	return nil
-}
-
-func microphoneAlertBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *MicrophoneAlert) error {
-	*o = MicrophoneAlert{}
-	return nil
-}
-
-func microphoneAlertAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *MicrophoneAlert) error {
-	*o = MicrophoneAlert{}
-	return nil
-}
-
-func microphoneAlertBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *MicrophoneAlert) error {
-	*o = MicrophoneAlert{}
-	return nil
-}
-
-func microphoneAlertAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *MicrophoneAlert) error {
-	*o = MicrophoneAlert{}
-	return nil
-}
-
-func microphoneAlertBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *MicrophoneAlert) error {
-	*o = MicrophoneAlert{}
-	return nil
-}
-
-func microphoneAlertAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *MicrophoneAlert) error {
-	*o = MicrophoneAlert{}
-	return nil
-}
-
-func testMicrophoneAlertsHooks(t *testing.T) {
-	t.Parallel()
-
-	var err error
-
-	ctx := context.Background()
-	empty := &MicrophoneAlert{}
-	o := &MicrophoneAlert{}
-
-	seed := randomize.NewSeed()
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, false); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert object: %s", err)
-	}
-
-	AddMicrophoneAlertHook(boil.BeforeInsertHook, microphoneAlertBeforeInsertHook)
-	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
-		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
-	}
-	if !reflect.DeepEqual(o, empty) {
-		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
-	}
-	microphoneAlertBeforeInsertHooks = []MicrophoneAlertHook{}
-
-	AddMicrophoneAlertHook(boil.AfterInsertHook, microphoneAlertAfterInsertHook)
-	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
-		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
-	}
-	if !reflect.DeepEqual(o, empty) {
-		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
-	}
-	microphoneAlertAfterInsertHooks = []MicrophoneAlertHook{}
-
-	AddMicrophoneAlertHook(boil.AfterSelectHook, microphoneAlertAfterSelectHook)
-	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
-		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
-	}
-	if !reflect.DeepEqual(o, empty) {
-		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
-	}
-	microphoneAlertAfterSelectHooks = []MicrophoneAlertHook{}
-
-	AddMicrophoneAlertHook(boil.BeforeUpdateHook, microphoneAlertBeforeUpdateHook)
-	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
-		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
-	}
-	if !reflect.DeepEqual(o, empty) {
-		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
-	}
-	microphoneAlertBeforeUpdateHooks = []MicrophoneAlertHook{}
-
-	AddMicrophoneAlertHook(boil.AfterUpdateHook, microphoneAlertAfterUpdateHook)
-	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
-		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
-	}
-	if !reflect.DeepEqual(o, empty) {
-		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
-	}
-	microphoneAlertAfterUpdateHooks = []MicrophoneAlertHook{}
-
-	AddMicrophoneAlertHook(boil.BeforeDeleteHook, microphoneAlertBeforeDeleteHook)
-	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
-		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
-	}
-	if !reflect.DeepEqual(o, empty) {
-		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
-	}
-	microphoneAlertBeforeDeleteHooks = []MicrophoneAlertHook{}
-
-	AddMicrophoneAlertHook(boil.AfterDeleteHook, microphoneAlertAfterDeleteHook)
-	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
-		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
-	}
-	if !reflect.DeepEqual(o, empty) {
-		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
-	}
-	microphoneAlertAfterDeleteHooks = []MicrophoneAlertHook{}
-
-	AddMicrophoneAlertHook(boil.BeforeUpsertHook, microphoneAlertBeforeUpsertHook)
-	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
-		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
-	}
-	if !reflect.DeepEqual(o, empty) {
-		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
-	}
-	microphoneAlertBeforeUpsertHooks = []MicrophoneAlertHook{}
-
-	AddMicrophoneAlertHook(boil.AfterUpsertHook, microphoneAlertAfterUpsertHook)
-	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
-		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
-	}
-	if !reflect.DeepEqual(o, empty) {
-		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
-	}
-	microphoneAlertAfterUpsertHooks = []MicrophoneAlertHook{}
-}
-
-func testMicrophoneAlertsInsert(t *testing.T) {
-	t.Parallel()
-
-	seed := randomize.NewSeed()
-	var err error
-	o := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	count, err := MicrophoneAlerts().Count(ctx, tx)
-	if err != nil {
-		t.Error(err)
-	}
-
-	if count != 1 {
-		t.Error("want one record, got:", count)
-	}
-}
-
-func testMicrophoneAlertsInsertWhitelist(t *testing.T) {
-	t.Parallel()
-
-	seed := randomize.NewSeed()
-	var err error
-	o := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Insert(ctx, tx, boil.Whitelist(microphoneAlertColumnsWithoutDefault...)); err != nil {
-		t.Error(err)
-	}
-
-	count, err := MicrophoneAlerts().Count(ctx, tx)
-	if err != nil {
-		t.Error(err)
-	}
-
-	if count != 1 {
-		t.Error("want one record, got:", count)
-	}
-}
-
-func testMicrophoneAlertToOneMicrophoneDatumUsingDatum(t *testing.T) {
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-
-	var local MicrophoneAlert
-	var foreign MicrophoneDatum
-
-	seed := randomize.NewSeed()
-	if err := randomize.Struct(seed, &local, microphoneAlertDBTypes, true, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-	if err := randomize.Struct(seed, &foreign, microphoneDatumDBTypes, false, microphoneDatumColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneDatum struct: %s", err)
-	}
-
-	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Fatal(err)
-	}
-
-	queries.Assign(&local.DataID, foreign.ID)
-	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Fatal(err)
-	}
-
-	check, err := local.Datum().One(ctx, tx)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	if !queries.Equal(check.ID, foreign.ID) {
-		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
-	}
-
-	ranAfterSelectHook := false
-	AddMicrophoneDatumHook(boil.AfterSelectHook, func(ctx context.Context, e boil.ContextExecutor, o *MicrophoneDatum) error {
-		ranAfterSelectHook = true
-		return nil
-	})
-
-	slice := MicrophoneAlertSlice{&local}
-	if err = local.L.LoadDatum(ctx, tx, false, (*[]*MicrophoneAlert)(&slice), nil); err != nil {
-		t.Fatal(err)
-	}
-	if local.R.Datum == nil {
-		t.Error("struct should have been eager loaded")
-	}
-
-	local.R.Datum = nil
-	if err = local.L.LoadDatum(ctx, tx, true, &local, nil); err != nil {
-		t.Fatal(err)
-	}
-	if local.R.Datum == nil {
-		t.Error("struct should have been eager loaded")
-	}
-
-	if !ranAfterSelectHook {
-		t.Error("failed to run AfterSelect hook for relationship")
-	}
-}
-
-func testMicrophoneAlertToOneSetOpMicrophoneDatumUsingDatum(t *testing.T) {
-	var err error
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-
-	var a MicrophoneAlert
-	var b, c MicrophoneDatum
-
-	seed := randomize.NewSeed()
-	if err = randomize.Struct(seed, &a, microphoneAlertDBTypes, false, strmangle.SetComplement(microphoneAlertPrimaryKeyColumns, microphoneAlertColumnsWithoutDefault)...); err != nil {
-		t.Fatal(err)
-	}
-	if err = randomize.Struct(seed, &b, microphoneDatumDBTypes, false, strmangle.SetComplement(microphoneDatumPrimaryKeyColumns, microphoneDatumColumnsWithoutDefault)...); err != nil {
-		t.Fatal(err)
-	}
-	if err = randomize.Struct(seed, &c, microphoneDatumDBTypes, false, strmangle.SetComplement(microphoneDatumPrimaryKeyColumns, microphoneDatumColumnsWithoutDefault)...); err != nil {
-		t.Fatal(err)
-	}
-
-	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Fatal(err)
-	}
-	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Fatal(err)
-	}
-
-	for i, x := range []*MicrophoneDatum{&b, &c} {
-		err = a.SetDatum(ctx, tx, i != 0, x)
-		if err != nil {
-			t.Fatal(err)
-		}
-
-		if a.R.Datum != x {
-			t.Error("relationship struct not set to correct value")
-		}
-
-		if x.R.DatumMicrophoneAlerts[0] != &a {
-			t.Error("failed to append to foreign relationship struct")
-		}
-		if !queries.Equal(a.DataID, x.ID) {
-			t.Error("foreign key was wrong value", a.DataID)
-		}
-
-		zero := reflect.Zero(reflect.TypeOf(a.DataID))
-		reflect.Indirect(reflect.ValueOf(&a.DataID)).Set(zero)
-
-		if err = a.Reload(ctx, tx); err != nil {
-			t.Fatal("failed to reload", err)
-		}
-
-		if !queries.Equal(a.DataID, x.ID) {
-			t.Error("foreign key was wrong value", a.DataID, x.ID)
-		}
-	}
-}
-
-func testMicrophoneAlertToOneRemoveOpMicrophoneDatumUsingDatum(t *testing.T) {
-	var err error
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-
-	var a MicrophoneAlert
-	var b MicrophoneDatum
-
-	seed := randomize.NewSeed()
-	if err = randomize.Struct(seed, &a, microphoneAlertDBTypes, false, strmangle.SetComplement(microphoneAlertPrimaryKeyColumns, microphoneAlertColumnsWithoutDefault)...); err != nil {
-		t.Fatal(err)
-	}
-	if err = randomize.Struct(seed, &b, microphoneDatumDBTypes, false, strmangle.SetComplement(microphoneDatumPrimaryKeyColumns, microphoneDatumColumnsWithoutDefault)...); err != nil {
-		t.Fatal(err)
-	}
-
-	if err = a.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Fatal(err)
-	}
-
-	if err = a.SetDatum(ctx, tx, true, &b); err != nil {
-		t.Fatal(err)
-	}
-
-	if err = a.RemoveDatum(ctx, tx, &b); err != nil {
-		t.Error("failed to remove relationship")
-	}
-
-	count, err := a.Datum().Count(ctx, tx)
-	if err != nil {
-		t.Error(err)
-	}
-	if count != 0 {
-		t.Error("want no relationships remaining")
-	}
-
-	if a.R.Datum != nil {
-		t.Error("R struct entry should be nil")
-	}
-
-	if !queries.IsValuerNil(a.DataID) {
-		t.Error("foreign key value should be nil")
-	}
-
-	if len(b.R.DatumMicrophoneAlerts) != 0 {
-		t.Error("failed to remove a from b's relationships")
-	}
-}
-
-func testMicrophoneAlertsReload(t *testing.T) {
-	t.Parallel()
-
-	seed := randomize.NewSeed()
-	var err error
-	o := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	if err = o.Reload(ctx, tx); err != nil {
-		t.Error(err)
-	}
-}
-
-func testMicrophoneAlertsReloadAll(t *testing.T) {
-	t.Parallel()
-
-	seed := randomize.NewSeed()
-	var err error
-	o := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	slice := MicrophoneAlertSlice{o}
-
-	if err = slice.ReloadAll(ctx, tx); err != nil {
-		t.Error(err)
-	}
-}
-
-func testMicrophoneAlertsSelect(t *testing.T) {
-	t.Parallel()
-
-	seed := randomize.NewSeed()
-	var err error
-	o := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	slice, err := MicrophoneAlerts().All(ctx, tx)
-	if err != nil {
-		t.Error(err)
-	}
-
-	if len(slice) != 1 {
-		t.Error("want one record, got:", len(slice))
-	}
-}
-
-var (
-	microphoneAlertDBTypes = map[string]string{`ID`: `bigint`, `DeviceID`: `character varying`, `DataID`: `bigint`, `Decibels`: `numeric`, `ThresholdExceeded`: `numeric`, `AlertStatus`: `character varying`, `AcknowledgedAt`: `timestamp without time zone`, `ResolvedAt`: `timestamp without time zone`, `CreatedAt`: `timestamp without time zone`}
-	_                      = bytes.MinRead
-)
-
-func testMicrophoneAlertsUpdate(t *testing.T) {
-	t.Parallel()
-
-	if 0 == len(microphoneAlertPrimaryKeyColumns) {
-		t.Skip("Skipping table with no primary key columns")
-	}
-	if len(microphoneAlertAllColumns) == len(microphoneAlertPrimaryKeyColumns) {
-		t.Skip("Skipping table with only primary key columns")
-	}
-
-	seed := randomize.NewSeed()
-	var err error
-	o := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	count, err := MicrophoneAlerts().Count(ctx, tx)
-	if err != nil {
-		t.Error(err)
-	}
-
-	if count != 1 {
-		t.Error("want one record, got:", count)
-	}
-
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertPrimaryKeyColumns...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	} else if rowsAff != 1 {
-		t.Error("should only affect one row but affected", rowsAff)
-	}
-}
-
-func testMicrophoneAlertsSliceUpdateAll(t *testing.T) {
-	t.Parallel()
-
-	if len(microphoneAlertAllColumns) == len(microphoneAlertPrimaryKeyColumns) {
-		t.Skip("Skipping table with only primary key columns")
-	}
-
-	seed := randomize.NewSeed()
-	var err error
-	o := &MicrophoneAlert{}
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
-		t.Error(err)
-	}
-
-	count, err := MicrophoneAlerts().Count(ctx, tx)
-	if err != nil {
-		t.Error(err)
-	}
-
-	if count != 1 {
-		t.Error("want one record, got:", count)
-	}
-
-	if err = randomize.Struct(seed, o, microphoneAlertDBTypes, true, microphoneAlertPrimaryKeyColumns...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	// Remove Primary keys and unique columns from what we plan to update
-	var fields []string
-	if strmangle.StringSliceMatch(microphoneAlertAllColumns, microphoneAlertPrimaryKeyColumns) {
-		fields = microphoneAlertAllColumns
-	} else {
-		fields = strmangle.SetComplement(
-			microphoneAlertAllColumns,
-			microphoneAlertPrimaryKeyColumns,
-		)
-	}
-
-	value := reflect.Indirect(reflect.ValueOf(o))
-	typ := reflect.TypeOf(o).Elem()
-	n := typ.NumField()
-
-	updateMap := M{}
-	for _, col := range fields {
-		for i := 0; i < n; i++ {
-			f := typ.Field(i)
-			if f.Tag.Get("boil") == col {
-				updateMap[col] = value.Field(i).Interface()
-			}
-		}
-	}
-
-	slice := MicrophoneAlertSlice{o}
-	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
-		t.Error(err)
-	} else if rowsAff != 1 {
-		t.Error("wanted one record updated but got", rowsAff)
-	}
-}
-
-func testMicrophoneAlertsUpsert(t *testing.T) {
-	t.Parallel()
-
-	if len(microphoneAlertAllColumns) == len(microphoneAlertPrimaryKeyColumns) {
-		t.Skip("Skipping table with only primary key columns")
-	}
-
-	seed := randomize.NewSeed()
-	var err error
-	// Attempt the INSERT side of an UPSERT
-	o := MicrophoneAlert{}
-	if err = randomize.Struct(seed, &o, microphoneAlertDBTypes, true); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	ctx := context.Background()
-	tx := MustTx(boil.BeginTx(ctx, nil))
-	defer func() { _ = tx.Rollback() }()
-	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
-		t.Errorf("Unable to upsert MicrophoneAlert: %s", err)
-	}
-
-	count, err := MicrophoneAlerts().Count(ctx, tx)
-	if err != nil {
-		t.Error(err)
-	}
-	if count != 1 {
-		t.Error("want one record, got:", count)
-	}
-
-	// Attempt the UPDATE side of an UPSERT
-	if err = randomize.Struct(seed, &o, microphoneAlertDBTypes, false, microphoneAlertPrimaryKeyColumns...); err != nil {
-		t.Errorf("Unable to randomize MicrophoneAlert struct: %s", err)
-	}
-
-	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
-		t.Errorf("Unable to upsert MicrophoneAlert: %s", err)
-	}
-
-	count, err = MicrophoneAlerts().Count(ctx, tx)
-	if err != nil {
-		t.Error(err)
-	}
-	if count != 1 {
-		t.Error("want one record, got:", count)
-	}
-}
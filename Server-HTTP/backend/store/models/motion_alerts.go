@@ -1,1227 +0,0 @@
-// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
-// This file is meant to be re-generated in place and/or deleted at any time.
-
-package models
-
-import (
-	"context"
-	"database/sql"
-	"fmt"
-	"reflect"
-	"strconv"
-	"strings"
-	"sync"
-	"time"
-
-	"github.com/friendsofgo/errors"
-	"github.com/volatiletech/null/v8"
-	"github.com/volatiletech/sqlboiler/v4/boil"
-	"github.com/volatiletech/sqlboiler/v4/queries"
-	"github.com/volatiletech/sqlboiler/v4/queries/qm"
-	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
-	"github.com/volatiletech/strmangle"
-)
-
-// MotionAlert is an object representing the database table.
-type MotionAlert struct {
-	ID             int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	DeviceID       string      `boil:"device_id" json:"device_id" toml:"device_id" yaml:"device_id"`
-	DataID         null.Int64  `boil:"data_id" json:"data_id,omitempty" toml:"data_id" yaml:"data_id,omitempty"`
-	MotionDetected bool        `boil:"motion_detected" json:"motion_detected" toml:"motion_detected" yaml:"motion_detected"`
-	AlertReason    null.String `boil:"alert_reason" json:"alert_reason,omitempty" toml:"alert_reason" yaml:"alert_reason,omitempty"`
-	AlertStatus    null.String `boil:"alert_status" json:"alert_status,omitempty" toml:"alert_status" yaml:"alert_status,omitempty"`
-	AcknowledgedAt null.Time   `boil:"acknowledged_at" json:"acknowledged_at,omitempty" toml:"acknowledged_at" yaml:"acknowledged_at,omitempty"`
-	ResolvedAt     null.Time   `boil:"resolved_at" json:"resolved_at,omitempty" toml:"resolved_at" yaml:"resolved_at,omitempty"`
-	CreatedAt      null.Time   `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
-
-	R *motionAlertR `boil:"-" json:"-" toml:"-" yaml:"-"`
-	L motionAlertL  `boil:"-" json:"-" toml:"-" yaml:"-"`
-}
-
-var MotionAlertColumns = struct {
-	ID             string
-	DeviceID       string
-	DataID         string
-	MotionDetected string
-	AlertReason    string
-	AlertStatus    string
-	AcknowledgedAt string
-	ResolvedAt     string
-	CreatedAt      string
-}{
-	ID:             "id",
-	DeviceID:       "device_id",
-	DataID:         "data_id",
-	MotionDetected: "motion_detected",
-	AlertReason:    "alert_reason",
-	AlertStatus:    "alert_status",
-	AcknowledgedAt: "acknowledged_at",
-	ResolvedAt:     "resolved_at",
-	CreatedAt:      "created_at",
-}
-
-var MotionAlertTableColumns = struct {
-	ID             string
-	DeviceID       string
-	DataID         string
-	MotionDetected string
-	AlertReason    string
-	AlertStatus    string
-	AcknowledgedAt string
-	ResolvedAt     string
-	CreatedAt      string
-}{
-	ID:             "motion_alerts.id",
-	DeviceID:       "motion_alerts.device_id",
-	DataID:         "motion_alerts.data_id",
-	MotionDetected: "motion_alerts.motion_detected",
-	AlertReason:    "motion_alerts.alert_reason",
-	AlertStatus:    "motion_alerts.alert_status",
-	AcknowledgedAt: "motion_alerts.acknowledged_at",
-	ResolvedAt:     "motion_alerts.resolved_at",
-	CreatedAt:      "motion_alerts.created_at",
-}
-
-// Generated where
-
-type whereHelperbool struct{ field string }
-
-func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-
-var MotionAlertWhere = struct {
-	ID             whereHelperint64
-	DeviceID       whereHelperstring
-	DataID         whereHelpernull_Int64
-	MotionDetected whereHelperbool
-	AlertReason    whereHelpernull_String
-	AlertStatus    whereHelpernull_String
-	AcknowledgedAt whereHelpernull_Time
-	ResolvedAt     whereHelpernull_Time
-	CreatedAt      whereHelpernull_Time
-}{
-	ID:             whereHelperint64{field: "\"motion_alerts\".\"id\""},
-	DeviceID:       whereHelperstring{field: "\"motion_alerts\".\"device_id\""},
-	DataID:         whereHelpernull_Int64{field: "\"motion_alerts\".\"data_id\""},
-	MotionDetected: whereHelperbool{field: "\"motion_alerts\".\"motion_detected\""},
-	AlertReason:    whereHelpernull_String{field: "\"motion_alerts\".\"alert_reason\""},
-	AlertStatus:    whereHelpernull_String{field: "\"motion_alerts\".\"alert_status\""},
-	AcknowledgedAt: whereHelpernull_Time{field: "\"motion_alerts\".\"acknowledged_at\""},
-	ResolvedAt:     whereHelpernull_Time{field: "\"motion_alerts\".\"resolved_at\""},
-	CreatedAt:      whereHelpernull_Time{field: "\"motion_alerts\".\"created_at\""},
-}
-
-// MotionAlertRels is where relationship names are stored.
-var MotionAlertRels = struct {
-	Datum string
-}{
-	Datum: "Datum",
-}
-
-// motionAlertR is where relationships are stored.
-type motionAlertR struct {
-	Datum *MotionDatum `boil:"Datum" json:"Datum" toml:"Datum" yaml:"Datum"`
-}
-
-// NewStruct creates a new relationship struct
-func (*motionAlertR) NewStruct() *motionAlertR {
-	return &motionAlertR{}
-}
-
-func (r *motionAlertR) GetDatum() *MotionDatum {
-	if r == nil {
-		return nil
-	}
-	return r.Datum
-}
-
-// motionAlertL is where Load methods for each relationship are stored.
-type motionAlertL struct{}
-
-var (
-	motionAlertAllColumns            = []string{"id", "device_id", "data_id", "motion_detected", "alert_reason", "alert_status", "acknowledged_at", "resolved_at", "created_at"}
-	motionAlertColumnsWithoutDefault = []string{"device_id", "motion_detected"}
-	motionAlertColumnsWithDefault    = []string{"id", "data_id", "alert_reason", "alert_status", "acknowledged_at", "resolved_at", "created_at"}
-	motionAlertPrimaryKeyColumns     = []string{"id"}
-	motionAlertGeneratedColumns      = []string{}
-)
-
-type (
-	// MotionAlertSlice is an alias for a slice of pointers to MotionAlert.
-	// This should almost always be used instead of []MotionAlert.
-	MotionAlertSlice []*MotionAlert
-	// MotionAlertHook is the signature for custom MotionAlert hook methods
-	MotionAlertHook func(context.Context, boil.ContextExecutor, *MotionAlert) error
-
-	motionAlertQuery struct {
-		*queries.Query
-	}
-)
-
-// Cache for insert, update and upsert
-var (
-	motionAlertType                 = reflect.TypeOf(&MotionAlert{})
-	motionAlertMapping              = queries.MakeStructMapping(motionAlertType)
-	motionAlertPrimaryKeyMapping, _ = queries.BindMapping(motionAlertType, motionAlertMapping, motionAlertPrimaryKeyColumns)
-	motionAlertInsertCacheMut       sync.RWMutex
-	motionAlertInsertCache          = make(map[string]insertCache)
-	motionAlertUpdateCacheMut       sync.RWMutex
-	motionAlertUpdateCache          = make(map[string]updateCache)
-	motionAlertUpsertCacheMut       sync.RWMutex
-	motionAlertUpsertCache          = make(map[string]insertCache)
-)
-
-var (
-	// Force time package dependency for automated UpdatedAt/CreatedAt.
-	_ = time.Second
-	// Force qmhelper dependency for where clause generation (which doesn't
-	// always happen)
-	_ = qmhelper.Where
-)
-
-var motionAlertAfterSelectMu sync.Mutex
-var motionAlertAfterSelectHooks []MotionAlertHook
-
-var motionAlertBeforeInsertMu sync.Mutex
-var motionAlertBeforeInsertHooks []MotionAlertHook
-var motionAlertAfterInsertMu sync.Mutex
-var motionAlertAfterInsertHooks []MotionAlertHook
-
-var motionAlertBeforeUpdateMu sync.Mutex
-var motionAlertBeforeUpdateHooks []MotionAlertHook
-var motionAlertAfterUpdateMu sync.Mutex
-var motionAlertAfterUpdateHooks []MotionAlertHook
-
-var motionAlertBeforeDeleteMu sync.Mutex
-var motionAlertBeforeDeleteHooks []MotionAlertHook
-var motionAlertAfterDeleteMu sync.Mutex
-var motionAlertAfterDeleteHooks []MotionAlertHook
-
-var motionAlertBeforeUpsertMu sync.Mutex
-var motionAlertBeforeUpsertHooks []MotionAlertHook
-var motionAlertAfterUpsertMu sync.Mutex
-var motionAlertAfterUpsertHooks []MotionAlertHook
-
-// doAfterSelectHooks executes all "after Select" hooks.
-func (o *MotionAlert) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range motionAlertAfterSelectHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doBeforeInsertHooks executes all "before insert" hooks.
-func (o *MotionAlert) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range motionAlertBeforeInsertHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doAfterInsertHooks executes all "after Insert" hooks.
-func (o *MotionAlert) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range motionAlertAfterInsertHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doBeforeUpdateHooks executes all "before Update" hooks.
-func (o *MotionAlert) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range motionAlertBeforeUpdateHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doAfterUpdateHooks executes all "after Update" hooks.
-func (o *MotionAlert) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range motionAlertAfterUpdateHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doBeforeDeleteHooks executes all "before Delete" hooks.
-func (o *MotionAlert) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range motionAlertBeforeDeleteHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doAfterDeleteHooks executes all "after Delete" hooks.
-func (o *MotionAlert) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range motionAlertAfterDeleteHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doBeforeUpsertHooks executes all "before Upsert" hooks.
-func (o *MotionAlert) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range motionAlertBeforeUpsertHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// doAfterUpsertHooks executes all "after Upsert" hooks.
-func (o *MotionAlert) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
-	if boil.HooksAreSkipped(ctx) {
-		return nil
-	}
-
-	for _, hook := range motionAlertAfterUpsertHooks {
-		if err := hook(ctx, exec, o); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// AddMotionAlertHook registers your hook function for all future operations.
-func AddMotionAlertHook(hookPoint boil.HookPoint, motionAlertHook MotionAlertHook) {
-	switch hookPoint {
-	case boil.AfterSelectHook:
-		motionAlertAfterSelectMu.Lock()
-		motionAlertAfterSelectHooks = append(motionAlertAfterSelectHooks, motionAlertHook)
-		motionAlertAfterSelectMu.Unlock()
-	case boil.BeforeInsertHook:
-		motionAlertBeforeInsertMu.Lock()
-		motionAlertBeforeInsertHooks = append(motionAlertBeforeInsertHooks, motionAlertHook)
-		motionAlertBeforeInsertMu.Unlock()
-	case boil.AfterInsertHook:
-		motionAlertAfterInsertMu.Lock()
-		motionAlertAfterInsertHooks = append(motionAlertAfterInsertHooks, motionAlertHook)
-		motionAlertAfterInsertMu.Unlock()
-	case boil.BeforeUpdateHook:
-		motionAlertBeforeUpdateMu.Lock()
-		motionAlertBeforeUpdateHooks = append(motionAlertBeforeUpdateHooks, motionAlertHook)
-		motionAlertBeforeUpdateMu.Unlock()
-	case boil.AfterUpdateHook:
-		motionAlertAfterUpdateMu.Lock()
-		motionAlertAfterUpdateHooks = append(motionAlertAfterUpdateHooks, motionAlertHook)
-		motionAlertAfterUpdateMu.Unlock()
-	case boil.BeforeDeleteHook:
-		motionAlertBeforeDeleteMu.Lock()
-		motionAlertBeforeDeleteHooks = append(motionAlertBeforeDeleteHooks, motionAlertHook)
-		motionAlertBeforeDeleteMu.Unlock()
-	case boil.AfterDeleteHook:
-		motionAlertAfterDeleteMu.Lock()
-		motionAlertAfterDeleteHooks = append(motionAlertAfterDeleteHooks, motionAlertHook)
-		motionAlertAfterDeleteMu.Unlock()
-	case boil.BeforeUpsertHook:
-		motionAlertBeforeUpsertMu.Lock()
-		motionAlertBeforeUpsertHooks = append(motionAlertBeforeUpsertHooks, motionAlertHook)
-		motionAlertBeforeUpsertMu.Unlock()
-	case boil.AfterUpsertHook:
-		motionAlertAfterUpsertMu.Lock()
-		motionAlertAfterUpsertHooks = append(motionAlertAfterUpsertHooks, motionAlertHook)
-		motionAlertAfterUpsertMu.Unlock()
-	}
-}
-
-// One returns a single motionAlert record from the query.
-func (q motionAlertQuery) One(ctx context.Context, exec boil.ContextExecutor) (*MotionAlert, error) {
-	o := &MotionAlert{}
-
-	queries.SetLimit(q.Query, 1)
-
-	err := q.Bind(ctx, exec, o)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, sql.ErrNoRows
-		}
-		return nil, errors.Wrap(err, "models: failed to execute a one query for motion_alerts")
-	}
-
-	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
-		return o, err
-	}
-
-	return o, nil
-}
-
-// All returns all MotionAlert records from the query.
-func (q motionAlertQuery) All(ctx context.Context, exec boil.ContextExecutor) (MotionAlertSlice, error) {
-	var o []*MotionAlert
-
-	err := q.Bind(ctx, exec, &o)
-	if err != nil {
-		return nil, errors.Wrap(err, "models: failed to assign all query results to MotionAlert slice")
-	}
-
-	if len(motionAlertAfterSelectHooks) != 0 {
-		for _, obj := range o {
-			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
-				return o, err
-			}
-		}
-	}
-
-	return o, nil
-}
-
-// Count returns the count of all MotionAlert records in the query.
-func (q motionAlertQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
-	var count int64
-
-	queries.SetSelect(q.Query, nil)
-	queries.SetCount(q.Query)
-
-	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
-	if err != nil {
-		return 0, errors.Wrap(err, "models: failed to count motion_alerts rows")
-	}
-
-	return count, nil
-}
-
-// Exists checks if the row exists in the table.
-func (q motionAlertQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
-	var count int64
-
-	queries.SetSelect(q.Query, nil)
-	queries.SetCount(q.Query)
-	queries.SetLimit(q.Query, 1)
-
-	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
-	if err != nil {
-		return false, errors.Wrap(err, "models: failed to check if motion_alerts exists")
-	}
-
-	return count > 0, nil
-}
-
-// Datum pointed to by the foreign key.
-func (o *MotionAlert) Datum(mods ...qm.QueryMod) motionDatumQuery {
-	queryMods := []qm.QueryMod{
-		qm.Where("\"id\" = ?", o.DataID),
-	}
-
-	queryMods = append(queryMods, mods...)
-
-	return MotionData(queryMods...)
-}
-
-// LoadDatum allows an eager lookup of values, cached into the
-// loaded structs of the objects. This is for an N-1 relationship.
-func (motionAlertL) LoadDatum(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMotionAlert interface{}, mods queries.Applicator) error {
-	var slice []*MotionAlert
-	var object *MotionAlert
-
-	if singular {
-		var ok bool
-		object, ok = maybeMotionAlert.(*MotionAlert)
-		if !ok {
-			object = new(MotionAlert)
-			ok = queries.SetFromEmbeddedStruct(&object, &maybeMotionAlert)
-			if !ok {
-				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeMotionAlert))
-			}
-		}
-	} else {
-		s, ok := maybeMotionAlert.(*[]*MotionAlert)
-		if ok {
-			slice = *s
-		} else {
-			ok = queries.SetFromEmbeddedStruct(&slice, maybeMotionAlert)
-			if !ok {
-				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeMotionAlert))
-			}
-		}
-	}
-
-	args := make(map[interface{}]struct{})
-	if singular {
-		if object.R == nil {
-			object.R = &motionAlertR{}
-		}
-		if !queries.IsNil(object.DataID) {
-			args[object.DataID] = struct{}{}
-		}
-
-	} else {
-		for _, obj := range slice {
-			if obj.R == nil {
-				obj.R = &motionAlertR{}
-			}
-
-			if !queries.IsNil(obj.DataID) {
-				args[obj.DataID] = struct{}{}
-			}
-
-		}
-	}
-
-	if len(args) == 0 {
-		return nil
-	}
-
-	argsSlice := make([]interface{}, len(args))
-	i := 0
-	for arg := range args {
-		argsSlice[i] = arg
-		i++
-	}
-
-	query := NewQuery(
-		qm.From(`motion_data`),
-		qm.WhereIn(`motion_data.id in ?`, argsSlice...),
-	)
-	if mods != nil {
-		mods.Apply(query)
-	}
-
-	results, err := query.QueryContext(ctx, e)
-	if err != nil {
-		return errors.Wrap(err, "failed to eager load MotionDatum")
-	}
-
-	var resultSlice []*MotionDatum
-	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice MotionDatum")
-	}
-
-	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results of eager load for motion_data")
-	}
-	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for motion_data")
-	}
-
-	if len(motionDatumAfterSelectHooks) != 0 {
-		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
-				return err
-			}
-		}
-	}
-
-	if len(resultSlice) == 0 {
-		return nil
-	}
-
-	if singular {
-		foreign := resultSlice[0]
-		object.R.Datum = foreign
-		if foreign.R == nil {
-			foreign.R = &motionDatumR{}
-		}
-		foreign.R.DatumMotionAlerts = append(foreign.R.DatumMotionAlerts, object)
-		return nil
-	}
-
-	for _, local := range slice {
-		for _, foreign := range resultSlice {
-			if queries.Equal(local.DataID, foreign.ID) {
-				local.R.Datum = foreign
-				if foreign.R == nil {
-					foreign.R = &motionDatumR{}
-				}
-				foreign.R.DatumMotionAlerts = append(foreign.R.DatumMotionAlerts, local)
-				break
-			}
-		}
-	}
-
-	return nil
-}
-
-// SetDatum of the motionAlert to the related item.
-// Sets o.R.Datum to related.
-// Adds o to related.R.DatumMotionAlerts.
-func (o *MotionAlert) SetDatum(ctx context.Context, exec boil.ContextExecutor, insert bool, related *MotionDatum) error {
-	var err error
-	if insert {
-		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
-		}
-	}
-
-	updateQuery := fmt.Sprintf(
-		"UPDATE \"motion_alerts\" SET %s WHERE %s",
-		strmangle.SetParamNames("\"", "\"", 1, []string{"data_id"}),
-		strmangle.WhereClause("\"", "\"", 2, motionAlertPrimaryKeyColumns),
-	)
-	values := []interface{}{related.ID, o.ID}
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, updateQuery)
-		fmt.Fprintln(writer, values)
-	}
-	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-		return errors.Wrap(err, "failed to update local table")
-	}
-
-	queries.Assign(&o.DataID, related.ID)
-	if o.R == nil {
-		o.R = &motionAlertR{
-			Datum: related,
-		}
-	} else {
-		o.R.Datum = related
-	}
-
-	if related.R == nil {
-		related.R = &motionDatumR{
-			DatumMotionAlerts: MotionAlertSlice{o},
-		}
-	} else {
-		related.R.DatumMotionAlerts = append(related.R.DatumMotionAlerts, o)
-	}
-
-	return nil
-}
-
-// RemoveDatum relationship.
-// Sets o.R.Datum to nil.
-// Removes o from all passed in related items' relationships struct.
-func (o *MotionAlert) RemoveDatum(ctx context.Context, exec boil.ContextExecutor, related *MotionDatum) error {
-	var err error
-
-	queries.SetScanner(&o.DataID, nil)
-	if _, err = o.Update(ctx, exec, boil.Whitelist("data_id")); err != nil {
-		return errors.Wrap(err, "failed to update local table")
-	}
-
-	if o.R != nil {
-		o.R.Datum = nil
-	}
-	if related == nil || related.R == nil {
-		return nil
-	}
-
-	for i, ri := range related.R.DatumMotionAlerts {
-		if queries.Equal(o.DataID, ri.DataID) {
-			continue
-		}
-
-		ln := len(related.R.DatumMotionAlerts)
-		if ln > 1 && i < ln-1 {
-			related.R.DatumMotionAlerts[i] = related.R.DatumMotionAlerts[ln-1]
-		}
-		related.R.DatumMotionAlerts = related.R.DatumMotionAlerts[:ln-1]
-		break
-	}
-	return nil
-}
-
-// MotionAlerts retrieves all the records using an executor.
-func MotionAlerts(mods ...qm.QueryMod) motionAlertQuery {
-	mods = append(mods, qm.From("\"motion_alerts\""))
-	q := NewQuery(mods...)
-	if len(queries.GetSelect(q)) == 0 {
-		queries.SetSelect(q, []string{"\"motion_alerts\".*"})
-	}
-
-	return motionAlertQuery{q}
-}
-
-// FindMotionAlert retrieves a single record by ID with an executor.
-// If selectCols is empty Find will return all columns.
-func FindMotionAlert(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*MotionAlert, error) {
-	motionAlertObj := &MotionAlert{}
-
-	sel := "*"
-	if len(selectCols) > 0 {
-		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
-	}
-	query := fmt.Sprintf(
-		"select %s from \"motion_alerts\" where \"id\"=$1", sel,
-	)
-
-	q := queries.Raw(query, iD)
-
-	err := q.Bind(ctx, exec, motionAlertObj)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, sql.ErrNoRows
-		}
-		return nil, errors.Wrap(err, "models: unable to select from motion_alerts")
-	}
-
-	if err = motionAlertObj.doAfterSelectHooks(ctx, exec); err != nil {
-		return motionAlertObj, err
-	}
-
-	return motionAlertObj, nil
-}
-
-// Insert a single record using an executor.
-// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
-func (o *MotionAlert) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
-	if o == nil {
-		return errors.New("models: no motion_alerts provided for insertion")
-	}
-
-	var err error
-	if !boil.TimestampsAreSkipped(ctx) {
-		currTime := time.Now().In(boil.GetLocation())
-
-		if queries.MustTime(o.CreatedAt).IsZero() {
-			queries.SetScanner(&o.CreatedAt, currTime)
-		}
-	}
-
-	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
-		return err
-	}
-
-	nzDefaults := queries.NonZeroDefaultSet(motionAlertColumnsWithDefault, o)
-
-	key := makeCacheKey(columns, nzDefaults)
-	motionAlertInsertCacheMut.RLock()
-	cache, cached := motionAlertInsertCache[key]
-	motionAlertInsertCacheMut.RUnlock()
-
-	if !cached {
-		wl, returnColumns := columns.InsertColumnSet(
-			motionAlertAllColumns,
-			motionAlertColumnsWithDefault,
-			motionAlertColumnsWithoutDefault,
-			nzDefaults,
-		)
-
-		cache.valueMapping, err = queries.BindMapping(motionAlertType, motionAlertMapping, wl)
-		if err != nil {
-			return err
-		}
-		cache.retMapping, err = queries.BindMapping(motionAlertType, motionAlertMapping, returnColumns)
-		if err != nil {
-			return err
-		}
-		if len(wl) != 0 {
-			cache.query = fmt.Sprintf("INSERT INTO \"motion_alerts\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
-		} else {
-			cache.query = "INSERT INTO \"motion_alerts\" %sDEFAULT VALUES%s"
-		}
-
-		var queryOutput, queryReturning string
-
-		if len(cache.retMapping) != 0 {
-			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
-		}
-
-		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
-	}
-
-	value := reflect.Indirect(reflect.ValueOf(o))
-	vals := queries.ValuesFromMapping(value, cache.valueMapping)
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, cache.query)
-		fmt.Fprintln(writer, vals)
-	}
-
-	if len(cache.retMapping) != 0 {
-		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
-	} else {
-		_, err = exec.ExecContext(ctx, cache.query, vals...)
-	}
-
-	if err != nil {
-		return errors.Wrap(err, "models: unable to insert into motion_alerts")
-	}
-
-	if !cached {
-		motionAlertInsertCacheMut.Lock()
-		motionAlertInsertCache[key] = cache
-		motionAlertInsertCacheMut.Unlock()
-	}
-
-	return o.doAfterInsertHooks(ctx, exec)
-}
-
-// Update uses an executor to update the MotionAlert.
-// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
-// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
-func (o *MotionAlert) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
-	var err error
-	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
-		return 0, err
-	}
-	key := makeCacheKey(columns, nil)
-	motionAlertUpdateCacheMut.RLock()
-	cache, cached := motionAlertUpdateCache[key]
-	motionAlertUpdateCacheMut.RUnlock()
-
-	if !cached {
-		wl := columns.UpdateColumnSet(
-			motionAlertAllColumns,
-			motionAlertPrimaryKeyColumns,
-		)
-
-		if !columns.IsWhitelist() {
-			wl = strmangle.SetComplement(wl, []string{"created_at"})
-		}
-		if len(wl) == 0 {
-			return 0, errors.New("models: unable to update motion_alerts, could not build whitelist")
-		}
-
-		cache.query = fmt.Sprintf("UPDATE \"motion_alerts\" SET %s WHERE %s",
-			strmangle.SetParamNames("\"", "\"", 1, wl),
-			strmangle.WhereClause("\"", "\"", len(wl)+1, motionAlertPrimaryKeyColumns),
-		)
-		cache.valueMapping, err = queries.BindMapping(motionAlertType, motionAlertMapping, append(wl, motionAlertPrimaryKeyColumns...))
-		if err != nil {
-			return 0, err
-		}
-	}
-
-	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, cache.query)
-		fmt.Fprintln(writer, values)
-	}
-	var result sql.Result
-	result, err = exec.ExecContext(ctx, cache.query, values...)
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to update motion_alerts row")
-	}
-
-	rowsAff, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "models: failed to get rows affected by update for motion_alerts")
-	}
-
-	if !cached {
-		motionAlertUpdateCacheMut.Lock()
-		motionAlertUpdateCache[key] = cache
-		motionAlertUpdateCacheMut.Unlock()
-	}
-
-	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
-}
-
-// UpdateAll updates all rows with the specified column values.
-func (q motionAlertQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
-	queries.SetUpdate(q.Query, cols)
-
-	result, err := q.Query.ExecContext(ctx, exec)
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to update all for motion_alerts")
-	}
-
-	rowsAff, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for motion_alerts")
-	}
-
-	return rowsAff, nil
-}
-
-// UpdateAll updates all rows with the specified column values, using an executor.
-func (o MotionAlertSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
-	ln := int64(len(o))
-	if ln == 0 {
-		return 0, nil
-	}
-
-	if len(cols) == 0 {
-		return 0, errors.New("models: update all requires at least one column argument")
-	}
-
-	colNames := make([]string, len(cols))
-	args := make([]interface{}, len(cols))
-
-	i := 0
-	for name, value := range cols {
-		colNames[i] = name
-		args[i] = value
-		i++
-	}
-
-	// Append all of the primary key values for each column
-	for _, obj := range o {
-		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), motionAlertPrimaryKeyMapping)
-		args = append(args, pkeyArgs...)
-	}
-
-	sql := fmt.Sprintf("UPDATE \"motion_alerts\" SET %s WHERE %s",
-		strmangle.SetParamNames("\"", "\"", 1, colNames),
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, motionAlertPrimaryKeyColumns, len(o)))
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, sql)
-		fmt.Fprintln(writer, args...)
-	}
-	result, err := exec.ExecContext(ctx, sql, args...)
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to update all in motionAlert slice")
-	}
-
-	rowsAff, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all motionAlert")
-	}
-	return rowsAff, nil
-}
-
-// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
-// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
-func (o *MotionAlert) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
-	if o == nil {
-		return errors.New("models: no motion_alerts provided for upsert")
-	}
-	if !boil.TimestampsAreSkipped(ctx) {
-		currTime := time.Now().In(boil.GetLocation())
-
-		if queries.MustTime(o.CreatedAt).IsZero() {
-			queries.SetScanner(&o.CreatedAt, currTime)
-		}
-	}
-
-	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
-		return err
-	}
-
-	nzDefaults := queries.NonZeroDefaultSet(motionAlertColumnsWithDefault, o)
-
-	// Build cache key in-line uglily - mysql vs psql problems
-	buf := strmangle.GetBuffer()
-	if updateOnConflict {
-		buf.WriteByte('t')
-	} else {
-		buf.WriteByte('f')
-	}
-	buf.WriteByte('.')
-	for _, c := range conflictColumns {
-		buf.WriteString(c)
-	}
-	buf.WriteByte('.')
-	buf.WriteString(strconv.Itoa(updateColumns.Kind))
-	for _, c := range updateColumns.Cols {
-		buf.WriteString(c)
-	}
-	buf.WriteByte('.')
-	buf.WriteString(strconv.Itoa(insertColumns.Kind))
-	for _, c := range insertColumns.Cols {
-		buf.WriteString(c)
-	}
-	buf.WriteByte('.')
-	for _, c := range nzDefaults {
-		buf.WriteString(c)
-	}
-	key := buf.String()
-	strmangle.PutBuffer(buf)
-
-	motionAlertUpsertCacheMut.RLock()
-	cache, cached := motionAlertUpsertCache[key]
-	motionAlertUpsertCacheMut.RUnlock()
-
-	var err error
-
-	if !cached {
-		insert, _ := insertColumns.InsertColumnSet(
-			motionAlertAllColumns,
-			motionAlertColumnsWithDefault,
-			motionAlertColumnsWithoutDefault,
-			nzDefaults,
-		)
-
-		update := updateColumns.UpdateColumnSet(
-			motionAlertAllColumns,
-			motionAlertPrimaryKeyColumns,
-		)
-
-		if updateOnConflict && len(update) == 0 {
-			return errors.New("models: unable to upsert motion_alerts, could not build update column list")
-		}
-
-		ret := strmangle.SetComplement(motionAlertAllColumns, strmangle.SetIntersect(insert, update))
-
-		conflict := conflictColumns
-		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
-			if len(motionAlertPrimaryKeyColumns) == 0 {
-				return errors.New("models: unable to upsert motion_alerts, could not build conflict column list")
-			}
-
-			conflict = make([]string, len(motionAlertPrimaryKeyColumns))
-			copy(conflict, motionAlertPrimaryKeyColumns)
-		}
-		cache.query = buildUpsertQueryPostgres(dialect, "\"motion_alerts\"", updateOnConflict, ret, update, conflict, insert, opts...)
-
-		cache.valueMapping, err = queries.BindMapping(motionAlertType, motionAlertMapping, insert)
-		if err != nil {
-			return err
-		}
-		if len(ret) != 0 {
-			cache.retMapping, err = queries.BindMapping(motionAlertType, motionAlertMapping, ret)
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	value := reflect.Indirect(reflect.ValueOf(o))
-	vals := queries.ValuesFromMapping(value, cache.valueMapping)
-	var returns []interface{}
-	if len(cache.retMapping) != 0 {
-		returns = queries.PtrsFromMapping(value, cache.retMapping)
-	}
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, cache.query)
-		fmt.Fprintln(writer, vals)
-	}
-	if len(cache.retMapping) != 0 {
-		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
-		if errors.Is(err, sql.ErrNoRows) {
-			err = nil // Postgres doesn't return anything when there's no update
-		}
-	} else {
-		_, err = exec.ExecContext(ctx, cache.query, vals...)
-	}
-	if err != nil {
-		return errors.Wrap(err, "models: unable to upsert motion_alerts")
-	}
-
-	if !cached {
-		motionAlertUpsertCacheMut.Lock()
-		motionAlertUpsertCache[key] = cache
-		motionAlertUpsertCacheMut.Unlock()
-	}
-
-	return o.doAfterUpsertHooks(ctx, exec)
-}
-
-// Delete deletes a single MotionAlert record with an executor.
-// Delete will match against the primary key column to find the record to delete.
-func (o *MotionAlert) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
-	if o == nil {
-		return 0, errors.New("models: no MotionAlert provided for delete")
-	}
-
-	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
-		return 0, err
-	}
-
-	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), motionAlertPrimaryKeyMapping)
-	sql := "DELETE FROM \"motion_alerts\" WHERE \"id\"=$1"
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, sql)
-		fmt.Fprintln(writer, args...)
-	}
-	result, err := exec.ExecContext(ctx, sql, args...)
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to delete from motion_alerts")
-	}
-
-	rowsAff, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for motion_alerts")
-	}
-
-	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
-		return 0, err
-	}
-
-	return rowsAff, nil
-}
-
-// DeleteAll deletes all matching rows.
-func (q motionAlertQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
-	if q.Query == nil {
-		return 0, errors.New("models: no motionAlertQuery provided for delete all")
-	}
-
-	queries.SetDelete(q.Query)
-
-	result, err := q.Query.ExecContext(ctx, exec)
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to delete all from motion_alerts")
-	}
-
-	rowsAff, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for motion_alerts")
-	}
-
-	return rowsAff, nil
-}
-
-// DeleteAll deletes all rows in the slice, using an executor.
-func (o MotionAlertSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
-	if len(o) == 0 {
-		return 0, nil
-	}
-
-	if len(motionAlertBeforeDeleteHooks) != 0 {
-		for _, obj := range o {
-			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
-				return 0, err
-			}
-		}
-	}
-
-	var args []interface{}
-	for _, obj := range o {
-		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), motionAlertPrimaryKeyMapping)
-		args = append(args, pkeyArgs...)
-	}
-
-	sql := "DELETE FROM \"motion_alerts\" WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, motionAlertPrimaryKeyColumns, len(o))
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, sql)
-		fmt.Fprintln(writer, args)
-	}
-	result, err := exec.ExecContext(ctx, sql, args...)
-	if err != nil {
-		return 0, errors.Wrap(err, "models: unable to delete all from motionAlert slice")
-	}
-
-	rowsAff, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for motion_alerts")
-	}
-
-	if len(motionAlertAfterDeleteHooks) != 0 {
-		for _, obj := range o {
-			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
-				return 0, err
-			}
-		}
-	}
-
-	return rowsAff, nil
-}
-
-// Reload refetches the object from the database
-// using the primary keys with an executor.
-func (o *MotionAlert) Reload(ctx context.Context, exec boil.ContextExecutor) error {
-	ret, err := FindMotionAlert(ctx, exec, o.ID)
-	if err != nil {
-		return err
-	}
-
-	*o = *ret
-	return nil
-}
-
-// ReloadAll refetches every row with matching primary key column values
-// and overwrites the original object slice with the newly updated slice.
-func (o *MotionAlertSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
-	if o == nil || len(*o) == 0 {
-		return nil
-	}
-
-	slice := MotionAlertSlice{}
-	var args []interface{}
-	for _, obj := range *o {
-		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), motionAlertPrimaryKeyMapping)
-		args = append(args, pkeyArgs...)
-	}
-
-	sql := "SELECT \"motion_alerts\".* FROM \"motion_alerts\" WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, motionAlertPrimaryKeyColumns, len(*o))
-
-	q := queries.Raw(sql, args...)
-
-	err := q.Bind(ctx, exec, &slice)
-	if err != nil {
-		return errors.Wrap(err, "models: unable to reload all in MotionAlertSlice")
-	}
-
-	*o = slice
-
-	return nil
-}
-
-// MotionAlertExists checks if the MotionAlert row exists.
-func MotionAlertExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
-	var exists bool
-	sql := "select exists(select 1 from \"motion_alerts\" where \"id\"=$1 limit 1)"
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, sql)
-		fmt.Fprintln(writer, iD)
-	}
-	row := exec.QueryRowContext(ctx, sql, iD)
-
-	err := row.Scan(&exists)
-	if err != nil {
-		return false, errors.Wrap(err, "models: unable to check if motion_alerts exists")
-	}
-
-	return exists, nil
-}
-
-// Exists checks if the MotionAlert row exists.
-func (o *MotionAlert) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
-	return MotionAlertExists(ctx, exec, o.ID)
-}
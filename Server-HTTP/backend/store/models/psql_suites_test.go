@@ -6,17 +6,5 @@ package models
 import "testing"
 
 func TestUpsert(t *testing.T) {
-	t.Run("DistanceAlerts", testDistanceAlertsUpsert)
-
-	t.Run("DistanceData", testDistanceDataUpsert)
-
-	t.Run("MicrophoneAlerts", testMicrophoneAlertsUpsert)
-
-	t.Run("MicrophoneData", testMicrophoneDataUpsert)
-
-	t.Run("MotionAlerts", testMotionAlertsUpsert)
-
-	t.Run("MotionData", testMotionDataUpsert)
-
 	t.Run("PushTokens", testPushTokensUpsert)
 }
@@ -24,13 +24,15 @@ import (
 
 // PushToken is an object representing the database table.
 type PushToken struct {
-	ID         int64     `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Token      string    `boil:"token" json:"token" toml:"token" yaml:"token"`
-	Platform   string    `boil:"platform" json:"platform" toml:"platform" yaml:"platform"`
-	DeviceInfo null.JSON `boil:"device_info" json:"device_info,omitempty" toml:"device_info" yaml:"device_info,omitempty"`
-	IsActive   null.Bool `boil:"is_active" json:"is_active,omitempty" toml:"is_active" yaml:"is_active,omitempty"`
-	CreatedAt  null.Time `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
-	UpdatedAt  null.Time `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	ID         int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Token      string      `boil:"token" json:"token" toml:"token" yaml:"token"`
+	SiteID     string      `boil:"site_id" json:"site_id" toml:"site_id" yaml:"site_id"`
+	Platform   string      `boil:"platform" json:"platform" toml:"platform" yaml:"platform"`
+	DeviceInfo null.JSON   `boil:"device_info" json:"device_info,omitempty" toml:"device_info" yaml:"device_info,omitempty"`
+	Locale     null.String `boil:"locale" json:"locale,omitempty" toml:"locale" yaml:"locale,omitempty"`
+	IsActive   null.Bool   `boil:"is_active" json:"is_active,omitempty" toml:"is_active" yaml:"is_active,omitempty"`
+	CreatedAt  null.Time   `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
+	UpdatedAt  null.Time   `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
 
 	R *pushTokenR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L pushTokenL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -39,16 +41,20 @@ type PushToken struct {
 var PushTokenColumns = struct {
 	ID         string
 	Token      string
+	SiteID     string
 	Platform   string
 	DeviceInfo string
+	Locale     string
 	IsActive   string
 	CreatedAt  string
 	UpdatedAt  string
 }{
 	ID:         "id",
 	Token:      "token",
+	SiteID:     "site_id",
 	Platform:   "platform",
 	DeviceInfo: "device_info",
+	Locale:     "locale",
 	IsActive:   "is_active",
 	CreatedAt:  "created_at",
 	UpdatedAt:  "updated_at",
@@ -57,16 +63,20 @@ var PushTokenColumns = struct {
 var PushTokenTableColumns = struct {
 	ID         string
 	Token      string
+	SiteID     string
 	Platform   string
 	DeviceInfo string
+	Locale     string
 	IsActive   string
 	CreatedAt  string
 	UpdatedAt  string
 }{
 	ID:         "push_tokens.id",
 	Token:      "push_tokens.token",
+	SiteID:     "push_tokens.site_id",
 	Platform:   "push_tokens.platform",
 	DeviceInfo: "push_tokens.device_info",
+	Locale:     "push_tokens.locale",
 	IsActive:   "push_tokens.is_active",
 	CreatedAt:  "push_tokens.created_at",
 	UpdatedAt:  "push_tokens.updated_at",
@@ -74,6 +84,56 @@ var PushTokenTableColumns = struct {
 
 // Generated where
 
+type whereHelperint64 struct{ field string }
+
+func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelperstring struct{ field string }
+
+func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
+func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
+func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
+func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
+func (w whereHelperstring) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
 type whereHelpernull_JSON struct{ field string }
 
 func (w whereHelpernull_JSON) EQ(x null.JSON) qm.QueryMod {
@@ -98,6 +158,56 @@ func (w whereHelpernull_JSON) GTE(x null.JSON) qm.QueryMod {
 func (w whereHelpernull_JSON) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_JSON) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
+type whereHelpernull_String struct{ field string }
+
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" LIKE ?", x)
+}
+func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" NOT LIKE ?", x)
+}
+func (w whereHelpernull_String) ILIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" ILIKE ?", x)
+}
+func (w whereHelpernull_String) NILIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" NOT ILIKE ?", x)
+}
+func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 type whereHelpernull_Bool struct{ field string }
 
 func (w whereHelpernull_Bool) EQ(x null.Bool) qm.QueryMod {
@@ -122,19 +232,47 @@ func (w whereHelpernull_Bool) GTE(x null.Bool) qm.QueryMod {
 func (w whereHelpernull_Bool) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_Bool) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
+type whereHelpernull_Time struct{ field string }
+
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var PushTokenWhere = struct {
 	ID         whereHelperint64
 	Token      whereHelperstring
+	SiteID     whereHelperstring
 	Platform   whereHelperstring
 	DeviceInfo whereHelpernull_JSON
+	Locale     whereHelpernull_String
 	IsActive   whereHelpernull_Bool
 	CreatedAt  whereHelpernull_Time
 	UpdatedAt  whereHelpernull_Time
 }{
 	ID:         whereHelperint64{field: "\"push_tokens\".\"id\""},
 	Token:      whereHelperstring{field: "\"push_tokens\".\"token\""},
+	SiteID:     whereHelperstring{field: "\"push_tokens\".\"site_id\""},
 	Platform:   whereHelperstring{field: "\"push_tokens\".\"platform\""},
 	DeviceInfo: whereHelpernull_JSON{field: "\"push_tokens\".\"device_info\""},
+	Locale:     whereHelpernull_String{field: "\"push_tokens\".\"locale\""},
 	IsActive:   whereHelpernull_Bool{field: "\"push_tokens\".\"is_active\""},
 	CreatedAt:  whereHelpernull_Time{field: "\"push_tokens\".\"created_at\""},
 	UpdatedAt:  whereHelpernull_Time{field: "\"push_tokens\".\"updated_at\""},
@@ -157,9 +295,9 @@ func (*pushTokenR) NewStruct() *pushTokenR {
 type pushTokenL struct{}
 
 var (
-	pushTokenAllColumns            = []string{"id", "token", "platform", "device_info", "is_active", "created_at", "updated_at"}
+	pushTokenAllColumns            = []string{"id", "token", "site_id", "platform", "device_info", "locale", "is_active", "created_at", "updated_at"}
 	pushTokenColumnsWithoutDefault = []string{"token", "platform"}
-	pushTokenColumnsWithDefault    = []string{"id", "device_info", "is_active", "created_at", "updated_at"}
+	pushTokenColumnsWithDefault    = []string{"id", "site_id", "device_info", "locale", "is_active", "created_at", "updated_at"}
 	pushTokenPrimaryKeyColumns     = []string{"id"}
 	pushTokenGeneratedColumns      = []string{}
 )
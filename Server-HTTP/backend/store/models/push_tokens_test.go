@@ -568,7 +568,7 @@ func testPushTokensSelect(t *testing.T) {
 }
 
 var (
-	pushTokenDBTypes = map[string]string{`ID`: `bigint`, `Token`: `character varying`, `Platform`: `character varying`, `DeviceInfo`: `jsonb`, `IsActive`: `boolean`, `CreatedAt`: `timestamp without time zone`, `UpdatedAt`: `timestamp without time zone`}
+	pushTokenDBTypes = map[string]string{`ID`: `bigint`, `Token`: `character varying`, `SiteID`: `character varying`, `Platform`: `character varying`, `DeviceInfo`: `jsonb`, `IsActive`: `boolean`, `CreatedAt`: `timestamp without time zone`, `UpdatedAt`: `timestamp without time zone`}
 	_                = bytes.MinRead
 )
 
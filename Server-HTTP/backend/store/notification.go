@@ -23,6 +23,7 @@ var _ sensormanager.NotificationManager = (*notificationsStore)(nil)
 
 func (ns *notificationsStore) RegisterPushToken(params *sensormanager.PushTokenParams) (*sensormanager.PushToken, error) {
 	fmt.Println("📝 RegisterPushToken appelé")
+	fmt.Printf("   Site: %s\n", params.SiteID)
 	fmt.Printf("   Token: %s\n", params.Token)
 	fmt.Printf("   Platform: %s\n", params.Platform)
 
@@ -33,11 +34,15 @@ func (ns *notificationsStore) RegisterPushToken(params *sensormanager.PushTokenP
 
 	existingToken, err := models.PushTokens(
 		models.PushTokenWhere.Token.EQ(params.Token),
+		models.PushTokenWhere.SiteID.EQ(params.SiteID),
 	).One(context.TODO(), ns.baseStore.db)
 
 	if err == nil {
 		fmt.Println("♻️  Token existe déjà, réactivation...")
 		existingToken.IsActive = null.BoolFrom(true)
+		if params.Locale != "" {
+			existingToken.Locale = null.StringFrom(params.Locale)
+		}
 		existingToken.UpdatedAt = null.TimeFrom(time.Now())
 		_, updateErr := existingToken.Update(context.TODO(), ns.baseStore.db, boil.Infer())
 		if updateErr != nil {
@@ -48,8 +53,10 @@ func (ns *notificationsStore) RegisterPushToken(params *sensormanager.PushTokenP
 		fmt.Println("✅ Token réactivé avec succès")
 		return &sensormanager.PushToken{
 			ID:        existingToken.ID,
+			SiteID:    existingToken.SiteID,
 			Token:     existingToken.Token,
 			Platform:  sensormanager.Platform(existingToken.Platform),
+			Locale:    sensormanager.Locale(existingToken.Locale.String),
 			IsActive:  existingToken.IsActive.Bool,
 			CreatedAt: existingToken.CreatedAt.Time,
 			UpdatedAt: existingToken.UpdatedAt.Time,
@@ -60,8 +67,10 @@ func (ns *notificationsStore) RegisterPushToken(params *sensormanager.PushTokenP
 	deviceInfoJSON, _ := json.Marshal(params.DeviceInfo)
 	model := &models.PushToken{
 		Token:      params.Token,
+		SiteID:     params.SiteID,
 		Platform:   params.Platform,
 		DeviceInfo: null.JSONFrom(deviceInfoJSON),
+		Locale:     null.NewString(params.Locale, params.Locale != ""),
 		IsActive:   null.BoolFrom(true),
 		CreatedAt:  null.TimeFrom(time.Now()),
 		UpdatedAt:  null.TimeFrom(time.Now()),
@@ -75,18 +84,21 @@ func (ns *notificationsStore) RegisterPushToken(params *sensormanager.PushTokenP
 	fmt.Println("✅ Token créé avec succès")
 	return &sensormanager.PushToken{
 		ID:        model.ID,
+		SiteID:    model.SiteID,
 		Token:     model.Token,
 		Platform:  sensormanager.Platform(model.Platform),
+		Locale:    sensormanager.Locale(model.Locale.String),
 		IsActive:  model.IsActive.Bool,
 		CreatedAt: model.CreatedAt.Time,
 		UpdatedAt: model.UpdatedAt.Time,
 	}, nil
 }
 
-func (ns *notificationsStore) GetActivePushTokens() ([]*sensormanager.PushToken, error) {
-	fmt.Println("🔍 GetActivePushTokens appelé")
+func (ns *notificationsStore) GetActivePushTokens(siteID string) ([]*sensormanager.PushToken, error) {
+	fmt.Printf("🔍 GetActivePushTokens appelé (site: %s)\n", siteID)
 
 	modelsDB, err := models.PushTokens(
+		models.PushTokenWhere.SiteID.EQ(siteID),
 		models.PushTokenWhere.IsActive.EQ(null.BoolFrom(true)),
 		qm.OrderBy(fmt.Sprintf("%s DESC", models.PushTokenColumns.CreatedAt)),
 	).All(context.TODO(), ns.baseStore.db)
@@ -101,8 +113,10 @@ func (ns *notificationsStore) GetActivePushTokens() ([]*sensormanager.PushToken,
 	for i, m := range modelsDB {
 		result[i] = &sensormanager.PushToken{
 			ID:        m.ID,
+			SiteID:    m.SiteID,
 			Token:     m.Token,
 			Platform:  sensormanager.Platform(m.Platform),
+			Locale:    sensormanager.Locale(m.Locale.String),
 			IsActive:  m.IsActive.Bool,
 			CreatedAt: m.CreatedAt.Time,
 			UpdatedAt: m.UpdatedAt.Time,
@@ -131,15 +145,16 @@ func (ns *notificationsStore) DeactivatePushToken(token string) error {
 	return nil
 }
 
-func (ns *notificationsStore) SendNotificationToAll(params *sensormanager.NotificationParams) error {
+func (ns *notificationsStore) SendNotificationToSite(siteID string, params *sensormanager.NotificationParams) error {
 	fmt.Println("┌─────────────────────────────────────────┐")
-	fmt.Println("│   🔔 SendNotificationToAll              │")
+	fmt.Println("│   🔔 SendNotificationToSite             │")
 	fmt.Println("└─────────────────────────────────────────┘")
+	fmt.Printf("📋 Site: %s\n", siteID)
 	fmt.Printf("📋 Title: %s\n", params.Title)
 	fmt.Printf("📋 Body: %s\n", params.Body)
 	fmt.Printf("📋 Data: %+v\n", params.Data)
 
-	tokens, err := ns.GetActivePushTokens()
+	tokens, err := ns.GetActivePushTokens(siteID)
 	if err != nil {
 		fmt.Printf("❌ Erreur récupération tokens: %v\n", err)
 		return err
@@ -152,18 +167,63 @@ func (ns *notificationsStore) SendNotificationToAll(params *sensormanager.Notifi
 
 	fmt.Printf("🚀 Envoi vers %d token(s)...\n", len(tokens))
 
+	// 🌐 Titre et corps rendus dans la langue de chaque token
+	templates := ns.baseStore.notificationTemplates
+	localized := templates.render(templates.withDeviceName(siteID, params), sensormanager.Locales)
+
 	for i, token := range tokens {
 		fmt.Printf("\n--- Token %d/%d ---\n", i+1, len(tokens))
-		go ns.sendExpoNotification(token, params, i+1)
+		go ns.sendExpoNotification(token, localized[ns.tokenLocale(token)], i+1)
 	}
 
 	fmt.Println("\n✅ Toutes les goroutines lancées")
 	return nil
 }
 
+func (ns *notificationsStore) SendNotificationToTokens(tokens []string, params *sensormanager.NotificationParams) error {
+	fmt.Printf("🎯 SendNotificationToTokens: %d token(s)\n", len(tokens))
+	fmt.Printf("📋 Title: %s\n", params.Title)
+
+	// 🌐 Les tokens enregistrés reçoivent leur langue, les autres celle par défaut.
+	modelsDB, err := models.PushTokens(
+		models.PushTokenWhere.Token.IN(tokens),
+	).All(context.TODO(), ns.baseStore.db)
+	if err != nil {
+		fmt.Printf("❌ Erreur lecture tokens: %v\n", err)
+		return errors.MapSQLError(err)
+	}
+
+	stored := make(map[string]*sensormanager.PushToken, len(modelsDB))
+	for _, m := range modelsDB {
+		stored[m.Token] = &sensormanager.PushToken{
+			ID:        m.ID,
+			SiteID:    m.SiteID,
+			Token:     m.Token,
+			Platform:  sensormanager.Platform(m.Platform),
+			Locale:    sensormanager.Locale(m.Locale.String),
+			IsActive:  m.IsActive.Bool,
+			CreatedAt: m.CreatedAt.Time,
+			UpdatedAt: m.UpdatedAt.Time,
+		}
+	}
+
+	localized := ns.baseStore.notificationTemplates.render(params, sensormanager.Locales)
+
+	for i, value := range tokens {
+		token, exists := stored[value]
+		if !exists {
+			token = &sensormanager.PushToken{Token: value}
+		}
+
+		go ns.sendExpoNotification(token, localized[ns.tokenLocale(token)], i+1)
+	}
+
+	return nil
+}
+
 func (ns *notificationsStore) sendExpoNotification(token *sensormanager.PushToken, params *sensormanager.NotificationParams, index int) {
 	fmt.Printf("  🚀 [Goroutine %d] Démarrage\n", index)
-	fmt.Printf("  📱 [Goroutine %d] Token: %s...\n", index, token.Token[:30])
+	fmt.Printf("  📱 [Goroutine %d] Token: %s...\n", index, truncateToken(token.Token))
 
 	dataJSON, _ := json.Marshal(params.Data)
 	payload := map[string]interface{}{
@@ -211,4 +271,22 @@ func (ns *notificationsStore) sendExpoNotification(token *sensormanager.PushToke
 
 	log.Success = true
 	fmt.Printf("  ✅ [Goroutine %d] Notification envoyée avec succès\n", index)
-}
\ No newline at end of file
+}
+
+// tokenLocale retourne la langue des notifications du token, à défaut celle du serveur.
+func (ns *notificationsStore) tokenLocale(token *sensormanager.PushToken) sensormanager.Locale {
+	if token.Locale.Validate() != nil {
+		return ns.baseStore.notificationLocale
+	}
+
+	return token.Locale
+}
+
+// truncateToken raccourcit un token pour les logs.
+func truncateToken(token string) string {
+	if len(token) > 30 {
+		return token[:30]
+	}
+
+	return token
+}
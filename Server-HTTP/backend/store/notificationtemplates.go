@@ -0,0 +1,166 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sensormanager"
+	"time"
+
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+type notificationTemplatesStore struct{ baseStore *Store }
+
+var _ sensormanager.NotificationTemplateManager = (*notificationTemplatesStore)(nil)
+
+type notificationTemplateRow struct {
+	Kind      string    `boil:"kind"`
+	Locale    string    `boil:"locale"`
+	Title     string    `boil:"title"`
+	Body      string    `boil:"body"`
+	UpdatedAt null.Time `boil:"updated_at"`
+}
+
+const notificationTemplateColumns = "kind, locale, title, body, updated_at"
+
+func (ts *notificationTemplatesStore) GetNotificationTemplates() ([]*sensormanager.NotificationTemplate, error) {
+	var rows []*notificationTemplateRow
+	if err := queries.Raw(
+		"SELECT "+notificationTemplateColumns+" FROM notification_templates ORDER BY kind, locale",
+	).Bind(context.TODO(), ts.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.NotificationTemplate, len(rows))
+	for i, row := range rows {
+		result[i] = row.toNotificationTemplate()
+	}
+
+	return result, nil
+}
+
+func (ts *notificationTemplatesStore) SetNotificationTemplate(
+	params *sensormanager.NotificationTemplateParams,
+) (*sensormanager.NotificationTemplate, error) {
+	if err := params.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	row := &notificationTemplateRow{}
+	if err := queries.Raw(
+		"INSERT INTO notification_templates (kind, locale, title, body, updated_at) VALUES ($1, $2, $3, $4, $5) "+
+			"ON CONFLICT (kind, locale) DO UPDATE SET title = EXCLUDED.title, body = EXCLUDED.body, updated_at = EXCLUDED.updated_at "+
+			"RETURNING "+notificationTemplateColumns,
+		string(params.Kind), string(params.Locale), params.Title, params.Body, time.Now(),
+	).Bind(context.TODO(), ts.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return row.toNotificationTemplate(), nil
+}
+
+func (ts *notificationTemplatesStore) ResetNotificationTemplate(kind sensormanager.NotificationKind, locale sensormanager.Locale) error {
+	result, err := queries.Raw(
+		"DELETE FROM notification_templates WHERE kind = $1 AND locale = $2",
+		string(kind), string(locale),
+	).ExecContext(context.TODO(), ts.baseStore.db)
+	if err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.MapSQLError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// render returns the notification of each locale, rendered from the template of its kind. A template that fails
+// with the values falls back to the default one, then to the title and body of the notification.
+func (ts *notificationTemplatesStore) render(
+	params *sensormanager.NotificationParams,
+	locales []sensormanager.Locale,
+) map[sensormanager.Locale]*sensormanager.NotificationParams {
+	result := make(map[sensormanager.Locale]*sensormanager.NotificationParams, len(locales))
+	for _, locale := range locales {
+		result[locale] = params
+	}
+
+	if params.Kind == "" || params.Values == nil {
+		return result
+	}
+
+	saved, err := ts.GetNotificationTemplates()
+	if err != nil {
+		fmt.Printf("❌ Could not read notification templates, using the default ones: %v\n", err)
+	}
+
+	for _, locale := range locales {
+		candidates := []*sensormanager.NotificationTemplate{
+			sensormanager.ResolveNotificationTemplate(saved, params.Kind, locale),
+			sensormanager.ResolveNotificationTemplate(nil, params.Kind, locale),
+		}
+
+		for _, template := range candidates {
+			if template == nil {
+				continue
+			}
+
+			title, body, err := template.Render(params.Values)
+			if err != nil {
+				fmt.Printf("❌ Could not render %s notification template (%s): %v\n", template.Kind, locale, err)
+				continue
+			}
+
+			result[locale] = &sensormanager.NotificationParams{
+				Title:  title,
+				Body:   body,
+				Data:   params.Data,
+				Kind:   params.Kind,
+				Values: params.Values,
+			}
+			break
+		}
+	}
+
+	return result
+}
+
+func (row *notificationTemplateRow) toNotificationTemplate() *sensormanager.NotificationTemplate {
+	var updatedAt *time.Time
+	if row.UpdatedAt.Valid {
+		updatedAt = &row.UpdatedAt.Time
+	}
+
+	return &sensormanager.NotificationTemplate{
+		Kind:      sensormanager.NotificationKind(row.Kind),
+		Locale:    sensormanager.Locale(row.Locale),
+		Title:     row.Title,
+		Body:      row.Body,
+		Custom:    true,
+		UpdatedAt: updatedAt,
+	}
+}
+
+// withDeviceName returns the notification with the location of its device as device name, when it has one.
+func (ts *notificationTemplatesStore) withDeviceName(siteID string, params *sensormanager.NotificationParams) *sensormanager.NotificationParams {
+	if params.Values == nil || params.Values.DeviceID == "" {
+		return params
+	}
+
+	device, err := ts.baseStore.Devices.GetDevice(siteID, params.Values.DeviceID)
+	if err != nil || device.Location == "" {
+		return params
+	}
+
+	values := *params.Values
+	values.DeviceName = device.Location
+
+	result := *params
+	result.Values = &values
+
+	return &result
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"sensormanager"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestRenderNotificationTemplates(t *testing.T) {
+	store, mock := newMockStore(t)
+
+	// Le modèle français du microphone est personnalisé, mais celui du mouvement échoue avec les vraies valeurs.
+	expectQuery(mock, "SELECT kind, locale, title, body, updated_at FROM notification_templates").
+		WillReturnRows(sqlmock.NewRows([]string{"kind", "locale", "title", "body", "updated_at"}).
+			AddRow("alert.microphone", "fr", "🔊 Bruit : {{.DeviceName}}", `{{printf "%.0f" .Value}} dB`, time.Now()).
+			AddRow("alert", "en", "{{.Label}} alert", "{{.Message}}", time.Now()))
+
+	values := sensormanager.NewAlertNotificationValues(sensormanager.AlertTypeMicrophone, &sensormanager.AlertResponse{
+		DeviceID:   "ESP_001",
+		Value:      72.4,
+		Threshold:  sensormanager.MicrophoneThresholdDB,
+		Message:    "High noise level detected: 72.4 dB",
+		RecordedAt: time.Date(2026, 3, 1, 22, 15, 0, 0, time.Local),
+	})
+	values.DeviceName = "Salon"
+
+	localized := store.notificationTemplates.render(&sensormanager.NotificationParams{
+		Title:  "fallback",
+		Body:   "fallback",
+		Kind:   sensormanager.AlertNotificationKind(sensormanager.AlertTypeMicrophone),
+		Values: values,
+	}, sensormanager.Locales)
+
+	if got := localized[sensormanager.LocaleFR]; got.Title != "🔊 Bruit : Salon" || got.Body != "72 dB" {
+		t.Errorf("expected the saved French template, got %q / %q", got.Title, got.Body)
+	}
+
+	// Le modèle du type de capteur, même par défaut, prime sur le modèle générique enregistré.
+	if got := localized[sensormanager.LocaleEN]; got.Title != "⚠️ Noise alert" ||
+		got.Body != "Salon: high noise level, 72.4 dB (threshold 50 dB) at 22:15" {
+		t.Errorf("expected the default English template, got %q / %q", got.Title, got.Body)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRenderNotificationTemplatesFallsBack(t *testing.T) {
+	store, mock := newMockStore(t)
+
+	// Un modèle enregistré avant un changement des valeurs peut ne plus se rendre : le modèle par défaut le remplace.
+	expectQuery(mock, "SELECT kind, locale, title, body, updated_at FROM notification_templates").
+		WillReturnRows(sqlmock.NewRows([]string{"kind", "locale", "title", "body", "updated_at"}).
+			AddRow("escalation", "fr", "{{.Missing}}", "{{.Elapsed}}", time.Now()))
+
+	values := sensormanager.NewNotificationValues(sensormanager.AlertTypeMotion, sensormanager.SensorTypeMotion, "ESP_003", time.Now())
+	values.Elapsed = 15 * time.Minute
+
+	localized := store.notificationTemplates.render(&sensormanager.NotificationParams{
+		Title:  "fallback",
+		Body:   "fallback",
+		Kind:   sensormanager.NotificationKindEscalation,
+		Values: values,
+	}, []sensormanager.Locale{sensormanager.LocaleFR})
+
+	if got := localized[sensormanager.LocaleFR]; got.Title != "⏰ Rappel Alerte" ||
+		got.Body != "Alerte Mouvement de ESP_003 toujours non acquittée après 15m0s" {
+		t.Errorf("expected the default template, got %q / %q", got.Title, got.Body)
+	}
+
+	// Sans type, la notification est envoyée telle quelle, sans lire les modèles.
+	plain := &sensormanager.NotificationParams{Title: "Test", Body: "Hello"}
+	if got := store.notificationTemplates.render(plain, sensormanager.Locales)[sensormanager.LocaleEN]; got != plain {
+		t.Errorf("expected the notification unchanged, got %+v", got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetNotificationTemplateValidates(t *testing.T) {
+	store, mock := newMockStore(t)
+
+	for _, params := range []*sensormanager.NotificationTemplateParams{
+		{Kind: "alert.unknown", Locale: "fr", Title: "Alerte", Body: "{{.DeviceName}}"},
+		{Kind: "alert", Locale: "de", Title: "Alarm", Body: "{{.DeviceName}}"},
+		{Kind: "alert", Locale: "fr", Title: "{{.DeviceName", Body: "{{.DeviceName}}"},
+		{Kind: "alert", Locale: "fr", Title: "Alerte", Body: "{{.Room}}"},
+		{Kind: "alert", Locale: "fr", Title: "{{if false}}Alerte{{end}}", Body: "{{.DeviceName}}"},
+		{Kind: "alert", Locale: "fr", Title: "Alerte", Body: strings.Repeat("a", sensormanager.MaxNotificationBodyLength+1)},
+	} {
+		if _, err := store.NotificationTemplates.SetNotificationTemplate(params); err == nil {
+			t.Errorf("expected template %+v to be refused", params)
+		}
+	}
+
+	expectQuery(mock, "INSERT INTO notification_templates (kind, locale, title, body, updated_at) VALUES ($1, $2, $3, $4, $5)").
+		WithArgs("alert.temperature", "en", "🌡️ {{.Label}}", `{{.DeviceName}}: {{printf "%.1f" .Value}} {{.Unit}}`, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"kind", "locale", "title", "body", "updated_at"}).
+			AddRow("alert.temperature", "en", "🌡️ {{.Label}}", `{{.DeviceName}}: {{printf "%.1f" .Value}} {{.Unit}}`, time.Now()))
+
+	template, err := store.NotificationTemplates.SetNotificationTemplate(&sensormanager.NotificationTemplateParams{
+		Kind:   sensormanager.AlertNotificationKind(sensormanager.AlertType(sensormanager.SensorTypeTemperature)),
+		Locale: sensormanager.LocaleEN,
+		Title:  " 🌡️ {{.Label}} ",
+		Body:   `{{.DeviceName}}: {{printf "%.1f" .Value}} {{.Unit}}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !template.Custom || template.UpdatedAt == nil {
+		t.Errorf("unexpected template %+v", template)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
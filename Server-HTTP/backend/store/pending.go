@@ -0,0 +1,177 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sensormanager"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type pendingDeviceRow struct {
+	deviceRow           `boil:",bind"`
+	QuarantinedReadings int       `boil:"quarantined_readings"`
+	LastReadingAt       null.Time `boil:"last_reading_at"`
+}
+
+// quarantineMove copies the quarantined readings of a device to the history of their sensor type. The readings are
+// not filtered: their filtered value is left empty.
+const quarantineMove = `INSERT INTO sensor_readings (sensor_type, device_id, value, recorded_at, received_at, sequence)
+	SELECT sensor_type, device_id, value, recorded_at, received_at, sequence FROM quarantined_readings WHERE device_id = $1`
+
+func (ds *devicesStore) GetPendingDevices(siteID string) ([]*sensormanager.PendingDevice, error) {
+	var rows []*pendingDeviceRow
+	if err := queries.Raw(
+		`SELECT `+deviceColumns+`,
+		(SELECT COUNT(*) FROM quarantined_readings q WHERE q.device_id = devices.device_id) AS quarantined_readings,
+		(SELECT MAX(q.received_at) FROM quarantined_readings q WHERE q.device_id = devices.device_id) AS last_reading_at
+		FROM devices WHERE site_id = $1 AND status = $2 ORDER BY created_at DESC`,
+		siteID, string(sensormanager.DeviceStatusPending),
+	).Bind(context.TODO(), ds.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.PendingDevice, len(rows))
+	for i, row := range rows {
+		result[i] = &sensormanager.PendingDevice{
+			Device:              row.toDevice(),
+			QuarantinedReadings: row.QuarantinedReadings,
+			LastReadingAt:       row.LastReadingAt.Ptr(),
+		}
+	}
+
+	return result, nil
+}
+
+func (ds *devicesStore) ApproveDevice(siteID, deviceID string) (*sensormanager.Device, error) {
+	ctx := context.TODO()
+
+	tx, err := ds.baseStore.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+	defer tx.Rollback()
+
+	status, err := lockPendingDevice(ctx, tx, siteID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	if status != sensormanager.DeviceStatusPending && status != sensormanager.DeviceStatusRejected {
+		return nil, sensormanager.ErrDeviceNotPending
+	}
+
+	if _, err := queries.Raw(quarantineMove, deviceID).ExecContext(ctx, tx); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if _, err := queries.Raw("DELETE FROM quarantined_readings WHERE device_id = $1", deviceID).ExecContext(ctx, tx); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if _, err := queries.Raw(
+		"UPDATE devices SET status = $1 WHERE device_id = $2",
+		string(sensormanager.DeviceStatusActive), deviceID,
+	).ExecContext(ctx, tx); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if err := ds.baseStore.events.add(ctx, tx, sensormanager.NewDeviceStatusEvent(siteID, deviceID, "", sensormanager.DeviceStatusActive)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	ds.registered.Store(deviceID, sensormanager.DeviceStatusActive)
+
+	fmt.Printf("✅ Device %s approved\n", deviceID)
+
+	return ds.GetDevice(siteID, deviceID)
+}
+
+func (ds *devicesStore) RejectDevice(siteID, deviceID string) error {
+	ctx := context.TODO()
+
+	tx, err := ds.baseStore.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.MapSQLError(err)
+	}
+	defer tx.Rollback()
+
+	status, err := lockPendingDevice(ctx, tx, siteID, deviceID)
+	if err != nil {
+		return err
+	}
+	if status != sensormanager.DeviceStatusPending {
+		return sensormanager.ErrDeviceNotPending
+	}
+
+	if _, err := queries.Raw("DELETE FROM quarantined_readings WHERE device_id = $1", deviceID).ExecContext(ctx, tx); err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	if _, err := queries.Raw(
+		"UPDATE devices SET status = $1 WHERE device_id = $2",
+		string(sensormanager.DeviceStatusRejected), deviceID,
+	).ExecContext(ctx, tx); err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	if err := ds.baseStore.events.add(ctx, tx, sensormanager.NewDeviceStatusEvent(siteID, deviceID, "", sensormanager.DeviceStatusRejected)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	ds.registered.Store(deviceID, sensormanager.DeviceStatusRejected)
+
+	fmt.Printf("🚫 Device %s rejected\n", deviceID)
+
+	return nil
+}
+
+func lockPendingDevice(ctx context.Context, tx *sql.Tx, siteID, deviceID string) (sensormanager.DeviceStatus, error) {
+	var status null.String
+	err := queries.Raw(
+		"SELECT status FROM devices WHERE site_id = $1 AND device_id = $2 FOR UPDATE",
+		siteID, deviceID,
+	).QueryRowContext(ctx, tx).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", sensormanager.ErrUnknownDevice
+	}
+	if err != nil {
+		return "", errors.MapSQLError(err)
+	}
+
+	return sensormanager.DeviceStatus(status.String), nil
+}
+
+// quarantineReading keeps the reading of a pending device aside until the device is approved.
+func (ss *sensorsStore) quarantineReading(reading *pendingReading) (*sensormanager.AlertResponse, error) {
+	if _, err := queries.Raw(
+		`INSERT INTO quarantined_readings (site_id, device_id, sensor_type, value, recorded_at, received_at, sequence)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		reading.siteID, reading.deviceID, string(reading.sensorType), types.NewDecimal(new(decimal.Big).SetFloat64(reading.value)),
+		reading.recordedAt, reading.receivedAt, null.Int64FromPtr(reading.timing.Sequence),
+	).ExecContext(context.TODO(), ss.baseStore.db); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return &sensormanager.AlertResponse{
+		Alert:       false,
+		Message:     "Device pending approval: reading quarantined",
+		SiteID:      reading.siteID,
+		DeviceID:    reading.deviceID,
+		Value:       reading.value,
+		RecordedAt:  reading.recordedAt,
+		Quarantined: true,
+	}, nil
+}
@@ -0,0 +1,433 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sensormanager"
+	"sensormanager/filter"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// ============= REGISTRY READINGS =============
+
+type readingRow struct {
+	ID            int64             `boil:"id"`
+	SensorType    string            `boil:"sensor_type"`
+	DeviceID      string            `boil:"device_id"`
+	Value         types.Decimal     `boil:"value"`
+	FilteredValue types.NullDecimal `boil:"filtered_value"`
+	RecordedAt    null.Time         `boil:"recorded_at"`
+	ReceivedAt    null.Time         `boil:"received_at"`
+	Sequence      null.Int64        `boil:"sequence"`
+}
+
+type sensorAlertRow struct {
+	ID             int64             `boil:"id"`
+	SensorType     string            `boil:"sensor_type"`
+	DeviceID       string            `boil:"device_id"`
+	DataID         null.Int64        `boil:"data_id"`
+	Rule           string            `boil:"rule"`
+	Value          types.Decimal     `boil:"value"`
+	Threshold      types.NullDecimal `boil:"threshold"`
+	Reference      types.NullDecimal `boil:"reference"`
+	AlertStatus    null.String       `boil:"alert_status"`
+	AcknowledgedAt null.Time         `boil:"acknowledged_at"`
+	ResolvedAt     null.Time         `boil:"resolved_at"`
+	CreatedAt      null.Time         `boil:"created_at"`
+}
+
+const (
+	readingColumns     = "id, sensor_type, device_id, value, filtered_value, recorded_at, received_at, sequence"
+	sensorAlertColumns = "id, sensor_type, device_id, data_id, rule, value, threshold, reference, alert_status, acknowledged_at, resolved_at, created_at"
+)
+
+func (ss *sensorsStore) RecordReading(params *sensormanager.ReadingParams) (*sensormanager.AlertResponse, error) {
+	results, err := ss.RecordReadingBatch([]*sensormanager.ReadingParams{params})
+	if err != nil {
+		return nil, err
+	}
+
+	return results[0].Response, results[0].Err
+}
+
+// RecordReadingBatch records readings of a sensor type of the registry. All the readings must be of the same type.
+func (ss *sensorsStore) RecordReadingBatch(params []*sensormanager.ReadingParams) ([]*sensormanager.RecordResult, error) {
+	if len(params) == 0 {
+		return nil, sensormanager.ErrEmptyBatch
+	}
+
+	definition, err := sensormanager.LookupSensorType(params[0].SensorType)
+	if err != nil {
+		return nil, err
+	}
+
+	readings := make([]*pendingReading, len(params))
+	for i, p := range params {
+		if p.SensorType != definition.Name {
+			return nil, fmt.Errorf("every reading of a batch must be of type %s", definition.Name)
+		}
+
+		readings[i] = &pendingReading{
+			sensorType:  definition.Name,
+			siteID:      p.SiteID,
+			deviceID:    p.DeviceID,
+			value:       p.Value,
+			signedValue: signedReadingValue(definition, p.Value),
+			dedupKey:    p.DedupKey(&p.ReadingTime),
+			sanitize:    p.Sanitize,
+			timing:      &p.ReadingTime,
+			id:          &p.ReadingID,
+			signed:      &p.SignedPayload,
+			source:      &p.ReadingSource,
+		}
+	}
+
+	return ss.recordBatch(readings, &readingStorage{
+		table:   "sensor_readings",
+		columns: []string{"sensor_type", "device_id", "value", "filtered_value", "recorded_at", "received_at", "sequence"},
+		values: func(reading *pendingReading) []interface{} {
+			return []interface{}{
+				string(reading.sensorType),
+				reading.deviceID,
+				types.NewDecimal(new(decimal.Big).SetFloat64(reading.value)),
+				filteredValue(reading.filtered),
+				reading.recordedAt,
+				reading.receivedAt,
+				null.Int64FromPtr(reading.timing.Sequence),
+			}
+		},
+		evaluate: func(reading *pendingReading) (*sensormanager.AlertResponse, error) {
+			return ss.evaluateReading(definition, reading)
+		},
+	})
+}
+
+// evaluateReading evaluates the alerts of a reading. The readings of a filtered sensor type also feed its quality
+// checker, and only their confirmed filtered value is evaluated.
+func (ss *sensorsStore) evaluateReading(definition *sensormanager.SensorTypeDefinition, reading *pendingReading) (*sensormanager.AlertResponse, error) {
+	// ⏱️ Mesure ancienne ou arrivée dans le désordre : conservée dans l'historique, sans évaluer les alertes.
+	if response := ss.lateReading(reading); response != nil {
+		return response, nil
+	}
+
+	value := reading.value
+
+	var fault *sensormanager.SensorFaultAlert
+	if reading.filtered != nil {
+		var err error
+		if fault, err = ss.checkSensorQuality(definition.Name, reading.deviceID, reading.value); err != nil {
+			return nil, err
+		}
+
+		if response := unconfirmedReading(reading.deviceID, reading.filtered, reading.recordedAt); response != nil {
+			response.SensorFault = fault
+			return response, nil
+		}
+
+		value = reading.filtered.Filtered
+	}
+
+	var (
+		response *sensormanager.AlertResponse
+		err      error
+	)
+	if definition.Name == sensormanager.SensorTypeMotion {
+		response, err = ss.checkMotionAlert(reading)
+	} else {
+		response, err = ss.checkAlertRules(definition, reading, value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	response.SensorFault = fault
+
+	return response, nil
+}
+
+// checkAlertRules applies the alert rules of the sensor type. Only the first rule triggered by a reading raises an
+// alert, the rules being declared by priority.
+func (ss *sensorsStore) checkAlertRules(definition *sensormanager.SensorTypeDefinition, reading *pendingReading, value float64) (*sensormanager.AlertResponse, error) {
+	var thresholds map[string]float64
+	if len(definition.Rules) > 0 {
+		var err error
+		if thresholds, err = ss.baseStore.thresholds.siteThresholds(reading.siteID, definition.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		triggered *sensormanager.AlertRule
+		previous  float64
+	)
+	for i := range definition.Rules {
+		rule := &definition.Rules[i]
+
+		// 🎚️ Seuil remplacé par le site : la règle est évaluée sur une copie, la définition reste partagée.
+		if threshold, exists := thresholds[rule.Name]; exists {
+			siteRule := *rule
+			siteRule.Threshold = threshold
+			rule = &siteRule
+		}
+
+		key := reading.siteID + "/" + string(definition.Name) + "/" + reading.deviceID + "/" + rule.Name
+		if matched, last := ss.baseStore.ruleTracker.observe(key, rule, value, reading.recordedAt); matched && triggered == nil {
+			triggered, previous = rule, last
+		}
+	}
+
+	if triggered == nil {
+		return &sensormanager.AlertResponse{
+			Alert:      false,
+			DeviceID:   reading.deviceID,
+			Value:      value,
+			RecordedAt: reading.recordedAt,
+		}, nil
+	}
+
+	// 📏 Une règle de variation conserve la mesure précédente, et son message reçoit l'écart.
+	reference := types.NullDecimal{}
+	messageValue := value
+	if triggered.Condition == sensormanager.AlertConditionVariation {
+		reference = types.NewNullDecimal(new(decimal.Big).SetFloat64(previous))
+		messageValue = math.Abs(value - previous)
+	}
+
+	// 💾 Enregistrer l'alerte dans la DB
+	row := &sensorAlertRow{}
+	if err := queries.Raw(
+		"INSERT INTO sensor_alerts (sensor_type, device_id, data_id, rule, value, threshold, reference, alert_status) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING "+sensorAlertColumns,
+		string(definition.Name), reading.deviceID, reading.dataID, triggered.Name,
+		types.NewDecimal(new(decimal.Big).SetFloat64(value)),
+		types.NewNullDecimal(new(decimal.Big).SetFloat64(triggered.Threshold)),
+		reference,
+		string(sensormanager.AlertStatusActive),
+	).Bind(context.TODO(), ss.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return &sensormanager.AlertResponse{
+		Alert:      true,
+		AlertID:    row.ID,
+		Message:    fmt.Sprintf(triggered.Message, messageValue),
+		Value:      value,
+		Threshold:  triggered.Threshold,
+		DeviceID:   reading.deviceID,
+		Event:      triggered.Name,
+		RecordedAt: reading.recordedAt,
+	}, nil
+}
+
+func (ss *sensorsStore) GetReadingHistory(siteID string, sensorType sensormanager.SensorType, deviceID string, limit int) ([]*sensormanager.ReadingData, error) {
+	if _, err := sensormanager.LookupSensorType(sensorType); err != nil {
+		return nil, err
+	}
+
+	var rows []*readingRow
+	if err := queries.Raw(
+		"SELECT "+readingColumns+" FROM sensor_readings WHERE sensor_type = $1 AND device_id = $2 AND "+siteDevicesCondition(3)+
+			" ORDER BY recorded_at DESC, sequence DESC NULLS LAST LIMIT $4",
+		string(sensorType), deviceID, siteID, limit,
+	).Bind(context.TODO(), ss.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.ReadingData, len(rows))
+	for i, row := range rows {
+		value, _ := row.Value.Float64()
+		result[i] = &sensormanager.ReadingData{
+			ID:            row.ID,
+			SensorType:    sensormanager.SensorType(row.SensorType),
+			DeviceID:      row.DeviceID,
+			Value:         value,
+			FilteredValue: nullDecimalToFloat64(row.FilteredValue),
+			RecordedAt:    row.RecordedAt.Time,
+			ReceivedAt:    row.ReceivedAt.Time,
+			Sequence:      row.Sequence.Ptr(),
+		}
+	}
+
+	return result, nil
+}
+
+// ============= REGISTRY ALERTS =============
+
+func (ss *sensorsStore) GetSensorAlerts(sensorType sensormanager.SensorType, params *sensormanager.GetAlertsParams) ([]*sensormanager.SensorAlert, error) {
+	if _, err := sensormanager.LookupSensorType(sensorType); err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + sensorAlertColumns + " FROM sensor_alerts WHERE sensor_type = $1 AND device_id = $2 AND " + siteDevicesCondition(3)
+	args := []interface{}{string(sensorType), params.DeviceID, params.SiteID}
+
+	if params.Status != "" {
+		args = append(args, string(params.Status))
+		query += fmt.Sprintf(" AND alert_status = $%d", len(args))
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	if params.Limit > 0 {
+		args = append(args, params.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	var rows []*sensorAlertRow
+	if err := queries.Raw(query, args...).Bind(context.TODO(), ss.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.SensorAlert, len(rows))
+	for i, row := range rows {
+		result[i] = row.toSensorAlert()
+	}
+
+	return result, nil
+}
+
+func (ss *sensorsStore) UpdateSensorAlertStatus(sensorType sensormanager.SensorType, params *sensormanager.UpdateAlertStatusParams) error {
+	query := "UPDATE sensor_alerts SET alert_status = $1 WHERE id = $2 AND sensor_type = $3 AND " + siteDevicesCondition(4)
+	args := []interface{}{string(params.Status), params.AlertID, string(sensorType), params.SiteID}
+
+	switch params.Status {
+	case sensormanager.AlertStatusAcknowledged:
+		query = "UPDATE sensor_alerts SET alert_status = $1, acknowledged_at = $5 WHERE id = $2 AND sensor_type = $3 AND " + siteDevicesCondition(4)
+		args = append(args, time.Now())
+	case sensormanager.AlertStatusResolved:
+		query = "UPDATE sensor_alerts SET alert_status = $1, resolved_at = $5 WHERE id = $2 AND sensor_type = $3 AND " + siteDevicesCondition(4)
+		args = append(args, time.Now())
+	}
+
+	return ss.baseStore.updateAlertStatus(sensormanager.AlertType(sensorType), params, query+" RETURNING device_id", args...)
+}
+
+func (row *sensorAlertRow) toSensorAlert() *sensormanager.SensorAlert {
+	value, _ := row.Value.Float64()
+
+	var dataID *int64
+	if row.DataID.Valid {
+		dataID = &row.DataID.Int64
+	}
+
+	var ackAt, resAt *time.Time
+	if row.AcknowledgedAt.Valid {
+		ackAt = &row.AcknowledgedAt.Time
+	}
+	if row.ResolvedAt.Valid {
+		resAt = &row.ResolvedAt.Time
+	}
+
+	return &sensormanager.SensorAlert{
+		ID:             row.ID,
+		SensorType:     sensormanager.SensorType(row.SensorType),
+		DeviceID:       row.DeviceID,
+		DataID:         dataID,
+		Rule:           row.Rule,
+		Value:          value,
+		Threshold:      nullDecimalToFloat64(row.Threshold),
+		Reference:      nullDecimalToFloat64(row.Reference),
+		AlertStatus:    sensormanager.AlertStatus(row.AlertStatus.String),
+		AcknowledgedAt: ackAt,
+		ResolvedAt:     resAt,
+		CreatedAt:      row.CreatedAt.Time,
+	}
+}
+
+// filteredValue returns the filtered value stored with a reading, NULL when its sensor type is not filtered or when the
+// filter rejected it.
+func filteredValue(filtered *filter.Result) types.NullDecimal {
+	if filtered == nil || filtered.Rejected {
+		return types.NullDecimal{}
+	}
+
+	return types.NewNullDecimal(new(decimal.Big).SetFloat64(filtered.Filtered))
+}
+
+// signedReadingValue formats the value as the device signs it: true/false for booleans.
+func signedReadingValue(definition *sensormanager.SensorTypeDefinition, value float64) string {
+	if definition.Kind == sensormanager.ValueKindBool {
+		return strconv.FormatBool(value == 1)
+	}
+
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// ============= ALERT RULES =============
+
+// ruleTracker keeps, per site, device and rule, the state needed by the duration, cooldown and variation conditions.
+// The state is held in memory only: after a restart, a rule with a duration waits for the condition to hold again for
+// the whole duration, and a variation rule for a first reading to compare with.
+type ruleTracker struct {
+	mu     sync.Mutex
+	states map[string]*ruleState
+}
+
+type ruleState struct {
+	matchingSince time.Time // Zéro quand la condition n'est pas vérifiée
+	active        bool      // Une alerte a été levée depuis que la condition est vérifiée
+	triggeredAt   time.Time
+
+	last    float64 // Mesure précédente, comparée par les règles de variation
+	hasLast bool
+}
+
+func newRuleTracker() *ruleTracker {
+	return &ruleTracker{states: make(map[string]*ruleState)}
+}
+
+// observe feeds a reading to the rule and reports whether it raises an alert. It also returns the previous reading,
+// which the variation rules compare the reading with.
+func (t *ruleTracker) observe(key string, rule *sensormanager.AlertRule, value float64, at time.Time) (bool, float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, exists := t.states[key]
+	if !exists {
+		state = &ruleState{}
+		t.states[key] = state
+	}
+
+	previous := state.last
+
+	var matching bool
+	switch rule.Condition {
+	case sensormanager.AlertConditionAbove:
+		matching = value >= rule.Threshold
+	case sensormanager.AlertConditionBelow:
+		matching = value <= rule.Threshold
+	case sensormanager.AlertConditionVariation:
+		matching = state.hasLast && math.Abs(value-state.last) >= rule.Threshold
+	}
+	state.last, state.hasLast = value, true
+
+	if !matching {
+		state.matchingSince = time.Time{}
+		state.active = false
+		return false, previous
+	}
+
+	if state.matchingSince.IsZero() {
+		state.matchingSince = at
+	}
+
+	if at.Sub(state.matchingSince) < rule.For {
+		return false, previous
+	}
+
+	if state.active && (rule.Cooldown == 0 || at.Sub(state.triggeredAt) < rule.Cooldown) {
+		return false, previous
+	}
+
+	state.active = true
+	state.triggeredAt = at
+
+	return true, previous
+}
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"sensormanager"
+	"testing"
+	"time"
+)
+
+func TestRuleTracker(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		rule     sensormanager.AlertRule
+		readings []float64 // Une mesure par minute
+		want     []bool
+	}{
+		{
+			name:     "single alert while the condition holds",
+			rule:     sensormanager.AlertRule{Condition: sensormanager.AlertConditionBelow, Threshold: 0},
+			readings: []float64{2, -1, -2, 3, -1},
+			want:     []bool{false, true, false, false, true},
+		},
+		{
+			name:     "condition held for a duration",
+			rule:     sensormanager.AlertRule{Condition: sensormanager.AlertConditionAbove, Threshold: 70, For: 2 * time.Minute},
+			readings: []float64{75, 80, 72, 60, 75, 75, 75},
+			want:     []bool{false, false, true, false, false, false, true},
+		},
+		{
+			name:     "cooldown between alerts",
+			rule:     sensormanager.AlertRule{Condition: sensormanager.AlertConditionAbove, Threshold: 50, Cooldown: 2 * time.Minute},
+			readings: []float64{60, 60, 60, 60},
+			want:     []bool{true, false, true, false},
+		},
+		{
+			name:     "variation with the previous reading",
+			rule:     sensormanager.AlertRule{Condition: sensormanager.AlertConditionVariation, Threshold: 30},
+			readings: []float64{100, 110, 50, 55},
+			want:     []bool{false, false, true, false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := newRuleTracker()
+
+			for i, value := range test.readings {
+				at := start.Add(time.Duration(i) * time.Minute)
+				if got, _ := tracker.observe("sensor", &test.rule, value, at); got != test.want[i] {
+					t.Fatalf("reading %d (%g): got alert %t, want %t", i, value, got, test.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sensormanager"
+	"time"
+
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+type readingReceiptRow struct {
+	Response null.JSON `boil:"response"`
+}
+
+// receiptKey identifie une mesure comme la clé primaire de reading_receipts.
+type receiptKey struct {
+	siteID     string
+	deviceID   string
+	sensorType sensormanager.SensorType
+	dedupKey   string
+}
+
+func (reading *pendingReading) receiptKey() receiptKey {
+	return receiptKey{reading.siteID, reading.deviceID, reading.sensorType, reading.dedupKey}
+}
+
+// claimReading reserves the dedup key of the reading, for its sensor type: a device numbers the readings of each of its
+// sensors on its own. It returns the original response when the reading has already
+// been recorded within the dedup window, and nil when the reading must be recorded. A reservation without response is
+// dropped after the claim lease, so that a crash while recording does not block the retransmissions.
+func (ss *sensorsStore) claimReading(reading *pendingReading) (*sensormanager.AlertResponse, error) {
+	if reading.dedupKey == "" {
+		return nil, nil
+	}
+	if len(reading.dedupKey) > sensormanager.MaxDedupKeyLength {
+		return nil, sensormanager.ErrInvalidMessageID
+	}
+
+	ctx := context.TODO()
+	now := time.Now()
+
+	if _, err := queries.Raw(
+		"DELETE FROM reading_receipts WHERE site_id = $1 AND device_id = $2 AND (received_at < $3 OR (response IS NULL AND received_at < $4))",
+		reading.siteID, reading.deviceID, now.Add(-ss.baseStore.dedupWindow), now.Add(-ss.baseStore.claimLease),
+	).ExecContext(ctx, ss.baseStore.db); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result, err := queries.Raw(
+		"INSERT INTO reading_receipts (site_id, device_id, sensor_type, message_id, received_at) VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING",
+		reading.siteID, reading.deviceID, string(reading.sensorType), reading.dedupKey, now,
+	).ExecContext(ctx, ss.baseStore.db)
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 1 {
+		reading.claimed = true
+		return nil, nil
+	}
+
+	// 🔁 Retransmission : on retourne le résultat d'origine, sans réenregistrer la mesure.
+	row := &readingReceiptRow{}
+	err = queries.Raw(
+		"SELECT response FROM reading_receipts WHERE site_id = $1 AND device_id = $2 AND sensor_type = $3 AND message_id = $4",
+		reading.siteID, reading.deviceID, string(reading.sensorType), reading.dedupKey,
+	).Bind(ctx, ss.baseStore.db, row)
+	if err == sql.ErrNoRows || (err == nil && !row.Response.Valid) {
+		return nil, sensormanager.ErrReadingInProgress
+	}
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	response := &sensormanager.AlertResponse{}
+	if err := row.Response.Unmarshal(response); err != nil {
+		return nil, err
+	}
+	response.Duplicate = true
+
+	return response, nil
+}
+
+// completeReading stores the response of a claimed reading, to be returned to its retransmissions.
+func (ss *sensorsStore) completeReading(reading *pendingReading, response *sensormanager.AlertResponse) error {
+	if !reading.claimed {
+		return nil
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	_, err = queries.Raw(
+		"UPDATE reading_receipts SET response = $1 WHERE site_id = $2 AND device_id = $3 AND sensor_type = $4 AND message_id = $5",
+		null.JSONFrom(data), reading.siteID, reading.deviceID, string(reading.sensorType), reading.dedupKey,
+	).ExecContext(context.TODO(), ss.baseStore.db)
+
+	return errors.MapSQLError(err)
+}
+
+// releaseReading drops the claim of a reading that was not recorded, so that it can be sent again.
+func (ss *sensorsStore) releaseReading(reading *pendingReading) {
+	if !reading.claimed {
+		return
+	}
+
+	reading.claimed = false
+
+	if _, err := queries.Raw(
+		"DELETE FROM reading_receipts WHERE site_id = $1 AND device_id = $2 AND sensor_type = $3 AND message_id = $4",
+		reading.siteID, reading.deviceID, string(reading.sensorType), reading.dedupKey,
+	).ExecContext(context.TODO(), ss.baseStore.db); err != nil {
+		// La réservation expirera avec le bail.
+		fmt.Printf("❌ Could not release reading %s of device %s: %v\n", reading.dedupKey, reading.deviceID, err)
+	}
+}
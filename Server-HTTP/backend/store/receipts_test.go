@@ -0,0 +1,444 @@
+package store
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"sensormanager"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// captureArgument matches any argument and keeps it, to feed it back as a later query result.
+type captureArgument struct{ value driver.Value }
+
+func (a *captureArgument) Match(value driver.Value) bool {
+	a.value = value
+
+	return true
+}
+
+func newMockStore(t *testing.T, options ...Option) (*Store, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return New(append([]Option{WithDB(db)}, options...)...), mock
+}
+
+func expectQuery(mock sqlmock.Sqlmock, query string) *sqlmock.ExpectedQuery {
+	return mock.ExpectQuery(regexp.QuoteMeta(query))
+}
+
+func expectExec(mock sqlmock.Sqlmock, query string) *sqlmock.ExpectedExec {
+	return mock.ExpectExec(regexp.QuoteMeta(query))
+}
+
+// expectClaim expects the reservation of a dedup key, affected being 0 when the key has already been received.
+func expectClaim(mock sqlmock.Sqlmock, affected int64) {
+	expectExec(mock, "DELETE FROM reading_receipts WHERE site_id = $1 AND device_id = $2 AND (received_at < $3 OR (response IS NULL AND received_at < $4))").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectExec(mock, "INSERT INTO reading_receipts").
+		WillReturnResult(sqlmock.NewResult(0, affected))
+}
+
+// expectNewMicrophoneReading expects the recording of the first reading of an unknown microphone, unsigned readings
+// and unknown devices being accepted. The reading claims its dedup key once admitted. A new device is healthy: its
+// first reading does not update its health. The site does not replace the noise threshold.
+func expectNewMicrophoneReading(mock sqlmock.Sqlmock, dataID int64) {
+	expectQuery(mock, "FROM devices WHERE device_id = $1").
+		WillReturnRows(sqlmock.NewRows([]string{"device_id"}))
+	expectQuery(mock, "SELECT status FROM devices WHERE device_id = $1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}))
+	expectExec(mock, "INSERT INTO devices").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectClaim(mock, 1)
+
+	mock.ExpectBegin()
+	expectQuery(mock, "INSERT INTO sensor_readings").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(dataID))
+	mock.ExpectCommit()
+
+	expectQuery(mock, "FROM alert_thresholds WHERE site_id = $1 AND sensor_type = $2").
+		WithArgs("home", "microphone").
+		WillReturnRows(sqlmock.NewRows([]string{"rule", "threshold"}))
+}
+
+// expectNewIncident expects a recorded alert of a device without location to open a new incident.
+func expectNewIncident(mock sqlmock.Sqlmock, incidentID int64) {
+	mock.ExpectBegin()
+	expectQuery(mock, "SELECT location FROM devices WHERE device_id = $1").
+		WillReturnRows(sqlmock.NewRows([]string{"location"}))
+	expectExec(mock, "SELECT pg_advisory_xact_lock(hashtext($1))").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectQuery(mock, "FROM incidents WHERE site_id = $1 AND group_key = $2").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	expectQuery(mock, "INSERT INTO incidents").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "site_id", "group_key", "status", "alert_count"}).
+			AddRow(incidentID, "home", "device:ESP_RETRIED", "open", 1))
+	expectExec(mock, "INSERT INTO incident_alerts").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectQuery(mock, "FROM incident_alerts WHERE incident_id = $1").
+		WillReturnRows(sqlmock.NewRows([]string{"incident_id"}))
+	mock.ExpectCommit()
+}
+
+func TestRecordRetriedReading(t *testing.T) {
+	store, mock := newMockStore(t, WithDeviceSignatures(false, time.Minute), WithUnknownDevicePolicy(sensormanager.UnknownDeviceAccept))
+
+	params := &sensormanager.ReadingParams{
+		SiteID:     "home",
+		SensorType: sensormanager.SensorTypeMicrophone,
+		DeviceID:   "ESP_RETRIED",
+		Value:      80,
+		ReadingID:  sensormanager.ReadingID{MessageID: "message-1"},
+	}
+
+	expectNewMicrophoneReading(mock, 10)
+	expectQuery(mock, "INSERT INTO sensor_alerts").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(20))
+
+	stored := &captureArgument{}
+	expectExec(mock, "UPDATE reading_receipts SET response = $1").
+		WithArgs(stored, "home", "ESP_RETRIED", "microphone", "message-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectNewIncident(mock, 30)
+
+	original, err := store.Sensors.RecordReading(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !original.Alert || original.AlertID != 20 || original.Duplicate {
+		t.Fatalf("unexpected original response: %+v", original)
+	}
+	if original.Incident == nil || original.Incident.ID != 30 || !original.IncidentOpened {
+		t.Fatalf("the alert did not open an incident when recorded: %+v", original.Incident)
+	}
+
+	// 🔁 La même mesure est renvoyée : elle est authentifiée à nouveau, sans nouvelle ligne ni nouvelle alerte.
+	expectQuery(mock, "FROM devices WHERE device_id = $1").
+		WillReturnRows(sqlmock.NewRows([]string{"device_id"}))
+	expectClaim(mock, 0)
+	expectQuery(mock, "SELECT response FROM reading_receipts").
+		WithArgs("home", "ESP_RETRIED", "microphone", "message-1").
+		WillReturnRows(sqlmock.NewRows([]string{"response"}).AddRow(stored.value))
+
+	retried, err := store.Sensors.RecordReading(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !retried.Duplicate {
+		t.Fatal("the retried reading is not flagged as a duplicate")
+	}
+	if !retried.Alert || retried.AlertID != original.AlertID || retried.Value != original.Value {
+		t.Fatalf("retried response %+v differs from the original %+v", retried, original)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecordReadingStillInProgress(t *testing.T) {
+	store, mock := newMockStore(t, WithDeviceSignatures(false, time.Minute), WithUnknownDevicePolicy(sensormanager.UnknownDeviceAccept))
+
+	expectQuery(mock, "FROM devices WHERE device_id = $1").
+		WillReturnRows(sqlmock.NewRows([]string{"device_id"}))
+	expectQuery(mock, "SELECT status FROM devices WHERE device_id = $1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("active"))
+	expectClaim(mock, 0)
+	expectQuery(mock, "SELECT response FROM reading_receipts").
+		WillReturnRows(sqlmock.NewRows([]string{"response"}).AddRow(nil))
+
+	_, err := store.Sensors.RecordReading(&sensormanager.ReadingParams{
+		SiteID:     "home",
+		SensorType: sensormanager.SensorTypeMicrophone,
+		DeviceID:   "ESP_IN_PROGRESS",
+		Value:      30,
+		ReadingID:  sensormanager.ReadingID{MessageID: "message-1"},
+	})
+	if err != sensormanager.ErrReadingInProgress {
+		t.Fatalf("got error %v, want %v", err, sensormanager.ErrReadingInProgress)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecordRetriedRejectedReading(t *testing.T) {
+	store, mock := newMockStore(t, WithDeviceSignatures(true, time.Minute), WithUnknownDevicePolicy(sensormanager.UnknownDeviceReject))
+
+	params := &sensormanager.ReadingParams{
+		SiteID:     "home",
+		SensorType: sensormanager.SensorTypeMicrophone,
+		DeviceID:   "ESP_UNKNOWN",
+		Value:      30,
+		ReadingID:  sensormanager.ReadingID{MessageID: "message-1"},
+	}
+
+	// Une mesure rejetée ne réserve pas son identifiant : sa retransmission est vérifiée à nouveau.
+	for i := 0; i < 2; i++ {
+		expectQuery(mock, "FROM devices WHERE device_id = $1").
+			WillReturnRows(sqlmock.NewRows([]string{"device_id"}))
+		expectQuery(mock, "SELECT status FROM devices WHERE device_id = $1").
+			WillReturnRows(sqlmock.NewRows([]string{"status"}))
+
+		if _, err := store.Sensors.RecordReading(params); err != sensormanager.ErrUnregisteredDevice {
+			t.Fatalf("attempt %d: got error %v, want %v", i+1, err, sensormanager.ErrUnregisteredDevice)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestUnknownDeviceWithSignaturesRequired checks that the unknown device policy applies to the devices that have not
+// been provisioned yet, signatures being required.
+func TestUnknownDeviceWithSignaturesRequired(t *testing.T) {
+	tests := map[sensormanager.UnknownDevicePolicy]struct {
+		expect      func(sqlmock.Sqlmock)
+		quarantined bool
+		err         error
+	}{
+		sensormanager.UnknownDeviceAccept: {
+			expect: func(mock sqlmock.Sqlmock) {
+				expectExec(mock, "INSERT INTO devices").
+					WithArgs("home", "ESP_NEW", "microphone", "pending").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectBegin()
+				expectQuery(mock, "INSERT INTO sensor_readings").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+				mock.ExpectCommit()
+				expectQuery(mock, "FROM alert_thresholds WHERE site_id = $1 AND sensor_type = $2").
+					WillReturnRows(sqlmock.NewRows([]string{"rule", "threshold"}))
+			},
+		},
+		sensormanager.UnknownDeviceQuarantine: {
+			expect: func(mock sqlmock.Sqlmock) {
+				expectExec(mock, "INSERT INTO devices").
+					WithArgs("home", "ESP_NEW", "microphone", "pending").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectExec(mock, "INSERT INTO quarantined_readings").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			quarantined: true,
+		},
+		sensormanager.UnknownDeviceReject: {
+			expect: func(sqlmock.Sqlmock) {},
+			err:    sensormanager.ErrUnregisteredDevice,
+		},
+	}
+
+	for policy, test := range tests {
+		t.Run(string(policy), func(t *testing.T) {
+			store, mock := newMockStore(t, WithDeviceSignatures(true, time.Minute), WithUnknownDevicePolicy(policy))
+
+			expectQuery(mock, "FROM devices WHERE device_id = $1").
+				WithArgs("ESP_NEW").
+				WillReturnRows(sqlmock.NewRows([]string{"device_id"}))
+			expectQuery(mock, "SELECT status FROM devices WHERE device_id = $1").
+				WillReturnRows(sqlmock.NewRows([]string{"status"}))
+			test.expect(mock)
+
+			response, err := store.Sensors.RecordReading(&sensormanager.ReadingParams{
+				SiteID:     "home",
+				SensorType: sensormanager.SensorTypeMicrophone,
+				DeviceID:   "ESP_NEW",
+				Value:      30,
+			})
+			if err != test.err {
+				t.Fatalf("got error %v, want %v", err, test.err)
+			}
+			if err == nil && response.Quarantined != test.quarantined {
+				t.Fatalf("got quarantined %t, want %t", response.Quarantined, test.quarantined)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestRecordQuarantinedReading(t *testing.T) {
+	store, mock := newMockStore(t, WithDeviceSignatures(false, time.Minute))
+
+	// 🆕 Un identifiant inconnu passe en attente d'approbation et sa mesure est mise de côté.
+	expectQuery(mock, "FROM devices WHERE device_id = $1").
+		WillReturnRows(sqlmock.NewRows([]string{"device_id"}))
+	expectQuery(mock, "SELECT status FROM devices WHERE device_id = $1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}))
+	expectExec(mock, "INSERT INTO devices").
+		WithArgs("home", "ESP_0002", "microphone", "pending").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectClaim(mock, 1)
+	expectExec(mock, "INSERT INTO quarantined_readings").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectExec(mock, "UPDATE reading_receipts SET response = $1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	response, err := store.Sensors.RecordReading(&sensormanager.ReadingParams{
+		SiteID:     "home",
+		SensorType: sensormanager.SensorTypeMicrophone,
+		DeviceID:   "ESP_0002",
+		Value:      90,
+		ReadingID:  sensormanager.ReadingID{MessageID: "message-1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !response.Quarantined || response.Alert {
+		t.Fatalf("the reading of the unknown device is not quarantined: %+v", response)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecordUnregisteredDeviceInStrictMode(t *testing.T) {
+	store, mock := newMockStore(t, WithDeviceSignatures(false, time.Minute), WithUnknownDevicePolicy(sensormanager.UnknownDeviceReject))
+
+	// Une mesure refusée ne réserve pas son identifiant.
+	expectQuery(mock, "FROM devices WHERE device_id = $1").
+		WillReturnRows(sqlmock.NewRows([]string{"device_id"}))
+	expectQuery(mock, "SELECT status FROM devices WHERE device_id = $1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}))
+
+	_, err := store.Sensors.RecordReading(&sensormanager.ReadingParams{
+		SiteID:     "home",
+		SensorType: sensormanager.SensorTypeMicrophone,
+		DeviceID:   "ESP_0002",
+		Value:      90,
+		ReadingID:  sensormanager.ReadingID{MessageID: "message-1"},
+	})
+	if err != sensormanager.ErrUnregisteredDevice {
+		t.Fatalf("got error %v, want %v", err, sensormanager.ErrUnregisteredDevice)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecordBatchWithRetransmission(t *testing.T) {
+	store, mock := newMockStore(t, WithDeviceSignatures(false, time.Minute), WithUnknownDevicePolicy(sensormanager.UnknownDeviceAccept))
+
+	sequence := int64(42)
+	recordedAt := time.Now().Add(-time.Minute).Truncate(time.Millisecond)
+	reading := &sensormanager.ReadingParams{
+		SiteID:      "home",
+		SensorType:  sensormanager.SensorTypeMicrophone,
+		DeviceID:    "ESP_BATCH_RETRIED",
+		Value:       30,
+		ReadingTime: sensormanager.ReadingTime{Sequence: &sequence, RecordedAt: &recordedAt},
+	}
+
+	expectNewMicrophoneReading(mock, 11)
+	expectExec(mock, "UPDATE reading_receipts SET response = $1").
+		WithArgs(sqlmock.AnyArg(), "home", "ESP_BATCH_RETRIED", "microphone", fmt.Sprintf("seq:42@%d", recordedAt.UnixMilli())).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	results, err := store.Sensors.RecordReadingBatch([]*sensormanager.ReadingParams{reading, reading})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results[0].Err != nil || results[0].Response.Duplicate {
+		t.Fatalf("unexpected result for the first reading: %+v", results[0])
+	}
+	if results[1].Err != nil || !results[1].Response.Duplicate {
+		t.Fatalf("the retransmitted reading is not flagged as a duplicate: %+v", results[1])
+	}
+	if results[1].Response.Value != results[0].Response.Value {
+		t.Fatalf("retransmitted response %+v differs from the original %+v", results[1].Response, results[0].Response)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestClaimIsScopedBySensorType checks that the readings of two sensors of a device are not taken for retransmissions
+// of each other when they share an identifier.
+func TestClaimIsScopedBySensorType(t *testing.T) {
+	store, mock := newMockStore(t)
+
+	for _, sensorType := range []sensormanager.SensorType{sensormanager.SensorTypeMicrophone, sensormanager.SensorTypeMotion} {
+		expectExec(mock, "DELETE FROM reading_receipts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		expectExec(mock, "INSERT INTO reading_receipts (site_id, device_id, sensor_type, message_id, received_at)").
+			WithArgs("home", "ESP_COMBO", string(sensorType), "loop-7", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		original, err := store.sensors.claimReading(&pendingReading{
+			sensorType: sensorType,
+			siteID:     "home",
+			deviceID:   "ESP_COMBO",
+			dedupKey:   "loop-7",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if original != nil {
+			t.Fatalf("the %s reading was taken for a retransmission: %+v", sensorType, original)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// cutoff matches a time argument at the given age, give or take the duration of the test.
+type cutoff struct{ age time.Duration }
+
+func (c cutoff) Match(value driver.Value) bool {
+	at, ok := value.(time.Time)
+	if !ok {
+		return false
+	}
+
+	age := time.Since(at)
+
+	return age >= c.age && age < c.age+time.Second
+}
+
+func TestClaimExpiresStaleReservations(t *testing.T) {
+	store, mock := newMockStore(t, WithDedupWindow(10*time.Minute, 30*time.Second))
+
+	// ⏳ Une réservation sans réponse est abandonnée après le bail, une réponse est gardée toute la fenêtre.
+	expectExec(mock, "DELETE FROM reading_receipts WHERE site_id = $1 AND device_id = $2 AND (received_at < $3 OR (response IS NULL AND received_at < $4))").
+		WithArgs("home", "ESP_STALE", cutoff{10 * time.Minute}, cutoff{30 * time.Second}).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectExec(mock, "INSERT INTO reading_receipts").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	original, err := store.sensors.claimReading(&pendingReading{siteID: "home", deviceID: "ESP_STALE", dedupKey: "message-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if original != nil {
+		t.Fatalf("the stale reservation was not dropped: %+v", original)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDedupWindowValidatesClaimLease(t *testing.T) {
+	store, _ := newMockStore(t)
+
+	for _, lease := range []time.Duration{0, 20 * time.Minute} {
+		if err := WithDedupWindow(10*time.Minute, lease)(store); err == nil {
+			t.Errorf("expected claim lease %s to be refused", lease)
+		}
+	}
+}
@@ -3,16 +3,17 @@ package store
 import (
 	"context"
 	"fmt"
-	"math"
 	"sensormanager"
-	"sensormanager/store/models"
+	"sensormanager/filter"
+	"sensormanager/motion"
+	"sensormanager/quality"
+	"sync"
 	"time"
 
 	"github.com/ericlagergren/decimal"
 	"github.com/loungeup/go-loungeup/pkg/errors"
 	"github.com/volatiletech/null/v8"
-	"github.com/volatiletech/sqlboiler/v4/boil"
-	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/types"
 )
 
@@ -20,552 +21,336 @@ type sensorsStore struct{ baseStore *Store }
 
 var _ sensormanager.SensorManager = (*sensorsStore)(nil)
 
-const (
-	MicrophoneThresholdDB        = 50.0
-	DistanceVariationThresholdCM = 30.0
-	AlertCooldownSeconds         = 10
-)
-
-type lastValue struct {
-	value         float64
-	timestamp     time.Time
-	lastTriggered time.Time
-}
-
-var (
-	lastDistances   = make(map[string]*lastValue)
-	lastMotions     = make(map[string]*lastValue)
-	lastMicrophones = make(map[string]*lastValue)
-)
-
-// ============= MICROPHONE =============
-
-func (ss *sensorsStore) RecordMicrophone(params *sensormanager.MicrophoneParams) (*sensormanager.AlertResponse, error) {
-	if err := params.Sanitize(); err != nil {
-		return nil, err
-	}
-
-	model := &models.MicrophoneDatum{
-		DeviceID:   params.DeviceID,
-		Decibels:   types.NewDecimal(new(decimal.Big).SetFloat64(params.Decibels)),
-		RecordedAt: null.TimeFrom(time.Now()),
-	}
-
-	if err := model.Insert(context.TODO(), ss.baseStore.db, boil.Infer()); err != nil {
-		return nil, errors.MapSQLError(err)
-	}
-
-	return ss.checkMicrophoneAlert(params.DeviceID, params.Decibels, model.ID)
-}
-
-func (ss *sensorsStore) GetMicrophoneHistory(deviceID string, limit int) ([]*sensormanager.MicrophoneData, error) {
-	modelsDB, err := models.MicrophoneData(
-		models.MicrophoneDatumWhere.DeviceID.EQ(deviceID),
-		qm.OrderBy(fmt.Sprintf("%s DESC", models.MicrophoneDatumColumns.RecordedAt)),
-		qm.Limit(limit),
-	).All(context.TODO(), ss.baseStore.db)
-	if err != nil {
-		return nil, errors.MapSQLError(err)
-	}
-
-	result := make([]*sensormanager.MicrophoneData, len(modelsDB))
-	for i, m := range modelsDB {
-		decibels, _ := m.Decibels.Float64()
-		result[i] = &sensormanager.MicrophoneData{
-			ID:         m.ID,
-			DeviceID:   m.DeviceID,
-			Decibels:   decibels,
-			RecordedAt: m.RecordedAt.Time,
-		}
-	}
-
-	return result, nil
-}
+// ============= MOTION =============
 
-func (ss *sensorsStore) checkMicrophoneAlert(deviceID string, decibels float64, dataID int64) (*sensormanager.AlertResponse, error) {
-	now := time.Now()
+// checkMotionAlert feeds the reading to the motion detector. The start and the continuation of a motion raise an alert
+// whose rule is the motion event, its end is only reported.
+func (ss *sensorsStore) checkMotionAlert(reading *pendingReading) (*sensormanager.AlertResponse, error) {
+	now := reading.recordedAt
 
-	last, exists := lastMicrophones[deviceID]
-	if exists && now.Sub(last.lastTriggered).Seconds() < AlertCooldownSeconds {
+	event := ss.baseStore.motionDetector.Observe(reading.deviceID, reading.value == 1, now)
+	if event == nil {
 		return &sensormanager.AlertResponse{
 			Alert:      false,
-			Message:    "Cooldown active",
-			DeviceID:   deviceID,
-			Value:      decibels,
+			DeviceID:   reading.deviceID,
+			Value:      reading.value,
 			RecordedAt: now,
 		}, nil
 	}
 
-	if decibels >= MicrophoneThresholdDB {
-		if !exists {
-			lastMicrophones[deviceID] = &lastValue{}
-		}
-		lastMicrophones[deviceID].lastTriggered = now
-
-		// 💾 Enregistrer l'alerte dans la DB
-		alert := &models.MicrophoneAlert{
-			DeviceID:          deviceID,
-			DataID:            null.Int64From(dataID),
-			Decibels:          types.NewDecimal(new(decimal.Big).SetFloat64(decibels)),
-			ThresholdExceeded: types.NewDecimal(new(decimal.Big).SetFloat64(MicrophoneThresholdDB)),
-			AlertStatus:       null.StringFrom(string(sensormanager.AlertStatusActive)),
-		}
-
-		if err := alert.Insert(context.TODO(), ss.baseStore.db, boil.Infer()); err != nil {
-			return nil, errors.MapSQLError(err)
-		}
-
+	if event.Type == motion.EventEnded {
 		return &sensormanager.AlertResponse{
-			Alert:      true,
-			Message:    fmt.Sprintf("High noise level detected: %.1f dB", decibels),
-			Value:      decibels,
-			Threshold:  MicrophoneThresholdDB,
-			DeviceID:   deviceID,
+			Alert:      false,
+			Message:    fmt.Sprintf("Motion ended after %s", event.Duration.Round(time.Second)),
+			Value:      reading.value,
+			DeviceID:   reading.deviceID,
+			Event:      string(event.Type),
 			RecordedAt: now,
 		}, nil
 	}
 
+	// 💾 Enregistrer l'alerte dans la DB
+	row := &sensorAlertRow{}
+	if err := queries.Raw(
+		"INSERT INTO sensor_alerts (sensor_type, device_id, data_id, rule, value, alert_status) VALUES ($1, $2, $3, $4, $5, $6) RETURNING "+sensorAlertColumns,
+		string(reading.sensorType), reading.deviceID, reading.dataID, string(event.Type),
+		types.NewDecimal(new(decimal.Big).SetFloat64(reading.value)),
+		string(sensormanager.AlertStatusActive),
+	).Bind(context.TODO(), ss.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	message := "Motion detected"
+	if event.Type == motion.EventContinuous {
+		message = fmt.Sprintf("Continuous motion detected for %s", event.Duration.Round(time.Second))
+	}
+
 	return &sensormanager.AlertResponse{
-		Alert:      false,
-		DeviceID:   deviceID,
-		Value:      decibels,
+		Alert:      true,
+		AlertID:    row.ID,
+		Message:    message,
+		Value:      reading.value,
+		DeviceID:   reading.deviceID,
+		Event:      string(event.Type),
 		RecordedAt: now,
 	}, nil
 }
 
-// ============= DISTANCE =============
+// ============= READING TIME =============
 
-func (ss *sensorsStore) RecordDistance(params *sensormanager.DistanceParams) (*sensormanager.AlertResponse, error) {
-	if err := params.Sanitize(); err != nil {
-		return nil, err
+// readingTime returns the time of the reading: the time given by the device, within the configured bounds around the
+// time of reception, or the time of reception.
+func (ss *sensorsStore) readingTime(params *sensormanager.ReadingTime, receivedAt time.Time) (time.Time, error) {
+	if params.RecordedAt == nil {
+		return receivedAt, nil
 	}
 
-	model := &models.DistanceDatum{
-		DeviceID:   params.DeviceID,
-		DistanceCM: types.NewDecimal(new(decimal.Big).SetFloat64(params.DistanceCm)),
-		RecordedAt: null.TimeFrom(time.Now()),
-	}
+	// Les colonnes TIMESTAMP sont sans fuseau horaire : on les écrit en heure locale, comme time.Now().
+	recordedAt := params.RecordedAt.Local()
 
-	if err := model.Insert(context.TODO(), ss.baseStore.db, boil.Infer()); err != nil {
-		return nil, errors.MapSQLError(err)
+	switch {
+	case recordedAt.After(receivedAt.Add(ss.baseStore.maxClockSkew)):
+		return time.Time{}, sensormanager.ErrReadingInFuture
+	case recordedAt.Before(receivedAt.Add(-ss.baseStore.maxReadingAge)):
+		return time.Time{}, sensormanager.ErrReadingTooOld
 	}
 
-	return ss.checkDistanceAlert(params.DeviceID, params.DistanceCm, model.ID)
+	return recordedAt, nil
 }
 
-func (ss *sensorsStore) GetDistanceHistory(deviceID string, limit int) ([]*sensormanager.DistanceData, error) {
-	modelsDB, err := models.DistanceData(
-		models.DistanceDatumWhere.DeviceID.EQ(deviceID),
-		qm.OrderBy(fmt.Sprintf("%s DESC", models.DistanceDatumColumns.RecordedAt)),
-		qm.Limit(limit),
-	).All(context.TODO(), ss.baseStore.db)
-	if err != nil {
-		return nil, errors.MapSQLError(err)
+// lateReading returns the response of a reading that is too old, or older than the last evaluated reading of the
+// sensor, to be evaluated for alerts. It returns nil when the reading can be evaluated.
+func (ss *sensorsStore) lateReading(reading *pendingReading) *sensormanager.AlertResponse {
+	var message string
+	switch {
+	case reading.receivedAt.Sub(reading.recordedAt) > ss.baseStore.alertHorizon:
+		message = "Reading older than the alert horizon: stored without alert evaluation"
+	case !ss.baseStore.readingClock.advance(string(reading.sensorType)+"/"+reading.deviceID, reading.recordedAt):
+		message = "Reading out of order: stored without alert evaluation"
+	default:
+		return nil
 	}
 
-	result := make([]*sensormanager.DistanceData, len(modelsDB))
-	for i, m := range modelsDB {
-		distanceCm, _ := m.DistanceCM.Float64()
-		result[i] = &sensormanager.DistanceData{
-			ID:         m.ID,
-			DeviceID:   m.DeviceID,
-			DistanceCm: distanceCm,
-			RecordedAt: m.RecordedAt.Time,
-		}
+	return &sensormanager.AlertResponse{
+		Alert:      false,
+		Message:    message,
+		DeviceID:   reading.deviceID,
+		Value:      reading.value,
+		RecordedAt: reading.recordedAt,
 	}
+}
 
-	return result, nil
+// readingClock remembers, per sensor, the time of the last reading evaluated for alerts.
+type readingClock struct {
+	mu   sync.Mutex
+	last map[string]time.Time
 }
 
-func (ss *sensorsStore) checkDistanceAlert(deviceID string, distance float64, dataID int64) (*sensormanager.AlertResponse, error) {
-	now := time.Now()
+func newReadingClock() *readingClock {
+	return &readingClock{last: make(map[string]time.Time)}
+}
 
-	last, exists := lastDistances[deviceID]
-	if !exists {
-		lastDistances[deviceID] = &lastValue{
-			value:     distance,
-			timestamp: now,
-		}
-		return &sensormanager.AlertResponse{
-			Alert:      false,
-			DeviceID:   deviceID,
-			Value:      distance,
-			RecordedAt: now,
-		}, nil
-	}
+// advance records the time of the reading and reports whether it is not older than the last evaluated one.
+func (c *readingClock) advance(key string, recordedAt time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	if now.Sub(last.lastTriggered).Seconds() < AlertCooldownSeconds {
-		last.value = distance
-		last.timestamp = now
-		return &sensormanager.AlertResponse{
-			Alert:      false,
-			Message:    "Cooldown active",
-			DeviceID:   deviceID,
-			Value:      distance,
-			RecordedAt: now,
-		}, nil
+	if last, exists := c.last[key]; exists && recordedAt.Before(last) {
+		return false
 	}
 
-	variation := math.Abs(distance - last.value)
+	c.last[key] = recordedAt
 
-	if variation >= DistanceVariationThresholdCM {
-		last.lastTriggered = now
-		oldValue := last.value
-		last.value = distance
-		last.timestamp = now
-
-		// 💾 Enregistrer l'alerte dans la DB
-		thresholdType := "too_close"
-		if distance > oldValue {
-			thresholdType = "too_far"
-		}
+	return true
+}
 
-		alert := &models.DistanceAlert{
-			DeviceID:       deviceID,
-			DataID:         null.Int64From(dataID),
-			DistanceCM:     types.NewDecimal(new(decimal.Big).SetFloat64(distance)),
-			ThresholdType:  thresholdType,
-			ThresholdValue: types.NewDecimal(new(decimal.Big).SetFloat64(oldValue)),
-			AlertStatus:    null.StringFrom(string(sensormanager.AlertStatusActive)),
-		}
+// ============= FILTERING =============
 
-		if err := alert.Insert(context.TODO(), ss.baseStore.db, boil.Infer()); err != nil {
-			return nil, errors.MapSQLError(err)
-		}
-
-		return &sensormanager.AlertResponse{
-			Alert:      true,
-			Message:    fmt.Sprintf("Large distance change detected: %.1f cm variation", variation),
-			Value:      distance,
-			Threshold:  oldValue,
-			DeviceID:   deviceID,
-			RecordedAt: now,
-		}, nil
+// unconfirmedReading returns the response of a reading that must not feed alert evaluation, or nil when the filtered
+// value can be evaluated.
+func unconfirmedReading(deviceID string, filtered *filter.Result, recordedAt time.Time) *sensormanager.AlertResponse {
+	var message string
+	switch {
+	case filtered.Rejected:
+		message = "Reading rejected: value out of range"
+	case !filtered.Confirmed:
+		message = "Reading awaiting confirmation"
+	default:
+		return nil
 	}
 
-	last.value = distance
-	last.timestamp = now
-
 	return &sensormanager.AlertResponse{
 		Alert:      false,
+		Message:    message,
 		DeviceID:   deviceID,
-		Value:      distance,
-		RecordedAt: now,
-	}, nil
-}
-
-// ============= MOTION =============
-
-func (ss *sensorsStore) RecordMotion(params *sensormanager.MotionParams) (*sensormanager.AlertResponse, error) {
-	if err := params.Sanitize(); err != nil {
-		return nil, err
-	}
-
-	model := &models.MotionDatum{
-		DeviceID:       params.DeviceID,
-		MotionDetected: params.MotionDetected,
-		RecordedAt:     null.TimeFrom(time.Now()),
-	}
-
-	if err := model.Insert(context.TODO(), ss.baseStore.db, boil.Infer()); err != nil {
-		return nil, errors.MapSQLError(err)
+		Value:      filtered.Raw,
+		RecordedAt: recordedAt,
 	}
-
-	return ss.checkMotionAlert(params.DeviceID, params.MotionDetected, model.ID)
 }
 
-func (ss *sensorsStore) GetMotionHistory(deviceID string, limit int) ([]*sensormanager.MotionData, error) {
-	modelsDB, err := models.MotionData(
-		models.MotionDatumWhere.DeviceID.EQ(deviceID),
-		qm.OrderBy(fmt.Sprintf("%s DESC", models.MotionDatumColumns.RecordedAt)),
-		qm.Limit(limit),
-	).All(context.TODO(), ss.baseStore.db)
-	if err != nil {
-		return nil, errors.MapSQLError(err)
+func nullDecimalToFloat64(value types.NullDecimal) *float64 {
+	if value.Big == nil {
+		return nil
 	}
 
-	result := make([]*sensormanager.MotionData, len(modelsDB))
-	for i, m := range modelsDB {
-		result[i] = &sensormanager.MotionData{
-			ID:             m.ID,
-			DeviceID:       m.DeviceID,
-			MotionDetected: m.MotionDetected,
-			RecordedAt:     m.RecordedAt.Time,
-		}
-	}
+	result, _ := value.Float64()
 
-	return result, nil
+	return &result
 }
 
-func (ss *sensorsStore) checkMotionAlert(deviceID string, motionDetected bool, dataID int64) (*sensormanager.AlertResponse, error) {
-	now := time.Now()
-
-	if !motionDetected {
-		return &sensormanager.AlertResponse{
-			Alert:      false,
-			DeviceID:   deviceID,
-			Value:      0,
-			RecordedAt: now,
-		}, nil
-	}
-
-	last, exists := lastMotions[deviceID]
-	if exists && now.Sub(last.lastTriggered).Seconds() < AlertCooldownSeconds {
-		return &sensormanager.AlertResponse{
-			Alert:      false,
-			Message:    "Cooldown active",
-			DeviceID:   deviceID,
-			Value:      1,
-			RecordedAt: now,
-		}, nil
-	}
-
-	if !exists {
-		lastMotions[deviceID] = &lastValue{}
-	}
-	lastMotions[deviceID].lastTriggered = now
-
-	// 💾 Enregistrer l'alerte dans la DB
-	alert := &models.MotionAlert{
-		DeviceID:       deviceID,
-		DataID:         null.Int64From(dataID),
-		MotionDetected: motionDetected,
-		AlertReason:    null.StringFrom("unexpected_motion"),
-		AlertStatus:    null.StringFrom(string(sensormanager.AlertStatusActive)),
-	}
-
-	if err := alert.Insert(context.TODO(), ss.baseStore.db, boil.Infer()); err != nil {
-		return nil, errors.MapSQLError(err)
-	}
+// ============= ACTIVE ALERTS =============
 
-	return &sensormanager.AlertResponse{
-		Alert:      true,
-		Message:    "Motion detected",
-		Value:      1,
-		DeviceID:   deviceID,
-		RecordedAt: now,
-	}, nil
+type activeAlertRow struct {
+	AlertType    string    `boil:"alert_type"`
+	ID           int64     `boil:"id"`
+	SiteID       string    `boil:"site_id"`
+	DeviceID     string    `boil:"device_id"`
+	SnoozedUntil null.Time `boil:"snoozed_until"`
+	CreatedAt    null.Time `boil:"created_at"`
 }
 
-// ============= MICROPHONE ALERTS =============
-
-func (ss *sensorsStore) GetMicrophoneAlerts(params *sensormanager.GetAlertsParams) ([]*sensormanager.MicrophoneAlert, error) {
-	queryMods := []qm.QueryMod{
-		models.MicrophoneAlertWhere.DeviceID.EQ(params.DeviceID),
-		qm.OrderBy(fmt.Sprintf("%s DESC", models.MicrophoneAlertColumns.CreatedAt)),
-	}
-
-	if params.Status != "" {
-		queryMods = append(queryMods, models.MicrophoneAlertWhere.AlertStatus.EQ(null.StringFrom(string(params.Status))))
-	}
-
-	if params.Limit > 0 {
-		queryMods = append(queryMods, qm.Limit(params.Limit))
-	}
-
-	modelsDB, err := models.MicrophoneAlerts(queryMods...).All(context.TODO(), ss.baseStore.db)
-	if err != nil {
+// GetActiveAlerts returns the alerts of every type and every site that have been neither acknowledged nor resolved.
+func (ss *sensorsStore) GetActiveAlerts() ([]*sensormanager.ActiveAlert, error) {
+	var rows []*activeAlertRow
+	if err := queries.Raw(`
+		SELECT a.alert_type, a.id, d.site_id, a.device_id, s.snoozed_until, a.created_at FROM (
+			SELECT 'sensor_fault' AS alert_type, id, device_id, created_at FROM sensor_fault_alerts WHERE alert_status = $1
+			UNION ALL
+			SELECT 'telemetry', id, device_id, created_at FROM telemetry_alerts WHERE alert_status = $1
+			UNION ALL
+			SELECT sensor_type, id, device_id, created_at FROM sensor_alerts WHERE alert_status = $1
+		) a JOIN devices d ON d.device_id = a.device_id
+		LEFT JOIN alert_snoozes s ON s.alert_type = a.alert_type AND s.alert_id = a.id
+		ORDER BY a.created_at`,
+		string(sensormanager.AlertStatusActive),
+	).Bind(context.TODO(), ss.baseStore.db, &rows); err != nil {
 		return nil, errors.MapSQLError(err)
 	}
 
-	result := make([]*sensormanager.MicrophoneAlert, len(modelsDB))
-	for i, m := range modelsDB {
-		decibels, _ := m.Decibels.Float64()
-		threshold, _ := m.ThresholdExceeded.Float64()
-
-		var dataID *int64
-		if m.DataID.Valid {
-			dataID = &m.DataID.Int64
-		}
-
-		var ackAt, resAt *time.Time
-		if m.AcknowledgedAt.Valid {
-			ackAt = &m.AcknowledgedAt.Time
-		}
-		if m.ResolvedAt.Valid {
-			resAt = &m.ResolvedAt.Time
-		}
-
-		result[i] = &sensormanager.MicrophoneAlert{
-			ID:                m.ID,
-			DeviceID:          m.DeviceID,
-			DataID:            dataID,
-			Decibels:          decibels,
-			ThresholdExceeded: threshold,
-			AlertStatus:       sensormanager.AlertStatus(m.AlertStatus.String),
-			AcknowledgedAt:    ackAt,
-			ResolvedAt:        resAt,
-			CreatedAt:         m.CreatedAt.Time,
+	result := make([]*sensormanager.ActiveAlert, len(rows))
+	for i, row := range rows {
+		result[i] = &sensormanager.ActiveAlert{
+			Type:         sensormanager.AlertType(row.AlertType),
+			ID:           row.ID,
+			SiteID:       row.SiteID,
+			DeviceID:     row.DeviceID,
+			SnoozedUntil: row.SnoozedUntil.Ptr(),
+			CreatedAt:    row.CreatedAt.Time,
 		}
 	}
 
 	return result, nil
 }
 
-func (ss *sensorsStore) UpdateMicrophoneAlertStatus(params *sensormanager.UpdateAlertStatusParams) error {
-	alert, err := models.FindMicrophoneAlert(context.TODO(), ss.baseStore.db, params.AlertID)
-	if err != nil {
-		return errors.MapSQLError(err)
-	}
-
-	alert.AlertStatus = null.StringFrom(string(params.Status))
-
-	now := time.Now()
-	switch params.Status {
-	case sensormanager.AlertStatusAcknowledged:
-		alert.AcknowledgedAt = null.TimeFrom(now)
-	case sensormanager.AlertStatusResolved:
-		alert.ResolvedAt = null.TimeFrom(now)
-	}
-
-	_, err = alert.Update(context.TODO(), ss.baseStore.db, boil.Infer())
-	return errors.MapSQLError(err)
+// ============= DATA QUALITY =============
+
+type sensorFaultAlertRow struct {
+	ID             int64         `boil:"id"`
+	DeviceID       string        `boil:"device_id"`
+	SensorType     string        `boil:"sensor_type"`
+	FaultType      string        `boil:"fault_type"`
+	Value          types.Decimal `boil:"value"`
+	Details        null.String   `boil:"details"`
+	AlertStatus    null.String   `boil:"alert_status"`
+	AcknowledgedAt null.Time     `boil:"acknowledged_at"`
+	ResolvedAt     null.Time     `boil:"resolved_at"`
+	CreatedAt      null.Time     `boil:"created_at"`
 }
 
-// ============= DISTANCE ALERTS =============
+const sensorFaultAlertColumns = "id, device_id, sensor_type, fault_type, value, details, alert_status, acknowledged_at, resolved_at, created_at"
 
-func (ss *sensorsStore) GetDistanceAlerts(params *sensormanager.GetAlertsParams) ([]*sensormanager.DistanceAlert, error) {
-	queryMods := []qm.QueryMod{
-		models.DistanceAlertWhere.DeviceID.EQ(params.DeviceID),
-		qm.OrderBy(fmt.Sprintf("%s DESC", models.DistanceAlertColumns.CreatedAt)),
+// checkSensorQuality feeds the raw reading to the quality checker of the sensor type. It returns the fault alert
+// raised by this reading, if any.
+func (ss *sensorsStore) checkSensorQuality(sensorType sensormanager.SensorType, deviceID string, value float64) (*sensormanager.SensorFaultAlert, error) {
+	var checker *quality.Checker
+	switch sensorType {
+	case sensormanager.SensorTypeDistance:
+		checker = ss.baseStore.distanceQuality
+	case sensormanager.SensorTypeMicrophone:
+		checker = ss.baseStore.microphoneQuality
+	default:
+		return nil, nil
 	}
 
-	if params.Status != "" {
-		queryMods = append(queryMods, models.DistanceAlertWhere.AlertStatus.EQ(null.StringFrom(string(params.Status))))
+	report := checker.Observe(deviceID, value)
+	if !report.Changed {
+		return nil, nil
 	}
 
-	if params.Limit > 0 {
-		queryMods = append(queryMods, qm.Limit(params.Limit))
+	health := sensormanager.DeviceHealth(report.Health)
+	if err := ss.baseStore.devices.updateHealth(deviceID, health, report.Details); err != nil {
+		return nil, err
 	}
 
-	modelsDB, err := models.DistanceAlerts(queryMods...).All(context.TODO(), ss.baseStore.db)
-	if err != nil {
+	// ✅ Le capteur est de nouveau sain : on résout ses défauts en cours
+	if health == sensormanager.DeviceHealthOK {
+		_, err := queries.Raw(
+			"UPDATE sensor_fault_alerts SET alert_status = $1, resolved_at = $2 WHERE device_id = $3 AND alert_status <> $1",
+			string(sensormanager.AlertStatusResolved), time.Now(), deviceID,
+		).ExecContext(context.TODO(), ss.baseStore.db)
+
 		return nil, errors.MapSQLError(err)
 	}
 
-	result := make([]*sensormanager.DistanceAlert, len(modelsDB))
-	for i, m := range modelsDB {
-		distance, _ := m.DistanceCM.Float64()
-		threshold, _ := m.ThresholdValue.Float64()
-
-		var dataID *int64
-		if m.DataID.Valid {
-			dataID = &m.DataID.Int64
-		}
-
-		var ackAt, resAt *time.Time
-		if m.AcknowledgedAt.Valid {
-			ackAt = &m.AcknowledgedAt.Time
-		}
-		if m.ResolvedAt.Valid {
-			resAt = &m.ResolvedAt.Time
-		}
-
-		result[i] = &sensormanager.DistanceAlert{
-			ID:             m.ID,
-			DeviceID:       m.DeviceID,
-			DataID:         dataID,
-			DistanceCm:     distance,
-			ThresholdType:  m.ThresholdType,
-			ThresholdValue: threshold,
-			AlertStatus:    sensormanager.AlertStatus(m.AlertStatus.String),
-			AcknowledgedAt: ackAt,
-			ResolvedAt:     resAt,
-			CreatedAt:      m.CreatedAt.Time,
-		}
+	row := &sensorFaultAlertRow{}
+	if err := queries.Raw(
+		"INSERT INTO sensor_fault_alerts (device_id, sensor_type, fault_type, value, details, alert_status) VALUES ($1, $2, $3, $4, $5, $6) RETURNING "+sensorFaultAlertColumns,
+		deviceID, string(sensorType), string(health), types.NewDecimal(new(decimal.Big).SetFloat64(value)), report.Details, string(sensormanager.AlertStatusActive),
+	).Bind(context.TODO(), ss.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
 	}
 
-	return result, nil
+	return row.toSensorFaultAlert(), nil
 }
 
-func (ss *sensorsStore) UpdateDistanceAlertStatus(params *sensormanager.UpdateAlertStatusParams) error {
-	alert, err := models.FindDistanceAlert(context.TODO(), ss.baseStore.db, params.AlertID)
-	if err != nil {
-		return errors.MapSQLError(err)
-	}
-
-	alert.AlertStatus = null.StringFrom(string(params.Status))
+func (row *sensorFaultAlertRow) toSensorFaultAlert() *sensormanager.SensorFaultAlert {
+	value, _ := row.Value.Float64()
 
-	now := time.Now()
-	switch params.Status {
-	case sensormanager.AlertStatusAcknowledged:
-		alert.AcknowledgedAt = null.TimeFrom(now)
-	case sensormanager.AlertStatusResolved:
-		alert.ResolvedAt = null.TimeFrom(now)
+	var ackAt, resAt *time.Time
+	if row.AcknowledgedAt.Valid {
+		ackAt = &row.AcknowledgedAt.Time
+	}
+	if row.ResolvedAt.Valid {
+		resAt = &row.ResolvedAt.Time
 	}
 
-	_, err = alert.Update(context.TODO(), ss.baseStore.db, boil.Infer())
-	return errors.MapSQLError(err)
+	return &sensormanager.SensorFaultAlert{
+		ID:             row.ID,
+		DeviceID:       row.DeviceID,
+		SensorType:     sensormanager.SensorType(row.SensorType),
+		FaultType:      sensormanager.DeviceHealth(row.FaultType),
+		Value:          value,
+		Details:        row.Details.String,
+		AlertStatus:    sensormanager.AlertStatus(row.AlertStatus.String),
+		AcknowledgedAt: ackAt,
+		ResolvedAt:     resAt,
+		CreatedAt:      row.CreatedAt.Time,
+	}
 }
 
-// ============= MOTION ALERTS =============
+// ============= SENSOR FAULT ALERTS =============
 
-func (ss *sensorsStore) GetMotionAlerts(params *sensormanager.GetAlertsParams) ([]*sensormanager.MotionAlert, error) {
-	queryMods := []qm.QueryMod{
-		models.MotionAlertWhere.DeviceID.EQ(params.DeviceID),
-		qm.OrderBy(fmt.Sprintf("%s DESC", models.MotionAlertColumns.CreatedAt)),
-	}
+func (ss *sensorsStore) GetSensorFaultAlerts(params *sensormanager.GetAlertsParams) ([]*sensormanager.SensorFaultAlert, error) {
+	query := "SELECT " + sensorFaultAlertColumns + " FROM sensor_fault_alerts WHERE device_id = $1 AND " + siteDevicesCondition(2)
+	args := []interface{}{params.DeviceID, params.SiteID}
 
 	if params.Status != "" {
-		queryMods = append(queryMods, models.MotionAlertWhere.AlertStatus.EQ(null.StringFrom(string(params.Status))))
+		args = append(args, string(params.Status))
+		query += fmt.Sprintf(" AND alert_status = $%d", len(args))
 	}
 
+	query += " ORDER BY created_at DESC"
+
 	if params.Limit > 0 {
-		queryMods = append(queryMods, qm.Limit(params.Limit))
+		args = append(args, params.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
 	}
 
-	modelsDB, err := models.MotionAlerts(queryMods...).All(context.TODO(), ss.baseStore.db)
-	if err != nil {
+	var rows []*sensorFaultAlertRow
+	if err := queries.Raw(query, args...).Bind(context.TODO(), ss.baseStore.db, &rows); err != nil {
 		return nil, errors.MapSQLError(err)
 	}
 
-	result := make([]*sensormanager.MotionAlert, len(modelsDB))
-	for i, m := range modelsDB {
-		var dataID *int64
-		if m.DataID.Valid {
-			dataID = &m.DataID.Int64
-		}
-
-		var ackAt, resAt *time.Time
-		if m.AcknowledgedAt.Valid {
-			ackAt = &m.AcknowledgedAt.Time
-		}
-		if m.ResolvedAt.Valid {
-			resAt = &m.ResolvedAt.Time
-		}
-
-		result[i] = &sensormanager.MotionAlert{
-			ID:             m.ID,
-			DeviceID:       m.DeviceID,
-			DataID:         dataID,
-			MotionDetected: m.MotionDetected,
-			AlertReason:    m.AlertReason.String,
-			AlertStatus:    sensormanager.AlertStatus(m.AlertStatus.String),
-			AcknowledgedAt: ackAt,
-			ResolvedAt:     resAt,
-			CreatedAt:      m.CreatedAt.Time,
-		}
+	result := make([]*sensormanager.SensorFaultAlert, len(rows))
+	for i, row := range rows {
+		result[i] = row.toSensorFaultAlert()
 	}
 
 	return result, nil
 }
 
-func (ss *sensorsStore) UpdateMotionAlertStatus(params *sensormanager.UpdateAlertStatusParams) error {
-	alert, err := models.FindMotionAlert(context.TODO(), ss.baseStore.db, params.AlertID)
-	if err != nil {
-		return errors.MapSQLError(err)
-	}
-
-	alert.AlertStatus = null.StringFrom(string(params.Status))
+func (ss *sensorsStore) UpdateSensorFaultAlertStatus(params *sensormanager.UpdateAlertStatusParams) error {
+	query := "UPDATE sensor_fault_alerts SET alert_status = $1 WHERE id = $2 AND " + siteDevicesCondition(3)
+	args := []interface{}{string(params.Status), params.AlertID, params.SiteID}
 
-	now := time.Now()
 	switch params.Status {
 	case sensormanager.AlertStatusAcknowledged:
-		alert.AcknowledgedAt = null.TimeFrom(now)
+		query = "UPDATE sensor_fault_alerts SET alert_status = $1, acknowledged_at = $4 WHERE id = $2 AND " + siteDevicesCondition(3)
+		args = append(args, time.Now())
 	case sensormanager.AlertStatusResolved:
-		alert.ResolvedAt = null.TimeFrom(now)
+		query = "UPDATE sensor_fault_alerts SET alert_status = $1, resolved_at = $4 WHERE id = $2 AND " + siteDevicesCondition(3)
+		args = append(args, time.Now())
 	}
 
-	_, err = alert.Update(context.TODO(), ss.baseStore.db, boil.Infer())
-	return errors.MapSQLError(err)
+	return ss.baseStore.updateAlertStatus(sensormanager.AlertTypeSensorFault, params, query+" RETURNING device_id", args...)
 }
@@ -0,0 +1,252 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sensormanager"
+	"time"
+
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type shadowsStore struct{ baseStore *Store }
+
+var _ sensormanager.ShadowManager = (*shadowsStore)(nil)
+
+type shadowRow struct {
+	DeviceID          string     `boil:"device_id"`
+	Desired           types.JSON `boil:"desired"`
+	Reported          types.JSON `boil:"reported"`
+	Version           int64      `boil:"version"`
+	DesiredUpdatedAt  null.Time  `boil:"desired_updated_at"`
+	ReportedUpdatedAt null.Time  `boil:"reported_updated_at"`
+}
+
+const shadowColumns = "device_id, desired, reported, version, desired_updated_at, reported_updated_at"
+
+func (ss *shadowsStore) GetShadows(siteID string) ([]*sensormanager.DeviceShadow, error) {
+	var rows []*shadowRow
+	if err := queries.Raw(
+		"SELECT "+shadowColumns+" FROM device_shadows WHERE "+siteDevicesCondition(1)+" ORDER BY device_id",
+		siteID,
+	).Bind(context.TODO(), ss.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.DeviceShadow, len(rows))
+	for i, row := range rows {
+		shadow, err := row.toDeviceShadow()
+		if err != nil {
+			return nil, err
+		}
+		result[i] = shadow
+	}
+
+	return result, nil
+}
+
+func (ss *shadowsStore) GetShadow(siteID, deviceID string) (*sensormanager.DeviceShadow, error) {
+	ctx := context.TODO()
+
+	if err := checkSiteDevice(ctx, ss.baseStore.db, siteID, deviceID); err != nil {
+		return nil, err
+	}
+
+	row, err := loadShadow(ctx, ss.baseStore.db, deviceID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return row.toDeviceShadow()
+}
+
+// UpdateDesiredShadow merges the changes into the desired configuration. The version is only incremented when the
+// configuration changes.
+func (ss *shadowsStore) UpdateDesiredShadow(params *sensormanager.UpdateDesiredShadowParams) (*sensormanager.DeviceShadow, error) {
+	if err := params.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	return ss.update(params.SiteID, params.DeviceID, func(shadow *sensormanager.DeviceShadow) (bool, error) {
+		if params.ExpectedVersion != nil && *params.ExpectedVersion != shadow.Version {
+			return false, sensormanager.ErrShadowVersionConflict
+		}
+
+		desired := shadow.Desired.Merge(params.Desired)
+		if desired.Equal(shadow.Desired) {
+			return false, nil
+		}
+
+		now := time.Now()
+		shadow.Desired = desired
+		shadow.DesiredUpdatedAt = &now
+
+		return true, nil
+	})
+}
+
+// ReportShadow records the configuration applied by the device. The returned shadow carries the delta the device
+// still has to apply.
+func (ss *shadowsStore) ReportShadow(params *sensormanager.ReportShadowParams) (*sensormanager.DeviceShadow, error) {
+	// 🔐 La signature porte sur le document reçu : elle est vérifiée avant toute normalisation.
+	if err := ss.baseStore.devices.verify(
+		params.SiteID, sensormanager.ShadowSignatureType, params.DeviceID, params.SignedValue(), nil, nil,
+		&params.SignedPayload, time.Now(),
+	); err != nil {
+		return nil, err
+	}
+
+	if err := params.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	shadow, err := ss.update(params.SiteID, params.DeviceID, func(shadow *sensormanager.DeviceShadow) (bool, error) {
+		reported := shadow.Reported.Merge(params.Reported)
+		now := time.Now()
+		shadow.ReportedUpdatedAt = &now
+
+		// La date de rapport est toujours mise à jour, la version seulement si la configuration change.
+		changed := !reported.Equal(shadow.Reported)
+		shadow.Reported = reported
+
+		return changed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ss.baseStore.devices.updateAddress(params.DeviceID, &params.ReadingSource); err != nil {
+		return nil, err
+	}
+
+	return shadow, nil
+}
+
+// update applies the change to the shadow of the device within a transaction. The change returns whether the
+// configuration changed, in which case the version is incremented.
+func (ss *shadowsStore) update(
+	siteID, deviceID string,
+	change func(shadow *sensormanager.DeviceShadow) (bool, error),
+) (*sensormanager.DeviceShadow, error) {
+	ctx := context.TODO()
+
+	tx, err := ss.baseStore.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+	defer tx.Rollback()
+
+	if err := checkSiteDevice(ctx, tx, siteID, deviceID); err != nil {
+		return nil, err
+	}
+
+	row, err := loadShadow(ctx, tx, deviceID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	shadow, err := row.toDeviceShadow()
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := change(shadow)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		shadow.Version++
+	}
+
+	desired, _ := json.Marshal(shadow.Desired)
+	reported, _ := json.Marshal(shadow.Reported)
+
+	if _, err := queries.Raw(
+		`INSERT INTO device_shadows (`+shadowColumns+`) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (device_id) DO UPDATE SET desired = EXCLUDED.desired, reported = EXCLUDED.reported, version = EXCLUDED.version,
+		desired_updated_at = EXCLUDED.desired_updated_at, reported_updated_at = EXCLUDED.reported_updated_at`,
+		deviceID, types.JSON(desired), types.JSON(reported), shadow.Version,
+		null.TimeFromPtr(shadow.DesiredUpdatedAt), null.TimeFromPtr(shadow.ReportedUpdatedAt),
+	).ExecContext(ctx, tx); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	shadow.Delta = shadow.Desired.Delta(shadow.Reported)
+
+	return shadow, nil
+}
+
+func checkSiteDevice(ctx context.Context, exec boil.ContextExecutor, siteID, deviceID string) error {
+	var exists bool
+	if err := queries.Raw(
+		"SELECT EXISTS (SELECT 1 FROM devices WHERE site_id = $1 AND device_id = $2)",
+		siteID, deviceID,
+	).QueryRowContext(ctx, exec).Scan(&exists); err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	if !exists {
+		return sensormanager.ErrUnknownDevice
+	}
+
+	return nil
+}
+
+// loadShadow returns the shadow of the device, empty when it has none yet.
+func loadShadow(ctx context.Context, exec boil.ContextExecutor, deviceID string, forUpdate bool) (*shadowRow, error) {
+	query := "SELECT " + shadowColumns + " FROM device_shadows WHERE device_id = $1"
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	row := &shadowRow{}
+	err := queries.Raw(query, deviceID).Bind(ctx, exec, row)
+	if err == sql.ErrNoRows {
+		return &shadowRow{DeviceID: deviceID}, nil
+	}
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return row, nil
+}
+
+func (row *shadowRow) toDeviceShadow() (*sensormanager.DeviceShadow, error) {
+	result := &sensormanager.DeviceShadow{
+		DeviceID: row.DeviceID,
+		Desired:  sensormanager.ShadowDocument{},
+		Reported: sensormanager.ShadowDocument{},
+		Version:  row.Version,
+	}
+
+	if len(row.Desired) > 0 {
+		if err := json.Unmarshal(row.Desired, &result.Desired); err != nil {
+			return nil, err
+		}
+	}
+	if len(row.Reported) > 0 {
+		if err := json.Unmarshal(row.Reported, &result.Reported); err != nil {
+			return nil, err
+		}
+	}
+
+	if row.DesiredUpdatedAt.Valid {
+		result.DesiredUpdatedAt = &row.DesiredUpdatedAt.Time
+	}
+	if row.ReportedUpdatedAt.Valid {
+		result.ReportedUpdatedAt = &row.ReportedUpdatedAt.Time
+	}
+
+	result.Delta = result.Desired.Delta(result.Reported)
+
+	return result, nil
+}
@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sensormanager"
+
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+type sitesStore struct{ baseStore *Store }
+
+var _ sensormanager.SiteManager = (*sitesStore)(nil)
+
+type siteRow struct {
+	ID        string    `boil:"id"`
+	Name      string    `boil:"name"`
+	Armed     bool      `boil:"armed"`
+	CreatedAt null.Time `boil:"created_at"`
+}
+
+const siteColumns = "id, name, armed, created_at"
+
+func (ss *sitesStore) CreateSite(params *sensormanager.CreateSiteParams) (*sensormanager.Site, error) {
+	if err := params.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	row := &siteRow{}
+	if err := queries.Raw(
+		"INSERT INTO sites (id, name) VALUES ($1, $2) RETURNING "+siteColumns,
+		params.ID, params.Name,
+	).Bind(context.TODO(), ss.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return row.toSite(), nil
+}
+
+func (ss *sitesStore) GetSites() ([]*sensormanager.Site, error) {
+	return ss.getSites("SELECT " + siteColumns + " FROM sites ORDER BY id")
+}
+
+func (ss *sitesStore) GetSite(siteID string) (*sensormanager.Site, error) {
+	row := &siteRow{}
+	if err := queries.Raw(
+		"SELECT "+siteColumns+" FROM sites WHERE id = $1",
+		siteID,
+	).Bind(context.TODO(), ss.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return row.toSite(), nil
+}
+
+func (ss *sitesStore) GetUserSites(userID int64) ([]*sensormanager.Site, error) {
+	return ss.getSites(
+		"SELECT s.id, s.name, s.armed, s.created_at FROM sites s JOIN site_members m ON m.site_id = s.id WHERE m.user_id = $1 ORDER BY s.id",
+		userID,
+	)
+}
+
+func (ss *sitesStore) AddSiteMember(siteID string, userID int64) error {
+	_, err := queries.Raw(
+		"INSERT INTO site_members (site_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		siteID, userID,
+	).ExecContext(context.TODO(), ss.baseStore.db)
+
+	return errors.MapSQLError(err)
+}
+
+func (ss *sitesStore) RemoveSiteMember(siteID string, userID int64) error {
+	result, err := queries.Raw(
+		"DELETE FROM site_members WHERE site_id = $1 AND user_id = $2",
+		siteID, userID,
+	).ExecContext(context.TODO(), ss.baseStore.db)
+	if err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.MapSQLError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (ss *sitesStore) SetSiteArmed(siteID string, armed bool) (*sensormanager.Site, error) {
+	var site *sensormanager.Site
+	if err := ss.baseStore.withEvents(func(ctx context.Context, exec boil.ContextExecutor) ([]*sensormanager.Event, error) {
+		row := &siteRow{}
+		if err := queries.Raw(
+			"UPDATE sites SET armed = $1 WHERE id = $2 RETURNING "+siteColumns,
+			armed, siteID,
+		).Bind(ctx, exec, row); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+
+		site = row.toSite()
+
+		return []*sensormanager.Event{sensormanager.NewSiteArmedEvent(site)}, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return site, nil
+}
+
+func (ss *sitesStore) getSites(query string, args ...interface{}) ([]*sensormanager.Site, error) {
+	var rows []*siteRow
+	if err := queries.Raw(query, args...).Bind(context.TODO(), ss.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.Site, len(rows))
+	for i, row := range rows {
+		result[i] = row.toSite()
+	}
+
+	return result, nil
+}
+
+func (row *siteRow) toSite() *sensormanager.Site {
+	return &sensormanager.Site{
+		ID:        row.ID,
+		Name:      row.Name,
+		Armed:     row.Armed,
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
+
+// siteDevicesCondition restreint une requête sur une table ayant une colonne device_id aux appareils du site, le site
+// étant l'argument n.
+func siteDevicesCondition(n int) string {
+	return fmt.Sprintf("device_id IN (SELECT device_id FROM devices WHERE site_id = $%d)", n)
+}
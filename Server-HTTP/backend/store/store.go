@@ -1,25 +1,172 @@
 package store
 
 import (
+	"crypto/rand"
 	"database/sql"
 	"fmt"
 	"sensormanager"
+	"sensormanager/coap"
+	"sensormanager/filter"
+	"sensormanager/motion"
+	"sensormanager/quality"
+	"time"
 )
 
 type Store struct {
 	Sensors       sensormanager.SensorManager
 	Notifications sensormanager.NotificationManager
+	Devices       sensormanager.DeviceManager
+	Escalations   sensormanager.EscalationManager
+	Incidents     sensormanager.IncidentManager
+	Auth          sensormanager.AuthManager
+	Sites         sensormanager.SiteManager
+	Telemetry     sensormanager.TelemetryManager
+	Commands      sensormanager.CommandManager
+	Shadows       sensormanager.ShadowManager
+	Firmware      sensormanager.FirmwareManager
+	Events        sensormanager.EventManager
+	Webhooks      sensormanager.WebhookManager
+	AlertActions  sensormanager.AlertActionManager
+	Thresholds    sensormanager.AlertThresholdManager
+
+	NotificationTemplates sensormanager.NotificationTemplateManager
 
 	db *sql.DB
+
+	distanceFilter   *filter.Filter
+	microphoneFilter *filter.Filter
+	motionDetector   *motion.Detector
+
+	distanceQuality   *quality.Checker
+	microphoneQuality *quality.Checker
+
+	sensors    *sensorsStore
+	devices    *devicesStore
+	events     *eventsStore
+	webhooks   *webhooksStore
+	thresholds *thresholdsStore
+
+	notificationTemplates *notificationTemplatesStore
+
+	// Les alertes d'un même groupe arrivant dans cette fenêtre rejoignent l'incident en cours.
+	incidentWindow time.Duration
+
+	bootstrapAdminKey string
+
+	// Les mesures signées doivent avoir un horodatage à moins de signatureMaxAge de l'heure du serveur.
+	signatureRequired bool
+	signatureMaxAge   time.Duration
+
+	// L'heure fournie par l'appareil doit être comprise entre -maxReadingAge et +maxClockSkew autour de l'heure de
+	// réception. Les mesures plus anciennes que alertHorizon sont enregistrées sans évaluer les alertes.
+	maxClockSkew  time.Duration
+	maxReadingAge time.Duration
+	alertHorizon  time.Duration
+	readingClock  *readingClock
+
+	// État des règles d'alerte des types de capteurs du registre et de la télémétrie
+	ruleTracker *ruleTracker
+
+	// Un signal Wi-Fi sous weakSignalRSSI pendant weakSignalFor, ou rebootLoopCount redémarrages en rebootLoopWindow,
+	// lèvent une alerte de télémétrie.
+	weakSignalRSSI   int
+	weakSignalFor    time.Duration
+	rebootLoopCount  int
+	rebootLoopWindow time.Duration
+
+	// Une mesure identifiée (messageId ou séquence) reçue à nouveau dans cette fenêtre n'est pas réenregistrée. Une
+	// réservation restée sans réponse au-delà de claimLease (ex: redémarrage pendant l'enregistrement) est abandonnée.
+	dedupWindow time.Duration
+	claimLease  time.Duration
+
+	// Envoi des commandes aux appareils
+	coapClient *coap.Client
+
+	// Dossier des binaires de firmware, vide pour les stocker en base
+	firmwareDir string
+
+	// Traitement des mesures des appareils inconnus ou en attente d'approbation
+	unknownDevicePolicy sensormanager.UnknownDevicePolicy
+
+	// Les modifications écrivent leurs événements dans l'outbox, publiée sur NATS
+	eventsEnabled bool
+
+	// Les modifications écrivent les livraisons des webhooks abonnés à leurs événements
+	webhooksEnabled bool
+
+	// Un appareil actif sans mesure ni télémétrie depuis offlineAfter est hors ligne, 0 pour ne pas le détecter.
+	offlineAfter time.Duration
+
+	// Clé de signature et durée de validité des jetons d'action des notifications
+	alertActionSecret []byte
+	alertActionTTL    time.Duration
+
+	// Langue des notifications des tokens enregistrés sans langue
+	notificationLocale sensormanager.Locale
 }
 
 type Option func(*Store) error
 
 func New(options ...Option) *Store {
-	result := &Store{}
+	result := &Store{
+		distanceFilter:   filter.New(filter.Config{}),
+		microphoneFilter: filter.New(filter.Config{}),
+		motionDetector:   motion.New(motion.Config{}),
+
+		distanceQuality:   quality.New(quality.Config{}),
+		microphoneQuality: quality.New(quality.Config{}),
+
+		incidentWindow: 5 * time.Minute,
 
-	result.Sensors = &sensorsStore{baseStore: result}
+		signatureRequired: true,
+		signatureMaxAge:   5 * time.Minute,
+
+		maxClockSkew:  time.Minute,
+		maxReadingAge: 24 * time.Hour,
+		alertHorizon:  2 * time.Minute,
+		readingClock:  newReadingClock(),
+
+		ruleTracker: newRuleTracker(),
+
+		weakSignalRSSI:   -80,
+		weakSignalFor:    5 * time.Minute,
+		rebootLoopCount:  3,
+		rebootLoopWindow: 15 * time.Minute,
+
+		dedupWindow: 10 * time.Minute,
+		claimLease:  30 * time.Second,
+
+		coapClient: coap.New(coap.Config{}),
+
+		unknownDevicePolicy: sensormanager.UnknownDeviceQuarantine,
+
+		alertActionTTL: 30 * time.Minute,
+
+		notificationLocale: sensormanager.LocaleFR,
+	}
+
+	result.sensors = &sensorsStore{baseStore: result}
+	result.Sensors = result.sensors
 	result.Notifications = &notificationsStore{baseStore: result}
+	result.devices = &devicesStore{baseStore: result}
+	result.Devices = result.devices
+	result.Escalations = &escalationsStore{baseStore: result}
+	result.Incidents = &incidentsStore{baseStore: result}
+	result.Auth = &authStore{baseStore: result}
+	result.Sites = &sitesStore{baseStore: result}
+	result.Telemetry = &telemetryStore{baseStore: result}
+	result.Commands = &commandsStore{baseStore: result}
+	result.Shadows = &shadowsStore{baseStore: result}
+	result.Firmware = &firmwareStore{baseStore: result}
+	result.events = &eventsStore{baseStore: result}
+	result.Events = result.events
+	result.webhooks = &webhooksStore{baseStore: result}
+	result.Webhooks = result.webhooks
+	result.AlertActions = &alertActionsStore{baseStore: result}
+	result.thresholds = &thresholdsStore{baseStore: result}
+	result.Thresholds = result.thresholds
+	result.notificationTemplates = &notificationTemplatesStore{baseStore: result}
+	result.NotificationTemplates = result.notificationTemplates
 
 	for _, option := range options {
 		if err := option(result); err != nil {
@@ -27,6 +174,14 @@ func New(options ...Option) *Store {
 		}
 	}
 
+	// 🔑 Sans clé configurée, les jetons émis ne survivent pas au redémarrage.
+	if len(result.alertActionSecret) == 0 {
+		result.alertActionSecret = make([]byte, 32)
+		if _, err := rand.Read(result.alertActionSecret); err != nil {
+			panic(fmt.Errorf("could not create store: %w", err))
+		}
+	}
+
 	return result
 }
 
@@ -41,3 +196,225 @@ func WithDB(db *sql.DB) Option {
 		return nil
 	}
 }
+
+func WithDistanceFilter(config filter.Config) Option {
+	return func(s *Store) error {
+		s.distanceFilter = filter.New(config)
+
+		return nil
+	}
+}
+
+func WithMicrophoneFilter(config filter.Config) Option {
+	return func(s *Store) error {
+		s.microphoneFilter = filter.New(config)
+
+		return nil
+	}
+}
+
+func WithMotionDetector(config motion.Config) Option {
+	return func(s *Store) error {
+		s.motionDetector = motion.New(config)
+
+		return nil
+	}
+}
+
+func WithDistanceQuality(config quality.Config) Option {
+	return func(s *Store) error {
+		s.distanceQuality = quality.New(config)
+
+		return nil
+	}
+}
+
+func WithMicrophoneQuality(config quality.Config) Option {
+	return func(s *Store) error {
+		s.microphoneQuality = quality.New(config)
+
+		return nil
+	}
+}
+
+func WithIncidentWindow(window time.Duration) Option {
+	return func(s *Store) error {
+		if window <= 0 {
+			return fmt.Errorf("incident window must be positive, got %s", window)
+		}
+
+		s.incidentWindow = window
+
+		return nil
+	}
+}
+
+// WithBootstrapAdminKey accepts the given key as an admin API key, so the first users can be created.
+func WithBootstrapAdminKey(key string) Option {
+	return func(s *Store) error {
+		s.bootstrapAdminKey = key
+
+		return nil
+	}
+}
+
+// WithDeviceSignatures configures the verification of signed readings. When signatures are not required, unsigned
+// readings of devices that have not been provisioned are still accepted.
+func WithDeviceSignatures(required bool, maxAge time.Duration) Option {
+	return func(s *Store) error {
+		if maxAge <= 0 {
+			return fmt.Errorf("signature max age must be positive, got %s", maxAge)
+		}
+
+		s.signatureRequired = required
+		s.signatureMaxAge = maxAge
+
+		return nil
+	}
+}
+
+// WithReadingTime configures the readings timestamped by devices: their time must be at most maxClockSkew ahead and
+// maxAge behind the time of reception, and readings older than alertHorizon do not trigger alerts.
+func WithReadingTime(maxClockSkew, maxAge, alertHorizon time.Duration) Option {
+	return func(s *Store) error {
+		if maxClockSkew < 0 || maxAge <= 0 || alertHorizon <= 0 {
+			return fmt.Errorf("invalid reading time bounds: skew %s, max age %s, alert horizon %s", maxClockSkew, maxAge, alertHorizon)
+		}
+
+		s.maxClockSkew = maxClockSkew
+		s.maxReadingAge = maxAge
+		s.alertHorizon = alertHorizon
+
+		return nil
+	}
+}
+
+// WithDedupWindow sets how long the result of an identified reading is kept, to be returned to its retransmissions,
+// and how long a reading being recorded keeps its reservation.
+func WithDedupWindow(window, claimLease time.Duration) Option {
+	return func(s *Store) error {
+		if window <= 0 {
+			return fmt.Errorf("dedup window must be positive, got %s", window)
+		}
+		if claimLease <= 0 || claimLease > window {
+			return fmt.Errorf("claim lease must be positive and within the dedup window, got %s", claimLease)
+		}
+
+		s.dedupWindow = window
+		s.claimLease = claimLease
+
+		return nil
+	}
+}
+
+// WithTelemetryAlerts configures the telemetry alerts: a signal weaker than weakSignalRSSI dBm for weakSignalFor, and
+// rebootLoopCount reboots within rebootLoopWindow.
+func WithTelemetryAlerts(weakSignalRSSI int, weakSignalFor time.Duration, rebootLoopCount int, rebootLoopWindow time.Duration) Option {
+	return func(s *Store) error {
+		if weakSignalFor < 0 || rebootLoopCount <= 0 || rebootLoopWindow <= 0 {
+			return fmt.Errorf("invalid telemetry alerts: weak signal for %s, %d reboots in %s", weakSignalFor, rebootLoopCount, rebootLoopWindow)
+		}
+
+		s.weakSignalRSSI = weakSignalRSSI
+		s.weakSignalFor = weakSignalFor
+		s.rebootLoopCount = rebootLoopCount
+		s.rebootLoopWindow = rebootLoopWindow
+
+		return nil
+	}
+}
+
+// WithCoAP configures the retransmissions of the commands sent to the devices.
+func WithCoAP(config coap.Config) Option {
+	return func(s *Store) error {
+		s.coapClient = coap.New(config)
+
+		return nil
+	}
+}
+
+// WithFirmwareStorage stores the uploaded firmware binaries in the directory instead of the database.
+func WithFirmwareStorage(dir string) Option {
+	return func(s *Store) error {
+		s.firmwareDir = dir
+
+		return nil
+	}
+}
+
+// WithUnknownDevicePolicy sets how the readings of devices that have not been registered are handled.
+func WithUnknownDevicePolicy(policy sensormanager.UnknownDevicePolicy) Option {
+	return func(s *Store) error {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+
+		s.unknownDevicePolicy = policy
+
+		return nil
+	}
+}
+
+// WithEventOutbox writes the events of the readings, alerts and status changes to the outbox, to be published to
+// NATS.
+func WithEventOutbox(enabled bool) Option {
+	return func(s *Store) error {
+		s.eventsEnabled = enabled
+
+		return nil
+	}
+}
+
+// WithWebhooks writes a delivery for each webhook subscribed to the events of the changes, in the transaction of the
+// change.
+func WithWebhooks(enabled bool) Option {
+	return func(s *Store) error {
+		s.webhooksEnabled = enabled
+
+		return nil
+	}
+}
+
+// WithOfflineDetection sets how long an active device may send neither reading nor telemetry before it is marked
+// offline by MarkOfflineDevices. Zero disables the detection, and the tracking of the last readings.
+func WithOfflineDetection(after time.Duration) Option {
+	return func(s *Store) error {
+		if after < 0 {
+			return fmt.Errorf("offline delay must be positive, got %s", after)
+		}
+
+		s.offlineAfter = after
+
+		return nil
+	}
+}
+
+// WithAlertActionTokens sets the key signing the action tokens of the notifications, and how long a token is valid.
+// Without key, a random key is generated at startup.
+func WithAlertActionTokens(secret string, ttl time.Duration) Option {
+	return func(s *Store) error {
+		if ttl <= 0 {
+			return fmt.Errorf("alert action token validity must be positive, got %s", ttl)
+		}
+
+		if secret != "" {
+			s.alertActionSecret = []byte(secret)
+		}
+		s.alertActionTTL = ttl
+
+		return nil
+	}
+}
+
+// WithNotificationLocale sets the locale of the notifications sent to the push tokens registered without locale.
+func WithNotificationLocale(locale sensormanager.Locale) Option {
+	return func(s *Store) error {
+		if err := locale.Validate(); err != nil {
+			return fmt.Errorf("invalid notification locale %q: %w", locale, err)
+		}
+
+		s.notificationLocale = locale
+
+		return nil
+	}
+}
@@ -0,0 +1,370 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sensormanager"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type telemetryStore struct{ baseStore *Store }
+
+var _ sensormanager.TelemetryManager = (*telemetryStore)(nil)
+
+type telemetryRow struct {
+	ID              int64       `boil:"id"`
+	DeviceID        string      `boil:"device_id"`
+	RSSI            null.Int    `boil:"rssi"`
+	UptimeSeconds   null.Int64  `boil:"uptime_seconds"`
+	FreeHeap        null.Int64  `boil:"free_heap"`
+	ResetReason     null.String `boil:"reset_reason"`
+	IPAddress       null.String `boil:"ip_address"`
+	FirmwareVersion null.String `boil:"firmware_version"`
+	Rebooted        bool        `boil:"rebooted"`
+	RecordedAt      null.Time   `boil:"recorded_at"`
+	ReceivedAt      null.Time   `boil:"received_at"`
+}
+
+type telemetryAlertRow struct {
+	ID             int64         `boil:"id"`
+	DeviceID       string        `boil:"device_id"`
+	AlertKind      string        `boil:"alert_kind"`
+	Value          types.Decimal `boil:"value"`
+	Threshold      types.Decimal `boil:"threshold"`
+	Details        null.String   `boil:"details"`
+	AlertStatus    null.String   `boil:"alert_status"`
+	AcknowledgedAt null.Time     `boil:"acknowledged_at"`
+	ResolvedAt     null.Time     `boil:"resolved_at"`
+	CreatedAt      null.Time     `boil:"created_at"`
+}
+
+type latestTelemetryRow struct {
+	UptimeSeconds null.Int64 `boil:"uptime_seconds"`
+	TelemetryAt   null.Time  `boil:"telemetry_at"`
+}
+
+const (
+	telemetryColumns      = "id, device_id, rssi, uptime_seconds, free_heap, reset_reason, ip_address, firmware_version, rebooted, recorded_at, received_at"
+	telemetryAlertColumns = "id, device_id, alert_kind, value, threshold, details, alert_status, acknowledged_at, resolved_at, created_at"
+)
+
+// RecordTelemetry stores the report of a known device and updates its latest values. A reboot is detected when the
+// uptime decreases between two reports.
+func (ts *telemetryStore) RecordTelemetry(params *sensormanager.TelemetryParams) (*sensormanager.TelemetryResponse, error) {
+	receivedAt := time.Now()
+
+	// 🔐 La signature porte sur les valeurs reçues : elle est vérifiée avant toute normalisation.
+	if err := ts.baseStore.devices.verify(
+		params.SiteID, sensormanager.TelemetrySignatureType, params.DeviceID, params.SignedValue(), &params.ReadingTime, nil,
+		&params.SignedPayload, receivedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := params.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	recordedAt, err := ts.baseStore.sensors.readingTime(&params.ReadingTime, receivedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.TODO()
+
+	tx, err := ts.baseStore.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+	defer tx.Rollback()
+
+	// La télémétrie n'enregistre pas d'appareil : il doit avoir envoyé une mesure ou avoir été provisionné.
+	previous := &latestTelemetryRow{}
+	err = queries.Raw(
+		"SELECT uptime_seconds, telemetry_at FROM devices WHERE site_id = $1 AND device_id = $2 FOR UPDATE",
+		params.SiteID, params.DeviceID,
+	).Bind(ctx, tx, previous)
+	if err == sql.ErrNoRows {
+		return nil, sensormanager.ErrUnknownDevice
+	}
+	if err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	// Un rapport arrivé dans le désordre est conservé dans l'historique sans remplacer les dernières valeurs.
+	latest := !previous.TelemetryAt.Valid || !recordedAt.Before(previous.TelemetryAt.Time)
+	rebooted := latest && params.UptimeSeconds != nil && previous.UptimeSeconds.Valid &&
+		*params.UptimeSeconds < previous.UptimeSeconds.Int64
+
+	if _, err := queries.Raw(
+		`INSERT INTO device_telemetry (device_id, rssi, uptime_seconds, free_heap, reset_reason, ip_address, firmware_version, rebooted, recorded_at, received_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		params.DeviceID, null.IntFromPtr(params.RSSI), null.Int64FromPtr(params.UptimeSeconds), null.Int64FromPtr(params.FreeHeap),
+		nullString(params.ResetReason), nullString(params.IPAddress), nullString(params.FirmwareVersion),
+		rebooted, recordedAt, receivedAt,
+	).ExecContext(ctx, tx); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if latest {
+		if _, err := queries.Raw(
+			`UPDATE devices SET rssi = COALESCE($1, rssi), uptime_seconds = COALESCE($2, uptime_seconds), free_heap = COALESCE($3, free_heap),
+			reset_reason = COALESCE($4, reset_reason), ip_address = COALESCE($5, ip_address), firmware_version = COALESCE($6, firmware_version),
+			telemetry_at = $7 WHERE device_id = $8`,
+			null.IntFromPtr(params.RSSI), null.Int64FromPtr(params.UptimeSeconds), null.Int64FromPtr(params.FreeHeap),
+			nullString(params.ResetReason), nullString(params.IPAddress), nullString(params.FirmwareVersion),
+			recordedAt, params.DeviceID,
+		).ExecContext(ctx, tx); err != nil {
+			return nil, errors.MapSQLError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if err := ts.baseStore.devices.updateAddress(params.DeviceID, &params.ReadingSource); err != nil {
+		return nil, err
+	}
+
+	response := &sensormanager.TelemetryResponse{Rebooted: rebooted}
+
+	// ⏱️ Rapport ancien ou arrivé dans le désordre : pas d'évaluation des alertes.
+	if !latest || receivedAt.Sub(recordedAt) > ts.baseStore.alertHorizon {
+		return response, nil
+	}
+
+	if rebooted {
+		alert, err := ts.checkRebootLoop(params.DeviceID, recordedAt, receivedAt)
+		if err != nil {
+			return nil, err
+		}
+		if alert != nil {
+			response.Alerts = append(response.Alerts, alert)
+		}
+	}
+
+	if params.RSSI != nil {
+		alert, err := ts.checkWeakSignal(params.DeviceID, *params.RSSI, recordedAt)
+		if err != nil {
+			return nil, err
+		}
+		if alert != nil {
+			response.Alerts = append(response.Alerts, alert)
+		}
+	}
+
+	events := make([]*sensormanager.Event, len(response.Alerts))
+	for i, alert := range response.Alerts {
+		alert.SiteID = params.SiteID
+		events[i] = sensormanager.NewAlertCreatedEvent(sensormanager.AlertTypeTelemetry, params.SiteID, alert)
+	}
+
+	if err := ts.baseStore.events.add(context.TODO(), ts.baseStore.db, events...); err != nil {
+		return nil, err
+	}
+
+	for _, alert := range response.Alerts {
+		ts.baseStore.attachIncident(sensormanager.AlertTypeTelemetry, alert)
+	}
+
+	return response, nil
+}
+
+// checkRebootLoop raises an alert when the device rebooted too many times within the window, once per loop.
+func (ts *telemetryStore) checkRebootLoop(deviceID string, recordedAt, receivedAt time.Time) (*sensormanager.AlertResponse, error) {
+	ctx := context.TODO()
+	window := ts.baseStore.rebootLoopWindow
+
+	var reboots int
+	if err := queries.Raw(
+		"SELECT COUNT(*) FROM device_telemetry WHERE device_id = $1 AND rebooted AND recorded_at >= $2",
+		deviceID, recordedAt.Add(-window),
+	).QueryRowContext(ctx, ts.baseStore.db).Scan(&reboots); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if reboots < ts.baseStore.rebootLoopCount {
+		return nil, nil
+	}
+
+	var alreadyRaised bool
+	if err := queries.Raw(
+		"SELECT EXISTS (SELECT 1 FROM telemetry_alerts WHERE device_id = $1 AND alert_kind = $2 AND alert_status <> $3 AND created_at >= $4)",
+		deviceID, string(sensormanager.TelemetryAlertRebootLoop), string(sensormanager.AlertStatusResolved), receivedAt.Add(-window),
+	).QueryRowContext(ctx, ts.baseStore.db).Scan(&alreadyRaised); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	if alreadyRaised {
+		return nil, nil
+	}
+
+	return ts.insertAlert(
+		deviceID, sensormanager.TelemetryAlertRebootLoop, float64(reboots), float64(ts.baseStore.rebootLoopCount),
+		fmt.Sprintf("Reboot loop detected: %d reboots in %s", reboots, window), recordedAt,
+	)
+}
+
+// checkWeakSignal raises an alert when the signal stayed weak for the configured duration, once until it recovers.
+func (ts *telemetryStore) checkWeakSignal(deviceID string, rssi int, recordedAt time.Time) (*sensormanager.AlertResponse, error) {
+	rule := &sensormanager.AlertRule{
+		Name:      string(sensormanager.TelemetryAlertWeakSignal),
+		Condition: sensormanager.AlertConditionBelow,
+		Threshold: float64(ts.baseStore.weakSignalRSSI),
+		For:       ts.baseStore.weakSignalFor,
+	}
+
+	if triggered, _ := ts.baseStore.ruleTracker.observe("telemetry/"+deviceID+"/"+rule.Name, rule, float64(rssi), recordedAt); !triggered {
+		return nil, nil
+	}
+
+	return ts.insertAlert(
+		deviceID, sensormanager.TelemetryAlertWeakSignal, float64(rssi), rule.Threshold,
+		fmt.Sprintf("Weak Wi-Fi signal: %d dBm", rssi), recordedAt,
+	)
+}
+
+func (ts *telemetryStore) insertAlert(
+	deviceID string,
+	kind sensormanager.TelemetryAlertKind,
+	value, threshold float64,
+	message string,
+	recordedAt time.Time,
+) (*sensormanager.AlertResponse, error) {
+	// 💾 Enregistrer l'alerte dans la DB
+	row := &telemetryAlertRow{}
+	if err := queries.Raw(
+		"INSERT INTO telemetry_alerts (device_id, alert_kind, value, threshold, details, alert_status) VALUES ($1, $2, $3, $4, $5, $6) RETURNING "+telemetryAlertColumns,
+		deviceID, string(kind),
+		types.NewDecimal(new(decimal.Big).SetFloat64(value)),
+		types.NewDecimal(new(decimal.Big).SetFloat64(threshold)),
+		message, string(sensormanager.AlertStatusActive),
+	).Bind(context.TODO(), ts.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return &sensormanager.AlertResponse{
+		Alert:      true,
+		AlertID:    row.ID,
+		Message:    message,
+		Value:      value,
+		Threshold:  threshold,
+		DeviceID:   deviceID,
+		Event:      string(kind),
+		RecordedAt: recordedAt,
+	}, nil
+}
+
+func (ts *telemetryStore) GetTelemetryHistory(siteID, deviceID string, limit int) ([]*sensormanager.DeviceTelemetry, error) {
+	var rows []*telemetryRow
+	if err := queries.Raw(
+		"SELECT "+telemetryColumns+" FROM device_telemetry WHERE device_id = $1 AND "+siteDevicesCondition(2)+" ORDER BY recorded_at DESC LIMIT $3",
+		deviceID, siteID, limit,
+	).Bind(context.TODO(), ts.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.DeviceTelemetry, len(rows))
+	for i, row := range rows {
+		result[i] = &sensormanager.DeviceTelemetry{
+			ID:              row.ID,
+			DeviceID:        row.DeviceID,
+			RSSI:            row.RSSI.Ptr(),
+			UptimeSeconds:   row.UptimeSeconds.Ptr(),
+			FreeHeap:        row.FreeHeap.Ptr(),
+			ResetReason:     row.ResetReason.String,
+			IPAddress:       row.IPAddress.String,
+			FirmwareVersion: row.FirmwareVersion.String,
+			Rebooted:        row.Rebooted,
+			RecordedAt:      row.RecordedAt.Time,
+			ReceivedAt:      row.ReceivedAt.Time,
+		}
+	}
+
+	return result, nil
+}
+
+// ============= TELEMETRY ALERTS =============
+
+func (ts *telemetryStore) GetTelemetryAlerts(params *sensormanager.GetAlertsParams) ([]*sensormanager.TelemetryAlert, error) {
+	query := "SELECT " + telemetryAlertColumns + " FROM telemetry_alerts WHERE device_id = $1 AND " + siteDevicesCondition(2)
+	args := []interface{}{params.DeviceID, params.SiteID}
+
+	if params.Status != "" {
+		args = append(args, string(params.Status))
+		query += fmt.Sprintf(" AND alert_status = $%d", len(args))
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	if params.Limit > 0 {
+		args = append(args, params.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	var rows []*telemetryAlertRow
+	if err := queries.Raw(query, args...).Bind(context.TODO(), ts.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.TelemetryAlert, len(rows))
+	for i, row := range rows {
+		result[i] = row.toTelemetryAlert()
+	}
+
+	return result, nil
+}
+
+func (ts *telemetryStore) UpdateTelemetryAlertStatus(params *sensormanager.UpdateAlertStatusParams) error {
+	query := "UPDATE telemetry_alerts SET alert_status = $1 WHERE id = $2 AND " + siteDevicesCondition(3)
+	args := []interface{}{string(params.Status), params.AlertID, params.SiteID}
+
+	switch params.Status {
+	case sensormanager.AlertStatusAcknowledged:
+		query = "UPDATE telemetry_alerts SET alert_status = $1, acknowledged_at = $4 WHERE id = $2 AND " + siteDevicesCondition(3)
+		args = append(args, time.Now())
+	case sensormanager.AlertStatusResolved:
+		query = "UPDATE telemetry_alerts SET alert_status = $1, resolved_at = $4 WHERE id = $2 AND " + siteDevicesCondition(3)
+		args = append(args, time.Now())
+	}
+
+	return ts.baseStore.updateAlertStatus(sensormanager.AlertTypeTelemetry, params, query+" RETURNING device_id", args...)
+}
+
+func (row *telemetryAlertRow) toTelemetryAlert() *sensormanager.TelemetryAlert {
+	value, _ := row.Value.Float64()
+	threshold, _ := row.Threshold.Float64()
+
+	var ackAt, resAt *time.Time
+	if row.AcknowledgedAt.Valid {
+		ackAt = &row.AcknowledgedAt.Time
+	}
+	if row.ResolvedAt.Valid {
+		resAt = &row.ResolvedAt.Time
+	}
+
+	return &sensormanager.TelemetryAlert{
+		ID:             row.ID,
+		DeviceID:       row.DeviceID,
+		Kind:           sensormanager.TelemetryAlertKind(row.AlertKind),
+		Value:          value,
+		Threshold:      threshold,
+		Details:        row.Details.String,
+		AlertStatus:    sensormanager.AlertStatus(row.AlertStatus.String),
+		AcknowledgedAt: ackAt,
+		ResolvedAt:     resAt,
+		CreatedAt:      row.CreatedAt.Time,
+	}
+}
+
+func nullString(value string) null.String {
+	return null.NewString(value, value != "")
+}
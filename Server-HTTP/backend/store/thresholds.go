@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"sensormanager"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type thresholdsStore struct{ baseStore *Store }
+
+var _ sensormanager.AlertThresholdManager = (*thresholdsStore)(nil)
+
+type alertThresholdRow struct {
+	SiteID     string        `boil:"site_id"`
+	SensorType string        `boil:"sensor_type"`
+	Rule       string        `boil:"rule"`
+	Threshold  types.Decimal `boil:"threshold"`
+	UpdatedAt  null.Time     `boil:"updated_at"`
+}
+
+const alertThresholdColumns = "site_id, sensor_type, rule, threshold, updated_at"
+
+func (ts *thresholdsStore) GetAlertThresholds(siteID string) ([]*sensormanager.AlertThreshold, error) {
+	var rows []*alertThresholdRow
+	if err := queries.Raw(
+		"SELECT "+alertThresholdColumns+" FROM alert_thresholds WHERE site_id = $1 ORDER BY sensor_type, rule",
+		siteID,
+	).Bind(context.TODO(), ts.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.AlertThreshold, len(rows))
+	for i, row := range rows {
+		result[i] = row.toAlertThreshold()
+	}
+
+	return result, nil
+}
+
+func (ts *thresholdsStore) SetAlertThreshold(params *sensormanager.AlertThresholdParams) (*sensormanager.AlertThreshold, error) {
+	if err := params.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	row := &alertThresholdRow{}
+	if err := queries.Raw(
+		"INSERT INTO alert_thresholds (site_id, sensor_type, rule, threshold, updated_at) VALUES ($1, $2, $3, $4, $5) "+
+			"ON CONFLICT (site_id, sensor_type, rule) DO UPDATE SET threshold = EXCLUDED.threshold, updated_at = EXCLUDED.updated_at "+
+			"RETURNING "+alertThresholdColumns,
+		params.SiteID, string(params.SensorType), params.Rule, types.NewDecimal(new(decimal.Big).SetFloat64(params.Threshold)), time.Now(),
+	).Bind(context.TODO(), ts.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return row.toAlertThreshold(), nil
+}
+
+func (ts *thresholdsStore) ResetAlertThreshold(siteID string, sensorType sensormanager.SensorType, rule string) error {
+	result, err := queries.Raw(
+		"DELETE FROM alert_thresholds WHERE site_id = $1 AND sensor_type = $2 AND rule = $3",
+		siteID, string(sensorType), rule,
+	).ExecContext(context.TODO(), ts.baseStore.db)
+	if err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.MapSQLError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// siteThresholds returns the thresholds replaced by the site for the rules of the sensor type, by rule.
+func (ts *thresholdsStore) siteThresholds(siteID string, sensorType sensormanager.SensorType) (map[string]float64, error) {
+	var rows []*alertThresholdRow
+	if err := queries.Raw(
+		"SELECT "+alertThresholdColumns+" FROM alert_thresholds WHERE site_id = $1 AND sensor_type = $2",
+		siteID, string(sensorType),
+	).Bind(context.TODO(), ts.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		result[row.Rule], _ = row.Threshold.Float64()
+	}
+
+	return result, nil
+}
+
+// threshold returns the threshold of the rule for the site: the one it replaced, or the default one.
+func (ts *thresholdsStore) threshold(siteID string, sensorType sensormanager.SensorType, rule string, fallback float64) (float64, error) {
+	thresholds, err := ts.siteThresholds(siteID, sensorType)
+	if err != nil {
+		return 0, err
+	}
+
+	if threshold, exists := thresholds[rule]; exists {
+		return threshold, nil
+	}
+
+	return fallback, nil
+}
+
+func (row *alertThresholdRow) toAlertThreshold() *sensormanager.AlertThreshold {
+	threshold, _ := row.Threshold.Float64()
+
+	return &sensormanager.AlertThreshold{
+		SiteID:     row.SiteID,
+		SensorType: sensormanager.SensorType(row.SensorType),
+		Rule:       row.Rule,
+		Threshold:  threshold,
+		UpdatedAt:  row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,210 @@
+package store
+
+import (
+	"database/sql/driver"
+	"sensormanager"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func expectSiteThresholds(mock sqlmock.Sqlmock, siteID string, sensorType sensormanager.SensorType, rule string, threshold float64) {
+	rows := sqlmock.NewRows([]string{"site_id", "sensor_type", "rule", "threshold", "updated_at"})
+	if rule != "" {
+		rows.AddRow(siteID, string(sensorType), rule, threshold, time.Now())
+	}
+
+	expectQuery(mock, "FROM alert_thresholds WHERE site_id = $1 AND sensor_type = $2").
+		WithArgs(siteID, string(sensorType)).
+		WillReturnRows(rows)
+}
+
+func TestSiteThresholdOfBuiltinAlert(t *testing.T) {
+	store, mock := newMockStore(t)
+	definition, err := sensormanager.LookupSensorType(sensormanager.SensorTypeMicrophone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	reading := func(siteID, deviceID string) *pendingReading {
+		return &pendingReading{
+			sensorType: sensormanager.SensorTypeMicrophone,
+			siteID:     siteID,
+			deviceID:   deviceID,
+			value:      45,
+			dataID:     1,
+			recordedAt: now,
+			receivedAt: now,
+		}
+	}
+
+	// Le site "home" abaisse le seuil de bruit à 40 dB : 45 dB y déclenche une alerte, mais pas sur "office".
+	expectSiteThresholds(mock, "home", sensormanager.SensorTypeMicrophone, sensormanager.AlertRuleHighNoise, 40)
+	expectQuery(mock, "INSERT INTO sensor_alerts").
+		WithArgs("microphone", "ESP_THRESHOLD_HOME", int64(1), "high_noise", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "active").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(30))
+
+	response, err := store.sensors.checkAlertRules(definition, reading("home", "ESP_THRESHOLD_HOME"), 45)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !response.Alert || response.Threshold != 40 {
+		t.Errorf("expected an alert with the threshold of the site, got %+v", response)
+	}
+
+	expectSiteThresholds(mock, "office", sensormanager.SensorTypeMicrophone, "", 0)
+
+	response, err = store.sensors.checkAlertRules(definition, reading("office", "ESP_THRESHOLD_OFFICE"), 45)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Alert {
+		t.Errorf("expected the default threshold on another site, got %+v", response)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSiteThresholdOfRegistryRule(t *testing.T) {
+	store, mock := newMockStore(t)
+	definition, err := sensormanager.LookupSensorType(sensormanager.SensorTypeTemperature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	reading := func(siteID, deviceID string) *pendingReading {
+		return &pendingReading{
+			sensorType: sensormanager.SensorTypeTemperature,
+			siteID:     siteID,
+			deviceID:   deviceID,
+			value:      4,
+			dataID:     1,
+			recordedAt: now,
+			receivedAt: now,
+		}
+	}
+
+	// Le site "home" relève le seuil de gel à 5 °C : 4 °C y déclenche l'alerte, pas sur "office" où le seuil est de 3 °C.
+	expectSiteThresholds(mock, "home", sensormanager.SensorTypeTemperature, "frost", 5)
+	expectQuery(mock, "INSERT INTO sensor_alerts").
+		WithArgs("temperature", "ESP_FROST_HOME", int64(1), "frost", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "active").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(40))
+
+	response, err := store.sensors.evaluateReading(definition, reading("home", "ESP_FROST_HOME"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !response.Alert || response.Event != "frost" || response.Threshold != 5 {
+		t.Errorf("expected a frost alert with the threshold of the site, got %+v", response)
+	}
+
+	expectSiteThresholds(mock, "office", sensormanager.SensorTypeTemperature, "", 0)
+
+	response, err = store.sensors.evaluateReading(definition, reading("office", "ESP_FROST_OFFICE"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Alert {
+		t.Errorf("expected the default threshold on another site, got %+v", response)
+	}
+
+	// La définition partagée garde son seuil par défaut.
+	if definition.Rules[0].Threshold != sensormanager.FrostThresholdC {
+		t.Errorf("the threshold of the definition changed to %g", definition.Rules[0].Threshold)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetAlertThresholdValidates(t *testing.T) {
+	store, _ := newMockStore(t)
+
+	for _, params := range []*sensormanager.AlertThresholdParams{
+		{SensorType: sensormanager.SensorTypeMicrophone, Rule: sensormanager.AlertRuleHighNoise, Threshold: 40},
+		{SiteID: "home", SensorType: sensormanager.SensorTypeMicrophone, Rule: "frost", Threshold: 40},
+		{SiteID: "home", SensorType: sensormanager.SensorTypeMotion, Rule: "motion", Threshold: 1},
+		{SiteID: "home", SensorType: "unknown", Rule: "frost", Threshold: 1},
+	} {
+		if _, err := store.Thresholds.SetAlertThreshold(params); err == nil {
+			t.Errorf("expected threshold %+v to be refused", params)
+		}
+	}
+}
+
+// TestSiteFilters checks that the queries of a site only read the rows of its devices, other sites' rows being
+// excluded by the database.
+func TestSiteFilters(t *testing.T) {
+	tests := map[string]struct {
+		query string
+		args  []driver.Value
+		call  func(*Store) (int, error)
+	}{
+		"sensor readings": {
+			query: "FROM sensor_readings WHERE sensor_type = $1 AND device_id = $2 AND device_id IN (SELECT device_id FROM devices WHERE site_id = $3)",
+			args:  []driver.Value{"microphone", "ESP_001", "office", 10},
+			call: func(store *Store) (int, error) {
+				readings, err := store.Sensors.GetReadingHistory("office", sensormanager.SensorTypeMicrophone, "ESP_001", 10)
+				return len(readings), err
+			},
+		},
+		"sensor alerts": {
+			query: "FROM sensor_alerts WHERE sensor_type = $1 AND device_id = $2 AND device_id IN (SELECT device_id FROM devices WHERE site_id = $3)",
+			args:  []driver.Value{"distance", "ESP_001", "office"},
+			call: func(store *Store) (int, error) {
+				alerts, err := store.Sensors.GetSensorAlerts(sensormanager.SensorTypeDistance, &sensormanager.GetAlertsParams{SiteID: "office", DeviceID: "ESP_001"})
+				return len(alerts), err
+			},
+		},
+		"sensor fault alerts": {
+			query: "FROM sensor_fault_alerts WHERE device_id = $1 AND device_id IN (SELECT device_id FROM devices WHERE site_id = $2)",
+			args:  []driver.Value{"ESP_001", "office"},
+			call: func(store *Store) (int, error) {
+				alerts, err := store.Sensors.GetSensorFaultAlerts(&sensormanager.GetAlertsParams{SiteID: "office", DeviceID: "ESP_001"})
+				return len(alerts), err
+			},
+		},
+		"incidents": {
+			query: "FROM incidents WHERE site_id = $1",
+			args:  []driver.Value{"office"},
+			call: func(store *Store) (int, error) {
+				incidents, err := store.Incidents.GetIncidents(&sensormanager.GetIncidentsParams{SiteID: "office"})
+				return len(incidents), err
+			},
+		},
+		"push tokens": {
+			query: `"push_tokens"."site_id" = $1`,
+			args:  []driver.Value{"office", true},
+			call: func(store *Store) (int, error) {
+				tokens, err := store.Notifications.GetActivePushTokens("office")
+				return len(tokens), err
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			store, mock := newMockStore(t)
+
+			expectQuery(mock, test.query).WithArgs(test.args...).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			count, err := test.call(store)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != 0 {
+				t.Errorf("expected no rows of the site, got %d", count)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sensormanager"
+	"time"
+
+	"github.com/loungeup/go-loungeup/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type webhooksStore struct{ baseStore *Store }
+
+var _ sensormanager.WebhookManager = (*webhooksStore)(nil)
+
+type webhookRow struct {
+	ID        int64             `boil:"id"`
+	SiteID    string            `boil:"site_id"`
+	Name      string            `boil:"name"`
+	URL       string            `boil:"url"`
+	Events    types.StringArray `boil:"events"`
+	IsActive  bool              `boil:"is_active"`
+	CreatedAt null.Time         `boil:"created_at"`
+}
+
+type webhookDeliveryRow struct {
+	ID             int64       `boil:"id"`
+	WebhookID      int64       `boil:"webhook_id"`
+	Event          string      `boil:"event"`
+	Version        int         `boil:"version"`
+	SiteID         string      `boil:"site_id"`
+	Data           null.JSON   `boil:"data"`
+	OccurredAt     time.Time   `boil:"occurred_at"`
+	Status         string      `boil:"status"`
+	Attempts       int         `boil:"attempts"`
+	NextAttemptAt  time.Time   `boil:"next_attempt_at"`
+	LastStatusCode null.Int    `boil:"last_status_code"`
+	LastError      null.String `boil:"last_error"`
+	DeliveredAt    null.Time   `boil:"delivered_at"`
+	CreatedAt      null.Time   `boil:"created_at"`
+}
+
+// claimedDeliveryRow is a delivery with the URL and secret of its webhook.
+type claimedDeliveryRow struct {
+	webhookDeliveryRow `boil:",bind"`
+	URL                string `boil:"url"`
+	Secret             string `boil:"secret"`
+}
+
+const (
+	webhookColumns         = "id, site_id, name, url, events, is_active, created_at"
+	webhookDeliveryColumns = "id, webhook_id, event, version, site_id, data, occurred_at, status, attempts, next_attempt_at, " +
+		"last_status_code, last_error, delivered_at, created_at"
+)
+
+func (ws *webhooksStore) CreateWebhook(params *sensormanager.WebhookParams) (*sensormanager.Webhook, string, error) {
+	if err := params.Sanitize(); err != nil {
+		return nil, "", err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+
+	row := &webhookRow{}
+	if err := queries.Raw(
+		"INSERT INTO webhooks (site_id, name, url, secret, events, is_active) VALUES ($1, $2, $3, $4, $5, $6) RETURNING "+webhookColumns,
+		params.SiteID, params.Name, params.URL, hex.EncodeToString(secret), types.StringArray(params.Events), params.IsActive,
+	).Bind(context.TODO(), ws.baseStore.db, row); err != nil {
+		return nil, "", errors.MapSQLError(err)
+	}
+
+	return row.toWebhook(), hex.EncodeToString(secret), nil
+}
+
+func (ws *webhooksStore) GetWebhooks(siteID string) ([]*sensormanager.Webhook, error) {
+	var rows []*webhookRow
+	if err := queries.Raw(
+		"SELECT "+webhookColumns+" FROM webhooks WHERE site_id = $1 ORDER BY id",
+		siteID,
+	).Bind(context.TODO(), ws.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.Webhook, len(rows))
+	for i, row := range rows {
+		result[i] = row.toWebhook()
+	}
+
+	return result, nil
+}
+
+// UpdateWebhook replaces the name, URL, events and state of the webhook. Its secret and pending deliveries are kept.
+func (ws *webhooksStore) UpdateWebhook(webhookID int64, params *sensormanager.WebhookParams) (*sensormanager.Webhook, error) {
+	if err := params.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	row := &webhookRow{}
+	if err := queries.Raw(
+		"UPDATE webhooks SET name = $1, url = $2, events = $3, is_active = $4 WHERE id = $5 AND site_id = $6 RETURNING "+webhookColumns,
+		params.Name, params.URL, types.StringArray(params.Events), params.IsActive, webhookID, params.SiteID,
+	).Bind(context.TODO(), ws.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return row.toWebhook(), nil
+}
+
+// DeleteWebhook drops the webhook and its delivery history.
+func (ws *webhooksStore) DeleteWebhook(siteID string, webhookID int64) error {
+	result, err := queries.Raw(
+		"DELETE FROM webhooks WHERE id = $1 AND site_id = $2",
+		webhookID, siteID,
+	).ExecContext(context.TODO(), ws.baseStore.db)
+	if err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.MapSQLError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries returns the most recent deliveries first.
+func (ws *webhooksStore) GetWebhookDeliveries(params *sensormanager.GetWebhookDeliveriesParams) ([]*sensormanager.WebhookDelivery, error) {
+	query := "SELECT " + webhookDeliveryColumns + " FROM webhook_deliveries WHERE site_id = $1"
+	args := []interface{}{params.SiteID}
+
+	if params.WebhookID != 0 {
+		args = append(args, params.WebhookID)
+		query += fmt.Sprintf(" AND webhook_id = $%d", len(args))
+	}
+
+	if params.Status != "" {
+		if err := params.Status.Validate(); err != nil {
+			return nil, err
+		}
+
+		args = append(args, string(params.Status))
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+
+	query += " ORDER BY id DESC"
+
+	if params.Limit > 0 {
+		args = append(args, params.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	var rows []*webhookDeliveryRow
+	if err := queries.Raw(query, args...).Bind(context.TODO(), ws.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		result[i] = row.toWebhookDelivery()
+	}
+
+	return result, nil
+}
+
+func (ws *webhooksStore) AddTestDelivery(siteID string, webhookID int64, lease time.Duration) (*sensormanager.WebhookDelivery, error) {
+	now := time.Now()
+
+	data, err := json.Marshal(map[string]interface{}{"webhookId": webhookID})
+	if err != nil {
+		return nil, err
+	}
+
+	row := &claimedDeliveryRow{}
+	if err := queries.Raw(
+		"WITH webhook AS (SELECT id, site_id, url, secret FROM webhooks WHERE id = $1 AND site_id = $2), "+
+			"delivery AS (INSERT INTO webhook_deliveries (webhook_id, event, version, site_id, data, occurred_at, next_attempt_at) "+
+			"SELECT id, $3, $4, site_id, $5, $6, $7 FROM webhook RETURNING "+webhookDeliveryColumns+") "+
+			"SELECT delivery.*, webhook.url, webhook.secret FROM delivery, webhook",
+		webhookID, siteID, string(sensormanager.WebhookEventPing), sensormanager.EventVersion, null.JSONFrom(data), now, now.Add(lease),
+	).Bind(context.TODO(), ws.baseStore.db, row); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	return row.toClaimedDelivery(), nil
+}
+
+func (ws *webhooksStore) RedeliverWebhookDelivery(siteID string, deliveryID int64) error {
+	var status string
+	if err := queries.Raw(
+		"SELECT status FROM webhook_deliveries WHERE id = $1 AND site_id = $2",
+		deliveryID, siteID,
+	).QueryRowContext(context.TODO(), ws.baseStore.db).Scan(&status); err != nil {
+		return errors.MapSQLError(err)
+	}
+
+	if status != string(sensormanager.WebhookDeliveryDead) {
+		return sensormanager.ErrWebhookDeliveryNotDead
+	}
+
+	// Les tentatives repartent de zéro, avec le même délai que la première livraison.
+	_, err := queries.Raw(
+		"UPDATE webhook_deliveries SET status = $1, attempts = 0, next_attempt_at = $2 WHERE id = $3 AND status = $4",
+		string(sensormanager.WebhookDeliveryPending), time.Now(), deliveryID, string(sensormanager.WebhookDeliveryDead),
+	).ExecContext(context.TODO(), ws.baseStore.db)
+
+	return errors.MapSQLError(err)
+}
+
+func (ws *webhooksStore) ClaimWebhookDeliveries(limit int, lease time.Duration) ([]*sensormanager.WebhookDelivery, error) {
+	now := time.Now()
+
+	var rows []*claimedDeliveryRow
+	if err := queries.Raw(
+		"WITH due AS ("+
+			"SELECT id FROM webhook_deliveries WHERE status = $1 AND next_attempt_at <= $2 ORDER BY id LIMIT $3 FOR UPDATE SKIP LOCKED"+
+			") UPDATE webhook_deliveries d SET next_attempt_at = $4 FROM due, webhooks w WHERE d.id = due.id AND w.id = d.webhook_id "+
+			"RETURNING d.id, d.webhook_id, d.event, d.version, d.site_id, d.data, d.occurred_at, d.status, d.attempts, "+
+			"d.next_attempt_at, d.last_status_code, d.last_error, d.delivered_at, d.created_at, w.url, w.secret",
+		string(sensormanager.WebhookDeliveryPending), now, limit, now.Add(lease),
+	).Bind(context.TODO(), ws.baseStore.db, &rows); err != nil {
+		return nil, errors.MapSQLError(err)
+	}
+
+	result := make([]*sensormanager.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		result[i] = row.toClaimedDelivery()
+	}
+
+	return result, nil
+}
+
+func (ws *webhooksStore) RecordWebhookAttempt(deliveryID int64, attempt *sensormanager.WebhookAttempt) error {
+	now := time.Now()
+
+	status := sensormanager.WebhookDeliveryPending
+	nextAttemptAt := now
+	var deliveredAt null.Time
+
+	switch {
+	case attempt.Delivered:
+		status = sensormanager.WebhookDeliveryDelivered
+		deliveredAt = null.TimeFrom(now)
+	case attempt.NextAttemptAt == nil:
+		status = sensormanager.WebhookDeliveryDead
+	default:
+		nextAttemptAt = *attempt.NextAttemptAt
+	}
+
+	_, err := queries.Raw(
+		"UPDATE webhook_deliveries SET status = $1, attempts = attempts + 1, next_attempt_at = $2, last_status_code = $3, "+
+			"last_error = $4, delivered_at = $5 WHERE id = $6",
+		string(status), nextAttemptAt, null.IntFromPtr(attempt.StatusCode), null.NewString(attempt.Error, attempt.Error != ""),
+		deliveredAt, deliveryID,
+	).ExecContext(context.TODO(), ws.baseStore.db)
+
+	return errors.MapSQLError(err)
+}
+
+func (ws *webhooksStore) DeleteWebhookDeliveries(before time.Time) (int64, error) {
+	result, err := queries.Raw(
+		"DELETE FROM webhook_deliveries WHERE status <> $1 AND created_at < $2",
+		string(sensormanager.WebhookDeliveryPending), before,
+	).ExecContext(context.TODO(), ws.baseStore.db)
+	if err != nil {
+		return 0, errors.MapSQLError(err)
+	}
+
+	deleted, _ := result.RowsAffected()
+
+	return deleted, nil
+}
+
+// addDeliveries adds a delivery of the event to each active webhook of its site subscribed to it, with the executor
+// of the change the event describes.
+func (ws *webhooksStore) addDeliveries(ctx context.Context, exec boil.ContextExecutor, event *sensormanager.Event, data []byte) error {
+	webhookEvent := sensormanager.WebhookEventOf(event)
+	if webhookEvent == "" {
+		return nil
+	}
+
+	_, err := queries.Raw(
+		"INSERT INTO webhook_deliveries (webhook_id, event, version, site_id, data, occurred_at, next_attempt_at) "+
+			"SELECT id, $1, $2, site_id, $3, $4, $5 FROM webhooks WHERE site_id = $6 AND is_active AND $1 = ANY(events)",
+		string(webhookEvent), event.Version, null.JSONFrom(data), event.OccurredAt, time.Now(), event.SiteID,
+	).ExecContext(ctx, exec)
+
+	return errors.MapSQLError(err)
+}
+
+func (row *webhookRow) toWebhook() *sensormanager.Webhook {
+	events := make([]sensormanager.WebhookEvent, len(row.Events))
+	for i, event := range row.Events {
+		events[i] = sensormanager.WebhookEvent(event)
+	}
+
+	return &sensormanager.Webhook{
+		ID:        row.ID,
+		SiteID:    row.SiteID,
+		Name:      row.Name,
+		URL:       row.URL,
+		Events:    events,
+		IsActive:  row.IsActive,
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
+
+func (row *webhookDeliveryRow) toWebhookDelivery() *sensormanager.WebhookDelivery {
+	var lastStatusCode *int
+	if row.LastStatusCode.Valid {
+		lastStatusCode = &row.LastStatusCode.Int
+	}
+
+	return &sensormanager.WebhookDelivery{
+		ID:             row.ID,
+		WebhookID:      row.WebhookID,
+		Event:          sensormanager.WebhookEvent(row.Event),
+		Version:        row.Version,
+		SiteID:         row.SiteID,
+		Data:           json.RawMessage(row.Data.JSON),
+		OccurredAt:     row.OccurredAt,
+		Status:         sensormanager.WebhookDeliveryStatus(row.Status),
+		Attempts:       row.Attempts,
+		NextAttemptAt:  row.NextAttemptAt,
+		LastStatusCode: lastStatusCode,
+		LastError:      row.LastError.String,
+		DeliveredAt:    row.DeliveredAt.Ptr(),
+		CreatedAt:      row.CreatedAt.Time,
+	}
+}
+
+func (row *claimedDeliveryRow) toClaimedDelivery() *sensormanager.WebhookDelivery {
+	result := row.webhookDeliveryRow.toWebhookDelivery()
+	result.URL = row.URL
+	result.Secret = row.Secret
+
+	return result
+}
@@ -0,0 +1,141 @@
+package sensormanager
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TelemetrySignatureType is the sensor type of the signed message of a telemetry report, whose value is returned by
+// TelemetryParams.SignedValue.
+const TelemetrySignatureType SensorType = "telemetry"
+
+// TelemetryParams is a diagnostic report of a device. Every metric is optional, the last known value being kept.
+type TelemetryParams struct {
+	SiteID          string `json:"siteId"`
+	DeviceID        string `json:"deviceId"`
+	RSSI            *int   `json:"rssi,omitempty"` // dBm
+	UptimeSeconds   *int64 `json:"uptimeSeconds,omitempty"`
+	FreeHeap        *int64 `json:"freeHeap,omitempty"`    // Octets
+	ResetReason     string `json:"resetReason,omitempty"` // ex: ESP.getResetReason()
+	IPAddress       string `json:"ipAddress,omitempty"`
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+
+	ReadingTime
+	SignedPayload
+	ReadingSource
+}
+
+func (p *TelemetryParams) Sanitize() error {
+	if p.SiteID == "" {
+		return errors.New("siteId is required")
+	}
+	if p.DeviceID == "" {
+		return errors.New("deviceId is required")
+	}
+
+	p.ResetReason = strings.TrimSpace(p.ResetReason)
+	p.IPAddress = strings.TrimSpace(p.IPAddress)
+	p.FirmwareVersion = strings.TrimSpace(p.FirmwareVersion)
+
+	if p.RSSI == nil && p.UptimeSeconds == nil && p.FreeHeap == nil &&
+		p.ResetReason == "" && p.IPAddress == "" && p.FirmwareVersion == "" {
+		return errors.New("at least one telemetry metric is required")
+	}
+
+	if p.RSSI != nil && (*p.RSSI < -120 || *p.RSSI > 0) {
+		return errors.New("rssi must be between -120 and 0 dBm")
+	}
+	if p.UptimeSeconds != nil && *p.UptimeSeconds < 0 {
+		return errors.New("uptimeSeconds must be positive")
+	}
+	if p.FreeHeap != nil && *p.FreeHeap < 0 {
+		return errors.New("freeHeap must be positive")
+	}
+	if len(p.ResetReason) > 64 {
+		return errors.New("resetReason cannot be longer than 64 characters")
+	}
+	if p.IPAddress != "" && net.ParseIP(p.IPAddress) == nil {
+		return errors.New("invalid ipAddress")
+	}
+	if len(p.FirmwareVersion) > 32 {
+		return errors.New("firmwareVersion cannot be longer than 32 characters")
+	}
+
+	if err := p.ReadingTime.Sanitize(); err != nil {
+		return err
+	}
+	return p.ReadingSource.Sanitize()
+}
+
+// SignedValue returns the value signed by the device: the metrics in the order of TelemetryParams, separated by ","
+// and empty when missing, e.g. "-67,3600,41232,Power On,192.168.1.20,1.4.0".
+func (p *TelemetryParams) SignedValue() string {
+	values := make([]string, 0, 6)
+
+	if p.RSSI != nil {
+		values = append(values, strconv.Itoa(*p.RSSI))
+	} else {
+		values = append(values, "")
+	}
+
+	for _, value := range []*int64{p.UptimeSeconds, p.FreeHeap} {
+		if value != nil {
+			values = append(values, strconv.FormatInt(*value, 10))
+		} else {
+			values = append(values, "")
+		}
+	}
+
+	return strings.Join(append(values, p.ResetReason, p.IPAddress, p.FirmwareVersion), ",")
+}
+
+type DeviceTelemetry struct {
+	ID              int64 // Zéro pour les dernières valeurs connues d'un appareil
+	DeviceID        string
+	RSSI            *int
+	UptimeSeconds   *int64
+	FreeHeap        *int64
+	ResetReason     string
+	IPAddress       string
+	FirmwareVersion string
+	Rebooted        bool // L'uptime a diminué depuis le rapport précédent
+	RecordedAt      time.Time
+	ReceivedAt      time.Time
+}
+
+type TelemetryAlertKind string
+
+const (
+	TelemetryAlertRebootLoop TelemetryAlertKind = "reboot_loop"
+	TelemetryAlertWeakSignal TelemetryAlertKind = "weak_signal"
+)
+
+type TelemetryAlert struct {
+	ID             int64
+	DeviceID       string
+	Kind           TelemetryAlertKind
+	Value          float64
+	Threshold      float64
+	Details        string
+	AlertStatus    AlertStatus
+	AcknowledgedAt *time.Time
+	ResolvedAt     *time.Time
+	CreatedAt      time.Time
+}
+
+// TelemetryResponse is the outcome of a telemetry report: the alerts it raised, to be notified.
+type TelemetryResponse struct {
+	Rebooted bool
+	Alerts   []*AlertResponse
+}
+
+type TelemetryManager interface {
+	RecordTelemetry(params *TelemetryParams) (*TelemetryResponse, error)
+	GetTelemetryHistory(siteID, deviceID string, limit int) ([]*DeviceTelemetry, error)
+
+	GetTelemetryAlerts(params *GetAlertsParams) ([]*TelemetryAlert, error)
+	UpdateTelemetryAlertStatus(params *UpdateAlertStatusParams) error
+}
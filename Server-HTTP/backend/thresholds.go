@@ -0,0 +1,87 @@
+package sensormanager
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Règles des alertes des capteurs historiques, dont le seuil peut être remplacé pour un site
+const (
+	AlertRuleHighNoise      = "high_noise"
+	AlertRuleLargeVariation = "large_variation"
+)
+
+var ErrUnknownAlertRule = errors.New("unknown alert rule")
+
+// AlertThresholdRule is an alert rule whose threshold can be replaced for a site.
+type AlertThresholdRule struct {
+	SensorType SensorType
+	Rule       string
+	Default    float64
+}
+
+// AlertThreshold replaces, for a site, the default threshold of an alert rule.
+type AlertThreshold struct {
+	SiteID     string
+	SensorType SensorType
+	Rule       string
+	Threshold  float64
+	UpdatedAt  time.Time
+}
+
+type AlertThresholdParams struct {
+	SiteID     string
+	SensorType SensorType
+	Rule       string
+	Threshold  float64
+}
+
+func (p *AlertThresholdParams) Sanitize() error {
+	if p.SiteID == "" {
+		return errors.New("siteId is required")
+	}
+
+	if _, err := DefaultAlertThreshold(p.SensorType, p.Rule); err != nil {
+		return err
+	}
+
+	if math.IsNaN(p.Threshold) || math.IsInf(p.Threshold, 0) {
+		return errors.New("invalid threshold")
+	}
+
+	return nil
+}
+
+// AlertThresholdRules returns the rules of the registered sensor types.
+func AlertThresholdRules() []*AlertThresholdRule {
+	var result []*AlertThresholdRule
+
+	for _, definition := range SensorTypes() {
+		for _, rule := range definition.Rules {
+			result = append(result, &AlertThresholdRule{SensorType: definition.Name, Rule: rule.Name, Default: rule.Threshold})
+		}
+	}
+
+	return result
+}
+
+// DefaultAlertThreshold returns the threshold of the rule when the site does not replace it.
+func DefaultAlertThreshold(sensorType SensorType, rule string) (float64, error) {
+	for _, candidate := range AlertThresholdRules() {
+		if candidate.SensorType == sensorType && candidate.Rule == rule {
+			return candidate.Default, nil
+		}
+	}
+
+	return 0, ErrUnknownAlertRule
+}
+
+type AlertThresholdManager interface {
+	// GetAlertThresholds returns the thresholds replaced for the site.
+	GetAlertThresholds(siteID string) ([]*AlertThreshold, error)
+	SetAlertThreshold(params *AlertThresholdParams) (*AlertThreshold, error)
+
+	// ResetAlertThreshold drops the threshold of the site: the default one is used again.
+	ResetAlertThreshold(siteID string, sensorType SensorType, rule string) error
+}
@@ -2,6 +2,7 @@ package sensormanager
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -11,122 +12,153 @@ const (
 	SensorTypeDistance   SensorType = "distance"
 	SensorTypeMicrophone SensorType = "microphone"
 	SensorTypeMotion     SensorType = "motion"
-)
 
-type DistanceData struct {
-	ID         int64
-	DeviceID   string
-	DistanceCm float64
-	RecordedAt time.Time
-}
+	// Capteurs d'ambiance (DHT22, LDR)
+	SensorTypeTemperature SensorType = "temperature"
+	SensorTypeHumidity    SensorType = "humidity"
+	SensorTypeIlluminance SensorType = "illuminance"
+)
 
-type DistanceParams struct {
-	DeviceID   string  `json:"deviceId"`
-	DistanceCm float64 `json:"distanceCm"`
+// ReadingTime is the optional timing of a reading as seen by the device, so readings buffered while the device was
+// offline keep the time they were taken at.
+type ReadingTime struct {
+	RecordedAt *time.Time `json:"recordedAt,omitempty"`
+	Sequence   *int64     `json:"sequence,omitempty"` // Compteur de l'appareil, remis à zéro à chaque démarrage
 }
 
-func (p *DistanceParams) Sanitize() error {
-	if p.DeviceID == "" {
-		return errors.New("deviceId is required")
-	}
-	if p.DistanceCm < 0 {
-		return errors.New("distanceCm must be positive")
+func (t *ReadingTime) Sanitize() error {
+	if t.Sequence != nil && *t.Sequence < 0 {
+		return errors.New("sequence must be positive")
 	}
+
 	return nil
 }
 
-type MicrophoneData struct {
-	ID         int64
-	DeviceID   string
-	Decibels   float64
-	RecordedAt time.Time
-}
+var (
+	ErrReadingInFuture = errors.New("recordedAt is too far in the future")
+	ErrReadingTooOld   = errors.New("recordedAt is too far in the past")
+)
 
-type MicrophoneParams struct {
-	DeviceID string  `json:"deviceId"`
-	Decibels float64 `json:"decibels"`
-}
+// ReadingID optionally identifies a reading, so that its retransmissions are only recorded once.
+type ReadingID struct {
+	MessageID string `json:"messageId,omitempty"`
 
-func (p *MicrophoneParams) Sanitize() error {
-	if p.DeviceID == "" {
-		return errors.New("deviceId is required")
-	}
-	if p.Decibels < 0 {
-		return errors.New("decibels must be positive")
-	}
-	if p.Decibels > 115 {
-		return errors.New("decibels must be positive")
-	}
-	return nil
+	// QueueID is the position of a reading the device did not identify in the ingestion queue. Unlike the messageId,
+	// it is not signed by the device.
+	QueueID string `json:"-"`
 }
 
-type MotionData struct {
-	ID             int64
-	DeviceID       string
-	MotionDetected bool
-	RecordedAt     time.Time
-}
+// MaxDedupKeyLength is the maximum length of a messageId.
+const MaxDedupKeyLength = 64
 
-type MotionParams struct {
-	DeviceID       string `json:"deviceId"`
-	MotionDetected bool   `json:"motionDetected"`
-}
+var (
+	ErrInvalidMessageID  = fmt.Errorf("messageId cannot be longer than %d characters", MaxDedupKeyLength)
+	ErrReadingInProgress = errors.New("a reading with the same messageId is being recorded")
+)
 
-func (p *MotionParams) Sanitize() error {
-	if p.DeviceID == "" {
-		return errors.New("deviceId is required")
+// DedupKey returns the key identifying the retransmissions of the reading among the readings of the same sensor type:
+// its messageId, else its sequence number with its time since sequences restart with the device, else its position in
+// the ingestion queue. It is empty when the reading is not identified, a sequence without time included.
+func (id *ReadingID) DedupKey(timing *ReadingTime) string {
+	switch {
+	case id.MessageID != "":
+		return id.MessageID
+	case timing.Sequence != nil && timing.RecordedAt != nil:
+		return fmt.Sprintf("seq:%d@%d", *timing.Sequence, timing.RecordedAt.UnixMilli())
+	default:
+		// Une séquence seule se répète après chaque redémarrage : des mesures différentes seraient écartées.
+		return id.QueueID
 	}
-	return nil
 }
 
 type AlertResponse struct {
 	Alert      bool
+	AlertID    int64 // Optionnel - identifiant de l'alerte créée
 	Message    string
 	Value      float64
 	Threshold  float64
+	SiteID     string
 	DeviceID   string
+	Event      string // Optionnel - ex: 'motion_ended'
 	RecordedAt time.Time
+
+	SensorFault *SensorFaultAlert // Optionnel - défaut capteur détecté par cette mesure
+
+	// Incident is the incident the alert was grouped into when it was recorded, and IncidentOpened is set when the alert
+	// opened it. Incident is nil when the alert could not be grouped.
+	Incident       *Incident `json:"-"`
+	IncidentOpened bool      `json:"-"`
+
+	// Duplicate is set when the reading had already been recorded: the response is the original one, and its alerts
+	// have already been notified.
+	Duplicate bool
+
+	// Quarantined is set when the device is pending approval: the reading is kept aside without evaluating alerts.
+	Quarantined bool
 }
 
-type AlertStatus string
+// MaxBatchSize is the maximum number of readings of a record batch.
+const MaxBatchSize = 500
+
+var (
+	ErrEmptyBatch    = errors.New("readings are required")
+	ErrBatchTooLarge = fmt.Errorf("a batch cannot contain more than %d readings", MaxBatchSize)
+)
+
+// RecordResult is the outcome of a reading of a batch: its alert evaluation, or the reason it was rejected.
+type RecordResult struct {
+	Response *AlertResponse
+	Err      error
+}
+
+type AlertType string
 
 const (
-	AlertStatusActive       AlertStatus = "active"
-	AlertStatusAcknowledged AlertStatus = "acknowledged"
-	AlertStatusResolved     AlertStatus = "resolved"
+	AlertTypeDistance    AlertType = "distance"
+	AlertTypeMicrophone  AlertType = "microphone"
+	AlertTypeMotion      AlertType = "motion"
+	AlertTypeSensorFault AlertType = "sensor_fault"
+	AlertTypeTelemetry   AlertType = "telemetry"
 )
 
-type MicrophoneAlert struct {
-	ID                int64
-	DeviceID          string
-	DataID            *int64
-	Decibels          float64
-	ThresholdExceeded float64
-	AlertStatus       AlertStatus
-	AcknowledgedAt    *time.Time
-	ResolvedAt        *time.Time
-	CreatedAt         time.Time
+// Validate accepts the sensor fault and telemetry alerts, and the alerts of every registered sensor type.
+func (t AlertType) Validate() error {
+	if t == AlertTypeSensorFault || t == AlertTypeTelemetry {
+		return nil
+	}
+
+	if _, err := LookupSensorType(SensorType(t)); err != nil {
+		return errors.New("invalid alert type")
+	}
+
+	return nil
 }
 
-type DistanceAlert struct {
-	ID             int64
-	DeviceID       string
-	DataID         *int64
-	DistanceCm     float64
-	ThresholdType  string
-	ThresholdValue float64
-	AlertStatus    AlertStatus
-	AcknowledgedAt *time.Time
-	ResolvedAt     *time.Time
-	CreatedAt      time.Time
+// ActiveAlert is the common view of an alert that has been neither acknowledged nor resolved.
+type ActiveAlert struct {
+	Type         AlertType
+	ID           int64
+	SiteID       string
+	DeviceID     string
+	SnoozedUntil *time.Time // Optionnel - escalade suspendue jusqu'à cette date
+	CreatedAt    time.Time
 }
 
-type MotionAlert struct {
+type AlertStatus string
+
+const (
+	AlertStatusActive       AlertStatus = "active"
+	AlertStatusAcknowledged AlertStatus = "acknowledged"
+	AlertStatusResolved     AlertStatus = "resolved"
+)
+
+type SensorFaultAlert struct {
 	ID             int64
 	DeviceID       string
-	DataID         *int64
-	MotionDetected bool
-	AlertReason    string
+	SensorType     SensorType
+	FaultType      DeviceHealth
+	Value          float64
+	Details        string
 	AlertStatus    AlertStatus
 	AcknowledgedAt *time.Time
 	ResolvedAt     *time.Time
@@ -134,32 +166,29 @@ type MotionAlert struct {
 }
 
 type GetAlertsParams struct {
+	SiteID   string
 	DeviceID string
 	Status   AlertStatus // Optionnel - vide = tous
 	Limit    int
 }
 
 type UpdateAlertStatusParams struct {
+	SiteID  string
 	AlertID int64
 	Status  AlertStatus
 }
 
 type SensorManager interface {
-	RecordDistance(params *DistanceParams) (*AlertResponse, error)
-	GetDistanceHistory(deviceID string, limit int) ([]*DistanceData, error)
-
-	RecordMicrophone(params *MicrophoneParams) (*AlertResponse, error)
-	GetMicrophoneHistory(deviceID string, limit int) ([]*MicrophoneData, error)
-
-	RecordMotion(params *MotionParams) (*AlertResponse, error)
-	GetMotionHistory(deviceID string, limit int) ([]*MotionData, error)
+	// Types du registre, distance, microphone et mouvement compris
+	RecordReading(params *ReadingParams) (*AlertResponse, error)
+	RecordReadingBatch(params []*ReadingParams) ([]*RecordResult, error)
+	GetReadingHistory(siteID string, sensorType SensorType, deviceID string, limit int) ([]*ReadingData, error)
 
-	GetMicrophoneAlerts(params *GetAlertsParams) ([]*MicrophoneAlert, error)
-	UpdateMicrophoneAlertStatus(params *UpdateAlertStatusParams) error
+	GetSensorAlerts(sensorType SensorType, params *GetAlertsParams) ([]*SensorAlert, error)
+	UpdateSensorAlertStatus(sensorType SensorType, params *UpdateAlertStatusParams) error
 
-	GetDistanceAlerts(params *GetAlertsParams) ([]*DistanceAlert, error)
-	UpdateDistanceAlertStatus(params *UpdateAlertStatusParams) error
+	GetSensorFaultAlerts(params *GetAlertsParams) ([]*SensorFaultAlert, error)
+	UpdateSensorFaultAlertStatus(params *UpdateAlertStatusParams) error
 
-	GetMotionAlerts(params *GetAlertsParams) ([]*MotionAlert, error)
-	UpdateMotionAlertStatus(params *UpdateAlertStatusParams) error
+	GetActiveAlerts() ([]*ActiveAlert, error)
 }
@@ -0,0 +1,34 @@
+package sensormanager
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDedupKey(t *testing.T) {
+	sequence := int64(42)
+	recordedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		id     ReadingID
+		timing ReadingTime
+		want   string
+	}{
+		"message id":              {ReadingID{MessageID: "m-1"}, ReadingTime{Sequence: &sequence}, "m-1"},
+		"sequence with its time":  {ReadingID{}, ReadingTime{Sequence: &sequence, RecordedAt: &recordedAt}, fmt.Sprintf("seq:42@%d", recordedAt.UnixMilli())},
+		"sequence without time":   {ReadingID{}, ReadingTime{Sequence: &sequence}, ""},
+		"time without sequence":   {ReadingID{}, ReadingTime{RecordedAt: &recordedAt}, ""},
+		"unidentified reading":    {ReadingID{}, ReadingTime{}, ""},
+		"queue position":          {ReadingID{QueueID: "READINGS:7"}, ReadingTime{Sequence: &sequence}, "READINGS:7"},
+		"identified in the queue": {ReadingID{MessageID: "m-1", QueueID: "READINGS:7"}, ReadingTime{}, "m-1"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := test.id.DedupKey(&test.timing); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
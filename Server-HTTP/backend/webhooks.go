@@ -0,0 +1,224 @@
+package sensormanager
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WebhookEvent is an event a webhook can subscribe to.
+type WebhookEvent string
+
+const (
+	WebhookEventAlertCreated      WebhookEvent = "alert.created"
+	WebhookEventAlertAcknowledged WebhookEvent = "alert.acknowledged"
+	WebhookEventAlertResolved     WebhookEvent = "alert.resolved"
+	WebhookEventDeviceOffline     WebhookEvent = "device.offline"
+	WebhookEventDeviceOnline      WebhookEvent = "device.online"
+
+	// WebhookEventPing is sent by the test call. It cannot be subscribed to.
+	WebhookEventPing WebhookEvent = "ping"
+)
+
+func (e WebhookEvent) Validate() error {
+	switch e {
+	case WebhookEventAlertCreated, WebhookEventAlertAcknowledged, WebhookEventAlertResolved,
+		WebhookEventDeviceOffline, WebhookEventDeviceOnline:
+		return nil
+	default:
+		return errors.New("invalid webhook event")
+	}
+}
+
+// WebhookEventOf returns the webhook event of a domain event, or an empty string when webhooks cannot subscribe to it.
+func WebhookEventOf(event *Event) WebhookEvent {
+	switch data := event.Data.(type) {
+	case *AlertEventData:
+		switch {
+		case event.Type == EventTypeAlertCreated:
+			return WebhookEventAlertCreated
+		case data.Status == AlertStatusAcknowledged:
+			return WebhookEventAlertAcknowledged
+		case data.Status == AlertStatusResolved:
+			return WebhookEventAlertResolved
+		}
+	case *DeviceConnectivityEventData:
+		if data.Online {
+			return WebhookEventDeviceOnline
+		}
+
+		return WebhookEventDeviceOffline
+	}
+
+	return ""
+}
+
+// Webhook is a URL called with the events it subscribed to, on the site of the events.
+type Webhook struct {
+	ID        int64
+	SiteID    string
+	Name      string
+	URL       string
+	Events    []WebhookEvent
+	IsActive  bool
+	CreatedAt time.Time
+}
+
+type WebhookParams struct {
+	SiteID   string
+	Name     string
+	URL      string
+	Events   []string
+	IsActive bool
+}
+
+func (p *WebhookParams) Sanitize() error {
+	if p.SiteID == "" {
+		return errors.New("siteId is required")
+	}
+
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+
+	p.URL = strings.TrimSpace(p.URL)
+	if parsed, err := url.Parse(p.URL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+
+	if len(p.Events) == 0 {
+		return errors.New("at least one event is required")
+	}
+
+	seen := make(map[string]bool, len(p.Events))
+	events := p.Events[:0]
+	for _, event := range p.Events {
+		event = strings.TrimSpace(event)
+		if err := WebhookEvent(event).Validate(); err != nil {
+			return err
+		}
+
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+	p.Events = events
+
+	return nil
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending" // En attente de la prochaine tentative
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryDead      WebhookDeliveryStatus = "dead" // Abandonnée après la dernière tentative
+)
+
+func (s WebhookDeliveryStatus) Validate() error {
+	switch s {
+	case WebhookDeliveryPending, WebhookDeliveryDelivered, WebhookDeliveryDead:
+		return nil
+	default:
+		return errors.New("invalid delivery status")
+	}
+}
+
+// WebhookDelivery is an event to deliver to a webhook, with the result of its last attempt.
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      int64
+	Event          WebhookEvent
+	Version        int
+	SiteID         string
+	Data           json.RawMessage
+	OccurredAt     time.Time
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode *int   // Optionnel - nil quand la requête n'a pas abouti
+	LastError      string // Optionnel
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+
+	// URL et clé du webhook, renseignées pour l'envoi
+	URL    string
+	Secret string
+}
+
+// Payload returns the body of the delivery, sent as is at every attempt:
+//
+//	{"id": 42, "event": "alert.created", "version": 1, "siteId": "home", "occurredAt": "...", "data": {...}}
+//
+// The data are those of the domain event, see package events. The id identifies the delivery across its attempts.
+func (d *WebhookDelivery) Payload() ([]byte, error) {
+	return json.Marshal(&struct {
+		ID         int64           `json:"id"`
+		Event      WebhookEvent    `json:"event"`
+		Version    int             `json:"version"`
+		SiteID     string          `json:"siteId"`
+		OccurredAt time.Time       `json:"occurredAt"`
+		Data       json.RawMessage `json:"data"`
+	}{d.ID, d.Event, d.Version, d.SiteID, d.OccurredAt.UTC(), d.Data})
+}
+
+// SignWebhook returns the signature of a webhook body, sent in the X-SensorManager-Signature header: "sha256=" then
+// the hexadecimal HMAC-SHA256, with the secret of the webhook, of the timestamp of the X-SensorManager-Timestamp
+// header (Unix seconds), a "." and the body.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookAttempt is the result of an attempt to deliver a webhook. A failed delivery is tried again at NextAttemptAt,
+// or becomes dead when it is nil.
+type WebhookAttempt struct {
+	StatusCode    *int
+	Error         string
+	Delivered     bool
+	NextAttemptAt *time.Time
+}
+
+type GetWebhookDeliveriesParams struct {
+	SiteID    string
+	WebhookID int64                 // Optionnel - 0 = tous les webhooks du site
+	Status    WebhookDeliveryStatus // Optionnel
+	Limit     int
+}
+
+var ErrWebhookDeliveryNotDead = errors.New("only dead deliveries can be delivered again")
+
+type WebhookManager interface {
+	// CreateWebhook registers the webhook and returns its secret, only returned here.
+	CreateWebhook(params *WebhookParams) (*Webhook, string, error)
+	GetWebhooks(siteID string) ([]*Webhook, error)
+	UpdateWebhook(webhookID int64, params *WebhookParams) (*Webhook, error)
+	DeleteWebhook(siteID string, webhookID int64) error
+
+	GetWebhookDeliveries(params *GetWebhookDeliveriesParams) ([]*WebhookDelivery, error)
+
+	// AddTestDelivery adds a ping delivery to the webhook, claimed for an immediate attempt.
+	AddTestDelivery(siteID string, webhookID int64, lease time.Duration) (*WebhookDelivery, error)
+
+	// RedeliverWebhookDelivery schedules a dead delivery for a new series of attempts.
+	RedeliverWebhookDelivery(siteID string, deliveryID int64) error
+
+	// ClaimWebhookDeliveries returns the pending deliveries that are due, with the URL and secret of their webhook.
+	// Their next attempt is pushed back by the lease, so that they are not claimed twice.
+	ClaimWebhookDeliveries(limit int, lease time.Duration) ([]*WebhookDelivery, error)
+	RecordWebhookAttempt(deliveryID int64, attempt *WebhookAttempt) error
+
+	// DeleteWebhookDeliveries drops the delivered and dead deliveries created before the given time.
+	DeleteWebhookDeliveries(before time.Time) (int64, error)
+}
@@ -0,0 +1,224 @@
+// Package webhooks delivers the webhook deliveries written with the domain events, see sensormanager.WebhookManager.
+//
+// Every delivery is POSTed as JSON to the URL of its webhook, with the headers:
+//
+//	X-SensorManager-Event      event of the delivery, e.g. "alert.created"
+//	X-SensorManager-Delivery   ID of the delivery, the same at every attempt
+//	X-SensorManager-Timestamp  time of the attempt, in Unix seconds
+//	X-SensorManager-Signature  "sha256=" then the HMAC-SHA256 of the timestamp, a "." and the body, see SignWebhook
+//
+// A delivery is delivered when the URL answers with a 2xx status. Otherwise it is tried again after an exponential
+// backoff, and becomes dead after the last attempt. Dead deliveries can be delivered again through the RES API.
+package webhooks
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sensormanager"
+	"sensormanager/store"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxErrorLength bounds the error, or the body of the response, recorded with a failed attempt.
+	maxErrorLength = 512
+
+	userAgent = "SensorManager-Webhooks/1.0"
+)
+
+type Dispatcher struct {
+	store  *store.Store
+	client *http.Client
+
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	retryDelay  time.Duration
+	maxDelay    time.Duration
+	retention   time.Duration
+}
+
+type Option func(*Dispatcher)
+
+func New(options ...Option) *Dispatcher {
+	result := &Dispatcher{
+		client:      &http.Client{Timeout: 10 * time.Second},
+		interval:    5 * time.Second,
+		batchSize:   50,
+		maxAttempts: 8,
+		retryDelay:  30 * time.Second,
+		maxDelay:    time.Hour,
+		retention:   30 * 24 * time.Hour,
+	}
+
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.store == nil {
+		panic("could not create webhook dispatcher without Store configuration")
+	}
+
+	return result
+}
+
+func WithStore(store *store.Store) Option { return func(d *Dispatcher) { d.store = store } }
+
+func WithInterval(interval time.Duration) Option {
+	return func(d *Dispatcher) { d.interval = interval }
+}
+
+func WithBatchSize(size int) Option { return func(d *Dispatcher) { d.batchSize = size } }
+
+// WithTimeout sets how long the URL of a webhook may take to answer.
+func WithTimeout(timeout time.Duration) Option {
+	return func(d *Dispatcher) { d.client = &http.Client{Timeout: timeout} }
+}
+
+// WithRetries sets the number of attempts of a delivery, and the delay before the second one. The delay doubles at
+// every attempt, up to maxDelay.
+func WithRetries(maxAttempts int, delay, maxDelay time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.maxAttempts = maxAttempts
+		d.retryDelay = delay
+		d.maxDelay = maxDelay
+	}
+}
+
+// WithRetention sets how long the delivered and dead deliveries are kept.
+func WithRetention(retention time.Duration) Option {
+	return func(d *Dispatcher) { d.retention = retention }
+}
+
+// Lease returns how long a claimed delivery is kept from the other dispatchers, longer than an attempt can take.
+func (d *Dispatcher) Lease() time.Duration { return d.client.Timeout + time.Minute }
+
+// Run sends the due deliveries at every interval, and drops the expired deliveries. It never returns.
+func (d *Dispatcher) Run() {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	lastPurge := time.Time{}
+
+	for now := range ticker.C {
+		if err := d.dispatch(); err != nil {
+			fmt.Printf("❌ Webhooks: %v\n", err)
+		}
+
+		if now.Sub(lastPurge) < time.Hour {
+			continue
+		}
+		lastPurge = now
+
+		if deleted, err := d.store.Webhooks.DeleteWebhookDeliveries(now.Add(-d.retention)); err != nil {
+			fmt.Printf("❌ Webhooks: could not delete deliveries: %v\n", err)
+		} else if deleted > 0 {
+			fmt.Printf("🧹 %d webhook deliveries deleted\n", deleted)
+		}
+	}
+}
+
+// dispatch sends the due deliveries, batch after batch, until none is due.
+func (d *Dispatcher) dispatch() error {
+	for {
+		deliveries, err := d.store.Webhooks.ClaimWebhookDeliveries(d.batchSize, d.Lease())
+		if err != nil {
+			return fmt.Errorf("could not claim deliveries: %w", err)
+		}
+
+		for _, delivery := range deliveries {
+			attempt := d.Deliver(delivery)
+
+			if err := d.store.Webhooks.RecordWebhookAttempt(delivery.ID, attempt); err != nil {
+				// La livraison sera reprise à l'expiration du bail.
+				fmt.Printf("❌ Webhooks: could not record attempt of delivery %d: %v\n", delivery.ID, err)
+			}
+		}
+
+		if len(deliveries) < d.batchSize {
+			return nil
+		}
+	}
+}
+
+// Deliver makes an attempt to deliver a claimed delivery and returns its result, with the time of the next attempt
+// when it failed.
+func (d *Dispatcher) Deliver(delivery *sensormanager.WebhookDelivery) *sensormanager.WebhookAttempt {
+	attempt := d.send(delivery)
+
+	if !attempt.Delivered {
+		attempt.NextAttemptAt = d.nextAttempt(delivery.Attempts+1, time.Now())
+	}
+
+	return attempt
+}
+
+func (d *Dispatcher) send(delivery *sensormanager.WebhookDelivery) *sensormanager.WebhookAttempt {
+	body, err := delivery.Payload()
+	if err != nil {
+		return &sensormanager.WebhookAttempt{Error: err.Error()}
+	}
+
+	request, err := http.NewRequest(http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return &sensormanager.WebhookAttempt{Error: err.Error()}
+	}
+
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", userAgent)
+	request.Header.Set("X-SensorManager-Event", string(delivery.Event))
+	request.Header.Set("X-SensorManager-Delivery", strconv.FormatInt(delivery.ID, 10))
+	request.Header.Set("X-SensorManager-Timestamp", strconv.FormatInt(timestamp, 10))
+	request.Header.Set("X-SensorManager-Signature", sensormanager.SignWebhook(delivery.Secret, timestamp, body))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return &sensormanager.WebhookAttempt{Error: truncate(err.Error())}
+	}
+	defer response.Body.Close()
+
+	statusCode := response.StatusCode
+	attempt := &sensormanager.WebhookAttempt{StatusCode: &statusCode}
+
+	if statusCode >= 200 && statusCode < 300 {
+		attempt.Delivered = true
+		return attempt
+	}
+
+	// 📝 Le début de la réponse aide le destinataire à comprendre le refus.
+	responseBody, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorLength))
+	attempt.Error = truncate(fmt.Sprintf("%s: %s", response.Status, bytes.TrimSpace(responseBody)))
+
+	return attempt
+}
+
+// nextAttempt returns the time of the attempt following the given number of attempts, or nil after the last one.
+func (d *Dispatcher) nextAttempt(attempts int, now time.Time) *time.Time {
+	if attempts >= d.maxAttempts {
+		return nil
+	}
+
+	delay := d.retryDelay
+	for i := 1; i < attempts && delay < d.maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, d.maxDelay)
+
+	next := now.Add(delay)
+
+	return &next
+}
+
+func truncate(message string) string {
+	if len(message) <= maxErrorLength {
+		return message
+	}
+
+	// Une coupure au milieu d'un caractère serait refusée par PostgreSQL.
+	return strings.ToValidUTF8(message[:maxErrorLength], "")
+}
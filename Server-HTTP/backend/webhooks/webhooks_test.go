@@ -0,0 +1,186 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sensormanager"
+	"sensormanager/store"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeWebhooks keeps the deliveries in memory, like the webhook_deliveries table.
+type fakeWebhooks struct {
+	sensormanager.WebhookManager
+
+	mu         sync.Mutex
+	deliveries []*sensormanager.WebhookDelivery
+	attempts   map[int64][]*sensormanager.WebhookAttempt
+}
+
+func newFakeWebhooks(deliveries ...*sensormanager.WebhookDelivery) *fakeWebhooks {
+	return &fakeWebhooks{deliveries: deliveries, attempts: make(map[int64][]*sensormanager.WebhookAttempt)}
+}
+
+func (w *fakeWebhooks) ClaimWebhookDeliveries(limit int, lease time.Duration) ([]*sensormanager.WebhookDelivery, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now()
+
+	var result []*sensormanager.WebhookDelivery
+	for _, delivery := range w.deliveries {
+		if delivery.Status == sensormanager.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) && len(result) < limit {
+			delivery.NextAttemptAt = now.Add(lease)
+			claimed := *delivery
+			result = append(result, &claimed)
+		}
+	}
+
+	return result, nil
+}
+
+func (w *fakeWebhooks) RecordWebhookAttempt(deliveryID int64, attempt *sensormanager.WebhookAttempt) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.attempts[deliveryID] = append(w.attempts[deliveryID], attempt)
+
+	for _, delivery := range w.deliveries {
+		if delivery.ID != deliveryID {
+			continue
+		}
+
+		delivery.Attempts++
+		switch {
+		case attempt.Delivered:
+			delivery.Status = sensormanager.WebhookDeliveryDelivered
+		case attempt.NextAttemptAt == nil:
+			delivery.Status = sensormanager.WebhookDeliveryDead
+		default:
+			delivery.NextAttemptAt = *attempt.NextAttemptAt
+		}
+	}
+
+	return nil
+}
+
+func testDelivery(id int64, url string) *sensormanager.WebhookDelivery {
+	return &sensormanager.WebhookDelivery{
+		ID:         id,
+		WebhookID:  1,
+		Event:      sensormanager.WebhookEventAlertCreated,
+		Version:    1,
+		SiteID:     "home",
+		Data:       json.RawMessage(`{"alertId":7,"deviceId":"ESP_001"}`),
+		OccurredAt: time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC),
+		Status:     sensormanager.WebhookDeliveryPending,
+		URL:        url,
+		Secret:     "s3cr3t",
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	var requests []*http.Request
+	var bodies [][]byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r)
+		bodies = append(bodies, body)
+	}))
+	defer receiver.Close()
+
+	fake := newFakeWebhooks(testDelivery(42, receiver.URL))
+	dispatcher := New(WithStore(&store.Store{Webhooks: fake}))
+
+	if err := dispatcher.dispatch(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests) != 1 {
+		t.Fatalf("expected one request, got %d", len(requests))
+	}
+
+	request, body := requests[0], bodies[0]
+	if request.Header.Get("X-SensorManager-Event") != "alert.created" || request.Header.Get("X-SensorManager-Delivery") != "42" {
+		t.Errorf("unexpected headers %v", request.Header)
+	}
+
+	timestamp, err := strconv.ParseInt(request.Header.Get("X-SensorManager-Timestamp"), 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signature := request.Header.Get("X-SensorManager-Signature"); signature != sensormanager.SignWebhook("s3cr3t", timestamp, body) {
+		t.Errorf("expected the body to be signed with the secret of the webhook, got %s", signature)
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload["id"] != float64(42) || payload["event"] != "alert.created" || payload["siteId"] != "home" ||
+		payload["data"].(map[string]interface{})["alertId"] != float64(7) {
+		t.Errorf("unexpected payload %s", body)
+	}
+
+	if status := fake.deliveries[0].Status; status != sensormanager.WebhookDeliveryDelivered {
+		t.Errorf("expected the delivery to be delivered, got %s", status)
+	}
+}
+
+func TestDispatchRetriesUntilDead(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	fake := newFakeWebhooks(testDelivery(1, receiver.URL))
+	dispatcher := New(WithStore(&store.Store{Webhooks: fake}), WithRetries(3, time.Millisecond, time.Millisecond))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for fake.deliveries[0].Status == sensormanager.WebhookDeliveryPending && time.Now().Before(deadline) {
+		if err := dispatcher.dispatch(); err != nil {
+			t.Fatal(err)
+		}
+
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	attempts := fake.attempts[1]
+	if len(attempts) != 3 || fake.deliveries[0].Status != sensormanager.WebhookDeliveryDead {
+		t.Fatalf("expected the delivery to be dead after 3 attempts, got %d attempts and %s", len(attempts), fake.deliveries[0].Status)
+	}
+
+	if attempt := attempts[0]; attempt.StatusCode == nil || *attempt.StatusCode != http.StatusServiceUnavailable ||
+		attempt.Error != "503 Service Unavailable: maintenance" || attempt.NextAttemptAt == nil {
+		t.Errorf("expected the first attempt to be retried with the response recorded, got %+v", attempt)
+	}
+
+	if attempts[2].NextAttemptAt != nil {
+		t.Errorf("expected no attempt after the last one")
+	}
+}
+
+func TestNextAttempt(t *testing.T) {
+	dispatcher := New(WithStore(&store.Store{}), WithRetries(6, 30*time.Second, 2*time.Minute))
+	now := time.Now()
+
+	for attempts, expected := range map[int]time.Duration{
+		1: 30 * time.Second,
+		2: time.Minute,
+		3: 2 * time.Minute,
+		5: 2 * time.Minute,
+	} {
+		if next := dispatcher.nextAttempt(attempts, now); next == nil || next.Sub(now) != expected {
+			t.Errorf("expected attempt %d to be followed after %s, got %v", attempts, expected, next)
+		}
+	}
+
+	if next := dispatcher.nextAttempt(6, now); next != nil {
+		t.Errorf("expected no attempt after the last one, got %v", next)
+	}
+}
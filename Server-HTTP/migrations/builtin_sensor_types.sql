@@ -0,0 +1,64 @@
+-- Migration d'une base existante : les mesures et alertes de distance, microphone et mouvement rejoignent les tables
+-- génériques du registre (sensor_readings, sensor_alerts). À exécuter une fois, avant de démarrer le nouveau serveur :
+--   psql -v ON_ERROR_STOP=1 -f migrations/builtin_sensor_types.sql
+BEGIN;
+
+ALTER TABLE sensor_readings ADD COLUMN filtered_value DECIMAL(12, 3);
+ALTER TABLE sensor_alerts ALTER COLUMN threshold DROP NOT NULL;
+ALTER TABLE sensor_alerts ADD COLUMN reference DECIMAL(12, 3);
+
+-- 🔁 Identifiants d'origine, le temps de reporter les références vers les nouvelles lignes
+ALTER TABLE sensor_readings ADD COLUMN migrated_from BIGINT;
+ALTER TABLE sensor_alerts ADD COLUMN migrated_from BIGINT;
+
+INSERT INTO sensor_readings (sensor_type, device_id, value, filtered_value, recorded_at, received_at, sequence, migrated_from)
+SELECT 'microphone', device_id, decibels, filtered_decibels, recorded_at, received_at, sequence, id FROM microphone_data;
+
+INSERT INTO sensor_readings (sensor_type, device_id, value, filtered_value, recorded_at, received_at, sequence, migrated_from)
+SELECT 'distance', device_id, distance_cm, filtered_distance_cm, recorded_at, received_at, sequence, id FROM distance_data;
+
+INSERT INTO sensor_readings (sensor_type, device_id, value, recorded_at, received_at, sequence, migrated_from)
+SELECT 'motion', device_id, CASE WHEN motion_detected THEN 1 ELSE 0 END, recorded_at, received_at, sequence, id FROM motion_data;
+
+-- Le seuil dépassé du microphone devient le seuil de la règle 'high_noise'
+INSERT INTO sensor_alerts (sensor_type, device_id, data_id, rule, value, threshold, alert_status, acknowledged_at, resolved_at, created_at, migrated_from)
+SELECT 'microphone', a.device_id, r.id, 'high_noise', a.decibels, a.threshold_exceeded, a.alert_status, a.acknowledged_at, a.resolved_at, a.created_at, a.id
+FROM microphone_alerts a
+LEFT JOIN sensor_readings r ON r.sensor_type = 'microphone' AND r.migrated_from = a.data_id;
+
+-- La valeur de comparaison de la distance était la mesure précédente : elle devient la référence de 'large_variation'
+INSERT INTO sensor_alerts (sensor_type, device_id, data_id, rule, value, reference, alert_status, acknowledged_at, resolved_at, created_at, migrated_from)
+SELECT 'distance', a.device_id, r.id, 'large_variation', a.distance_cm, a.threshold_value, a.alert_status, a.acknowledged_at, a.resolved_at, a.created_at, a.id
+FROM distance_alerts a
+LEFT JOIN sensor_readings r ON r.sensor_type = 'distance' AND r.migrated_from = a.data_id;
+
+-- La raison d'une alerte de mouvement est l'événement qui l'a déclenchée, ex: 'unexpected_motion'
+INSERT INTO sensor_alerts (sensor_type, device_id, data_id, rule, value, alert_status, acknowledged_at, resolved_at, created_at, migrated_from)
+SELECT 'motion', a.device_id, r.id, COALESCE(a.alert_reason, 'unexpected_motion'), CASE WHEN a.motion_detected THEN 1 ELSE 0 END,
+       a.alert_status, a.acknowledged_at, a.resolved_at, a.created_at, a.id
+FROM motion_alerts a
+LEFT JOIN sensor_readings r ON r.sensor_type = 'motion' AND r.migrated_from = a.data_id;
+
+-- 🔗 Les incidents, escalades, journaux et mises en sourdine suivent les nouveaux identifiants des alertes. Les
+-- identifiants passent d'abord en négatif pour ne pas heurter les clés primaires (alert_type, alert_id) en cours de route.
+UPDATE incident_alerts SET alert_id = -alert_id WHERE alert_type IN ('distance', 'microphone', 'motion');
+UPDATE incident_alerts t SET alert_id = a.id FROM sensor_alerts a
+WHERE t.alert_type = a.sensor_type AND t.alert_id = -a.migrated_from;
+
+UPDATE alert_snoozes SET alert_id = -alert_id WHERE alert_type IN ('distance', 'microphone', 'motion');
+UPDATE alert_snoozes t SET alert_id = a.id FROM sensor_alerts a
+WHERE t.alert_type = a.sensor_type AND t.alert_id = -a.migrated_from;
+
+UPDATE alert_escalations t SET alert_id = a.id FROM sensor_alerts a
+WHERE t.alert_type = a.sensor_type AND t.alert_id = a.migrated_from;
+
+UPDATE alert_audit t SET alert_id = a.id FROM sensor_alerts a
+WHERE t.alert_type = a.sensor_type AND t.alert_id = a.migrated_from;
+
+ALTER TABLE sensor_readings DROP COLUMN migrated_from;
+ALTER TABLE sensor_alerts DROP COLUMN migrated_from;
+
+DROP TABLE microphone_alerts, distance_alerts, motion_alerts;
+DROP TABLE microphone_data, distance_data, motion_data;
+
+COMMIT;
@@ -0,0 +1,12 @@
+-- Migration d'une base existante : les identifiants des mesures sont propres à chaque type de capteur d'un appareil.
+-- Les réceptions ne servent qu'à la fenêtre de déduplication : elles sont vidées plutôt que migrées.
+--   psql -v ON_ERROR_STOP=1 -f migrations/reading_receipts_sensor_type.sql
+BEGIN;
+
+TRUNCATE reading_receipts;
+
+ALTER TABLE reading_receipts ADD COLUMN sensor_type VARCHAR(32) NOT NULL;
+ALTER TABLE reading_receipts DROP CONSTRAINT reading_receipts_pkey;
+ALTER TABLE reading_receipts ADD PRIMARY KEY (site_id, device_id, sensor_type, message_id);
+
+COMMIT;
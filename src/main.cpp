@@ -1,6 +1,9 @@
 #include <Arduino.h>
 #include <ESP8266WiFi.h>
 #include <WiFiUdp.h>
+#include <ESP8266httpUpdate.h>
+#include <Crypto.h>
+#include <time.h>
 #include <coap-simple.h>
 #include <HCSR04.h>
 #include <AudioOutputI2S.h>
@@ -17,6 +20,14 @@ UltraSonicDistanceSensor distanceSensor(triggerPin, echoPin);
 WiFiUDP Udp;
 int localUdpPort = 4832;
 
+// Mises à jour OTA : version envoyée dans l'en-tête x-ESP8266-version, secret émis par devices.provision, qui signe
+// aussi les mesures et les rapports de shadow
+#define FIRMWARE_VERSION "1.0.0"
+const char *firmwareUpdateUrl = "http://192.168.40.241:8084/firmware/ESP_002";
+const char *deviceSecret = "";
+const unsigned long updateCheckIntervalMs = 3600000UL;
+unsigned long lastUpdateCheckMillis = 0;
+
 AudioOutputI2S *out;
 AudioGeneratorWAV *wav;
 
@@ -46,6 +57,7 @@ void playBeep()
 
 void COAPResponse(CoapPacket &packet, IPAddress ip, int port);
 void myCOAPCallback(CoapPacket &packet, IPAddress ip, int port);
+void reportShadow();
 
 Coap coap(Udp);
 
@@ -92,10 +104,57 @@ float readMicrophoneDB()
   return db;
 }
 
+// Valeur telle que le backend la signe : sans zéros inutiles, ex: "12.5" et non "12.50"
+String canonicalValue(float value)
+{
+  String text = String(value, 2);
+  while (text.endsWith("0"))
+  {
+    text.remove(text.length() - 1);
+  }
+  if (text.endsWith("."))
+  {
+    text.remove(text.length() - 1);
+  }
+  return text;
+}
+
+// Numéro de séquence des mesures, remis à zéro à chaque démarrage
+unsigned long readingSequence = 0;
+
+// Champs d'authentification d'un message : horodatage, nonce et signature HMAC-SHA256 de
+// "type|appareil|valeur|horodatage|nonce|recordedAt (ms)|séquence|messageId", vides quand absents
+String signedFields(const char *type, const char *deviceId, const String &value, const String &recordedAtMs, const String &sequence)
+{
+  if (strlen(deviceSecret) == 0)
+  {
+    return "";
+  }
+
+  String timestamp = String((unsigned long)time(nullptr));
+  String nonce = String(ESP.random(), HEX) + String(ESP.random(), HEX);
+  String message = String(type) + "|" + deviceId + "|" + value + "|" + timestamp + "|" + nonce + "|" + recordedAtMs + "|" + sequence + "|";
+  String signature = experimental::crypto::SHA256::hmac(message, deviceSecret, strlen(deviceSecret), experimental::crypto::SHA256::NATURAL_LENGTH);
+
+  return ",\"timestamp\":" + timestamp + ",\"nonce\":\"" + nonce + "\",\"signature\":\"" + signature + "\"";
+}
+
+// Mesure datée et numérotée par l'appareil : l'heure et la séquence font partie du message signé
+String readingPayload(const char *type, const char *deviceId, const String &value)
+{
+  time_t now = time(nullptr);
+  char recordedAt[21];
+  strftime(recordedAt, sizeof(recordedAt), "%Y-%m-%dT%H:%M:%SZ", gmtime(&now));
+  String recordedAtMs = String((unsigned long)now) + "000";
+  String sequence = String(++readingSequence);
+
+  return "{\"deviceId\":\"" + String(deviceId) + "\",\"value\":" + value + ",\"recordedAt\":\"" + recordedAt + "\",\"sequence\":" + sequence + signedFields(type, deviceId, value, recordedAtMs, sequence) + "}";
+}
+
 void sendDistance()
 {
   float distance = distanceSensor.measureDistanceCm();
-  String payload = "{\"deviceId\":\"ESP_002\",\"value\":" + String(distance, 2) + "}";
+  String payload = readingPayload("distance", "ESP_002", canonicalValue(distance));
   Serial.print("Sending: ");
   Serial.println(payload);
   coap.put(IPAddress(192, 168, 40, 241), 4832, "distance", payload.c_str());
@@ -109,7 +168,7 @@ void sendMotion()
   {
     playBeep();
   }
-  String payload = "{\"deviceId\":\"ESP_004\",\"value\":" + motionStr + "}";
+  String payload = readingPayload("motion", "ESP_004", motionStr);
   Serial.print("Sending: ");
   Serial.println(payload);
   coap.put(IPAddress(192, 168, 40, 241), 4832, "motion", payload.c_str());
@@ -121,12 +180,32 @@ void sendMicrophone()
   Serial.print("Microphone: ");
   Serial.print(db);
   Serial.println(" dB");
-  String payload = "{\"deviceId\":\"ESP_001\",\"value\":" + String(db, 2) + "}";
+  String payload = readingPayload("microphone", "ESP_001", canonicalValue(db));
   Serial.print("Sending: ");
   Serial.println(payload);
   coap.put(IPAddress(192, 168, 40, 241), 4832, "microphone", payload.c_str());
 }
 
+// Redémarre sur le nouveau firmware si un déploiement est en cours pour l'appareil
+void checkFirmwareUpdate()
+{
+  WiFiClient client;
+  ESPhttpUpdate.setAuthorization("ESP_002", deviceSecret);
+
+  switch (ESPhttpUpdate.update(client, firmwareUpdateUrl, FIRMWARE_VERSION))
+  {
+  case HTTP_UPDATE_FAILED:
+    Serial.print("Firmware update failed: ");
+    Serial.println(ESPhttpUpdate.getLastErrorString());
+    break;
+  case HTTP_UPDATE_NO_UPDATES:
+    Serial.println("Firmware " FIRMWARE_VERSION " is up to date");
+    break;
+  case HTTP_UPDATE_OK:
+    break;
+  }
+}
+
 void setup()
 {
   Serial.begin(115200);
@@ -148,6 +227,15 @@ void setup()
   Serial.print("IP: ");
   Serial.println(WiFi.localIP());
 
+  // Heure UTC par NTP : horodatage des mesures et de leur signature
+  configTime(0, 0, "pool.ntp.org", "time.nist.gov");
+  while (time(nullptr) < 1700000000)
+  {
+    delay(500);
+    Serial.print("*");
+  }
+  Serial.println();
+
   Udp.begin(localUdpPort);
   coap.start(localUdpPort);
   coap.server(myCOAPCallback, "alert");
@@ -159,10 +247,17 @@ void setup()
 
   Serial.println("Ready");
   playBeep();
+  reportShadow();
+  checkFirmwareUpdate();
 }
 
 bool lastMotionState = LOW;
 
+// Réglables par les commandes du backend
+unsigned long reportIntervalMs = 200;
+unsigned long lastReportMillis = 0;
+bool ledForced = false;
+
 void loop()
 {
   if (wav && wav->isRunning())
@@ -171,7 +266,19 @@ void loop()
   }
 
   coap.loop();
-  delay(200);
+  delay(10);
+
+  if (millis() - lastUpdateCheckMillis >= updateCheckIntervalMs)
+  {
+    lastUpdateCheckMillis = millis();
+    checkFirmwareUpdate();
+  }
+
+  if (millis() - lastReportMillis < reportIntervalMs)
+  {
+    return;
+  }
+  lastReportMillis = millis();
 
   int motion = digitalRead(motionPin);
 
@@ -182,7 +289,10 @@ void loop()
   }
   else
   {
-    digitalWrite(LED_BUILTIN, HIGH);
+    if (!ledForced)
+    {
+      digitalWrite(LED_BUILTIN, HIGH);
+    }
     Serial.println("Motion: LOW");
   }
 
@@ -191,13 +301,118 @@ void loop()
   sendMicrophone();
 }
 
+// Applique les valeurs connues d'une configuration du shadow, ex: {"reportIntervalSeconds":5}
+bool applyConfig(const String &config)
+{
+  int start = config.indexOf("\"reportIntervalSeconds\":");
+  if (start < 0)
+  {
+    return false;
+  }
+
+  long seconds = config.substring(start + 24).toInt();
+  if (seconds <= 0 || seconds * 1000UL == reportIntervalMs)
+  {
+    return false;
+  }
+
+  reportIntervalMs = seconds * 1000UL;
+  return true;
+}
+
+// Rapporte la configuration appliquée : la passerelle répond avec le delta restant
+void reportShadow()
+{
+  String reported = "{\"reportIntervalSeconds\":" + String(reportIntervalMs / 1000) + "}";
+  String payload = "{\"deviceId\":\"ESP_002\",\"reported\":" + reported + signedFields("shadow", "ESP_002", reported, "", "") + "}";
+  Serial.print("Reporting shadow: ");
+  Serial.println(payload);
+  coap.put(IPAddress(192, 168, 40, 241), 4832, "shadow", payload.c_str());
+}
+
+// Commandes du backend, ex: {"command":"set_interval","intervalSeconds":5}
 void myCOAPCallback(CoapPacket &packet, IPAddress ip, int port)
 {
-  Serial.println("Alert received!");
-  playBeep();
+  char body[packet.payloadlen + 1];
+  memcpy(body, packet.payload, packet.payloadlen);
+  body[packet.payloadlen] = '\0';
+  String command(body);
+
+  Serial.print("Command received: ");
+  Serial.println(command);
+
+  bool reboot = false;
+  bool configChanged = false;
+  COAP_RESPONSE_CODE code = COAP_CHANGED;
+
+  if (command.indexOf("\"config\"") >= 0)
+  {
+    configChanged = applyConfig(command);
+  }
+  else if (command.length() == 0 || command.indexOf("\"beep\"") >= 0)
+  {
+    playBeep();
+  }
+  else if (command.indexOf("\"led_on\"") >= 0)
+  {
+    ledForced = true;
+    digitalWrite(LED_BUILTIN, LOW);
+  }
+  else if (command.indexOf("\"led_off\"") >= 0)
+  {
+    ledForced = false;
+    digitalWrite(LED_BUILTIN, HIGH);
+  }
+  else if (command.indexOf("\"reboot\"") >= 0)
+  {
+    reboot = true;
+  }
+  else if (command.indexOf("\"set_interval\"") >= 0)
+  {
+    int start = command.indexOf("\"intervalSeconds\":");
+    long seconds = start >= 0 ? command.substring(start + 18).toInt() : 0;
+    if (seconds > 0)
+    {
+      reportIntervalMs = seconds * 1000UL;
+    }
+    else
+    {
+      code = COAP_BAD_REQUEST;
+    }
+  }
+  else
+  {
+    code = COAP_BAD_REQUEST;
+  }
+
+  // ACK portant la réponse : le backend suit la livraison de la commande
+  coap.sendResponse(ip, port, packet.messageid, "", 0, code, COAP_NONE, packet.token, packet.tokenlen);
+
+  if (reboot)
+  {
+    delay(100);
+    ESP.restart();
+  }
+
+  if (configChanged)
+  {
+    reportShadow();
+  }
 }
 
 void COAPResponse(CoapPacket &packet, IPAddress ip, int port)
 {
   Serial.println("Response received");
+
+  // Réponse à un rapport de shadow : {"version":3,"delta":{...}}
+  char body[packet.payloadlen + 1];
+  memcpy(body, packet.payload, packet.payloadlen);
+  body[packet.payloadlen] = '\0';
+  String response(body);
+
+  int delta = response.indexOf("\"delta\":");
+  if (delta >= 0 && applyConfig(response.substring(delta)))
+  {
+    reportShadow();
+  }
 }
\ No newline at end of file